@@ -1,6 +1,9 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"io"
 	"os"
 
 	mcmd "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/cmd"
@@ -22,6 +25,10 @@ import (
 // @in header
 // @name Authorization
 func main() {
+	flag.Usage = func() {
+		usage(os.Stderr)
+	}
+
 	config, err := cmd.LoadConfiguration()
 	if err != nil {
 		panic(err)
@@ -29,10 +36,37 @@ func main() {
 
 	config.BuildVersion = mcmd.Version
 
-	err = cmd.Run(config)
+	switch flag.Arg(0) {
+	case "migrate":
+		err = cmd.Migrate(config, flag.Args()[1:])
+	case "generate-registration":
+		err = cmd.GenerateRegistration(config, os.Stdout)
+	case "":
+		err = cmd.Run(config)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", flag.Arg(0))
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+
 	if err != nil {
 		os.Exit(1)
 	}
 
 	os.Exit(0)
 }
+
+func usage(out io.Writer) {
+	fmt.Fprintf(out, `Usage: %s [flags] [command]
+
+Commands:
+  (none)                  run the bot
+  migrate [-dry-run]      apply pending database migrations and exit
+  generate-registration   print the appservice registration and exit
+
+Flags:
+`, os.Args[0])
+
+	flag.CommandLine.SetOutput(out)
+	flag.PrintDefaults()
+}
@@ -1,6 +1,9 @@
 package apictx
 
 import (
+	"net/http"
+	"strconv"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -21,3 +24,17 @@ func RequireIDInURI() gin.HandlerFunc {
 		ctx.Set("id", requestModel.ID)
 	}
 }
+
+// RequireUintInURI returns a Gin middleware which requires the named URI parameter to be of the type uint.
+// The value is set in the context under the same name.
+func RequireUintInURI(name string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		value, err := strconv.ParseUint(ctx.Param(name), 10, 0)
+		if err != nil || value == 0 {
+			ctx.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		ctx.Set(name, uint(value))
+	}
+}
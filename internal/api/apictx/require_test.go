@@ -63,3 +63,74 @@ func TestRequireIDInURI(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	})
 }
+
+func TestRequireUintInURI(t *testing.T) {
+	r := gin.New()
+	r.GET("/:id/:eventID", apictx.RequireIDInURI(), apictx.RequireUintInURI("eventID"), func(ctx *gin.Context) {
+		id, ok := apictx.GetUintFromContext(ctx, "id")
+		require.True(t, ok)
+
+		eventID, ok := apictx.GetUintFromContext(ctx, "eventID")
+		require.True(t, ok)
+
+		ctx.String(http.StatusOK, strconv.Itoa(int(id))+"/"+strconv.Itoa(int(eventID)))
+	})
+
+	server := httptest.NewServer(r)
+
+	t.Run("happy case", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(
+			t.Context(),
+			http.MethodGet,
+			server.URL+"/123/456",
+			nil,
+		)
+		require.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		assert.Equal(t, "123/456", string(body))
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(
+			t.Context(),
+			http.MethodGet,
+			server.URL+"/123/abcd",
+			nil,
+		)
+		require.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(
+			t.Context(),
+			http.MethodGet,
+			server.URL+"/123/0",
+			nil,
+		)
+		require.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
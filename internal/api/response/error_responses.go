@@ -25,3 +25,13 @@ func AbortWithNotFoundError(ctx *gin.Context) {
 	ctx.JSON(http.StatusNotFound, response)
 	ctx.Abort()
 }
+
+// AbortWithBadRequestError aborts request with the given message.
+func AbortWithBadRequestError(ctx *gin.Context, message string) {
+	response := MessageErrorResponse{
+		Status:  "error",
+		Message: message,
+	}
+	ctx.JSON(http.StatusBadRequest, response)
+	ctx.Abort()
+}
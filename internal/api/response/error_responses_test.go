@@ -67,3 +67,31 @@ func TestAbortWithNotFoundError(t *testing.T) {
 
 	assert.JSONEq(t, `{"message":"Not Found","status":"error"}`, string(body))
 }
+
+func TestAbortWithBadRequestError(t *testing.T) {
+	r := gin.New()
+	r.GET("/", func(ctx *gin.Context) {
+		response.AbortWithBadRequestError(ctx, "missing message")
+	})
+	server := httptest.NewServer(r)
+
+	req, err := http.NewRequestWithContext(
+		t.Context(),
+		http.MethodGet,
+		server.URL+"/",
+		nil,
+	)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"message":"missing message","status":"error"}`, string(body))
+}
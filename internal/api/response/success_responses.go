@@ -15,3 +15,13 @@ func WithData(ctx *gin.Context, data any) {
 
 	ctx.JSON(http.StatusOK, resp)
 }
+
+// WithMessage responds with the given message and a 200 status code.
+func WithMessage(ctx *gin.Context, message string) {
+	resp := MessageSuccessResponse{
+		Status:  "success",
+		Message: message,
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -99,3 +99,31 @@ func TestWithDataWithNil(t *testing.T) {
 
 	assert.JSONEq(t, `{"status":"success","data":null}`, string(body))
 }
+
+func TestWithMessage(t *testing.T) {
+	r := gin.New()
+	r.GET("/", func(ctx *gin.Context) {
+		response.WithMessage(ctx, "Deleted")
+	})
+	server := httptest.NewServer(r)
+
+	req, err := http.NewRequestWithContext(
+		t.Context(),
+		http.MethodGet,
+		server.URL+"/",
+		nil,
+	)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"status":"success","message":"Deleted"}`, string(body))
+}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/CubicrootXYZ/configor"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/api"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email"
+	emaildb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email/database"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/daemon"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
@@ -22,6 +24,8 @@ type Config struct {
 	Daemon   configDaemon
 	Matrix   configMatrix
 	ICal     configICal
+	Webhook  configWebhook
+	Email    configEmail
 	API      configAPI
 	Logger   configLogger
 	Metrics  configMetrics
@@ -35,6 +39,7 @@ type configLogger struct {
 }
 
 type configDatabase struct {
+	Driver        string `default:"mysql"`
 	Connection    string `required:"true"`
 	LogStatements bool
 }
@@ -45,16 +50,27 @@ type configDaemon struct {
 		DailyReminders       uint `default:"600"`
 		ResendUnacknowledged uint `default:"3600"`
 	}
+	Delivery struct {
+		MaxAttempts  uint `default:"10"`
+		RetryBackoff uint `default:"60"`
+	}
 }
 
 type configMatrix struct {
 	Bot struct {
-		Username   string
-		Password   string
-		Homeserver string
-		DeviceID   string
-		E2EE       bool
-		DeviceKey  string
+		Username    string
+		Password    string
+		Homeserver  string
+		DeviceID    string
+		AccessToken string
+		E2EE        bool
+		DeviceKey   string
+	}
+	AppService struct {
+		Enabled bool
+		ID      string `default:"remindme"`
+		ASToken string
+		HSToken string
 	}
 	AllowInvites  bool
 	RoomLimit     uint
@@ -62,7 +78,23 @@ type configMatrix struct {
 }
 
 type configICal struct {
-	RefreshInterval uint `default:"60"`
+	RefreshInterval      uint `default:"60"`
+	AllowPrivateNetworks bool
+}
+
+type configWebhook struct {
+	AllowPrivateNetworks bool
+}
+
+type configEmail struct {
+	Enabled bool
+	From    string
+	SMTP    struct {
+		Host     string
+		Port     uint `default:"587"`
+		Username string
+		Password string
+	}
 }
 
 type configAPI struct {
@@ -79,7 +111,9 @@ type configMetrics struct {
 
 func (config *Config) databaseConfig() *database.Config {
 	return &database.Config{
-		Connection: config.Database.Connection,
+		Driver:        config.Database.Driver,
+		Connection:    config.Database.Connection,
+		LogStatements: config.Database.LogStatements,
 	}
 }
 
@@ -117,20 +151,47 @@ func (config *Config) daemonConfig() *daemon.Config {
 		EventsInterval:               time.Second * time.Duration(config.Daemon.Intervals.Events),
 		DailyReminderInterval:        time.Second * time.Duration(config.Daemon.Intervals.DailyReminders),
 		ResendUnacknowledgedInterval: time.Second * time.Duration(config.Daemon.Intervals.ResendUnacknowledged),
+		MaxDeliveryAttempts:          config.Daemon.Delivery.MaxAttempts,
+		DeliveryRetryBackoff:         time.Second * time.Duration(config.Daemon.Delivery.RetryBackoff),
 	}
 }
 
 func (config *Config) matrixConfig() *matrix.Config {
-	return &matrix.Config{
+	cfg := &matrix.Config{
 		Username:      config.Matrix.Bot.Username,
 		Password:      config.Matrix.Bot.Password,
 		Homeserver:    config.Matrix.Bot.Homeserver,
 		DeviceID:      config.Matrix.Bot.DeviceID,
 		DeviceKey:     config.Matrix.Bot.DeviceKey,
+		E2EE:          config.Matrix.Bot.E2EE,
+		AccessToken:   config.Matrix.Bot.AccessToken,
 		AllowInvites:  config.Matrix.AllowInvites,
 		RoomLimit:     config.Matrix.RoomLimit,
 		UserWhitelist: config.Matrix.UserWhitelist,
 	}
+
+	if config.Matrix.AppService.Enabled {
+		cfg.AppService = &matrix.AppServiceConfig{
+			ID:      config.Matrix.AppService.ID,
+			ASToken: config.Matrix.AppService.ASToken,
+			HSToken: config.Matrix.AppService.HSToken,
+			URL:     config.API.BaseURL,
+		}
+	}
+
+	return cfg
+}
+
+func (config *Config) emailConfig(emailDB emaildb.Service, db database.Service) *email.Config {
+	return &email.Config{
+		EmailDB:  emailDB,
+		Database: db,
+		Host:     config.Email.SMTP.Host,
+		Port:     config.Email.SMTP.Port,
+		Username: config.Email.SMTP.Username,
+		Password: config.Email.SMTP.Password,
+		From:     config.Email.From,
+	}
 }
 
 func (config *Config) apiConfig() *api.Config {
@@ -166,6 +227,14 @@ func LoadConfiguration() (*Config, error) {
 		return nil, errors.New("API key needs to be at least 10 characters")
 	}
 
+	if config.Matrix.AppService.Enabled && !config.API.Enabled {
+		return nil, errors.New("matrix application service needs the API to be enabled")
+	}
+
+	if config.Email.Enabled && (config.Email.SMTP.Host == "" || config.Email.From == "") {
+		return nil, errors.New("e-mail needs a SMTP host and from address")
+	}
+
 	if !slices.Contains([]string{"text", "json"}, config.Logger.Format) {
 		return nil, errors.New("logger format must be one of: text, json")
 	}
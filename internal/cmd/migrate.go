@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	emaildb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email/database"
+	icaldb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/ical/database"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	webhookdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database/migration"
+)
+
+// migrationModules lists the migrations of all modules, connectors depend on the core tables.
+var migrationModules = []struct {
+	name       string
+	migrations func() []migration.Migration
+}{
+	{database.MigrationModule, database.Migrations},
+	{icaldb.MigrationModule, icaldb.Migrations},
+	{webhookdb.MigrationModule, webhookdb.Migrations},
+	{emaildb.MigrationModule, emaildb.Migrations},
+	{matrixdb.MigrationModule, matrixdb.Migrations},
+}
+
+// Migrate applies all pending database migrations, with "-dry-run" the SQL is printed instead.
+func Migrate(config *Config, args []string) error {
+	logger := config.logger()
+
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "Print the SQL of pending migrations without applying them")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	db, err := database.Open(config.databaseConfig())
+	if err != nil {
+		logger.Error("failed to connect to database", "error", err)
+		return err
+	}
+
+	for _, module := range migrationModules {
+		migrator := migration.New(db, module.name, module.migrations())
+
+		if *dryRun {
+			err = printPendingMigrations(os.Stdout, migrator, module.name)
+			if err != nil {
+				logger.Error("dry run failed", "module", module.name, "error", err)
+				return err
+			}
+
+			continue
+		}
+
+		applied, err := migrator.Up()
+		for _, m := range applied {
+			logger.Info("applied migration", "module", module.name, "version", m.Version, "name", m.Name)
+		}
+
+		if err != nil {
+			logger.Error("migration failed", "module", module.name, "error", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func printPendingMigrations(out io.Writer, migrator *migration.Migrator, module string) error {
+	statements, err := migrator.DryRun()
+	if err != nil {
+		return err
+	}
+
+	if len(statements) == 0 {
+		_, err = fmt.Fprintf(out, "-- %s: up to date\n", module)
+		return err
+	}
+
+	_, err = fmt.Fprintf(out, "-- %s\n", module)
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range statements {
+		_, err = fmt.Fprintf(out, "%s;\n", statement)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
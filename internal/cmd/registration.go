@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	"github.com/dchest/uniuri"
+	"gopkg.in/yaml.v3"
+)
+
+// GenerateRegistration writes the matrix application service registration for the homeserver.
+// Missing tokens are generated and need to be added to the configuration.
+func GenerateRegistration(config *Config, out io.Writer) error {
+	matrixConfig := config.matrixConfig()
+	if matrixConfig.AppService == nil {
+		return errors.New("matrix application service is not enabled")
+	}
+
+	generated := false
+
+	if matrixConfig.AppService.ASToken == "" {
+		matrixConfig.AppService.ASToken = uniuri.NewLen(64)
+		generated = true
+	}
+
+	if matrixConfig.AppService.HSToken == "" {
+		matrixConfig.AppService.HSToken = uniuri.NewLen(64)
+		generated = true
+	}
+
+	registration, err := yaml.Marshal(matrix.NewRegistration(matrixConfig))
+	if err != nil {
+		return err
+	}
+
+	if generated {
+		_, err = fmt.Fprintln(out, "# Tokens were generated, add as_token and hs_token to the RemindMe configuration.")
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = out.Write(registration)
+
+	return err
+}
@@ -11,6 +11,8 @@ import (
 
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/api"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/api/middleware"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email"
+	emaildb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email/database"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/ical"
 	icalapi "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/ical/api"
 	icaldb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/ical/database"
@@ -20,6 +22,9 @@ import (
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/reply"
 	matrixapi "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/api"
 	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook"
+	webhookapi "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook/api"
+	webhookdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook/database"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/coreapi"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/daemon"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
@@ -108,16 +113,51 @@ func setup(config *Config, logger *slog.Logger) ([]process, error) {
 	}
 
 	icalConnector := ical.New(&ical.Config{
-		ICalDB:          icalDB,
-		Database:        db,
-		BaseURL:         baseURL,
-		RefreshInterval: time.Minute * time.Duration(config.ICal.RefreshInterval),
+		ICalDB:               icalDB,
+		Database:             db,
+		BaseURL:              baseURL,
+		RefreshInterval:      time.Minute * time.Duration(config.ICal.RefreshInterval),
+		AllowPrivateNetworks: config.ICal.AllowPrivateNetworks,
 	}, logger.With("component", "ical connector"))
 
 	dbConfig.OutputServices[ical.OutputType] = icalConnector
-	dbConfig.OutputServices[ical.InputType] = icalConnector
+	dbConfig.InputServices[ical.InputType] = icalConnector
 	processes = append(processes, icalConnector)
 
+	// Webhook connector
+	webhookDB, err := webhookdb.New(db.GormDB())
+	if err != nil {
+		logger.Error("failed to assemble webhook database service", "error", err)
+		return nil, err
+	}
+
+	webhookConnector := webhook.New(&webhook.Config{
+		WebhookDB:            webhookDB,
+		Database:             db,
+		BaseURL:              baseURL,
+		AllowPrivateNetworks: config.Webhook.AllowPrivateNetworks,
+	}, logger.With("component", "webhook connector"))
+
+	dbConfig.InputServices[webhook.InputType] = webhookConnector
+	dbConfig.OutputServices[webhook.OutputType] = webhookConnector
+	processes = append(processes, webhookConnector)
+
+	// E-mail connector
+	var emailConnector email.Service
+
+	if config.Email.Enabled {
+		emailDB, err := emaildb.New(db.GormDB())
+		if err != nil {
+			logger.Error("failed to assemble e-mail database service", "error", err)
+			return nil, err
+		}
+
+		emailConnector = email.New(config.emailConfig(emailDB, db), logger.With("component", "email connector"))
+
+		dbConfig.OutputServices[email.OutputType] = emailConnector
+		processes = append(processes, emailConnector)
+	}
+
 	// Matrix connector
 	matrixDB, err := matrixdb.New(db.GormDB(), logger.With("component", "matrix_database"))
 	if err != nil {
@@ -125,7 +165,11 @@ func setup(config *Config, logger *slog.Logger) ([]process, error) {
 		return nil, err
 	}
 
-	matrixConnector, err := matrix.New(assembleMatrixConfig(config, icalConnector), db, matrixDB, logger.With("component", "matrix connector"))
+	matrixConnector, err := matrix.New(assembleMatrixConfig(config, &matrix.BridgeServices{
+		ICal:    icalConnector,
+		Webhook: webhookConnector,
+		Email:   emailConnector,
+	}), db, matrixDB, logger.With("component", "matrix connector"))
 	if err != nil {
 		logger.Error("failed to assemble matrix connector service", "error", err)
 		return nil, err
@@ -141,6 +185,12 @@ func setup(config *Config, logger *slog.Logger) ([]process, error) {
 	daemonConf.OutputServices = make(map[string]daemon.OutputService)
 	daemonConf.OutputServices[matrix.OutputType] = matrixConnector
 	daemonConf.OutputServices[ical.OutputType] = icalConnector
+	daemonConf.OutputServices[webhook.OutputType] = webhookConnector
+
+	if emailConnector != nil {
+		daemonConf.OutputServices[email.OutputType] = emailConnector
+	}
+
 	daemon := daemon.New(daemonConf, db, logger.With("component", "daemon"))
 	processes = append(processes, daemon)
 
@@ -153,11 +203,18 @@ func setup(config *Config, logger *slog.Logger) ([]process, error) {
 		}, logger.With("component", "core API"))
 
 		// Matrix API
-		matrixAPI := matrixapi.New(&matrixapi.Config{
+		matrixAPIConfig := &matrixapi.Config{
 			Database:            db,
 			MatrixDB:            matrixDB,
 			DefaultAuthProvider: middleware.APIKeyAuth(config.API.APIKey),
-		}, logger.With("component", "matrix API"))
+		}
+
+		if config.Matrix.AppService.Enabled {
+			matrixAPIConfig.AppService = matrixConnector
+			matrixAPIConfig.HSToken = config.Matrix.AppService.HSToken
+		}
+
+		matrixAPI := matrixapi.New(matrixAPIConfig, logger.With("component", "matrix API"))
 
 		// iCal API
 		icalAPI := icalapi.New(&icalapi.Config{
@@ -165,10 +222,17 @@ func setup(config *Config, logger *slog.Logger) ([]process, error) {
 			Database: db,
 		}, logger.With("component", "ical API"))
 
+		// Webhook API
+		webhookAPI := webhookapi.New(&webhookapi.Config{
+			WebhookDB: webhookDB,
+			Database:  db,
+		}, logger.With("component", "webhook API"))
+
 		apiConfig := config.apiConfig()
 		apiConfig.RouteProviders["core"] = coreAPI
 		apiConfig.RouteProviders["matrix"] = matrixAPI
 		apiConfig.RouteProviders["ical"] = icalAPI
+		apiConfig.RouteProviders["webhook"] = webhookAPI
 		server := api.NewServer(apiConfig, logger.With("component", "api"))
 		processes = append(processes, server)
 	}
@@ -186,7 +250,7 @@ func setup(config *Config, logger *slog.Logger) ([]process, error) {
 	return processes, nil
 }
 
-func assembleMatrixConfig(config *Config, icalConnector ical.Service) *matrix.Config {
+func assembleMatrixConfig(config *Config, bridgeServices *matrix.BridgeServices) *matrix.Config {
 	cfg := config.matrixConfig()
 
 	cfg.DefaultMessageAction = &message.NewEventAction{}
@@ -198,16 +262,35 @@ func assembleMatrixConfig(config *Config, icalConnector ical.Service) *matrix.Co
 
 	cfg.ReplyActions = append(cfg.ReplyActions,
 		&reply.DeleteEventAction{},
+		&reply.AddPreNotificationsAction{},
 		&reply.MakeRecurringAction{},
+		&reply.MarkDoneAction{},
 	)
 
 	cfg.MessageActions = append(cfg.MessageActions,
 		&message.AddUserAction{},
+		&message.AddICalInputAction{},
+		&message.ListICalInputsAction{},
+		&message.RemoveICalInputAction{},
+		&message.AddWebhookOutputAction{},
+		&message.ListWebhookOutputsAction{},
+		&message.RemoveWebhookOutputAction{},
+		&message.AddWebhookInputAction{},
+		&message.RemoveWebhookInputAction{},
+		&message.AddEmailOutputAction{},
+		&message.ListEmailOutputsAction{},
+		&message.RemoveEmailOutputAction{},
 		&message.EnableICalExportAction{},
 		&message.ChangeTimezoneAction{},
+		&message.ChangeLanguageAction{},
+		&message.ChangeUserPreferencesAction{},
 		&message.RegenICalTokenAction{},
 		&message.DeleteEventAction{},
 		&message.SetDailyReminderAction{},
+		&message.SetPreNotificationsAction{},
+		&message.SetQuietHoursAction{},
+		&message.SetEscalationAction{},
+		&message.SetDigestAction{},
 		&message.ListEventsAction{},
 		&message.RegenICalTokenAction{},
 		&message.ChangeEventAction{},
@@ -223,9 +306,7 @@ func assembleMatrixConfig(config *Config, icalConnector ical.Service) *matrix.Co
 		&reaction.SetImportanceAction{},
 	)
 
-	cfg.BridgeServices = &matrix.BridgeServices{
-		ICal: icalConnector,
-	}
+	cfg.BridgeServices = bridgeServices
 
 	return cfg
 }
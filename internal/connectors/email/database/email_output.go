@@ -0,0 +1,35 @@
+package database
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+func (service *service) NewEmailOutput(output *EmailOutput) (*EmailOutput, error) {
+	err := service.db.Save(output).Error
+
+	return output, err
+}
+
+func (service *service) GetEmailOutputByID(id uint) (*EmailOutput, error) {
+	var entity EmailOutput
+
+	err := service.db.First(&entity, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+
+	return &entity, err
+}
+
+func (service *service) DeleteEmailOutput(id uint) error {
+	result := service.db.Delete(&EmailOutput{Model: gorm.Model{ID: id}})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testOutput() *database.EmailOutput {
+	return &database.EmailOutput{
+		ChannelID:  1,
+		Recipients: "a@example.com,b@example.com",
+		TimeZone:   "Europe/Berlin",
+	}
+}
+
+func TestService_NewEmailOutput(t *testing.T) {
+	start := time.Now()
+
+	time.Sleep(time.Millisecond) // Avoids issues with database time representation being less accurate.
+
+	outputBefore, err := service.NewEmailOutput(testOutput())
+	require.NoError(t, err)
+
+	assert.NotZero(t, outputBefore.ID)
+	assert.GreaterOrEqual(t, outputBefore.CreatedAt, start)
+
+	outputAfter, err := service.GetEmailOutputByID(outputBefore.ID)
+	require.NoError(t, err)
+	assert.Equal(t, outputBefore.ID, outputAfter.ID)
+	assert.Equal(t, uint(1), outputAfter.ChannelID)
+	assert.Equal(t, "a@example.com,b@example.com", outputAfter.Recipients)
+	assert.Equal(t, "Europe/Berlin", outputAfter.TimeZone)
+}
+
+func TestService_GetEmailOutputByIDWithNotFound(t *testing.T) {
+	_, err := service.GetEmailOutputByID(999999)
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func TestService_DeleteEmailOutput(t *testing.T) {
+	output, err := service.NewEmailOutput(testOutput())
+	require.NoError(t, err)
+	require.NotZero(t, output.ID)
+
+	err = service.DeleteEmailOutput(output.ID)
+	require.NoError(t, err)
+
+	_, err = service.GetEmailOutputByID(output.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func TestService_DeleteEmailOutputWithNotFound(t *testing.T) {
+	err := service.DeleteEmailOutput(999999)
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
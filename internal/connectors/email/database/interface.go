@@ -0,0 +1,28 @@
+package database
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// List of common errors returned by the package.
+var (
+	ErrNotFound = errors.New("not found")
+)
+
+// EmailOutput holds information about recipients channel events are mailed to.
+type EmailOutput struct {
+	gorm.Model
+
+	ChannelID  uint
+	Recipients string // Comma separated list of e-mail addresses.
+	TimeZone   string // Times in mails and the daily reminder are based on this time zone.
+}
+
+// Service provides a database service for the e-mail connector.
+type Service interface {
+	NewEmailOutput(*EmailOutput) (*EmailOutput, error)
+	GetEmailOutputByID(id uint) (*EmailOutput, error)
+	DeleteEmailOutput(id uint) error
+}
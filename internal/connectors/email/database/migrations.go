@@ -0,0 +1,32 @@
+package database
+
+import (
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database/migration"
+	"gorm.io/gorm"
+)
+
+// Migrations returns the versioned schema migrations of the e-mail connector.
+//
+// Migrations work on snapshots of the models as of their version, changes to
+// the models require a new migration with a new snapshot.
+func Migrations() []migration.Migration {
+	return []migration.Migration{
+		{
+			Version: 1,
+			Name:    "initial schema",
+			Up:      migration.AutoMigrate(&emailOutputV1{}),
+		},
+	}
+}
+
+// Version 1
+
+type emailOutputV1 struct {
+	gorm.Model
+
+	ChannelID  uint
+	Recipients string
+	TimeZone   string
+}
+
+func (emailOutputV1) TableName() string { return "email_outputs" }
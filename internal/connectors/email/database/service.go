@@ -0,0 +1,32 @@
+package database
+
+import (
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database/migration"
+	"gorm.io/gorm"
+)
+
+// MigrationModule is the module name the e-mail schema version is tracked with.
+const MigrationModule = "email"
+
+type service struct {
+	db *gorm.DB
+}
+
+// New assembles a new e-mail connector database service.
+func New(db *gorm.DB) (Service, error) {
+	s := &service{
+		db: db,
+	}
+
+	err := s.migrate()
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (service *service) migrate() error {
+	_, err := migration.New(service.db, MigrationModule, Migrations()).Up()
+	return err
+}
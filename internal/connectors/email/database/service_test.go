@@ -0,0 +1,31 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email/database"
+	dbtests "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database/tests"
+	"gorm.io/gorm"
+)
+
+var service database.Service
+var gormDB *gorm.DB
+
+func getService(gormDB *gorm.DB) database.Service {
+	service, err := database.New(gormDB)
+	if err != nil {
+		panic(err)
+	}
+
+	return service
+}
+
+func TestMain(m *testing.M) {
+	config, cleanup := dbtests.Config()
+	defer cleanup()
+
+	gormDB = dbtests.GormDBWithCoreSchema(config)
+	service = getService(gormDB)
+
+	m.Run()
+}
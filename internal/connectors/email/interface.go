@@ -0,0 +1,40 @@
+package email
+
+import (
+	"errors"
+	"time"
+
+	emaildb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/daemon"
+)
+
+// The output type provided by this package
+const (
+	OutputType = "email"
+)
+
+// List of commonly used errors in this package.
+var (
+	ErrNotFound       = errors.New("not found")
+	ErrInvalidAddress = errors.New("invalid e-mail address")
+)
+
+// Service provides an interface for the e-mail connector.
+// The connector is suitable as output only.
+type Service interface {
+	Start() error
+	Stop() error
+
+	OutputExists(outputType string, outputID uint) (bool, error)
+	OutputRemoved(outputType string, outputID uint) error
+
+	NewOutput(channelID uint, recipients []string, timeZone string) (*emaildb.EmailOutput, error)
+	GetOutput(outputID uint) (*emaildb.EmailOutput, error)
+
+	SendReminder(*daemon.Event, *daemon.Output) error
+	SendDailyReminder(*daemon.DailyReminder, *daemon.Output) error
+
+	ToLocalTime(time.Time, *daemon.Output) time.Time
+
+	Cleanup() error
+}
@@ -0,0 +1,136 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"math"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/daemon"
+)
+
+const dateTimeFormat = "15:04 02.01.2006 (MST)"
+
+// buildMail assembles a multipart/alternative mail with a plain text and a HTML part.
+func buildMail(from string, to []string, subject, text, htmlText string, date time.Time) ([]byte, error) {
+	var body bytes.Buffer
+
+	writer := multipart.NewWriter(&body)
+
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", text},
+		{"text/html; charset=utf-8", htmlText},
+	} {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		qpWriter := quotedprintable.NewWriter(partWriter)
+
+		_, err = qpWriter.Write([]byte(part.content))
+		if err != nil {
+			return nil, err
+		}
+
+		err = qpWriter.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", writer.Boundary())
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
+
+func reminderMail(event *daemon.Event, loc *time.Location) (subject, text, htmlText string) {
+	subject = "Reminder: " + event.Message
+	when := "at " + event.EventTime.In(loc).Format(dateTimeFormat)
+
+	if event.StartsIn != nil {
+		subject = "Upcoming: " + event.Message
+		when = "starts in " + format.ToNiceDuration(*event.StartsIn) + " " + when
+	}
+
+	if event.Escalation != nil {
+		subject = "Escalation: " + event.Message
+		when += fmt.Sprintf(", not acknowledged after %d reminders", event.Escalation.Resends+1)
+	}
+
+	if event.Importance >= daemon.ImportanceImportant {
+		subject = "❗ " + subject
+	}
+
+	text = fmt.Sprintf("%s\r\n%s (#%d)\r\n", event.Message, when, event.ID)
+	htmlText = fmt.Sprintf("<p><b>%s</b><br><i>%s</i> (#%d)</p>", html.EscapeString(event.Message), when, event.ID)
+
+	return subject, text, htmlText
+}
+
+func dailyReminderMail(reminder *daemon.DailyReminder, now time.Time, loc *time.Location) (subject, text, htmlText string) {
+	subject = "Your reminders for " + now.In(loc).Format("02.01.2006")
+	if days := int(math.Ceil(reminder.Lookahead.Hours() / 24)); days > 1 {
+		subject = fmt.Sprintf("Your reminders for the %d days from %s", days, now.In(loc).Format("02.01.2006"))
+	}
+
+	if reminder.IsEmpty() {
+		return subject, "Nothing to do today 🥳.\r\n", "<p>Nothing to do today 🥳.</p>"
+	}
+
+	var textBuilder, htmlBuilder strings.Builder
+
+	writeMailEventList(&textBuilder, &htmlBuilder, reminder.Events, loc)
+
+	if len(reminder.Overdue) > 0 {
+		textBuilder.WriteString("\r\nOverdue:\r\n")
+		htmlBuilder.WriteString("<p>Overdue:</p>")
+		writeMailEventList(&textBuilder, &htmlBuilder, reminder.Overdue, loc)
+	}
+
+	if recurring := reminder.RecurringCount(); recurring > 0 {
+		fmt.Fprintf(&textBuilder, "\r\n%d of them recurring.\r\n", recurring)
+		fmt.Fprintf(&htmlBuilder, "<p><i>%d of them recurring.</i></p>", recurring)
+	}
+
+	return subject, textBuilder.String(), htmlBuilder.String()
+}
+
+func writeMailEventList(textBuilder, htmlBuilder *strings.Builder, events []daemon.Event, loc *time.Location) {
+	htmlBuilder.WriteString("<ul>")
+
+	for _, event := range events {
+		eventTime := event.EventTime.In(loc).Format(dateTimeFormat)
+
+		fmt.Fprintf(textBuilder, "- %s %s (#%d)\r\n", eventTime, event.Message, event.ID)
+		fmt.Fprintf(htmlBuilder, "<li>%s <b>%s</b> (#%d)</li>", eventTime, html.EscapeString(event.Message), event.ID)
+	}
+
+	htmlBuilder.WriteString("</ul>")
+}
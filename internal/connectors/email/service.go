@@ -0,0 +1,221 @@
+package email
+
+import (
+	"errors"
+	"log/slog"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	emaildb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/daemon"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+// Config holds the configuration for the service.
+type Config struct {
+	EmailDB  emaildb.Service
+	Database database.Service
+
+	Host     string
+	Port     uint
+	Username string // Leave empty to send without authentication.
+	Password string
+	From     string
+}
+
+type service struct {
+	config *Config
+	logger *slog.Logger
+
+	stop chan bool
+}
+
+// New assembles a new e-mail connector service.
+func New(config *Config, logger *slog.Logger) Service {
+	return &service{
+		config: config,
+		logger: logger,
+		stop:   make(chan bool, 1),
+	}
+}
+
+func (service *service) Start() error {
+	// Mails are only sent by the daemon, nothing to do in the background.
+	<-service.stop
+
+	return nil
+}
+
+func (service *service) Stop() error {
+	service.stop <- true
+
+	service.logger.Info("stopping")
+
+	return nil
+}
+
+func (service *service) OutputExists(outputType string, outputID uint) (bool, error) {
+	if outputType != OutputType {
+		return false, nil
+	}
+
+	_, err := service.config.EmailDB.GetEmailOutputByID(outputID)
+	if errors.Is(err, emaildb.ErrNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func (service *service) OutputRemoved(outputType string, outputID uint) error {
+	if outputType != OutputType {
+		return nil
+	}
+
+	err := service.config.EmailDB.DeleteEmailOutput(outputID)
+	if errors.Is(err, emaildb.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+func (service *service) NewOutput(channelID uint, recipients []string, timeZone string) (*emaildb.EmailOutput, error) {
+	if len(recipients) == 0 {
+		return nil, ErrInvalidAddress
+	}
+
+	addresses := make([]string, 0, len(recipients))
+
+	for _, recipient := range recipients {
+		address, err := mail.ParseAddress(recipient)
+		if err != nil {
+			return nil, ErrInvalidAddress
+		}
+
+		addresses = append(addresses, address.Address)
+	}
+
+	emailOutput, err := service.config.EmailDB.NewEmailOutput(&emaildb.EmailOutput{
+		ChannelID:  channelID,
+		Recipients: strings.Join(addresses, ","),
+		TimeZone:   timeZone,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = service.config.Database.AddOutputToChannel(channelID, &database.Output{
+		ChannelID:  channelID,
+		OutputType: OutputType,
+		OutputID:   emailOutput.ID,
+		Enabled:    true,
+	})
+	if err != nil {
+		// Do not leave an output behind no channel uses.
+		deleteErr := service.config.EmailDB.DeleteEmailOutput(emailOutput.ID)
+		if deleteErr != nil {
+			service.logger.Error("failed to delete e-mail output", "error", deleteErr, "email.output_id", emailOutput.ID)
+		}
+
+		return nil, err
+	}
+
+	return emailOutput, nil
+}
+
+func (service *service) GetOutput(outputID uint) (*emaildb.EmailOutput, error) {
+	output, err := service.config.EmailDB.GetEmailOutputByID(outputID)
+	if err != nil {
+		if errors.Is(err, emaildb.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (service *service) SendReminder(event *daemon.Event, output *daemon.Output) error {
+	emailOutput, err := service.GetOutput(output.OutputID)
+	if err != nil {
+		return err
+	}
+
+	subject, text, html := reminderMail(event, locationFromOutput(emailOutput))
+
+	return service.send(emailOutput, subject, text, html)
+}
+
+func (service *service) SendDailyReminder(reminder *daemon.DailyReminder, output *daemon.Output) error {
+	emailOutput, err := service.GetOutput(output.OutputID)
+	if err != nil {
+		return err
+	}
+
+	subject, text, html := dailyReminderMail(reminder, time.Now(), locationFromOutput(emailOutput))
+
+	return service.send(emailOutput, subject, text, html)
+}
+
+func (service *service) ToLocalTime(date time.Time, output *daemon.Output) time.Time {
+	emailOutput, err := service.GetOutput(output.OutputID)
+	if err != nil {
+		service.logger.Error("failed to get e-mail output", "error", err, "output.id", output.OutputID)
+		return date
+	}
+
+	if emailOutput.TimeZone == "" {
+		return date
+	}
+
+	return date.In(locationFromOutput(emailOutput))
+}
+
+func (service *service) Cleanup() error {
+	// Not supported.
+	return nil
+}
+
+func (service *service) send(output *emaildb.EmailOutput, subject, text, html string) error {
+	recipients := strings.Split(output.Recipients, ",")
+
+	msg, err := buildMail(service.config.From, recipients, subject, text, html, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if service.config.Username != "" {
+		auth = smtp.PlainAuth("", service.config.Username, service.config.Password, service.config.Host)
+	}
+
+	err = smtp.SendMail(
+		net.JoinHostPort(service.config.Host, strconv.FormatUint(uint64(service.config.Port), 10)),
+		auth,
+		service.config.From,
+		recipients,
+		msg,
+	)
+	if err != nil {
+		return err
+	}
+
+	service.logger.Debug("sent mail", "email.output_id", output.ID, "recipients", len(recipients))
+
+	return nil
+}
+
+func locationFromOutput(output *emaildb.EmailOutput) *time.Location {
+	loc, err := time.LoadLocation(output.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
@@ -0,0 +1,512 @@
+package email_test
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email"
+	emaildb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/daemon"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type receivedMail struct {
+	from       string
+	recipients []string
+	data       string
+}
+
+// fakeSMTPServer accepts a single connection and records the mail sent over it.
+func fakeSMTPServer(t *testing.T) (string, uint, chan receivedMail) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	mails := make(chan receivedMail, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		received := receivedMail{}
+
+		_, _ = conn.Write([]byte("220 localhost ESMTP\r\n"))
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			command := strings.ToUpper(strings.TrimSpace(line))
+
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				_, _ = conn.Write([]byte("250 localhost\r\n"))
+			case strings.HasPrefix(command, "MAIL FROM:"):
+				received.from = strings.Trim(strings.TrimSpace(line)[10:], "<>")
+				_, _ = conn.Write([]byte("250 OK\r\n"))
+			case strings.HasPrefix(command, "RCPT TO:"):
+				received.recipients = append(received.recipients, strings.Trim(strings.TrimSpace(line)[8:], "<>"))
+				_, _ = conn.Write([]byte("250 OK\r\n"))
+			case command == "DATA":
+				_, _ = conn.Write([]byte("354 Go ahead\r\n"))
+
+				var data strings.Builder
+
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+
+					data.WriteString(line)
+				}
+
+				received.data = data.String()
+				_, _ = conn.Write([]byte("250 OK\r\n"))
+			case command == "QUIT":
+				_, _ = conn.Write([]byte("221 Bye\r\n"))
+				mails <- received
+
+				return
+			default:
+				_, _ = conn.Write([]byte("250 OK\r\n"))
+			}
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+
+	return addr.IP.String(), uint(addr.Port), mails
+}
+
+func testService(t *testing.T, host string, port uint) (email.Service, *emaildb.MockService, *database.MockService) {
+	t.Helper()
+
+	db := database.NewMockService(t)
+	emailDB := emaildb.NewMockService(t)
+
+	return email.New(&email.Config{
+			Database: db,
+			EmailDB:  emailDB,
+			Host:     host,
+			Port:     port,
+			From:     "remindme@example.com",
+		}, slog.New(slog.NewTextHandler(os.Stdout, nil))),
+		emailDB,
+		db
+}
+
+func testEmailOutput() *emaildb.EmailOutput {
+	return &emaildb.EmailOutput{
+		Model: gorm.Model{
+			ID: 2,
+		},
+		ChannelID:  3,
+		Recipients: "a@example.com,b@example.com",
+		TimeZone:   "Europe/Berlin",
+	}
+}
+
+// parseMail returns the headers and the decoded plain text and HTML parts.
+func parseMail(t *testing.T, data string) (mail.Header, string, string) {
+	t.Helper()
+
+	msg, err := mail.ReadMessage(strings.NewReader(data))
+	require.NoError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	parts := map[string]string{}
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(quotedprintable.NewReader(part))
+		require.NoError(t, err)
+
+		parts[strings.Split(part.Header.Get("Content-Type"), ";")[0]] = string(content)
+	}
+
+	return msg.Header, parts["text/plain"], parts["text/html"]
+}
+
+func TestService_OutputExists(t *testing.T) {
+	service, emailDB, _ := testService(t, "", 0)
+
+	emailDB.EXPECT().GetEmailOutputByID(uint(1)).Return(testEmailOutput(), nil)
+
+	exists, err := service.OutputExists("email", 1)
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestService_OutputExistsWithNotFound(t *testing.T) {
+	service, emailDB, _ := testService(t, "", 0)
+
+	emailDB.EXPECT().GetEmailOutputByID(uint(1)).Return(nil, emaildb.ErrNotFound)
+
+	exists, err := service.OutputExists("email", 1)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestService_OutputExistsWithWrongType(t *testing.T) {
+	service, _, _ := testService(t, "", 0)
+
+	exists, err := service.OutputExists("notemail", 1)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestService_OutputExistsWithError(t *testing.T) {
+	service, emailDB, _ := testService(t, "", 0)
+
+	emailDB.EXPECT().GetEmailOutputByID(uint(1)).Return(nil, errors.New("test"))
+
+	_, err := service.OutputExists("email", 1)
+	require.Error(t, err)
+}
+
+func TestService_OutputRemoved(t *testing.T) {
+	service, emailDB, _ := testService(t, "", 0)
+
+	emailDB.EXPECT().DeleteEmailOutput(uint(1)).Return(nil)
+
+	err := service.OutputRemoved("email", 1)
+	require.NoError(t, err)
+}
+
+func TestService_OutputRemovedWithNotFound(t *testing.T) {
+	service, emailDB, _ := testService(t, "", 0)
+
+	emailDB.EXPECT().DeleteEmailOutput(uint(1)).Return(emaildb.ErrNotFound)
+
+	err := service.OutputRemoved("email", 1)
+	require.NoError(t, err)
+}
+
+func TestService_OutputRemovedWithWrongType(t *testing.T) {
+	service, _, _ := testService(t, "", 0)
+
+	err := service.OutputRemoved("notemail", 1)
+	require.NoError(t, err)
+}
+
+func TestService_OutputRemovedWithError(t *testing.T) {
+	service, emailDB, _ := testService(t, "", 0)
+
+	emailDB.EXPECT().DeleteEmailOutput(uint(1)).Return(errors.New("test"))
+
+	err := service.OutputRemoved("email", 1)
+	require.Error(t, err)
+}
+
+func TestService_NewOutput(t *testing.T) {
+	service, emailDB, db := testService(t, "", 0)
+
+	emailDB.EXPECT().NewEmailOutput(&emaildb.EmailOutput{
+		ChannelID:  3,
+		Recipients: "a@example.com,b@example.com",
+		TimeZone:   "Europe/Berlin",
+	}).Return(testEmailOutput(), nil)
+	db.EXPECT().AddOutputToChannel(uint(3), &database.Output{
+		ChannelID:  3,
+		OutputType: "email",
+		OutputID:   2,
+		Enabled:    true,
+	}).Return(nil)
+
+	output, err := service.NewOutput(3, []string{"a@example.com", "Bob <b@example.com>"}, "Europe/Berlin")
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), output.ID)
+}
+
+func TestService_NewOutputWithInvalidAddress(t *testing.T) {
+	service, _, _ := testService(t, "", 0)
+
+	for _, recipients := range [][]string{nil, {"example.com"}, {"a@example.com", "b"}} {
+		_, err := service.NewOutput(3, recipients, "UTC")
+		require.ErrorIs(t, err, email.ErrInvalidAddress)
+	}
+}
+
+func TestService_NewOutputWithChannelError(t *testing.T) {
+	service, emailDB, db := testService(t, "", 0)
+
+	emailDB.EXPECT().NewEmailOutput(&emaildb.EmailOutput{
+		ChannelID:  3,
+		Recipients: "a@example.com",
+		TimeZone:   "UTC",
+	}).Return(testEmailOutput(), nil)
+	db.EXPECT().AddOutputToChannel(uint(3), &database.Output{
+		ChannelID:  3,
+		OutputType: "email",
+		OutputID:   2,
+		Enabled:    true,
+	}).Return(errors.New("test"))
+	emailDB.EXPECT().DeleteEmailOutput(uint(2)).Return(nil)
+
+	_, err := service.NewOutput(3, []string{"a@example.com"}, "UTC")
+	require.Error(t, err)
+}
+
+func TestService_GetOutputWithNotFound(t *testing.T) {
+	service, emailDB, _ := testService(t, "", 0)
+
+	emailDB.EXPECT().GetEmailOutputByID(uint(2)).Return(nil, emaildb.ErrNotFound)
+
+	_, err := service.GetOutput(2)
+	require.ErrorIs(t, err, email.ErrNotFound)
+}
+
+func TestService_SendReminder(t *testing.T) {
+	host, port, mails := fakeSMTPServer(t)
+	service, emailDB, _ := testService(t, host, port)
+
+	emailDB.EXPECT().GetEmailOutputByID(uint(2)).Return(testEmailOutput(), nil)
+
+	err := service.SendReminder(&daemon.Event{
+		ID:         5,
+		EventTime:  time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC),
+		Message:    "water <plants>",
+		Importance: daemon.ImportanceImportant,
+	}, &daemon.Output{
+		ID:         1,
+		OutputType: "email",
+		OutputID:   2,
+	})
+	require.NoError(t, err)
+
+	received := <-mails
+	assert.Equal(t, "remindme@example.com", received.from)
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, received.recipients)
+
+	header, text, html := parseMail(t, received.data)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "❗ Reminder: water <plants>", subject)
+	assert.Equal(t, "a@example.com, b@example.com", header.Get("To"))
+	assert.Equal(t, "water <plants>\r\nat 12:30 01.05.2024 (CEST) (#5)\r\n", text)
+	assert.Equal(t, "<p><b>water &lt;plants&gt;</b><br><i>at 12:30 01.05.2024 (CEST)</i> (#5)</p>", html)
+}
+
+func TestService_SendReminderWithEscalation(t *testing.T) {
+	host, port, mails := fakeSMTPServer(t)
+	service, emailDB, _ := testService(t, host, port)
+
+	emailDB.EXPECT().GetEmailOutputByID(uint(2)).Return(testEmailOutput(), nil)
+
+	err := service.SendReminder(&daemon.Event{
+		ID:         5,
+		EventTime:  time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC),
+		Message:    "restart server",
+		Importance: daemon.ImportanceImportant,
+		Escalation: &daemon.Escalation{
+			Level:   1,
+			Resends: 2,
+		},
+	}, &daemon.Output{OutputID: 2})
+	require.NoError(t, err)
+
+	header, text, _ := parseMail(t, (<-mails).data)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "❗ Escalation: restart server", subject)
+	assert.Equal(t, "restart server\r\nat 12:30 01.05.2024 (CEST), not acknowledged after 3 reminders (#5)\r\n", text)
+}
+
+func TestService_SendReminderWithPreNotification(t *testing.T) {
+	host, port, mails := fakeSMTPServer(t)
+	service, emailDB, _ := testService(t, host, port)
+
+	emailDB.EXPECT().GetEmailOutputByID(uint(2)).Return(testEmailOutput(), nil)
+
+	err := service.SendReminder(&daemon.Event{
+		ID:        5,
+		EventTime: time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC),
+		Message:   "meeting",
+		StartsIn:  new(time.Minute * 15),
+	}, &daemon.Output{OutputID: 2})
+	require.NoError(t, err)
+
+	header, text, _ := parseMail(t, (<-mails).data)
+	assert.Equal(t, "Upcoming: meeting", header.Get("Subject"))
+	assert.Equal(t, "meeting\r\nstarts in 15 minutes at 12:30 01.05.2024 (CEST) (#5)\r\n", text)
+}
+
+func TestService_SendReminderWithUnknownOutput(t *testing.T) {
+	service, emailDB, _ := testService(t, "", 0)
+
+	emailDB.EXPECT().GetEmailOutputByID(uint(2)).Return(nil, emaildb.ErrNotFound)
+
+	err := service.SendReminder(&daemon.Event{ID: 5}, &daemon.Output{OutputID: 2})
+	require.ErrorIs(t, err, email.ErrNotFound)
+}
+
+func TestService_SendReminderWithUnreachableServer(t *testing.T) {
+	service, emailDB, _ := testService(t, "127.0.0.1", 1)
+
+	emailDB.EXPECT().GetEmailOutputByID(uint(2)).Return(testEmailOutput(), nil)
+
+	err := service.SendReminder(&daemon.Event{ID: 5}, &daemon.Output{OutputID: 2})
+	require.Error(t, err)
+}
+
+func TestService_SendDailyReminder(t *testing.T) {
+	host, port, mails := fakeSMTPServer(t)
+	service, emailDB, _ := testService(t, host, port)
+
+	emailDB.EXPECT().GetEmailOutputByID(uint(2)).Return(testEmailOutput(), nil)
+
+	err := service.SendDailyReminder(&daemon.DailyReminder{
+		Events: []daemon.Event{
+			{
+				ID:        5,
+				EventTime: time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC),
+				Message:   "event 1",
+			},
+			{
+				ID:        6,
+				EventTime: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+				Message:   "event 2",
+			},
+		},
+	}, &daemon.Output{OutputID: 2})
+	require.NoError(t, err)
+
+	header, text, html := parseMail(t, (<-mails).data)
+	assert.True(t, strings.HasPrefix(header.Get("Subject"), "Your reminders for "))
+	assert.Equal(t,
+		"- 12:30 01.05.2024 (CEST) event 1 (#5)\r\n"+
+			"- 14:00 01.05.2024 (CEST) event 2 (#6)\r\n",
+		text,
+	)
+	assert.Equal(t,
+		"<ul><li>12:30 01.05.2024 (CEST) <b>event 1</b> (#5)</li>"+
+			"<li>14:00 01.05.2024 (CEST) <b>event 2</b> (#6)</li></ul>",
+		html,
+	)
+}
+
+func TestService_SendDailyReminderWithLookaheadAndOverdue(t *testing.T) {
+	host, port, mails := fakeSMTPServer(t)
+	service, emailDB, _ := testService(t, host, port)
+
+	emailDB.EXPECT().GetEmailOutputByID(uint(2)).Return(testEmailOutput(), nil)
+
+	err := service.SendDailyReminder(&daemon.DailyReminder{
+		Events: []daemon.Event{
+			{
+				ID:        5,
+				EventTime: time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC),
+				Message:   "event 1",
+			},
+		},
+		Overdue: []daemon.Event{
+			{
+				ID:         6,
+				EventTime:  time.Date(2024, 4, 30, 12, 0, 0, 0, time.UTC),
+				Message:    "event 2",
+				RepeatRule: "FREQ=DAILY",
+			},
+		},
+		Lookahead: 7 * 24 * time.Hour,
+	}, &daemon.Output{OutputID: 2})
+	require.NoError(t, err)
+
+	header, text, html := parseMail(t, (<-mails).data)
+	assert.True(t, strings.HasPrefix(header.Get("Subject"), "Your reminders for the 7 days from "))
+	assert.Equal(t,
+		"- 12:30 01.05.2024 (CEST) event 1 (#5)\r\n"+
+			"\r\nOverdue:\r\n"+
+			"- 14:00 30.04.2024 (CEST) event 2 (#6)\r\n"+
+			"\r\n1 of them recurring.\r\n",
+		text,
+	)
+	assert.Equal(t,
+		"<ul><li>12:30 01.05.2024 (CEST) <b>event 1</b> (#5)</li></ul>"+
+			"<p>Overdue:</p>"+
+			"<ul><li>14:00 30.04.2024 (CEST) <b>event 2</b> (#6)</li></ul>"+
+			"<p><i>1 of them recurring.</i></p>",
+		html,
+	)
+}
+
+func TestService_SendDailyReminderWithoutEvents(t *testing.T) {
+	host, port, mails := fakeSMTPServer(t)
+	service, emailDB, _ := testService(t, host, port)
+
+	emailDB.EXPECT().GetEmailOutputByID(uint(2)).Return(testEmailOutput(), nil)
+
+	err := service.SendDailyReminder(&daemon.DailyReminder{}, &daemon.Output{OutputID: 2})
+	require.NoError(t, err)
+
+	_, text, html := parseMail(t, (<-mails).data)
+	assert.Equal(t, "Nothing to do today 🥳.\r\n", text)
+	assert.Equal(t, "<p>Nothing to do today 🥳.</p>", html)
+}
+
+func TestService_ToLocalTime(t *testing.T) {
+	service, emailDB, _ := testService(t, "", 0)
+
+	emailDB.EXPECT().GetEmailOutputByID(uint(2)).Return(testEmailOutput(), nil)
+
+	date := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	localTime := service.ToLocalTime(date, &daemon.Output{OutputID: 2})
+
+	assert.Equal(t, 12, localTime.Hour())
+	assert.True(t, date.Equal(localTime))
+}
+
+func TestService_ToLocalTimeWithoutTimeZone(t *testing.T) {
+	service, emailDB, _ := testService(t, "", 0)
+
+	output := testEmailOutput()
+	output.TimeZone = ""
+	emailDB.EXPECT().GetEmailOutputByID(uint(2)).Return(output, nil)
+
+	// Without an own time zone the date is kept as given, e.g. on the channels clock.
+	date := time.Date(2024, 5, 1, 10, 30, 0, 0, time.FixedZone("test", 3600))
+	assert.Equal(t, date, service.ToLocalTime(date, &daemon.Output{OutputID: 2}))
+}
+
+func TestService_ToLocalTimeWithError(t *testing.T) {
+	service, emailDB, _ := testService(t, "", 0)
+
+	emailDB.EXPECT().GetEmailOutputByID(uint(2)).Return(nil, errors.New("test"))
+
+	date := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	assert.Equal(t, date, service.ToLocalTime(date, &daemon.Output{OutputID: 2}))
+}
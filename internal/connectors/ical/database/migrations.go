@@ -0,0 +1,42 @@
+package database
+
+import (
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database/migration"
+	"gorm.io/gorm"
+)
+
+// Migrations returns the versioned schema migrations of the iCal connector.
+//
+// Migrations work on snapshots of the models as of their version, changes to
+// the models require a new migration with a new snapshot.
+func Migrations() []migration.Migration {
+	return []migration.Migration{
+		{
+			Version: 1,
+			Name:    "initial schema",
+			Up:      migration.AutoMigrate(&icalInputV1{}, &icalOutputV1{}),
+		},
+	}
+}
+
+// Version 1
+
+type icalInputV1 struct {
+	gorm.Model
+
+	URL         string
+	LastRefresh *time.Time
+	Disabled    bool
+}
+
+func (icalInputV1) TableName() string { return "ical_inputs" }
+
+type icalOutputV1 struct {
+	gorm.Model
+
+	Token string
+}
+
+func (icalOutputV1) TableName() string { return "ical_outputs" }
@@ -0,0 +1,15 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/ical/database"
+	dbtests "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database/tests"
+)
+
+func TestMigrations_MatchModels(t *testing.T) {
+	dbtests.AssertSchema(t, gormDB,
+		&database.IcalInput{},
+		&database.IcalOutput{},
+	)
+}
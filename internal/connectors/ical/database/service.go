@@ -1,6 +1,12 @@
 package database
 
-import "gorm.io/gorm"
+import (
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database/migration"
+	"gorm.io/gorm"
+)
+
+// MigrationModule is the module name the iCal schema version is tracked with.
+const MigrationModule = "ical"
 
 type service struct {
 	db *gorm.DB
@@ -21,15 +27,6 @@ func New(db *gorm.DB) (Service, error) {
 }
 
 func (service *service) migrate() error {
-	err := service.db.AutoMigrate(&IcalInput{})
-	if err != nil {
-		return err
-	}
-
-	err = service.db.AutoMigrate(&IcalOutput{})
-	if err != nil {
-		return err
-	}
-
-	return nil
+	_, err := migration.New(service.db, MigrationModule, Migrations()).Up()
+	return err
 }
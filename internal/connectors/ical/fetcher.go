@@ -5,14 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log/slog"
 	"net/http"
+	"slices"
 	"strconv"
 	"time"
 
 	icaldb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/ical/database"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/ical/format"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/httpclient"
 )
 
 const (
@@ -40,40 +41,49 @@ func (service *service) refreshIcalInputs() {
 			continue
 		}
 
-		now := time.Now()
-		service.metricLastRefresh.
-			WithLabelValues(strconv.FormatUint(uint64(input.ID), 10)).
-			Set(float64(now.Unix()))
-
-		err := service.refreshIcalInput(&input)
+		err := service.refreshAndUpdateIcalInput(&input)
 		if err != nil {
-			service.metricErrorCount.
-				WithLabelValues(strconv.FormatUint(uint64(input.ID), 10)).
-				Inc()
-
 			l.Info("failed refreshing input", "error", err)
+		}
+	}
+}
 
-			if input.LastRefresh != nil && time.Since(*input.LastRefresh) > time.Hour*48 {
-				l.Info("disabling input, no successful refresh in 48 hours")
+// refreshAndUpdateIcalInput refreshes the input and stores the outcome of the
+// refresh in the database. The returned error is the one of the refresh itself.
+func (service *service) refreshAndUpdateIcalInput(input *icaldb.IcalInput) error {
+	l := service.logger.With("ical.input_id", input.ID)
 
-				input.Disabled = true
-			}
-		} else {
-			input.LastRefresh = &now
-			input.Disabled = false
-		}
+	now := time.Now()
+	service.metricLastRefresh.
+		WithLabelValues(strconv.FormatUint(uint64(input.ID), 10)).
+		Set(float64(now.Unix()))
 
-		_, err = service.config.ICalDB.UpdateIcalInput(&input)
-		if err != nil {
-			service.metricErrorCount.
-				WithLabelValues(strconv.FormatUint(uint64(input.ID), 10)).
-				Inc()
+	refreshErr := service.refreshIcalInput(input)
+	if refreshErr != nil {
+		service.metricErrorCount.
+			WithLabelValues(strconv.FormatUint(uint64(input.ID), 10)).
+			Inc()
 
-			l.Info("failed updating input in database", "error", err)
+		if input.LastRefresh != nil && time.Since(*input.LastRefresh) > time.Hour*48 {
+			l.Info("disabling input, no successful refresh in 48 hours")
 
-			continue
+			input.Disabled = true
 		}
+	} else {
+		input.LastRefresh = &now
+		input.Disabled = false
+	}
+
+	_, err := service.config.ICalDB.UpdateIcalInput(input)
+	if err != nil {
+		service.metricErrorCount.
+			WithLabelValues(strconv.FormatUint(uint64(input.ID), 10)).
+			Inc()
+
+		l.Info("failed updating input in database", "error", err)
 	}
+
+	return refreshErr
 }
 
 func (service *service) refreshIcalInput(input *icaldb.IcalInput) error {
@@ -86,7 +96,7 @@ func (service *service) refreshIcalInput(input *icaldb.IcalInput) error {
 		return fmt.Errorf("can not get input for iCal input: %w", err)
 	}
 
-	content, err := getFileContent(input.URL, service.logger)
+	content, err := service.getFileContent(input.URL)
 	if err != nil {
 		return fmt.Errorf("can not fetch resource: %w", err)
 	}
@@ -103,13 +113,34 @@ func (service *service) refreshIcalInput(input *icaldb.IcalInput) error {
 		return fmt.Errorf("failed to load events from iCal string: %w", err)
 	}
 
+	references, err := format.ExternalReferencesFromIcal(content)
+	if err != nil {
+		return fmt.Errorf("failed to load references from iCal string: %w", err)
+	}
+
 	existingEvents, err := service.config.Database.ListEvents(&database.ListEventsOpts{
-		InputID: &i.ID,
+		InputID:         &i.ID,
+		IncludeInactive: true,
 	})
 	if err != nil {
 		return fmt.Errorf("can not list existing events: %w", err)
 	}
 
+	existingEventsByReference := make(map[string]*database.Event)
+
+	for j := range existingEvents {
+		if existingEvents[j].ExternalReference == "" {
+			continue
+		}
+
+		// Prefer active events if there are multiple with the same reference.
+		if e, ok := existingEventsByReference[existingEvents[j].ExternalReference]; ok && e.Active {
+			continue
+		}
+
+		existingEventsByReference[existingEvents[j].ExternalReference] = &existingEvents[j]
+	}
+
 	newEvents := make([]database.Event, 0)
 
 	for _, event := range events {
@@ -117,25 +148,41 @@ func (service *service) refreshIcalInput(input *icaldb.IcalInput) error {
 			continue
 		}
 
-		update := false
+		existingEvent, ok := existingEventsByReference[event.ExternalReference]
+		if !ok {
+			newEvents = append(newEvents, event)
+			continue
+		}
 
-		for _, eEvent := range existingEvents {
-			if eEvent.ExternalReference == "" {
-				continue
-			}
+		if !eventChanged(existingEvent, &event) {
+			continue
+		}
 
-			if eEvent.ExternalReference == event.ExternalReference {
-				update = true
-				break
-			}
+		// Events moved to the future are due again, otherwise the local state (e.g. acknowledged) is kept.
+		if !existingEvent.Time.Equal(event.Time) && event.Time.After(time.Now()) {
+			existingEvent.Active = true
 		}
 
-		if !update {
-			newEvents = append(newEvents, event)
-			continue
+		existingEvent.Time = event.Time
+		existingEvent.Duration = event.Duration
+		existingEvent.Message = event.Message
+		existingEvent.RepeatRule = event.RepeatRule
+		existingEvent.RepeatInterval = event.RepeatInterval
+		existingEvent.RepeatUntil = event.RepeatUntil
+
+		_, err = service.config.Database.UpdateEvent(existingEvent)
+		if err != nil {
+			return fmt.Errorf("failed to update event: %w", err)
 		}
+	}
+
+	err = service.deactivateRemovedEvents(existingEvents, references)
+	if err != nil {
+		return err
+	}
 
-		// TODO handle event updates.
+	if len(newEvents) == 0 {
+		return nil
 	}
 
 	err = service.config.Database.NewEvents(newEvents)
@@ -146,7 +193,43 @@ func (service *service) refreshIcalInput(input *icaldb.IcalInput) error {
 	return nil
 }
 
-func getFileContent(url string, logger *slog.Logger) (string, error) {
+// deactivateRemovedEvents deactivates all events that are no longer part of the iCal resource.
+func (service *service) deactivateRemovedEvents(existingEvents []database.Event, references []string) error {
+	for j := range existingEvents {
+		if !existingEvents[j].Active || existingEvents[j].ExternalReference == "" ||
+			slices.Contains(references, existingEvents[j].ExternalReference) {
+			continue
+		}
+
+		existingEvents[j].Active = false
+
+		_, err := service.config.Database.UpdateEvent(&existingEvents[j])
+		if err != nil {
+			return fmt.Errorf("failed to deactivate event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func eventChanged(existingEvent, event *database.Event) bool {
+	return !existingEvent.Time.Equal(event.Time) ||
+		existingEvent.Duration != event.Duration ||
+		existingEvent.Message != event.Message ||
+		existingEvent.RepeatRule != event.RepeatRule ||
+		!equalPointers(existingEvent.RepeatInterval, event.RepeatInterval, func(a, b time.Duration) bool { return a == b }) ||
+		!equalPointers(existingEvent.RepeatUntil, event.RepeatUntil, time.Time.Equal)
+}
+
+func equalPointers[T any](a, b *T, equal func(T, T) bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return equal(*a, *b)
+}
+
+func (service *service) getFileContent(url string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
 	defer cancel()
 
@@ -156,11 +239,9 @@ func getFileContent(url string, logger *slog.Logger) (string, error) {
 	}
 
 	req.Header.Set("Accept", "text/calendar")
-	logger.Debug("making request", "url", url, "http.method", "GET")
-
-	client := &http.Client{}
+	service.logger.Debug("making request", "url", url, "http.method", "GET")
 
-	resp, err := client.Do(req)
+	resp, err := service.httpClient().Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -177,3 +258,13 @@ func getFileContent(url string, logger *slog.Logger) (string, error) {
 
 	return string(data), nil
 }
+
+// httpClient returns the client to fetch iCal resources with. Unless private networks are allowed it refuses to
+// connect to non-public addresses, also after redirects.
+func (service *service) httpClient() *http.Client {
+	if service.config.AllowPrivateNetworks {
+		return &http.Client{}
+	}
+
+	return &http.Client{Transport: httpclient.PublicTransport()}
+}
@@ -14,9 +14,9 @@ import (
 
 // List of commonly used errors in this package.
 var (
-	ErrMissingDtStart     = errors.New("missing property DTSTART")
 	ErrCanNotGetStartTime = errors.New("can not get event start time")
 	ErrCanNotGetEndTime   = errors.New("can not get event end time")
+	ErrNotRecurring       = errors.New("event is not recurring")
 )
 
 var dateFormatICal = "20060102T150405Z07:00"
@@ -32,22 +32,16 @@ func NewCalendar(calendarID string, events []database.Event) string {
 		// TODO make configurable
 		endTime := event.Time.Add(time.Minute * 5)
 
-		ical.WriteString("BEGIN:VEVENT\nDTSTART:")
-		ical.WriteString(event.Time.UTC().Format(dateFormatICal))
-		ical.WriteString("\nDTEND:")
-		ical.WriteString(endTime.UTC().Format(dateFormatICal))
+		ical.WriteString("BEGIN:VEVENT\nDTSTART")
+		ical.WriteString(timeToIcal(event.Time, locationFromEvent(&event)))
+		ical.WriteString("\nDTEND")
+		ical.WriteString(timeToIcal(endTime, locationFromEvent(&event)))
 		ical.WriteString("\nDTSTAMP:")
 		ical.WriteString(event.CreatedAt.UTC().Format(dateFormatICal))
 
-		if event.RepeatInterval != nil {
-			if event.RepeatUntil != nil && time.Until(*event.RepeatUntil) > 0 ||
-				event.RepeatUntil == nil {
-				ical.WriteString("\n")
-				ical.WriteString(MinutesToIcalRecurrenceRule(
-					*event.RepeatInterval,
-					occurencesFromStartAndEnd(event.Time, *event.RepeatInterval, event.RepeatUntil),
-				))
-			}
+		if rule := rruleFromEvent(&event); rule != "" {
+			ical.WriteString("\n")
+			ical.WriteString(rule)
 		}
 
 		ical.WriteString("\nUID:")
@@ -64,6 +58,60 @@ func NewCalendar(calendarID string, events []database.Event) string {
 	return ical.String()
 }
 
+// rruleFromEvent returns the iCal recurrence rule for the event or an empty string
+// if the event does not repeat (anymore).
+func rruleFromEvent(event *database.Event) string {
+	if event.RepeatUntil != nil && time.Until(*event.RepeatUntil) <= 0 {
+		return ""
+	}
+
+	if event.RepeatRule != "" {
+		opts, err := rrule.StrToROption(event.RepeatRule)
+		if err != nil {
+			return ""
+		}
+
+		if event.RepeatUntil != nil && opts.Count == 0 {
+			opts.Until = *event.RepeatUntil
+		}
+
+		return "RRULE:" + opts.RRuleString()
+	}
+
+	if event.RepeatInterval != nil {
+		return MinutesToIcalRecurrenceRule(
+			*event.RepeatInterval,
+			occurencesFromStartAndEnd(event.Time, *event.RepeatInterval, event.RepeatUntil),
+		)
+	}
+
+	return ""
+}
+
+// locationFromEvent returns the time zone the event's repeat rule is anchored in.
+// Calendar based rules need to be expanded in that time zone to stay stable across DST switches.
+func locationFromEvent(event *database.Event) *time.Location {
+	if event.RepeatRule == "" {
+		return time.UTC
+	}
+
+	opts, err := rrule.StrToROption(event.RepeatRule)
+	if err != nil || opts.Dtstart.IsZero() {
+		return time.UTC
+	}
+
+	return opts.Dtstart.Location()
+}
+
+// timeToIcal formats the time as iCal property value including the ":" separator.
+func timeToIcal(t time.Time, loc *time.Location) string {
+	if loc == time.UTC {
+		return ":" + t.UTC().Format(dateFormatICal)
+	}
+
+	return ";TZID=" + loc.String() + ":" + t.In(loc).Format("20060102T150405")
+}
+
 // MinutesToIcalRecurrenceRule transfers the given minutes into an iCal recurrence rule
 // https://icalendar.org/iCalendar-RFC-5545/3-8-5-3-recurrence-rule.html
 func MinutesToIcalRecurrenceRule(interval time.Duration, occurences uint64) string {
@@ -139,8 +187,11 @@ type EventOpts struct {
 }
 
 // EventsFromIcal extracts events from the iCal input.
+//
+// A recurring event results in one event at its next occurrence that repeats by the recurrence rule of the
+// calendar. Occurrences removed via EXDATE or moved by an event with a RECURRENCE-ID are respected by refreshing
+// the calendar, cancelled events are skipped.
 func EventsFromIcal(input string, opts *EventOpts) ([]database.Event, error) {
-	// TODO currently does not set recurring attributes.
 	calendar, err := ical.ParseCalendar(strings.NewReader(input))
 	if err != nil {
 		return nil, fmt.Errorf("can not parse iCal calendar: %w", err)
@@ -148,73 +199,196 @@ func EventsFromIcal(input string, opts *EventOpts) ([]database.Event, error) {
 
 	events := make([]database.Event, 0)
 
+	for _, series := range seriesFromCalendar(calendar) {
+		event, ok := eventFromSeries(series, opts)
+		if !ok {
+			continue
+		}
+
+		events = append(events, *event)
+	}
+
+	return events, nil
+}
+
+// ExternalReferencesFromIcal returns the UIDs of all events in the iCal input that are not cancelled. Other
+// than EventsFromIcal it includes events in the past.
+func ExternalReferencesFromIcal(input string) ([]string, error) {
+	calendar, err := ical.ParseCalendar(strings.NewReader(input))
+	if err != nil {
+		return nil, fmt.Errorf("can not parse iCal calendar: %w", err)
+	}
+
+	references := make([]string, 0)
+
+	for _, series := range seriesFromCalendar(calendar) {
+		if series.master == nil || isCancelled(series.master) {
+			continue
+		}
+
+		references = append(references, series.uid)
+	}
+
+	return references, nil
+}
+
+// eventSeries groups all iCal events sharing the same UID.
+type eventSeries struct {
+	uid       string
+	master    *ical.VEvent
+	overrides []*ical.VEvent // Events with a RECURRENCE-ID replacing a single occurrence.
+}
+
+func seriesFromCalendar(calendar *ical.Calendar) []*eventSeries {
+	seriesList := make([]*eventSeries, 0)
+	seriesByUID := make(map[string]*eventSeries)
+
 	for _, event := range calendar.Events() {
 		idProp := event.GetProperty(ical.ComponentPropertyUniqueId)
-		if idProp == nil {
+		if idProp == nil || len(idProp.Value) <= 0 {
 			continue
 		}
 
-		id := idProp.Value
-		if len(id) <= 0 {
-			continue
+		series, ok := seriesByUID[idProp.Value]
+		if !ok {
+			series = &eventSeries{
+				uid: idProp.Value,
+			}
+			seriesByUID[idProp.Value] = series
+			seriesList = append(seriesList, series)
+		}
+
+		if event.GetProperty(ical.ComponentPropertyRecurrenceId) != nil {
+			series.overrides = append(series.overrides, event)
+		} else {
+			series.master = event
 		}
+	}
+
+	return seriesList
+}
+
+func eventFromSeries(series *eventSeries, opts *EventOpts) (*database.Event, bool) {
+	if series.master == nil || isCancelled(series.master) {
+		return nil, false
+	}
+
+	movedOccurences := make([]time.Time, 0, len(series.overrides))
 
-		startTime, err := getStartTimeFromEvent(event)
+	for _, override := range series.overrides {
+		recurrenceID, err := override.GetRecurrenceID()
 		if err != nil {
 			continue
 		}
 
-		duration, err := getDurationFromEvent(event)
-		if err != nil {
-			duration = opts.DefaultDuration
+		movedOccurences = append(movedOccurences, recurrenceID)
+	}
+
+	startTime, err := getStartTimeFromEvent(series.master, movedOccurences)
+	if err != nil {
+		return nil, false
+	}
+
+	duration, err := getDurationFromEvent(series.master)
+	if err != nil {
+		duration = opts.DefaultDuration
+	}
+
+	name := getMessageFromEvent(series.master)
+
+	startTime = startTime.Add(opts.EventDelay)
+
+	// An occurrence moved by an override might happen before the next regular one.
+	for _, override := range series.overrides {
+		if isCancelled(override) {
+			continue
 		}
 
-		startTime = startTime.Add(opts.EventDelay)
+		overrideStartTime, err := getStartTimeFromEvent(override, nil)
+		if err != nil {
+			continue
+		}
 
-		if time.Until(startTime) < 0 {
-			// Ignore past events
+		overrideStartTime = overrideStartTime.Add(opts.EventDelay)
+		if time.Until(overrideStartTime) < 0 {
 			continue
 		}
 
-		name := getMessageFromEvent(event)
-		if name == "" {
+		if time.Until(startTime) >= 0 && !overrideStartTime.Before(startTime) {
 			continue
 		}
 
-		events = append(events, database.Event{
-			Time:              startTime,
-			Duration:          duration,
-			Message:           getMessageFromEvent(event),
-			Active:            true,
-			ChannelID:         opts.ChannelID,
-			InputID:           &opts.InputID,
-			ExternalReference: id,
-		})
+		startTime = overrideStartTime
+
+		if overrideDuration, err := getDurationFromEvent(override); err == nil {
+			duration = overrideDuration
+		}
+
+		if overrideName := getMessageFromEvent(override); overrideName != "" {
+			name = overrideName
+		}
 	}
 
-	return events, nil
+	if time.Until(startTime) < 0 {
+		// Ignore past events
+		return nil, false
+	}
+
+	if name == "" {
+		return nil, false
+	}
+
+	event := &database.Event{
+		Time:              startTime,
+		Duration:          duration,
+		Message:           name,
+		Active:            true,
+		ChannelID:         opts.ChannelID,
+		InputID:           &opts.InputID,
+		ExternalReference: series.uid,
+	}
+
+	repeatRule, err := getRepeatRuleFromEvent(series.master, opts.EventDelay)
+	if err == nil {
+		event.RepeatRule = repeatRule
+	}
+
+	return event, true
 }
 
-func getStartTimeFromEvent(event *ical.VEvent) (time.Time, error) {
-	startTime, err := event.GetStartAt()
+func isCancelled(event *ical.VEvent) bool {
+	status := event.GetProperty(ical.ComponentPropertyStatus)
+	if status == nil {
+		return false
+	}
+
+	return strings.EqualFold(status.Value, string(ical.ObjectStatusCancelled))
+}
+
+// getStartTimeFromEvent returns the start time of the event. For recurring events the next occurrence not
+// listed in EXDATE or excludedOccurences is returned.
+func getStartTimeFromEvent(event *ical.VEvent, excludedOccurences []time.Time) (time.Time, error) {
+	startTime, err := getDtStartFromEvent(event)
 	if err != nil {
-		startTime, err = event.GetAllDayStartAt()
-		if err != nil {
-			return time.Now(), fmt.Errorf("%w: %v", ErrCanNotGetStartTime, err)
-		}
+		return time.Now(), err
 	}
 
-	rruleString := event.GetProperty(ical.ComponentPropertyRrule)
-	if rruleString != nil {
-		// RRULE needs the DTSTART too
-		dtStart := event.GetProperty(ical.ComponentPropertyDtStart)
-		if dtStart == nil {
-			return time.Now(), ErrMissingDtStart
+	if isRecurring(event) {
+		rruleObj, err := getRRuleFromEvent(event, startTime)
+		if err != nil {
+			return time.Now(), err
 		}
 
-		rruleObj, err := rrule.StrToRRule("DTSTART:" + dtStart.Value + "\n" + rruleString.Value)
+		set := &rrule.Set{}
+		set.RRule(rruleObj)
+
+		exDates, err := event.GetExDates()
 		if err != nil {
-			return time.Now(), fmt.Errorf("failed to parse RRULE: %w", err)
+			return time.Now(), fmt.Errorf("failed to parse EXDATE: %w", err)
+		}
+
+		for _, exDate := range append(exDates, excludedOccurences...) {
+			set.ExDate(exDate)
 		}
 
 		refTime := time.Now()
@@ -222,12 +396,66 @@ func getStartTimeFromEvent(event *ical.VEvent) (time.Time, error) {
 			refTime = startTime.Add(time.Second * -1)
 		}
 
-		return rruleObj.After(refTime, false), nil
+		return set.After(refTime, false), nil
 	}
 
 	return startTime, nil
 }
 
+func isRecurring(event *ical.VEvent) bool {
+	return event.GetProperty(ical.ComponentPropertyRrule) != nil
+}
+
+func getDtStartFromEvent(event *ical.VEvent) (time.Time, error) {
+	startTime, err := event.GetStartAt()
+	if err != nil {
+		startTime, err = event.GetAllDayStartAt()
+		if err != nil {
+			return time.Now(), fmt.Errorf("%w: %v", ErrCanNotGetStartTime, err)
+		}
+	}
+
+	return startTime, nil
+}
+
+// getRRuleFromEvent returns the recurrence rule of a recurring event.
+func getRRuleFromEvent(event *ical.VEvent, startTime time.Time) (*rrule.RRule, error) {
+	rruleString := event.GetProperty(ical.ComponentPropertyRrule)
+	if rruleString == nil {
+		return nil, ErrNotRecurring
+	}
+
+	rruleOpts, err := rrule.StrToROptionInLocation(rruleString.Value, startTime.Location())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse RRULE: %w", err)
+	}
+
+	rruleOpts.Dtstart = startTime
+
+	rruleObj, err := rrule.NewRRule(*rruleOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse RRULE: %w", err)
+	}
+
+	return rruleObj, nil
+}
+
+// getRepeatRuleFromEvent returns the recurrence rule of the event anchored at its start, empty if the event does
+// not repeat.
+func getRepeatRuleFromEvent(event *ical.VEvent, delay time.Duration) (string, error) {
+	rruleString := event.GetProperty(ical.ComponentPropertyRrule)
+	if rruleString == nil {
+		return "", nil
+	}
+
+	startTime, err := getDtStartFromEvent(event)
+	if err != nil {
+		return "", err
+	}
+
+	return database.NewRepeatRule(rruleString.Value, startTime.Add(delay))
+}
+
 func getDurationFromEvent(event *ical.VEvent) (time.Duration, error) {
 	// Check if is usual event
 	startTime, err := event.GetStartAt()
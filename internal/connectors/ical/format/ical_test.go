@@ -52,6 +52,42 @@ func TestNewCalendar(t *testing.T) {
 	assert.Equal(t, string(icalShould), ical)
 }
 
+func TestNewCalendarWithRepeatRule(t *testing.T) {
+	events := []database.Event{
+		{
+			Model: gorm.Model{
+				ID: 1,
+			},
+			Time:        time.Date(2120, 1, 1, 9, 0, 0, 0, time.UTC),
+			RepeatRule:  "DTSTART;TZID=Europe/Berlin:21200101T100000\nRRULE:FREQ=MONTHLY;BYMONTHDAY=1",
+			RepeatUntil: new(time.Date(2121, 1, 1, 9, 0, 0, 0, time.UTC)),
+			Message:     "Event 1",
+		},
+	}
+
+	ical := format.NewCalendar("cal 1", events)
+	assert.Contains(t, ical, "DTSTART;TZID=Europe/Berlin:21200101T100000\n")
+	assert.Contains(t, ical, "DTEND;TZID=Europe/Berlin:21200101T100500\n")
+	assert.Contains(t, ical, "RRULE:FREQ=MONTHLY;UNTIL=21210101T090000Z;BYMONTHDAY=1\n")
+}
+
+func TestNewCalendarWithExpiredRepeatRule(t *testing.T) {
+	events := []database.Event{
+		{
+			Model: gorm.Model{
+				ID: 1,
+			},
+			Time:        time.Date(2120, 1, 1, 9, 0, 0, 0, time.UTC),
+			RepeatRule:  "DTSTART;TZID=Europe/Berlin:21200101T100000\nRRULE:FREQ=MONTHLY;BYMONTHDAY=1",
+			RepeatUntil: new(time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC)),
+			Message:     "Event 1",
+		},
+	}
+
+	ical := format.NewCalendar("cal 1", events)
+	assert.NotContains(t, ical, "RRULE")
+}
+
 func TestMinutesToIcalRecurrenceRule(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -136,6 +172,87 @@ func TestEventsFromIcal(t *testing.T) {
 	assert.Equal(t, time.Minute*5, events[2].Duration)
 	assert.Equal(t, "Event 3", events[2].Message)
 	assert.Equal(t, "3", events[2].ExternalReference)
+
+	assert.Equal(t, "DTSTART:21200102T150405Z\nRRULE:FREQ=DAILY", events[0].RepeatRule)
+	assert.Empty(t, events[1].RepeatRule)
+	assert.Equal(t, "DTSTART:21200102T150405Z\nRRULE:FREQ=DAILY;COUNT=4", events[2].RepeatRule)
+
+	for _, event := range events {
+		assert.Nil(t, event.RepeatInterval)
+		assert.Nil(t, event.RepeatUntil)
+	}
+}
+
+func TestEventsFromIcalWithRecurrenceRules(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rrule    string
+		expected []time.Time // Upcoming occurrences of the resulting event.
+	}{
+		{
+			name:     "hourly with interval",
+			rrule:    "FREQ=HOURLY;INTERVAL=3;COUNT=3",
+			expected: []time.Time{testTime().UTC(), testTime().UTC().Add(time.Hour * 3), testTime().UTC().Add(time.Hour * 6)},
+		},
+		{
+			name:     "weekly on multiple weekdays",
+			rrule:    "FREQ=WEEKLY;BYDAY=TU,FR;COUNT=3",
+			expected: []time.Time{testTime().UTC(), testTime().UTC().Add(time.Hour * 24 * 3), testTime().UTC().Add(time.Hour * 24 * 7)},
+		},
+		{
+			name:  "monthly on the last weekday",
+			rrule: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;UNTIL=21200401T000000Z",
+			expected: []time.Time{
+				time.Date(2120, 1, 31, 15, 4, 5, 0, time.UTC),
+				time.Date(2120, 2, 29, 15, 4, 5, 0, time.UTC),
+				time.Date(2120, 3, 29, 15, 4, 5, 0, time.UTC),
+			},
+		},
+		{
+			name:  "daily with hours",
+			rrule: "FREQ=DAILY;BYHOUR=10,15;COUNT=3",
+			expected: []time.Time{
+				testTime().UTC(),
+				time.Date(2120, 1, 3, 10, 4, 5, 0, time.UTC),
+				time.Date(2120, 1, 3, 15, 4, 5, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := `
+BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:cal 1
+BEGIN:VEVENT
+DTSTART:21200102T150405Z
+RRULE:` + tc.rrule + `
+UID:1
+SUMMARY:Event 1
+END:VEVENT
+END:VCALENDAR
+`
+
+			events, err := format.EventsFromIcal(data, &format.EventOpts{})
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+
+			assert.Contains(t, events[0].RepeatRule, "DTSTART:21200102T150405Z\nRRULE:")
+			assert.Nil(t, events[0].RepeatInterval)
+			assert.Nil(t, events[0].RepeatUntil)
+
+			event := events[0]
+			occurrences := []time.Time{}
+
+			for !event.Time.IsZero() {
+				occurrences = append(occurrences, event.Time.UTC())
+				event.Time = event.NextEventTime()
+			}
+
+			assert.Equal(t, tc.expected, occurrences)
+		})
+	}
 }
 
 func TestEventsFromIcalWithAllDayEvent(t *testing.T) {
@@ -192,6 +309,139 @@ END:VCALENDAR
 	require.Empty(t, events)
 }
 
+func TestEventsFromIcalWithExDate(t *testing.T) {
+	data := `
+BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:cal 1
+BEGIN:VEVENT
+DTSTART:21200102T150405Z
+DTEND:21200102T150905Z
+RRULE:FREQ=DAILY
+EXDATE:21200102T150405Z,21200103T150405Z
+UID:1
+SUMMARY:Event 1
+END:VEVENT
+END:VCALENDAR
+`
+
+	events, err := format.EventsFromIcal(data, &format.EventOpts{})
+	require.NoError(t, err)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, testTime().Add(time.Hour*48).UTC(), events[0].Time.UTC())
+}
+
+func TestEventsFromIcalWithRecurrenceID(t *testing.T) {
+	data := `
+BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:cal 1
+BEGIN:VEVENT
+DTSTART:21200102T150405Z
+DTEND:21200102T150905Z
+RRULE:FREQ=DAILY
+UID:1
+SUMMARY:Event 1
+END:VEVENT
+BEGIN:VEVENT
+RECURRENCE-ID:21200102T150405Z
+DTSTART:21200102T180405Z
+DTEND:21200102T190405Z
+UID:1
+SUMMARY:Event 1 moved
+END:VEVENT
+BEGIN:VEVENT
+DTSTART:21200102T150405Z
+DTEND:21200102T150905Z
+RRULE:FREQ=DAILY
+UID:2
+SUMMARY:Event 2
+END:VEVENT
+BEGIN:VEVENT
+RECURRENCE-ID:21200102T150405Z
+DTSTART:21200102T150405Z
+UID:2
+STATUS:CANCELLED
+SUMMARY:Event 2
+END:VEVENT
+END:VCALENDAR
+`
+
+	events, err := format.EventsFromIcal(data, &format.EventOpts{})
+	require.NoError(t, err)
+
+	require.Len(t, events, 2)
+
+	assert.Equal(t, testTime().Add(time.Hour*3).UTC(), events[0].Time.UTC())
+	assert.Equal(t, time.Hour, events[0].Duration)
+	assert.Equal(t, "Event 1 moved", events[0].Message)
+	assert.Equal(t, "1", events[0].ExternalReference)
+
+	assert.Equal(t, testTime().Add(time.Hour*24).UTC(), events[1].Time.UTC())
+	assert.Equal(t, "Event 2", events[1].Message)
+	assert.Equal(t, "2", events[1].ExternalReference)
+}
+
+func TestEventsFromIcalWithCancelledEvent(t *testing.T) {
+	data := `
+BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:cal 1
+BEGIN:VEVENT
+DTSTART:21200102T150405Z
+UID:1
+STATUS:CANCELLED
+SUMMARY:Event 1
+END:VEVENT
+END:VCALENDAR
+`
+
+	events, err := format.EventsFromIcal(data, &format.EventOpts{})
+	require.NoError(t, err)
+
+	require.Empty(t, events)
+}
+
+func TestExternalReferencesFromIcal(t *testing.T) {
+	data := `
+BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:cal 1
+BEGIN:VEVENT
+DTSTART:20000102T150405Z
+UID:1
+SUMMARY:Past event
+END:VEVENT
+BEGIN:VEVENT
+DTSTART:21200102T150405Z
+UID:2
+STATUS:CANCELLED
+SUMMARY:Cancelled event
+END:VEVENT
+BEGIN:VEVENT
+DTSTART:21200102T150405Z
+UID:3
+SUMMARY:Event
+END:VEVENT
+BEGIN:VEVENT
+DTSTART:21200102T150405Z
+SUMMARY:Event without UID
+END:VEVENT
+END:VCALENDAR
+`
+
+	references, err := format.ExternalReferencesFromIcal(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1", "3"}, references)
+}
+
+func TestExternalReferencesFromIcalWithInvalidCalendar(t *testing.T) {
+	_, err := format.ExternalReferencesFromIcal("BEGIN:VEVENT")
+	require.Error(t, err)
+}
+
 func toP[T any](elem T) *T {
 	return new(elem)
 }
@@ -6,6 +6,7 @@ import (
 
 	icaldb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/ical/database"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/daemon"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/httpclient"
 )
 
 // The in- and output type provided by this package
@@ -16,7 +17,9 @@ const (
 
 // List of commonly used errors in this package.
 var (
-	ErrNotFound = errors.New("not found")
+	ErrNotFound         = errors.New("not found")
+	ErrInvalidURL       = errors.New("invalid url")
+	ErrNonPublicAddress = httpclient.ErrNonPublicAddress
 )
 
 // Service provides and interface for the ical connector.
@@ -25,9 +28,15 @@ type Service interface {
 	Start() error
 	Stop() error
 
+	InputExists(inputType string, inputID uint) (bool, error)
 	InputRemoved(inputType string, inputID uint) error
+	OutputExists(outputType string, outputID uint) (bool, error)
 	OutputRemoved(outputType string, outputID uint) error
 
+	NewInput(channelID uint, url string) (*icaldb.IcalInput, error)
+	GetInput(inputID uint) (*icaldb.IcalInput, error)
+	RefreshInput(inputID uint) error
+
 	NewOutput(channelID uint) (*icaldb.IcalOutput, string, error)                 // Returns the calendar URL.
 	GetOutput(outputID uint, regenToken bool) (*icaldb.IcalOutput, string, error) // Returns the calendar URL.
 
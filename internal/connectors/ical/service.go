@@ -40,6 +40,8 @@ type Config struct {
 	BaseURL *url.URL
 
 	RefreshInterval time.Duration
+	// Allow fetching iCal inputs from loopback, private and link-local addresses.
+	AllowPrivateNetworks bool
 }
 
 type service struct {
@@ -86,6 +88,19 @@ func (service *service) Stop() error {
 	return nil
 }
 
+func (service *service) InputExists(inputType string, inputID uint) (bool, error) {
+	if inputType != InputType {
+		return false, nil
+	}
+
+	_, err := service.config.ICalDB.GetIcalInputByID(inputID)
+	if errors.Is(err, icaldb.ErrNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
 func (service *service) InputRemoved(inputType string, inputID uint) error {
 	if inputType != InputType {
 		return nil
@@ -99,6 +114,19 @@ func (service *service) InputRemoved(inputType string, inputID uint) error {
 	return err
 }
 
+func (service *service) OutputExists(outputType string, outputID uint) (bool, error) {
+	if outputType != OutputType {
+		return false, nil
+	}
+
+	_, err := service.config.ICalDB.GetIcalOutputByID(outputID)
+	if errors.Is(err, icaldb.ErrNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
 func (service *service) OutputRemoved(outputType string, outputID uint) error {
 	if outputType != OutputType {
 		return nil
@@ -112,6 +140,63 @@ func (service *service) OutputRemoved(outputType string, outputID uint) error {
 	return err
 }
 
+func (service *service) NewInput(channelID uint, inputURL string) (*icaldb.IcalInput, error) {
+	parsedURL, err := url.Parse(inputURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return nil, ErrInvalidURL
+	}
+
+	icalInput, err := service.config.ICalDB.NewIcalInput(&icaldb.IcalInput{
+		URL: parsedURL.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = service.config.Database.AddInputToChannel(channelID, &database.Input{
+		ChannelID: channelID,
+		InputType: InputType,
+		InputID:   icalInput.ID,
+		Enabled:   true,
+	})
+	if err != nil {
+		// Do not leave an input behind no channel uses.
+		deleteErr := service.config.ICalDB.DeleteIcalInput(icalInput.ID)
+		if deleteErr != nil {
+			service.logger.Error("failed to delete iCal input", "error", deleteErr, "ical.input_id", icalInput.ID)
+		}
+
+		return nil, err
+	}
+
+	return icalInput, nil
+}
+
+func (service *service) GetInput(inputID uint) (*icaldb.IcalInput, error) {
+	input, err := service.config.ICalDB.GetIcalInputByID(inputID)
+	if err != nil {
+		if errors.Is(err, icaldb.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return input, nil
+}
+
+func (service *service) RefreshInput(inputID uint) error {
+	input, err := service.GetInput(inputID)
+	if err != nil {
+		return err
+	}
+
+	// A manual refresh gives disabled inputs another chance.
+	input.Disabled = false
+
+	return service.refreshAndUpdateIcalInput(input)
+}
+
 func (service *service) NewOutput(channelID uint) (*icaldb.IcalOutput, string, error) {
 	icalOutput, err := service.config.ICalDB.NewIcalOutput(&icaldb.IcalOutput{})
 	if err != nil {
@@ -83,6 +83,68 @@ func (_c *MockService_Cleanup_Call) RunAndReturn(run func() error) *MockService_
 	return _c
 }
 
+// GetInput provides a mock function for the type MockService
+func (_mock *MockService) GetInput(inputID uint) (*database.IcalInput, error) {
+	ret := _mock.Called(inputID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInput")
+	}
+
+	var r0 *database.IcalInput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uint) (*database.IcalInput, error)); ok {
+		return returnFunc(inputID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uint) *database.IcalInput); ok {
+		r0 = returnFunc(inputID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*database.IcalInput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = returnFunc(inputID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetInput_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInput'
+type MockService_GetInput_Call struct {
+	*mock.Call
+}
+
+// GetInput is a helper method to define mock.On call
+//   - inputID uint
+func (_e *MockService_Expecter) GetInput(inputID interface{}) *MockService_GetInput_Call {
+	return &MockService_GetInput_Call{Call: _e.mock.On("GetInput", inputID)}
+}
+
+func (_c *MockService_GetInput_Call) Run(run func(inputID uint)) *MockService_GetInput_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uint
+		if args[0] != nil {
+			arg0 = args[0].(uint)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetInput_Call) Return(icalInput *database.IcalInput, err error) *MockService_GetInput_Call {
+	_c.Call.Return(icalInput, err)
+	return _c
+}
+
+func (_c *MockService_GetInput_Call) RunAndReturn(run func(inputID uint) (*database.IcalInput, error)) *MockService_GetInput_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetOutput provides a mock function for the type MockService
 func (_mock *MockService) GetOutput(outputID uint, regenToken bool) (*database.IcalOutput, string, error) {
 	ret := _mock.Called(outputID, regenToken)
@@ -157,6 +219,72 @@ func (_c *MockService_GetOutput_Call) RunAndReturn(run func(outputID uint, regen
 	return _c
 }
 
+// InputExists provides a mock function for the type MockService
+func (_mock *MockService) InputExists(inputType string, inputID uint) (bool, error) {
+	ret := _mock.Called(inputType, inputID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InputExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, uint) (bool, error)); ok {
+		return returnFunc(inputType, inputID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, uint) bool); ok {
+		r0 = returnFunc(inputType, inputID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, uint) error); ok {
+		r1 = returnFunc(inputType, inputID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_InputExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InputExists'
+type MockService_InputExists_Call struct {
+	*mock.Call
+}
+
+// InputExists is a helper method to define mock.On call
+//   - inputType string
+//   - inputID uint
+func (_e *MockService_Expecter) InputExists(inputType interface{}, inputID interface{}) *MockService_InputExists_Call {
+	return &MockService_InputExists_Call{Call: _e.mock.On("InputExists", inputType, inputID)}
+}
+
+func (_c *MockService_InputExists_Call) Run(run func(inputType string, inputID uint)) *MockService_InputExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 uint
+		if args[1] != nil {
+			arg1 = args[1].(uint)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_InputExists_Call) Return(b bool, err error) *MockService_InputExists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockService_InputExists_Call) RunAndReturn(run func(inputType string, inputID uint) (bool, error)) *MockService_InputExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // InputRemoved provides a mock function for the type MockService
 func (_mock *MockService) InputRemoved(inputType string, inputID uint) error {
 	ret := _mock.Called(inputType, inputID)
@@ -214,6 +342,74 @@ func (_c *MockService_InputRemoved_Call) RunAndReturn(run func(inputType string,
 	return _c
 }
 
+// NewInput provides a mock function for the type MockService
+func (_mock *MockService) NewInput(channelID uint, url string) (*database.IcalInput, error) {
+	ret := _mock.Called(channelID, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewInput")
+	}
+
+	var r0 *database.IcalInput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uint, string) (*database.IcalInput, error)); ok {
+		return returnFunc(channelID, url)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uint, string) *database.IcalInput); ok {
+		r0 = returnFunc(channelID, url)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*database.IcalInput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uint, string) error); ok {
+		r1 = returnFunc(channelID, url)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_NewInput_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewInput'
+type MockService_NewInput_Call struct {
+	*mock.Call
+}
+
+// NewInput is a helper method to define mock.On call
+//   - channelID uint
+//   - url string
+func (_e *MockService_Expecter) NewInput(channelID interface{}, url interface{}) *MockService_NewInput_Call {
+	return &MockService_NewInput_Call{Call: _e.mock.On("NewInput", channelID, url)}
+}
+
+func (_c *MockService_NewInput_Call) Run(run func(channelID uint, url string)) *MockService_NewInput_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uint
+		if args[0] != nil {
+			arg0 = args[0].(uint)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_NewInput_Call) Return(icalInput *database.IcalInput, err error) *MockService_NewInput_Call {
+	_c.Call.Return(icalInput, err)
+	return _c
+}
+
+func (_c *MockService_NewInput_Call) RunAndReturn(run func(channelID uint, url string) (*database.IcalInput, error)) *MockService_NewInput_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewOutput provides a mock function for the type MockService
 func (_mock *MockService) NewOutput(channelID uint) (*database.IcalOutput, string, error) {
 	ret := _mock.Called(channelID)
@@ -282,6 +478,72 @@ func (_c *MockService_NewOutput_Call) RunAndReturn(run func(channelID uint) (*da
 	return _c
 }
 
+// OutputExists provides a mock function for the type MockService
+func (_mock *MockService) OutputExists(outputType string, outputID uint) (bool, error) {
+	ret := _mock.Called(outputType, outputID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OutputExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, uint) (bool, error)); ok {
+		return returnFunc(outputType, outputID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, uint) bool); ok {
+		r0 = returnFunc(outputType, outputID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, uint) error); ok {
+		r1 = returnFunc(outputType, outputID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_OutputExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OutputExists'
+type MockService_OutputExists_Call struct {
+	*mock.Call
+}
+
+// OutputExists is a helper method to define mock.On call
+//   - outputType string
+//   - outputID uint
+func (_e *MockService_Expecter) OutputExists(outputType interface{}, outputID interface{}) *MockService_OutputExists_Call {
+	return &MockService_OutputExists_Call{Call: _e.mock.On("OutputExists", outputType, outputID)}
+}
+
+func (_c *MockService_OutputExists_Call) Run(run func(outputType string, outputID uint)) *MockService_OutputExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 uint
+		if args[1] != nil {
+			arg1 = args[1].(uint)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_OutputExists_Call) Return(b bool, err error) *MockService_OutputExists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockService_OutputExists_Call) RunAndReturn(run func(outputType string, outputID uint) (bool, error)) *MockService_OutputExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // OutputRemoved provides a mock function for the type MockService
 func (_mock *MockService) OutputRemoved(outputType string, outputID uint) error {
 	ret := _mock.Called(outputType, outputID)
@@ -339,6 +601,57 @@ func (_c *MockService_OutputRemoved_Call) RunAndReturn(run func(outputType strin
 	return _c
 }
 
+// RefreshInput provides a mock function for the type MockService
+func (_mock *MockService) RefreshInput(inputID uint) error {
+	ret := _mock.Called(inputID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshInput")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = returnFunc(inputID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_RefreshInput_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshInput'
+type MockService_RefreshInput_Call struct {
+	*mock.Call
+}
+
+// RefreshInput is a helper method to define mock.On call
+//   - inputID uint
+func (_e *MockService_Expecter) RefreshInput(inputID interface{}) *MockService_RefreshInput_Call {
+	return &MockService_RefreshInput_Call{Call: _e.mock.On("RefreshInput", inputID)}
+}
+
+func (_c *MockService_RefreshInput_Call) Run(run func(inputID uint)) *MockService_RefreshInput_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uint
+		if args[0] != nil {
+			arg0 = args[0].(uint)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_RefreshInput_Call) Return(err error) *MockService_RefreshInput_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_RefreshInput_Call) RunAndReturn(run func(inputID uint) error) *MockService_RefreshInput_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SendDailyReminder provides a mock function for the type MockService
 func (_mock *MockService) SendDailyReminder(dailyReminder *daemon.DailyReminder, output *daemon.Output) error {
 	ret := _mock.Called(dailyReminder, output)
@@ -31,11 +31,50 @@ func testService(t *testing.T) (ical.Service, *icaldb.MockService, *database.Moc
 			Database: db,
 			ICalDB:   icalDB,
 			BaseURL:  url,
+			// Test servers listen on the loopback interface.
+			AllowPrivateNetworks: true,
 		}, slog.New(slog.NewTextHandler(os.Stdout, nil))),
 		icalDB,
 		db
 }
 
+func TestService_InputExists(t *testing.T) {
+	service, icalDB, _ := testService(t)
+
+	icalDB.EXPECT().GetIcalInputByID(uint(1)).Return(&icaldb.IcalInput{}, nil)
+
+	exists, err := service.InputExists("ical", 1)
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestService_InputExistsWithNotFound(t *testing.T) {
+	service, icalDB, _ := testService(t)
+
+	icalDB.EXPECT().GetIcalInputByID(uint(1)).Return(nil, icaldb.ErrNotFound)
+
+	exists, err := service.InputExists("ical", 1)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestService_InputExistsWithWrongType(t *testing.T) {
+	service, _, _ := testService(t)
+
+	exists, err := service.InputExists("notical", 1)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestService_InputExistsWithError(t *testing.T) {
+	service, icalDB, _ := testService(t)
+
+	icalDB.EXPECT().GetIcalInputByID(uint(1)).Return(nil, errors.New("test"))
+
+	_, err := service.InputExists("ical", 1)
+	require.Error(t, err)
+}
+
 func TestService_InputRemoved(t *testing.T) {
 	service, icalDB, _ := testService(t)
 
@@ -70,6 +109,43 @@ func TestService_InputRemovedWithError(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestService_OutputExists(t *testing.T) {
+	service, icalDB, _ := testService(t)
+
+	icalDB.EXPECT().GetIcalOutputByID(uint(1)).Return(&icaldb.IcalOutput{}, nil)
+
+	exists, err := service.OutputExists("ical", 1)
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestService_OutputExistsWithNotFound(t *testing.T) {
+	service, icalDB, _ := testService(t)
+
+	icalDB.EXPECT().GetIcalOutputByID(uint(1)).Return(nil, icaldb.ErrNotFound)
+
+	exists, err := service.OutputExists("ical", 1)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestService_OutputExistsWithWrongType(t *testing.T) {
+	service, _, _ := testService(t)
+
+	exists, err := service.OutputExists("notical", 1)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestService_OutputExistsWithError(t *testing.T) {
+	service, icalDB, _ := testService(t)
+
+	icalDB.EXPECT().GetIcalOutputByID(uint(1)).Return(nil, errors.New("test"))
+
+	_, err := service.OutputExists("ical", 1)
+	require.Error(t, err)
+}
+
 func TestService_OutputRemoved(t *testing.T) {
 	service, icalDB, _ := testService(t)
 
@@ -104,6 +180,189 @@ func TestService_OutputRemovedWithError(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestService_NewInput(t *testing.T) {
+	service, icalDB, db := testService(t)
+
+	icalDB.EXPECT().NewIcalInput(&icaldb.IcalInput{
+		URL: "https://example.com/cal.ics",
+	}).Return(&icaldb.IcalInput{
+		Model: gorm.Model{
+			ID: 1,
+		},
+		URL: "https://example.com/cal.ics",
+	}, nil)
+
+	db.EXPECT().AddInputToChannel(uint(2), &database.Input{
+		ChannelID: 2,
+		InputType: "ical",
+		InputID:   1,
+		Enabled:   true,
+	}).Return(nil)
+
+	input, err := service.NewInput(2, "https://example.com/cal.ics")
+	require.NoError(t, err)
+
+	assert.Equal(t, uint(1), input.ID)
+}
+
+func TestService_NewInputWithInvalidURL(t *testing.T) {
+	service, _, _ := testService(t)
+
+	for _, u := range []string{"", "example.com", "ftp://example.com/cal.ics", "https://", ":/"} {
+		_, err := service.NewInput(2, u)
+		require.ErrorIs(t, err, ical.ErrInvalidURL, u)
+	}
+}
+
+func TestService_NewInputWithAddError(t *testing.T) {
+	service, icalDB, db := testService(t)
+
+	icalDB.EXPECT().NewIcalInput(mock.Anything).Return(&icaldb.IcalInput{
+		Model: gorm.Model{
+			ID: 1,
+		},
+	}, nil)
+
+	db.EXPECT().AddInputToChannel(uint(2), mock.Anything).Return(errors.New("test"))
+	icalDB.EXPECT().DeleteIcalInput(uint(1)).Return(nil)
+
+	_, err := service.NewInput(2, "https://example.com/cal.ics")
+	require.Error(t, err)
+}
+
+func TestService_NewInputWithNewIcalError(t *testing.T) {
+	service, icalDB, _ := testService(t)
+
+	icalDB.EXPECT().NewIcalInput(mock.Anything).Return(nil, errors.New("test"))
+
+	_, err := service.NewInput(2, "https://example.com/cal.ics")
+	require.Error(t, err)
+}
+
+func TestService_GetInput(t *testing.T) {
+	service, icalDB, _ := testService(t)
+
+	icalDB.EXPECT().GetIcalInputByID(uint(1)).Return(&icaldb.IcalInput{
+		Model: gorm.Model{
+			ID: 1,
+		},
+	}, nil)
+
+	input, err := service.GetInput(1)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint(1), input.ID)
+}
+
+func TestService_GetInputWithNotFound(t *testing.T) {
+	service, icalDB, _ := testService(t)
+
+	icalDB.EXPECT().GetIcalInputByID(uint(1)).Return(nil, icaldb.ErrNotFound)
+
+	_, err := service.GetInput(1)
+	require.ErrorIs(t, err, ical.ErrNotFound)
+}
+
+func TestService_RefreshInput(t *testing.T) {
+	service, icalDB, db := testService(t)
+
+	content, err := os.ReadFile("format/testdata/calendar1.ical")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	icalDB.EXPECT().GetIcalInputByID(uint(1)).Return(&icaldb.IcalInput{
+		Model: gorm.Model{
+			ID: 1,
+		},
+		URL:      server.URL + "/",
+		Disabled: true,
+	}, nil)
+
+	db.EXPECT().GetInputByType(uint(1), "ical").Return(&database.Input{
+		Model: gorm.Model{
+			ID: 2,
+		},
+		ChannelID: 3,
+	}, nil)
+	db.EXPECT().ListEvents(mock.Anything).Return([]database.Event{}, nil)
+	db.EXPECT().NewEvents(mock.Anything).Return(nil)
+
+	icalDB.EXPECT().UpdateIcalInput(mock.MatchedBy(func(input *icaldb.IcalInput) bool {
+		return !input.Disabled && input.LastRefresh != nil
+	})).Return(nil, nil)
+
+	err = service.RefreshInput(1)
+	require.NoError(t, err)
+}
+
+func TestService_RefreshInputWithFetchError(t *testing.T) {
+	service, icalDB, db := testService(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	icalDB.EXPECT().GetIcalInputByID(uint(1)).Return(&icaldb.IcalInput{
+		Model: gorm.Model{
+			ID: 1,
+		},
+		URL: server.URL + "/",
+	}, nil)
+
+	db.EXPECT().GetInputByType(uint(1), "ical").Return(&database.Input{
+		Model: gorm.Model{
+			ID: 2,
+		},
+		ChannelID: 3,
+	}, nil)
+
+	icalDB.EXPECT().UpdateIcalInput(mock.MatchedBy(func(input *icaldb.IcalInput) bool {
+		return input.LastRefresh == nil
+	})).Return(nil, nil)
+
+	err := service.RefreshInput(1)
+	require.Error(t, err)
+}
+
+func TestService_RefreshInputWithPrivateAddress(t *testing.T) {
+	db := database.NewMockService(t)
+	icalDB := icaldb.NewMockService(t)
+	service := ical.New(&ical.Config{
+		Database: db,
+		ICalDB:   icalDB,
+	}, slog.New(slog.NewTextHandler(os.Stdout, nil)))
+
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	icalDB.EXPECT().GetIcalInputByID(uint(1)).Return(&icaldb.IcalInput{
+		Model: gorm.Model{
+			ID: 1,
+		},
+		URL: server.URL + "/",
+	}, nil)
+	db.EXPECT().GetInputByType(uint(1), "ical").Return(&database.Input{
+		Model: gorm.Model{
+			ID: 2,
+		},
+		ChannelID: 3,
+	}, nil)
+	icalDB.EXPECT().UpdateIcalInput(mock.Anything).Return(nil, nil)
+
+	err := service.RefreshInput(1)
+	require.ErrorIs(t, err, ical.ErrNonPublicAddress)
+
+	assert.False(t, called)
+}
+
 func TestService_NewOutput(t *testing.T) {
 	service, icalDB, db := testService(t)
 
@@ -258,7 +517,8 @@ func TestService_Fetcher(t *testing.T) {
 	inputID := uint(2)
 
 	db.EXPECT().ListEvents(&database.ListEventsOpts{
-		InputID: &inputID,
+		InputID:         &inputID,
+		IncludeInactive: true,
 	}).Return([]database.Event{
 		{
 			Time:              testTime().UTC(),
@@ -277,6 +537,7 @@ func TestService_Fetcher(t *testing.T) {
 			Message:           "Event 1",
 			Active:            true,
 			Duration:          time.Minute * 5,
+			RepeatRule:        "DTSTART:21200102T150405Z\nRRULE:FREQ=DAILY",
 			ChannelID:         3,
 			InputID:           &inputID,
 			ExternalReference: "1",
@@ -286,6 +547,7 @@ func TestService_Fetcher(t *testing.T) {
 			Message:           "Event 3",
 			Active:            true,
 			Duration:          time.Minute * 5,
+			RepeatRule:        "DTSTART:21200102T150405Z\nRRULE:FREQ=DAILY;COUNT=4",
 			ChannelID:         3,
 			InputID:           &inputID,
 			ExternalReference: "3",
@@ -306,6 +568,139 @@ func TestService_Fetcher(t *testing.T) {
 	calledMutex.Unlock()
 }
 
+func TestService_RefreshInputSyncsEvents(t *testing.T) {
+	service, icalDB, db := testService(t)
+
+	content := `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:cal 1
+BEGIN:VEVENT
+DTSTART:21200102T160405Z
+DTEND:21200102T160905Z
+UID:1
+SUMMARY:Event 1 moved
+END:VEVENT
+BEGIN:VEVENT
+DTSTART:21200102T150405Z
+DTEND:21200102T150905Z
+RRULE:FREQ=WEEKLY
+UID:2
+SUMMARY:Event 2
+END:VEVENT
+BEGIN:VEVENT
+DTSTART:21200102T150405Z
+DTEND:21200102T150905Z
+UID:3
+SUMMARY:Event 3
+END:VEVENT
+BEGIN:VEVENT
+DTSTART:21200102T150405Z
+DTEND:21200102T150905Z
+UID:5
+STATUS:CANCELLED
+SUMMARY:Event 5
+END:VEVENT
+BEGIN:VEVENT
+DTSTART:21200102T150405Z
+DTEND:21200102T150905Z
+UID:6
+SUMMARY:Event 6 renamed
+END:VEVENT
+END:VCALENDAR
+`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	icalDB.EXPECT().GetIcalInputByID(uint(1)).Return(&icaldb.IcalInput{
+		Model: gorm.Model{
+			ID: 1,
+		},
+		URL: server.URL + "/",
+	}, nil)
+
+	db.EXPECT().GetInputByType(uint(1), "ical").Return(&database.Input{
+		Model: gorm.Model{
+			ID: 2,
+		},
+		ChannelID: 3,
+	}, nil)
+
+	inputID := uint(2)
+	existingEvent := func(id uint, reference string, active bool) database.Event {
+		return database.Event{
+			Model: gorm.Model{
+				ID: id,
+			},
+			Time:              testTime().UTC(),
+			Message:           "Event " + reference,
+			Active:            active,
+			Duration:          time.Minute * 5,
+			ChannelID:         3,
+			InputID:           &inputID,
+			ExternalReference: reference,
+		}
+	}
+
+	// Imported as fixed interval before recurrence rules were stored.
+	weeklyEvent := existingEvent(12, "2", true)
+	weeklyEvent.RepeatInterval = new(time.Hour * 24 * 7)
+	weeklyEvent.RepeatUntil = new(testTime().Add(time.Hour * 24 * 365).UTC())
+
+	db.EXPECT().ListEvents(&database.ListEventsOpts{
+		InputID:         &inputID,
+		IncludeInactive: true,
+	}).Return([]database.Event{
+		existingEvent(11, "1", false),
+		weeklyEvent,
+		existingEvent(14, "4", true),
+		existingEvent(15, "5", true),
+		existingEvent(16, "6", false),
+	}, nil)
+
+	// Moved event is updated and activated again.
+	movedEvent := existingEvent(11, "1", true)
+	movedEvent.Time = testTime().Add(time.Hour).UTC()
+	movedEvent.Message = "Event 1 moved"
+	db.EXPECT().UpdateEvent(&movedEvent).Return(nil, nil)
+
+	// Recurring events repeat by the rule of the calendar.
+	recurringEvent := existingEvent(12, "2", true)
+	recurringEvent.RepeatRule = "DTSTART:21200102T150405Z\nRRULE:FREQ=WEEKLY"
+	db.EXPECT().UpdateEvent(&recurringEvent).Return(nil, nil)
+
+	// Removed and cancelled events are deactivated.
+	removedEvent := existingEvent(14, "4", false)
+	db.EXPECT().UpdateEvent(&removedEvent).Return(nil, nil)
+
+	cancelledEvent := existingEvent(15, "5", false)
+	db.EXPECT().UpdateEvent(&cancelledEvent).Return(nil, nil)
+
+	// Changes that do not move the event keep it inactive, e.g. if it was acknowledged.
+	renamedEvent := existingEvent(16, "6", false)
+	renamedEvent.Message = "Event 6 renamed"
+	db.EXPECT().UpdateEvent(&renamedEvent).Return(nil, nil)
+
+	db.EXPECT().NewEvents([]database.Event{
+		{
+			Time:              testTime().UTC(),
+			Message:           "Event 3",
+			Active:            true,
+			Duration:          time.Minute * 5,
+			ChannelID:         3,
+			InputID:           &inputID,
+			ExternalReference: "3",
+		},
+	}).Return(nil)
+
+	icalDB.EXPECT().UpdateIcalInput(mock.Anything).Return(nil, nil)
+
+	err := service.RefreshInput(1)
+	require.NoError(t, err)
+}
+
 func testTime() time.Time {
 	t, _ := time.Parse(time.RFC3339, "2120-01-02T15:04:05+00:00")
 	return t
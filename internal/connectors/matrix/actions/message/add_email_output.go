@@ -0,0 +1,108 @@
+package message
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+var addEmailOutputActionRegex = regexp.MustCompile("(?i)^(add|send|mail)[ ]+(an|the|)[ ]*(reminders|email|e-mail|mail)[ ]+(to|for|)[ ]*[^ @]+@[^ ]+.*$")
+var emailOutputAddressRegex = regexp.MustCompile("[^ ,;<>]+@[^ ,;<>]+")
+
+// AddEmailOutputAction adds e-mail recipients reminders of the channel are mailed to.
+type AddEmailOutputAction struct {
+	logger      *slog.Logger
+	client      mautrixcl.Client
+	messenger   messenger.Messenger
+	matrixDB    matrixdb.Service
+	db          database.Service
+	emailBridge matrix.BridgeServiceEmail
+	storer      *msghelper.Storer
+}
+
+// Configure is called on startup and sets all dependencies.
+func (action *AddEmailOutputAction) Configure(
+	logger *slog.Logger,
+	client mautrixcl.Client,
+	messenger messenger.Messenger,
+	matrixDB matrixdb.Service,
+	db database.Service,
+	bridgeServices *matrix.BridgeServices,
+) {
+	action.logger = logger
+	action.client = client
+	action.matrixDB = matrixDB
+	action.db = db
+	action.messenger = messenger
+	action.emailBridge = bridgeServices.Email
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
+}
+
+// Name of the action.
+func (action *AddEmailOutputAction) Name() string {
+	return "Add e-mail recipients"
+}
+
+// GetDocu returns the documentation for the action.
+func (action *AddEmailOutputAction) GetDocu() (title, explaination string, examples []string) {
+	return "Add e-mail recipients",
+		"Mail reminders and daily reminders of this channel to the given addresses.",
+		[]string{"add email me@example.com", "send reminders to me@example.com, you@example.com"}
+}
+
+// Selector defines a regex on what messages the action should be used.
+func (action *AddEmailOutputAction) Selector() *regexp.Regexp {
+	return addEmailOutputActionRegex
+}
+
+// HandleEvent is where the message event get's send to if it matches the Selector.
+func (action *AddEmailOutputAction) HandleEvent(event *matrix.MessageEvent) {
+	dbMsg := mapping.MessageFromEvent(event)
+	dbMsg.Type = matrixdb.MessageTypeEmailOutputAdd
+
+	_, err := action.matrixDB.NewMessage(dbMsg)
+	if err != nil {
+		action.logger.Error("failed to save message to database", "error", err)
+	}
+
+	if action.emailBridge == nil {
+		msg := "Sending e-mails is not enabled on this server."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeEmailOutputAdd, *event)
+
+		return
+	}
+
+	recipients := emailOutputAddressRegex.FindAllString(event.Content.Body, -1)
+
+	output, err := action.emailBridge.NewOutput(event.Channel.ID, recipients, event.Room.TimeZone)
+	if err != nil {
+		msg := "Whoopsie, I could not add these recipients."
+		if errors.Is(err, email.ErrInvalidAddress) {
+			msg = "That does not look like valid e-mail addresses to me."
+		} else {
+			action.logger.Error("failed to create e-mail output", "error", err)
+		}
+
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeEmailOutputAdd, *event)
+
+		return
+	}
+
+	msg := fmt.Sprintf(
+		"I will mail reminders to %s, you can remove them again with \"remove email %d\".",
+		strings.ReplaceAll(output.Recipients, ",", ", "),
+		output.ID,
+	)
+	go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeEmailOutputAdd, *event)
+}
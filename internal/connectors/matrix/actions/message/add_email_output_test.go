@@ -0,0 +1,176 @@
+package message_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email"
+	emaildb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/message"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAddEmailOutputAction(t *testing.T) {
+	action := &message.AddEmailOutputAction{}
+
+	assert.NotEmpty(t, action.Name())
+
+	title, desc, examples := action.GetDocu()
+	assert.NotEmpty(t, title)
+	assert.NotEmpty(t, desc)
+	assert.NotEmpty(t, examples)
+
+	assert.NotNil(t, action.Selector())
+}
+
+func TestAddEmailOutputAction_Selector(t *testing.T) {
+	action := &message.AddEmailOutputAction{}
+	r := action.Selector()
+
+	_, _, examples := action.GetDocu()
+	for _, example := range examples {
+		assert.True(t, r.MatchString(example))
+	}
+
+	assert.False(t, r.MatchString("add email"))
+	assert.False(t, r.MatchString("send reminders tomorrow"))
+}
+
+func testAddEmailOutputAction(t *testing.T, withBridge bool) (*message.AddEmailOutputAction, *matrixdb.MockService, *messenger.MockMessenger, *email.MockService) {
+	t.Helper()
+
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+	emailBridge := email.NewMockService(t)
+
+	bridgeServices := &matrix.BridgeServices{}
+	if withBridge {
+		bridgeServices.Email = emailBridge
+	}
+
+	action := &message.AddEmailOutputAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		bridgeServices,
+	)
+
+	return action, matrixDB, msngr, emailBridge
+}
+
+func expectAddEmailOutputResponse(matrixDB *matrixdb.MockService, msngr *messenger.MockMessenger, body, response string) {
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		response,
+		"evt1",
+		body,
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "resp1",
+		UserID:        new("@user:example.com"),
+		Body:          response,
+		BodyFormatted: response,
+		Type:          matrixdb.MessageTypeEmailOutputAdd,
+	}).Return(nil, nil)
+}
+
+func TestAddEmailOutputAction_HandleEvent(t *testing.T) {
+	action, matrixDB, msngr, emailBridge := testAddEmailOutputAction(t, true)
+	event := tests.TestEvent(tests.MessageWithBody(
+		"send reminders to a@example.com, b@example.com",
+		"send reminders to a@example.com, b@example.com",
+	))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeEmailOutputAdd)
+
+	emailBridge.EXPECT().NewOutput(uint(68272), []string{"a@example.com", "b@example.com"}, "UTC").Return(&emaildb.EmailOutput{
+		Model: gorm.Model{
+			ID: 5,
+		},
+		Recipients: "a@example.com,b@example.com",
+	}, nil)
+
+	expectAddEmailOutputResponse(matrixDB, msngr, "send reminders to a@example.com, b@example.com",
+		"I will mail reminders to a@example.com, b@example.com, you can remove them again with \"remove email 5\".")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestAddEmailOutputAction_HandleEventWithInvalidAddress(t *testing.T) {
+	action, matrixDB, msngr, emailBridge := testAddEmailOutputAction(t, true)
+	event := tests.TestEvent(tests.MessageWithBody(
+		"add email a@",
+		"add email a@",
+	))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeEmailOutputAdd)
+
+	emailBridge.EXPECT().NewOutput(uint(68272), []string(nil), "UTC").Return(nil, email.ErrInvalidAddress)
+
+	expectAddEmailOutputResponse(matrixDB, msngr, "add email a@",
+		"That does not look like valid e-mail addresses to me.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestAddEmailOutputAction_HandleEventWithError(t *testing.T) {
+	action, matrixDB, msngr, emailBridge := testAddEmailOutputAction(t, true)
+	event := tests.TestEvent(tests.MessageWithBody(
+		"add email a@example.com",
+		"add email a@example.com",
+	))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeEmailOutputAdd)
+
+	emailBridge.EXPECT().NewOutput(uint(68272), []string{"a@example.com"}, "UTC").Return(nil, errors.New("test"))
+
+	expectAddEmailOutputResponse(matrixDB, msngr, "add email a@example.com",
+		"Whoopsie, I could not add these recipients.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestAddEmailOutputAction_HandleEventWithEmailDisabled(t *testing.T) {
+	action, matrixDB, msngr, _ := testAddEmailOutputAction(t, false)
+	event := tests.TestEvent(tests.MessageWithBody(
+		"add email a@example.com",
+		"add email a@example.com",
+	))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeEmailOutputAdd)
+
+	expectAddEmailOutputResponse(matrixDB, msngr, "add email a@example.com",
+		"Sending e-mails is not enabled on this server.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
@@ -0,0 +1,102 @@
+package message
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/ical"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+var addICalInputActionRegex = regexp.MustCompile("(?i)^(add|subscribe|subscribe to|import)[ ]+(a|the|)[ ]*(calendar|ical|cal)[ ]+(from|)[ ]*https?://[^ ]+[ ]*$")
+var icalInputURLRegex = regexp.MustCompile("(?i)https?://[^ ]+")
+
+// AddICalInputAction subscribes a channel to an iCal resource.
+type AddICalInputAction struct {
+	logger     *slog.Logger
+	client     mautrixcl.Client
+	messenger  messenger.Messenger
+	matrixDB   matrixdb.Service
+	db         database.Service
+	icalBridge matrix.BridgeServiceICal
+	storer     *msghelper.Storer
+}
+
+// Configure is called on startup and sets all dependencies.
+func (action *AddICalInputAction) Configure(
+	logger *slog.Logger,
+	client mautrixcl.Client,
+	messenger messenger.Messenger,
+	matrixDB matrixdb.Service,
+	db database.Service,
+	bridgeServices *matrix.BridgeServices,
+) {
+	action.logger = logger
+	action.client = client
+	action.matrixDB = matrixDB
+	action.db = db
+	action.messenger = messenger
+	action.icalBridge = bridgeServices.ICal
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
+}
+
+// Name of the action.
+func (action *AddICalInputAction) Name() string {
+	return "Add iCal calendar"
+}
+
+// GetDocu returns the documentation for the action.
+func (action *AddICalInputAction) GetDocu() (title, explaination string, examples []string) {
+	return "Add iCal calendar",
+		"Import events from an iCal URL, the calendar is refreshed regularly.",
+		[]string{"add calendar https://example.com/calendar.ics", "subscribe to calendar https://example.com/my.ical"}
+}
+
+// Selector defines a regex on what messages the action should be used.
+func (action *AddICalInputAction) Selector() *regexp.Regexp {
+	return addICalInputActionRegex
+}
+
+// HandleEvent is where the message event get's send to if it matches the Selector.
+func (action *AddICalInputAction) HandleEvent(event *matrix.MessageEvent) {
+	dbMsg := mapping.MessageFromEvent(event)
+	dbMsg.Type = matrixdb.MessageTypeIcalInputAdd
+
+	_, err := action.matrixDB.NewMessage(dbMsg)
+	if err != nil {
+		action.logger.Error("failed to save message to database", "error", err)
+	}
+
+	icalInput, err := action.icalBridge.NewInput(event.Channel.ID, icalInputURLRegex.FindString(event.Content.Body))
+	if err != nil {
+		msg := "Whoopsie, I could not add that calendar."
+		if errors.Is(err, ical.ErrInvalidURL) {
+			msg = "That does not look like a valid calendar URL, it needs to start with http:// or https://."
+		} else {
+			action.logger.Error("failed to create iCal input", "error", err)
+		}
+
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeIcalInputAdd, *event)
+
+		return
+	}
+
+	msg := fmt.Sprintf("Added the calendar with ID %d and imported its events 🥳.", icalInput.ID)
+
+	err = action.icalBridge.RefreshInput(icalInput.ID)
+	if err != nil {
+		action.logger.Info("failed to refresh new iCal input", "error", err, "ical.input_id", icalInput.ID)
+
+		msg = fmt.Sprintf("Added the calendar with ID %d, but fetching it failed. I will retry regularly.", icalInput.ID)
+	}
+
+	go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeIcalInputAdd, *event)
+}
@@ -0,0 +1,186 @@
+package message_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/ical"
+	icaldb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/ical/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/message"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAddICalInputAction(t *testing.T) {
+	action := &message.AddICalInputAction{}
+
+	assert.NotEmpty(t, action.Name())
+
+	title, desc, examples := action.GetDocu()
+	assert.NotEmpty(t, title)
+	assert.NotEmpty(t, desc)
+	assert.NotEmpty(t, examples)
+
+	assert.NotNil(t, action.Selector())
+}
+
+func TestAddICalInputAction_Selector(t *testing.T) {
+	action := &message.AddICalInputAction{}
+	r := action.Selector()
+
+	_, _, examples := action.GetDocu()
+	for _, example := range examples {
+		assert.True(t, r.MatchString(example))
+	}
+
+	assert.False(t, r.MatchString("add calendar"))
+	assert.False(t, r.MatchString("add calendar example.com"))
+}
+
+func testAddICalInputAction(t *testing.T) (*message.AddICalInputAction, *matrixdb.MockService, *messenger.MockMessenger, *ical.MockService) {
+	t.Helper()
+
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+	icalBridge := ical.NewMockService(t)
+
+	action := &message.AddICalInputAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		&matrix.BridgeServices{
+			ICal: icalBridge,
+		},
+	)
+
+	return action, matrixDB, msngr, icalBridge
+}
+
+func TestAddICalInputAction_HandleEvent(t *testing.T) {
+	action, matrixDB, msngr, icalBridge := testAddICalInputAction(t)
+	event := tests.TestEvent(tests.MessageWithBody(
+		"add calendar https://example.com/Cal.ics",
+		"add calendar https://example.com/Cal.ics",
+	))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeIcalInputAdd)
+
+	icalBridge.EXPECT().NewInput(uint(68272), "https://example.com/Cal.ics").Return(&icaldb.IcalInput{
+		Model: gorm.Model{
+			ID: 5,
+		},
+		URL: "https://example.com/Cal.ics",
+	}, nil)
+	icalBridge.EXPECT().RefreshInput(uint(5)).Return(nil)
+
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		"Added the calendar with ID 5 and imported its events 🥳.",
+		"evt1",
+		"add calendar https://example.com/Cal.ics",
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "resp1",
+		UserID:        new("@user:example.com"),
+		Body:          "Added the calendar with ID 5 and imported its events 🥳.",
+		BodyFormatted: "Added the calendar with ID 5 and imported its events 🥳.",
+		Type:          matrixdb.MessageTypeIcalInputAdd,
+	}).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestAddICalInputAction_HandleEventWithRefreshError(t *testing.T) {
+	action, matrixDB, msngr, icalBridge := testAddICalInputAction(t)
+	event := tests.TestEvent(tests.MessageWithBody(
+		"add calendar https://example.com/cal.ics",
+		"add calendar https://example.com/cal.ics",
+	))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeIcalInputAdd)
+
+	icalBridge.EXPECT().NewInput(uint(68272), "https://example.com/cal.ics").Return(&icaldb.IcalInput{
+		Model: gorm.Model{
+			ID: 5,
+		},
+	}, nil)
+	icalBridge.EXPECT().RefreshInput(uint(5)).Return(errors.New("bad status code: 404"))
+
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		"Added the calendar with ID 5, but fetching it failed. I will retry regularly.",
+		"evt1",
+		"add calendar https://example.com/cal.ics",
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "resp1",
+		UserID:        new("@user:example.com"),
+		Body:          "Added the calendar with ID 5, but fetching it failed. I will retry regularly.",
+		BodyFormatted: "Added the calendar with ID 5, but fetching it failed. I will retry regularly.",
+		Type:          matrixdb.MessageTypeIcalInputAdd,
+	}).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestAddICalInputAction_HandleEventWithInvalidURL(t *testing.T) {
+	action, matrixDB, msngr, icalBridge := testAddICalInputAction(t)
+	event := tests.TestEvent(tests.MessageWithBody(
+		"add calendar ftp://example.com",
+		"add calendar ftp://example.com",
+	))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeIcalInputAdd)
+
+	icalBridge.EXPECT().NewInput(uint(68272), "").Return(nil, ical.ErrInvalidURL)
+
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		"That does not look like a valid calendar URL, it needs to start with http:// or https://.",
+		"evt1",
+		"add calendar ftp://example.com",
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "resp1",
+		UserID:        new("@user:example.com"),
+		Body:          "That does not look like a valid calendar URL, it needs to start with http:// or https://.",
+		BodyFormatted: "That does not look like a valid calendar URL, it needs to start with http:// or https://.",
+		Type:          matrixdb.MessageTypeIcalInputAdd,
+	}).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
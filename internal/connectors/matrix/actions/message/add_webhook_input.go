@@ -0,0 +1,92 @@
+package message
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+var addWebhookInputActionRegex = regexp.MustCompile("(?i)^(add|create|register)[ ]+(a|an|the|)[ ]*(incoming|inbound)[ ]+(webhook|web hook)[ ]*$")
+
+// AddWebhookInputAction adds an incoming webhook other systems can create events with.
+type AddWebhookInputAction struct {
+	logger        *slog.Logger
+	client        mautrixcl.Client
+	messenger     messenger.Messenger
+	matrixDB      matrixdb.Service
+	db            database.Service
+	webhookBridge matrix.BridgeServiceWebhook
+	storer        *msghelper.Storer
+}
+
+// Configure is called on startup and sets all dependencies.
+func (action *AddWebhookInputAction) Configure(
+	logger *slog.Logger,
+	client mautrixcl.Client,
+	messenger messenger.Messenger,
+	matrixDB matrixdb.Service,
+	db database.Service,
+	bridgeServices *matrix.BridgeServices,
+) {
+	action.logger = logger
+	action.client = client
+	action.matrixDB = matrixDB
+	action.db = db
+	action.messenger = messenger
+	action.webhookBridge = bridgeServices.Webhook
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
+}
+
+// Name of the action.
+func (action *AddWebhookInputAction) Name() string {
+	return "Add incoming webhook"
+}
+
+// GetDocu returns the documentation for the action.
+func (action *AddWebhookInputAction) GetDocu() (title, explaination string, examples []string) {
+	return "Add incoming webhook",
+		"Get a secret URL CI systems, monitoring or scripts can post reminders for this channel to.",
+		[]string{"add incoming webhook", "create an inbound webhook"}
+}
+
+// Selector defines a regex on what messages the action should be used.
+func (action *AddWebhookInputAction) Selector() *regexp.Regexp {
+	return addWebhookInputActionRegex
+}
+
+// HandleEvent is where the message event get's send to if it matches the Selector.
+func (action *AddWebhookInputAction) HandleEvent(event *matrix.MessageEvent) {
+	dbMsg := mapping.MessageFromEvent(event)
+	dbMsg.Type = matrixdb.MessageTypeWebhookInputAdd
+
+	_, err := action.matrixDB.NewMessage(dbMsg)
+	if err != nil {
+		action.logger.Error("failed to save message to database", "error", err)
+	}
+
+	input, url, err := action.webhookBridge.NewInput(event.Channel.ID)
+	if err != nil {
+		action.logger.Error("failed to create webhook input", "error", err)
+
+		msg := "Whoopsie, I could not add an incoming webhook."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeWebhookInputAdd, *event)
+
+		return
+	}
+
+	msg := fmt.Sprintf(
+		"Added the incoming webhook with ID %d. Post events as JSON to %s, you can remove it again with \"remove incoming webhook %d\".",
+		input.ID,
+		url,
+		input.ID,
+	)
+	go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeWebhookInputAdd, *event)
+}
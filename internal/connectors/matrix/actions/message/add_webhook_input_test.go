@@ -0,0 +1,128 @@
+package message_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/message"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook"
+	webhookdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAddWebhookInputAction(t *testing.T) {
+	action := &message.AddWebhookInputAction{}
+
+	assert.NotEmpty(t, action.Name())
+
+	title, desc, examples := action.GetDocu()
+	assert.NotEmpty(t, title)
+	assert.NotEmpty(t, desc)
+	assert.NotEmpty(t, examples)
+
+	assert.NotNil(t, action.Selector())
+}
+
+func TestAddWebhookInputAction_Selector(t *testing.T) {
+	action := &message.AddWebhookInputAction{}
+	r := action.Selector()
+
+	_, _, examples := action.GetDocu()
+	for _, example := range examples {
+		assert.True(t, r.MatchString(example))
+	}
+
+	assert.False(t, r.MatchString("add webhook https://example.com"))
+}
+
+func testAddWebhookInputAction(t *testing.T) (*message.AddWebhookInputAction, *matrixdb.MockService, *messenger.MockMessenger, *webhook.MockService) {
+	t.Helper()
+
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+	webhookBridge := webhook.NewMockService(t)
+
+	action := &message.AddWebhookInputAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		&matrix.BridgeServices{
+			Webhook: webhookBridge,
+		},
+	)
+
+	return action, matrixDB, msngr, webhookBridge
+}
+
+func expectAddWebhookInputResponse(matrixDB *matrixdb.MockService, msngr *messenger.MockMessenger, response string) {
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		response,
+		"evt1",
+		"add incoming webhook",
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "resp1",
+		UserID:        new("@user:example.com"),
+		Body:          response,
+		BodyFormatted: response,
+		Type:          matrixdb.MessageTypeWebhookInputAdd,
+	}).Return(nil, nil)
+}
+
+func TestAddWebhookInputAction_HandleEvent(t *testing.T) {
+	action, matrixDB, msngr, webhookBridge := testAddWebhookInputAction(t)
+	event := tests.TestEvent(tests.MessageWithBody("add incoming webhook", "add incoming webhook"))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeWebhookInputAdd)
+
+	webhookBridge.EXPECT().NewInput(uint(68272)).Return(&webhookdb.WebhookInput{
+		Model: gorm.Model{
+			ID: 4,
+		},
+		Token: "abc",
+	}, "https://example.com/webhook/4/events?token=abc", nil)
+
+	expectAddWebhookInputResponse(matrixDB, msngr,
+		"Added the incoming webhook with ID 4. Post events as JSON to https://example.com/webhook/4/events?token=abc, "+
+			"you can remove it again with \"remove incoming webhook 4\".")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestAddWebhookInputAction_HandleEventWithError(t *testing.T) {
+	action, matrixDB, msngr, webhookBridge := testAddWebhookInputAction(t)
+	event := tests.TestEvent(tests.MessageWithBody("add incoming webhook", "add incoming webhook"))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeWebhookInputAdd)
+
+	webhookBridge.EXPECT().NewInput(uint(68272)).Return(nil, "", errors.New("test"))
+
+	expectAddWebhookInputResponse(matrixDB, msngr, "Whoopsie, I could not add an incoming webhook.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
@@ -0,0 +1,103 @@
+package message
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+var addWebhookOutputActionRegex = regexp.MustCompile("(?i)^(add|create|register)[ ]+(a|the|)[ ]*(webhook|web hook)[ ]+(to|for|)[ ]*https?://[^ ]+[ ]*$")
+var webhookOutputURLRegex = regexp.MustCompile("(?i)https?://[^ ]+")
+
+// AddWebhookOutputAction adds a webhook reminders of the channel are posted to.
+type AddWebhookOutputAction struct {
+	logger        *slog.Logger
+	client        mautrixcl.Client
+	messenger     messenger.Messenger
+	matrixDB      matrixdb.Service
+	db            database.Service
+	webhookBridge matrix.BridgeServiceWebhook
+	storer        *msghelper.Storer
+}
+
+// Configure is called on startup and sets all dependencies.
+func (action *AddWebhookOutputAction) Configure(
+	logger *slog.Logger,
+	client mautrixcl.Client,
+	messenger messenger.Messenger,
+	matrixDB matrixdb.Service,
+	db database.Service,
+	bridgeServices *matrix.BridgeServices,
+) {
+	action.logger = logger
+	action.client = client
+	action.matrixDB = matrixDB
+	action.db = db
+	action.messenger = messenger
+	action.webhookBridge = bridgeServices.Webhook
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
+}
+
+// Name of the action.
+func (action *AddWebhookOutputAction) Name() string {
+	return "Add webhook"
+}
+
+// GetDocu returns the documentation for the action.
+func (action *AddWebhookOutputAction) GetDocu() (title, explaination string, examples []string) {
+	return "Add webhook",
+		"Post reminders and daily reminders of this channel as signed JSON to the given URL.",
+		[]string{"add webhook https://example.com/hook", "register webhook for https://example.com/remindme"}
+}
+
+// Selector defines a regex on what messages the action should be used.
+func (action *AddWebhookOutputAction) Selector() *regexp.Regexp {
+	return addWebhookOutputActionRegex
+}
+
+// HandleEvent is where the message event get's send to if it matches the Selector.
+func (action *AddWebhookOutputAction) HandleEvent(event *matrix.MessageEvent) {
+	dbMsg := mapping.MessageFromEvent(event)
+	dbMsg.Type = matrixdb.MessageTypeWebhookOutputAdd
+
+	_, err := action.matrixDB.NewMessage(dbMsg)
+	if err != nil {
+		action.logger.Error("failed to save message to database", "error", err)
+	}
+
+	output, err := action.webhookBridge.NewOutput(
+		event.Channel.ID,
+		webhookOutputURLRegex.FindString(event.Content.Body),
+		event.Room.TimeZone,
+	)
+	if err != nil {
+		msg := "Whoopsie, I could not add that webhook."
+		if errors.Is(err, webhook.ErrInvalidURL) {
+			msg = "That does not look like a valid webhook URL, it needs to start with http:// or https://."
+		} else {
+			action.logger.Error("failed to create webhook output", "error", err)
+		}
+
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeWebhookOutputAdd, *event)
+
+		return
+	}
+
+	msg := fmt.Sprintf(
+		"Added the webhook with ID %d. Requests are signed with the secret \"%s\", the HMAC-SHA256 of the body is sent in the %s header.",
+		output.ID,
+		output.Secret,
+		webhook.HeaderSignature,
+	)
+	go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeWebhookOutputAdd, *event)
+}
@@ -0,0 +1,156 @@
+package message_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/message"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook"
+	webhookdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAddWebhookOutputAction(t *testing.T) {
+	action := &message.AddWebhookOutputAction{}
+
+	assert.NotEmpty(t, action.Name())
+
+	title, desc, examples := action.GetDocu()
+	assert.NotEmpty(t, title)
+	assert.NotEmpty(t, desc)
+	assert.NotEmpty(t, examples)
+
+	assert.NotNil(t, action.Selector())
+}
+
+func TestAddWebhookOutputAction_Selector(t *testing.T) {
+	action := &message.AddWebhookOutputAction{}
+	r := action.Selector()
+
+	_, _, examples := action.GetDocu()
+	for _, example := range examples {
+		assert.True(t, r.MatchString(example))
+	}
+
+	assert.False(t, r.MatchString("add webhook"))
+	assert.False(t, r.MatchString("add webhook example.com"))
+}
+
+func testAddWebhookOutputAction(t *testing.T) (*message.AddWebhookOutputAction, *matrixdb.MockService, *messenger.MockMessenger, *webhook.MockService) {
+	t.Helper()
+
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+	webhookBridge := webhook.NewMockService(t)
+
+	action := &message.AddWebhookOutputAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		&matrix.BridgeServices{
+			Webhook: webhookBridge,
+		},
+	)
+
+	return action, matrixDB, msngr, webhookBridge
+}
+
+func expectAddWebhookOutputResponse(matrixDB *matrixdb.MockService, msngr *messenger.MockMessenger, body, response string) {
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		response,
+		"evt1",
+		body,
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "resp1",
+		UserID:        new("@user:example.com"),
+		Body:          response,
+		BodyFormatted: response,
+		Type:          matrixdb.MessageTypeWebhookOutputAdd,
+	}).Return(nil, nil)
+}
+
+func TestAddWebhookOutputAction_HandleEvent(t *testing.T) {
+	action, matrixDB, msngr, webhookBridge := testAddWebhookOutputAction(t)
+	event := tests.TestEvent(tests.MessageWithBody(
+		"add webhook https://example.com/Hook",
+		"add webhook https://example.com/Hook",
+	))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeWebhookOutputAdd)
+
+	webhookBridge.EXPECT().NewOutput(uint(68272), "https://example.com/Hook", "UTC").Return(&webhookdb.WebhookOutput{
+		Model: gorm.Model{
+			ID: 5,
+		},
+		URL:    "https://example.com/Hook",
+		Secret: "abc",
+	}, nil)
+
+	expectAddWebhookOutputResponse(matrixDB, msngr, "add webhook https://example.com/Hook",
+		"Added the webhook with ID 5. Requests are signed with the secret \"abc\", the HMAC-SHA256 of the body is sent in the X-RemindMe-Signature header.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestAddWebhookOutputAction_HandleEventWithInvalidURL(t *testing.T) {
+	action, matrixDB, msngr, webhookBridge := testAddWebhookOutputAction(t)
+	event := tests.TestEvent(tests.MessageWithBody(
+		"add webhook ftp://example.com",
+		"add webhook ftp://example.com",
+	))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeWebhookOutputAdd)
+
+	webhookBridge.EXPECT().NewOutput(uint(68272), "", "UTC").Return(nil, webhook.ErrInvalidURL)
+
+	expectAddWebhookOutputResponse(matrixDB, msngr, "add webhook ftp://example.com",
+		"That does not look like a valid webhook URL, it needs to start with http:// or https://.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestAddWebhookOutputAction_HandleEventWithError(t *testing.T) {
+	action, matrixDB, msngr, webhookBridge := testAddWebhookOutputAction(t)
+	event := tests.TestEvent(tests.MessageWithBody(
+		"add webhook https://example.com/hook",
+		"add webhook https://example.com/hook",
+	))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeWebhookOutputAdd)
+
+	webhookBridge.EXPECT().NewOutput(uint(68272), "https://example.com/hook", "UTC").Return(nil, errors.New("test"))
+
+	expectAddWebhookOutputResponse(matrixDB, msngr, "add webhook https://example.com/hook",
+		"Whoopsie, I could not add that webhook.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
@@ -75,7 +75,7 @@ func (action *ChangeEventAction) HandleEvent(event *matrix.MessageEvent) {
 		return
 	}
 
-	newTime, err := format.ParseTime(event.Channel, event.Content.Body, event.Room.TimeZone, false)
+	newTime, err := format.ParseTime(event.Channel, event.Content.Body, event.TimeZone(), event.Room.Language, false)
 	if err != nil {
 		action.logger.Error("failed to parse time", "error", err)
 
@@ -107,10 +107,13 @@ func (action *ChangeEventAction) HandleEvent(event *matrix.MessageEvent) {
 	}
 
 	evt := &events[0]
-	evt.Time = newTime
 
-	evt, err = action.db.UpdateEvent(evt)
-	if err != nil || len(events) == 0 {
+	err = evt.Reschedule(newTime)
+	if err == nil {
+		evt, err = action.db.UpdateEvent(evt)
+	}
+
+	if err != nil {
 		action.logger.Error("failed to update event", "error", err)
 
 		go action.storer.SendAndStoreResponse(
@@ -126,7 +129,7 @@ func (action *ChangeEventAction) HandleEvent(event *matrix.MessageEvent) {
 	msgFormater.TextLine("I rescheduled your reminder")
 	msgFormater.QuoteLine(evt.Message)
 	msgFormater.Text("to ")
-	msgFormater.Text(format.ToLocalTime(newTime, event.Room.TimeZone))
+	msgFormater.Text(format.ToLocalTime(newTime, event.TimeZone(), event.Clock()))
 	msg, formattedMsg := msgFormater.Build()
 
 	go action.storer.SendAndStoreMessage(msg, formattedMsg, matrixdb.MessageTypeChangeEvent, *event)
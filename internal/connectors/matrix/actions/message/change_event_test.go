@@ -15,6 +15,7 @@ import (
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
 
@@ -101,6 +102,60 @@ to ` + today9PM(),
 	time.Sleep(time.Millisecond * 10)
 }
 
+func TestChangeEventAction_HandleEventWithRepeatRule(t *testing.T) {
+	// Setup
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.ChangeEventAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		nil,
+	)
+
+	evt := database.Event{
+		Model: gorm.Model{
+			ID: 45,
+		},
+		Time:       time.Date(2123, 3, 1, 9, 0, 0, 0, time.UTC),
+		RepeatRule: "DTSTART;TZID=Europe/Berlin:21230301T100000\nRRULE:FREQ=DAILY;COUNT=3",
+	}
+
+	db.EXPECT().ListEvents(&database.ListEventsOpts{
+		IDs:       []uint{1},
+		ChannelID: &tests.TestEvent().Channel.ID,
+	}).Return([]database.Event{evt}, nil)
+
+	db.EXPECT().UpdateEvent(mock.Anything).RunAndReturn(func(e *database.Event) (*database.Event, error) {
+		berlin, err := time.LoadLocation("Europe/Berlin")
+		require.NoError(t, err)
+
+		assert.Equal(t, 21, e.Time.UTC().Hour())
+		assert.Equal(t,
+			"DTSTART;TZID=Europe/Berlin:"+e.Time.In(berlin).Format("20060102T150405")+"\nRRULE:FREQ=DAILY;COUNT=3",
+			e.RepeatRule,
+		)
+
+		return e, nil
+	})
+
+	msngr.EXPECT().SendMessage(mock.Anything).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "id1",
+	}, nil)
+	matrixDB.EXPECT().NewMessage(mock.Anything).Return(nil, nil)
+
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody("change reminder 1 to 9 pm", "change reminder 1 to  9 pm")))
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
 func TestChangeEventAction_HandleEventWithUpdateError(t *testing.T) {
 	// Setup
 	db := database.NewMockService(t)
@@ -0,0 +1,99 @@
+package message
+
+import (
+	"log/slog"
+	"regexp"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+var changeLanguageActionRegex = regexp.MustCompile("(?i)^(set language|setze sprache) [^ ]+[ ]*$")
+var languageCaptureGroup = regexp.MustCompile("(?i)^(?:set language|setze sprache) ([^ ]+)[ ]*$")
+
+// ChangeLanguageAction allows setting the language the bot speaks in the matrix channel.
+type ChangeLanguageAction struct {
+	logger    *slog.Logger
+	client    mautrixcl.Client
+	messenger messenger.Messenger
+	matrixDB  matrixdb.Service
+	db        database.Service
+	storer    *msghelper.Storer
+}
+
+// Configure is called on startup and sets all dependencies.
+func (action *ChangeLanguageAction) Configure(logger *slog.Logger, client mautrixcl.Client, messenger messenger.Messenger, matrixDB matrixdb.Service, db database.Service, _ *matrix.BridgeServices) {
+	action.logger = logger
+	action.client = client
+	action.matrixDB = matrixDB
+	action.db = db
+	action.messenger = messenger
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
+}
+
+// Name of the action
+func (action *ChangeLanguageAction) Name() string {
+	return "Change Language"
+}
+
+// GetDocu returns the documentation for the action.
+func (action *ChangeLanguageAction) GetDocu() (title, explaination string, examples []string) {
+	return "Change Language",
+		"Change the language I understand and answer in for this channel. Available are English and German.",
+		[]string{"set language german", "set language english", "setze Sprache Deutsch"}
+}
+
+// Selector defines a regex on what messages the action should be used.
+func (action *ChangeLanguageAction) Selector() *regexp.Regexp {
+	return changeLanguageActionRegex
+}
+
+// HandleEvent is where the message event get's send to if it matches the Selector.
+func (action *ChangeLanguageAction) HandleEvent(event *matrix.MessageEvent) {
+	name := ""
+
+	matches := languageCaptureGroup.FindStringSubmatch(event.Content.Body)
+	if len(matches) >= 2 {
+		name = matches[1]
+	}
+
+	language, err := i18n.ParseLanguage(name)
+	if err != nil {
+		action.logger.Info("failed to parse language", "language", name, "error", err)
+		action.storer.SendAndStoreResponse(i18n.Translate(event.Room.Language, "Sorry, but I do not speak this language."), matrixdb.MessageTypeLanguageChange, *event)
+
+		return
+	}
+
+	room := event.Room
+	room.Language = language
+
+	_, err = action.matrixDB.UpdateRoom(room)
+	if err != nil {
+		action.logger.Error("failed to update room", "error", err)
+
+		err = action.messenger.SendResponseAsync(messenger.PlainTextResponse(
+			i18n.Translate(event.Room.Language, "Ups, that did not work 😨"),
+			event.Event.ID.String(),
+			event.Content.Body,
+			event.Event.Sender.String(),
+			event.Room.RoomID,
+		))
+		if err != nil {
+			action.logger.Error("failed to send response", "error", err)
+		}
+
+		return
+	}
+
+	go action.storer.SendAndStoreResponse(
+		i18n.Translatef(language, "I will speak %s in this channel from now on.", i18n.Name(language)),
+		matrixdb.MessageTypeLanguageChange,
+		*event,
+	)
+}
@@ -0,0 +1,177 @@
+package message_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/message"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeLanguageAction(t *testing.T) {
+	action := &message.ChangeLanguageAction{}
+
+	assert.NotEmpty(t, action.Name())
+
+	title, desc, examples := action.GetDocu()
+	assert.NotEmpty(t, title)
+	assert.NotEmpty(t, desc)
+	assert.NotEmpty(t, examples)
+
+	assert.NotNil(t, action.Selector())
+}
+
+func TestChangeLanguageAction_Selector(t *testing.T) {
+	action := &message.ChangeLanguageAction{}
+	r := action.Selector()
+
+	_, _, examples := action.GetDocu()
+	for _, example := range examples {
+		assert.True(t, r.MatchString(example))
+	}
+
+	assert.False(t, r.MatchString("set language lessons at monday"))
+}
+
+func TestChangeLanguageAction_HandleEvent(t *testing.T) {
+	// Setup
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.ChangeLanguageAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		nil,
+	)
+
+	testCases := map[string]struct {
+		language string
+		response string
+	}{
+		"set language german":   {i18n.German, "Ich spreche ab jetzt Deutsch in diesem Kanal."},
+		"Setze Sprache Deutsch": {i18n.German, "Ich spreche ab jetzt Deutsch in diesem Kanal."},
+		"set language English":  {i18n.English, "I will speak English in this channel from now on."},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(_ *testing.T) {
+			matrixDB.EXPECT().UpdateRoom(&matrixdb.MatrixRoom{
+				RoomID:   "!room123",
+				Users:    []matrixdb.MatrixUser{},
+				TimeZone: "UTC",
+				Language: tc.language,
+			}).Return(nil, nil)
+
+			msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+				tc.response,
+				"evt1",
+				msg,
+				"@user:example.com",
+				"!room123",
+			)).Return(&messenger.MessageResponse{
+				ExternalIdentifier: "id1",
+			}, nil)
+
+			matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+				ID:            "id1",
+				UserID:        new("@user:example.com"),
+				Body:          tc.response,
+				BodyFormatted: tc.response,
+				Type:          matrixdb.MessageTypeLanguageChange,
+			},
+			).Return(nil, nil)
+
+			action.HandleEvent(tests.TestEvent(tests.MessageWithBody(msg, msg)))
+			// Wait for async message sending.
+			time.Sleep(time.Millisecond * 10)
+		})
+	}
+}
+
+func TestChangeLanguageAction_HandleEventWithUnknownLanguage(t *testing.T) {
+	// Setup
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.ChangeLanguageAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		nil,
+	)
+
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		"Sorry, but I do not speak this language.",
+		"evt1",
+		"set language klingon",
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "id1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "id1",
+		UserID:        new("@user:example.com"),
+		Body:          `Sorry, but I do not speak this language.`,
+		BodyFormatted: `Sorry, but I do not speak this language.`,
+		Type:          matrixdb.MessageTypeLanguageChange,
+	},
+	).Return(nil, nil)
+
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody("set language klingon", "set language klingon")))
+}
+
+func TestChangeLanguageAction_HandleEventWithUpdateError(t *testing.T) {
+	// Setup
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.ChangeLanguageAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		nil,
+	)
+
+	matrixDB.EXPECT().UpdateRoom(&matrixdb.MatrixRoom{
+		RoomID:   "!room123",
+		Users:    []matrixdb.MatrixUser{},
+		TimeZone: "UTC",
+		Language: i18n.German,
+	}).Return(nil, errors.New("test"))
+
+	msngr.EXPECT().SendResponseAsync(messenger.PlainTextResponse(
+		"Ups, das hat nicht funktioniert 😨",
+		"evt1",
+		"set language german",
+		"@user:example.com",
+		"!room123",
+	)).Return(nil)
+
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody("set language german", "set language german")))
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
 	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
@@ -16,8 +17,8 @@ import (
 	_ "time/tzdata" // Import timezone data.
 )
 
-var changeTimezoneActionRegex = regexp.MustCompile("(?i)^set timezone .*$")
-var timezoneCaptureGroup = regexp.MustCompile("(?i)^set timezone (.*)$")
+var changeTimezoneActionRegex = regexp.MustCompile("(?i)^(set timezone|setze zeitzone) .*$")
+var timezoneCaptureGroup = regexp.MustCompile("(?i)^(?:set timezone|setze zeitzone) (.*)$")
 
 // ChangeTimezoneAction allows setting a timezone for the matrix channel.
 type ChangeTimezoneAction struct {
@@ -48,7 +49,7 @@ func (action *ChangeTimezoneAction) Name() string {
 func (action *ChangeTimezoneAction) GetDocu() (title, explaination string, examples []string) {
 	return "Change Timezone",
 		"Change the timezone for this channel",
-		[]string{"set timezone Europe/Berlin", "set timezone America/New_York", "set timezone Asia/Shanghai"}
+		[]string{"set timezone Europe/Berlin", "set timezone America/New_York", "set timezone Asia/Shanghai", "setze Zeitzone Europe/Berlin"}
 }
 
 // Selector defines a regex on what messages the action should be used.
@@ -68,7 +69,7 @@ func (action *ChangeTimezoneAction) HandleEvent(event *matrix.MessageEvent) {
 	_, err := time.LoadLocation(tz)
 	if err != nil {
 		action.logger.Info("failed to load timezone", "timezone", tz, "error", err)
-		action.storer.SendAndStoreResponse("Sorry, but I do not know what timezone this is.", matrixdb.MessageTypeTimezoneChange, *event)
+		action.storer.SendAndStoreResponse(i18n.Translate(event.Room.Language, "Sorry, but I do not know what timezone this is."), matrixdb.MessageTypeTimezoneChange, *event)
 
 		return
 	}
@@ -82,7 +83,7 @@ func (action *ChangeTimezoneAction) HandleEvent(event *matrix.MessageEvent) {
 		action.logger.Error("failed to update room", "error", err)
 
 		err = action.messenger.SendResponseAsync(messenger.PlainTextResponse(
-			"Ups, that did not work 😨",
+			i18n.Translate(event.Room.Language, "Ups, that did not work 😨"),
 			event.Event.ID.String(),
 			event.Content.Body,
 			event.Event.Sender.String(),
@@ -95,6 +96,14 @@ func (action *ChangeTimezoneAction) HandleEvent(event *matrix.MessageEvent) {
 		return
 	}
 
+	// Quiet hours and daily reminders are evaluated in the timezone of the channel.
+	event.Channel.TimeZone = tz
+
+	_, err = action.db.UpdateChannel(event.Channel)
+	if err != nil {
+		action.logger.Error("failed to update channel", "error", err)
+	}
+
 	msgBuilder := format.Formater{}
 	msgBuilder.Text("Changed this channels timezone from ")
 
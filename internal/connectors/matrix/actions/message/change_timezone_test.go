@@ -15,6 +15,7 @@ import (
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestChangeTimezoneAction(t *testing.T) {
@@ -74,6 +75,9 @@ func TestChangeTimezoneAction_HandleEvent(t *testing.T) {
 				Users:    []matrixdb.MatrixUser{},
 				TimeZone: tz,
 			}).Return(nil, nil)
+			db.EXPECT().UpdateChannel(mock.MatchedBy(func(channel *database.Channel) bool {
+				return channel.ID == 68272 && channel.TimeZone == tz
+			})).Return(nil, nil)
 
 			msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
 				"Changed this channels timezone from UTC to "+tz+" 🛫 🛬",
@@ -0,0 +1,135 @@
+package message
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+
+	_ "time/tzdata" // Import timezone data.
+)
+
+var changeUserPreferencesActionRegex = regexp.MustCompile("(?i)^(set my|setze meine?) (timezone|zeitzone|clock|uhr) [^ ]+[ ]*$")
+var userPreferencesCaptureGroup = regexp.MustCompile("(?i)^(?:set my|setze meine?) (timezone|zeitzone|clock|uhr) ([^ ]+)[ ]*$")
+
+// ChangeUserPreferencesAction allows users to set their own timezone and clock in a matrix channel.
+type ChangeUserPreferencesAction struct {
+	logger    *slog.Logger
+	client    mautrixcl.Client
+	messenger messenger.Messenger
+	matrixDB  matrixdb.Service
+	db        database.Service
+	storer    *msghelper.Storer
+}
+
+// Configure is called on startup and sets all dependencies.
+func (action *ChangeUserPreferencesAction) Configure(logger *slog.Logger, client mautrixcl.Client, messenger messenger.Messenger, matrixDB matrixdb.Service, db database.Service, _ *matrix.BridgeServices) {
+	action.logger = logger
+	action.client = client
+	action.matrixDB = matrixDB
+	action.db = db
+	action.messenger = messenger
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
+}
+
+// Name of the action
+func (action *ChangeUserPreferencesAction) Name() string {
+	return "Change User Preferences"
+}
+
+// GetDocu returns the documentation for the action.
+func (action *ChangeUserPreferencesAction) GetDocu() (title, explaination string, examples []string) {
+	return "Change User Preferences",
+		"Set your own timezone and clock (12h or 24h), they are used instead of the channel settings for your messages and reminders assigned to you. Set your timezone to \"default\" to use the one of the channel again.",
+		[]string{"set my timezone America/New_York", "set my timezone default", "set my clock 12h", "setze meine Zeitzone Europe/Berlin", "setze meine Uhr 24h"}
+}
+
+// Selector defines a regex on what messages the action should be used.
+func (action *ChangeUserPreferencesAction) Selector() *regexp.Regexp {
+	return changeUserPreferencesActionRegex
+}
+
+// HandleEvent is where the message event get's send to if it matches the Selector.
+func (action *ChangeUserPreferencesAction) HandleEvent(event *matrix.MessageEvent) {
+	setting, value := "", ""
+
+	matches := userPreferencesCaptureGroup.FindStringSubmatch(event.Content.Body)
+	if len(matches) >= 3 {
+		setting = strings.ToLower(matches[1])
+		value = matches[2]
+	}
+
+	user := event.User()
+	if user == nil {
+		action.logger.Error("failed to find user in room")
+		action.sendError(event)
+
+		return
+	}
+
+	var msg string
+
+	switch setting {
+	case "timezone", "zeitzone":
+		if strings.EqualFold(value, "default") || strings.EqualFold(value, "standard") {
+			user.TimeZone = ""
+			msg = i18n.Translate(event.Room.Language, "I will show you times in the timezone of this channel from now on.")
+
+			break
+		}
+
+		_, err := time.LoadLocation(value)
+		if err != nil {
+			action.logger.Info("failed to load timezone", "timezone", value, "error", err)
+			action.storer.SendAndStoreResponse(i18n.Translate(event.Room.Language, "Sorry, but I do not know what timezone this is."), matrixdb.MessageTypeUserPreferencesChange, *event)
+
+			return
+		}
+
+		user.TimeZone = value
+		msg = i18n.Translatef(event.Room.Language, "I will show you times in %s from now on.", value)
+	default:
+		clock, err := format.ParseClock(value)
+		if err != nil {
+			action.logger.Info("failed to parse clock", "clock", value, "error", err)
+			action.storer.SendAndStoreResponse(i18n.Translate(event.Room.Language, "Sorry, I only know the 12h and 24h clock."), matrixdb.MessageTypeUserPreferencesChange, *event)
+
+			return
+		}
+
+		user.Clock = clock
+		msg = i18n.Translatef(event.Room.Language, "I will show you times in the %s clock from now on.", clock)
+	}
+
+	_, err := action.matrixDB.UpdateUser(user)
+	if err != nil {
+		action.logger.Error("failed to update user", "error", err)
+		action.sendError(event)
+
+		return
+	}
+
+	go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeUserPreferencesChange, *event)
+}
+
+func (action *ChangeUserPreferencesAction) sendError(event *matrix.MessageEvent) {
+	err := action.messenger.SendResponseAsync(messenger.PlainTextResponse(
+		i18n.Translate(event.Room.Language, "Ups, that did not work 😨"),
+		event.Event.ID.String(),
+		event.Content.Body,
+		event.Event.Sender.String(),
+		event.Room.RoomID,
+	))
+	if err != nil {
+		action.logger.Error("failed to send response", "error", err)
+	}
+}
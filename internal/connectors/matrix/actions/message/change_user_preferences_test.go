@@ -0,0 +1,191 @@
+package message_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/message"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeUserPreferencesAction(t *testing.T) {
+	action := &message.ChangeUserPreferencesAction{}
+
+	assert.NotEmpty(t, action.Name())
+
+	title, desc, examples := action.GetDocu()
+	assert.NotEmpty(t, title)
+	assert.NotEmpty(t, desc)
+	assert.NotEmpty(t, examples)
+
+	assert.NotNil(t, action.Selector())
+}
+
+func TestChangeUserPreferencesAction_Selector(t *testing.T) {
+	action := &message.ChangeUserPreferencesAction{}
+	r := action.Selector()
+
+	_, _, examples := action.GetDocu()
+	for _, example := range examples {
+		assert.True(t, r.MatchString(example))
+	}
+
+	assert.False(t, r.MatchString("set timezone Europe/Berlin"))
+	assert.False(t, r.MatchString("set my clock to the right time tomorrow"))
+}
+
+func TestChangeUserPreferencesAction_HandleEvent(t *testing.T) {
+	// Setup
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.ChangeUserPreferencesAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		nil,
+	)
+
+	testCases := map[string]struct {
+		user     matrixdb.MatrixUser
+		response string
+	}{
+		"set my timezone America/New_York": {
+			matrixdb.MatrixUser{ID: "@user:example.com", TimeZone: "America/New_York"},
+			"I will show you times in America/New_York from now on.",
+		},
+		"set my timezone default": {
+			matrixdb.MatrixUser{ID: "@user:example.com"},
+			"I will show you times in the timezone of this channel from now on.",
+		},
+		"Set my clock AM/PM": {
+			matrixdb.MatrixUser{ID: "@user:example.com", TimeZone: "Europe/Berlin", Clock: "12h"},
+			"I will show you times in the 12h clock from now on.",
+		},
+	}
+
+	for msg, tc := range testCases {
+		t.Run(msg, func(_ *testing.T) {
+			matrixDB.EXPECT().UpdateUser(&tc.user).Return(nil, nil).Once()
+
+			msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+				tc.response,
+				"evt1",
+				msg,
+				"@user:example.com",
+				"!room123",
+			)).Return(&messenger.MessageResponse{
+				ExternalIdentifier: "id1",
+			}, nil).Once()
+
+			matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+				ID:            "id1",
+				UserID:        new("@user:example.com"),
+				Body:          tc.response,
+				BodyFormatted: tc.response,
+				Type:          matrixdb.MessageTypeUserPreferencesChange,
+			},
+			).Return(nil, nil).Once()
+
+			action.HandleEvent(tests.TestEvent(
+				tests.MessageWithBody(msg, msg),
+				tests.MessageWithUserInRoom(matrixdb.MatrixUser{ID: "@user:example.com", TimeZone: "Europe/Berlin"}),
+			))
+			// Wait for async message sending.
+			time.Sleep(time.Millisecond * 10)
+		})
+	}
+}
+
+func TestChangeUserPreferencesAction_HandleEventWithUnknownClock(t *testing.T) {
+	// Setup
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.ChangeUserPreferencesAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		nil,
+	)
+
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		"Sorry, I only know the 12h and 24h clock.",
+		"evt1",
+		"set my clock 36h",
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "id1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "id1",
+		UserID:        new("@user:example.com"),
+		Body:          "Sorry, I only know the 12h and 24h clock.",
+		BodyFormatted: "Sorry, I only know the 12h and 24h clock.",
+		Type:          matrixdb.MessageTypeUserPreferencesChange,
+	},
+	).Return(nil, nil)
+
+	action.HandleEvent(tests.TestEvent(
+		tests.MessageWithBody("set my clock 36h", "set my clock 36h"),
+		tests.MessageWithUserInRoom(matrixdb.MatrixUser{ID: "@user:example.com"}),
+	))
+}
+
+func TestChangeUserPreferencesAction_HandleEventWithUpdateError(t *testing.T) {
+	// Setup
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.ChangeUserPreferencesAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		nil,
+	)
+
+	matrixDB.EXPECT().UpdateUser(&matrixdb.MatrixUser{
+		ID:       "@user:example.com",
+		TimeZone: "Europe/Berlin",
+	}).Return(nil, errors.New("test"))
+
+	msngr.EXPECT().SendResponseAsync(messenger.PlainTextResponse(
+		"Ups, das hat nicht funktioniert 😨",
+		"evt1",
+		"setze meine Zeitzone Europe/Berlin",
+		"@user:example.com",
+		"!room123",
+	)).Return(nil)
+
+	event := tests.TestEvent(
+		tests.MessageWithBody("setze meine Zeitzone Europe/Berlin", "setze meine Zeitzone Europe/Berlin"),
+		tests.MessageWithUserInRoom(matrixdb.MatrixUser{ID: "@user:example.com"}),
+	)
+	event.Room.Language = "de"
+
+	action.HandleEvent(event)
+}
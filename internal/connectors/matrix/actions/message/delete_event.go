@@ -9,6 +9,7 @@ import (
 
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
 	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
@@ -16,7 +17,7 @@ import (
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 )
 
-var deleteEventActionRegex = regexp.MustCompile("(?i)(^(delete|remove)[ ]*(reminder|)[ ]+[0-9]+)[ ]*$")
+var deleteEventActionRegex = regexp.MustCompile("(?i)(^(delete|remove|lösche|entferne)[ ]*(reminder|erinnerung|termin|)[ ]+[0-9]+)[ ]*$")
 
 // DeleteEventAction acts as a template for new actions.
 type DeleteEventAction struct {
@@ -47,7 +48,7 @@ func (action *DeleteEventAction) Name() string {
 func (action *DeleteEventAction) GetDocu() (title, explaination string, examples []string) {
 	return "Delete event",
 		"Delete an event by its ID.",
-		[]string{"delete reminder 1", "remove 68"}
+		[]string{"delete reminder 1", "remove 68", "lösche termin 3"}
 }
 
 // Selector defines a regex on what messages the action should be used.
@@ -62,7 +63,7 @@ func (action *DeleteEventAction) HandleEvent(event *matrix.MessageEvent) {
 		action.logger.Error("failed to get ID from message", "error", err)
 
 		err = action.messenger.SendResponseAsync(messenger.PlainTextResponse(
-			"Ups, can not find an ID in there.",
+			i18n.Translate(event.Room.Language, "Ups, can not find an ID in there."),
 			event.Event.ID.String(),
 			event.Content.Body,
 			event.Event.Sender.String(),
@@ -83,7 +84,7 @@ func (action *DeleteEventAction) HandleEvent(event *matrix.MessageEvent) {
 		action.logger.Error("failed to list events", "error", err)
 
 		err = action.messenger.SendResponseAsync(messenger.PlainTextResponse(
-			"Sorry, an error appeared.",
+			i18n.Translate(event.Room.Language, "Sorry, an error appeared."),
 			event.Event.ID.String(),
 			event.Content.Body,
 			event.Event.Sender.String(),
@@ -98,7 +99,7 @@ func (action *DeleteEventAction) HandleEvent(event *matrix.MessageEvent) {
 
 	if len(events) != 1 {
 		err = action.messenger.SendResponseAsync(messenger.PlainTextResponse(
-			"I could not find that event in my database.",
+			i18n.Translate(event.Room.Language, "I could not find that event in my database."),
 			event.Event.ID.String(),
 			event.Content.Body,
 			event.Event.Sender.String(),
@@ -126,7 +127,7 @@ func (action *DeleteEventAction) HandleEvent(event *matrix.MessageEvent) {
 		action.logger.Error("failed to delete event", "error", err)
 
 		err = action.messenger.SendResponseAsync(messenger.PlainTextResponse(
-			"Sorry, an error appeared.",
+			i18n.Translate(event.Room.Language, "Sorry, an error appeared."),
 			event.Event.ID.String(),
 			event.Content.Body,
 			event.Event.Sender.String(),
@@ -139,7 +140,7 @@ func (action *DeleteEventAction) HandleEvent(event *matrix.MessageEvent) {
 		return
 	}
 
-	go action.storer.SendAndStoreResponse("Deleted event \""+events[0].Message+"\"", matrixdb.MessageTypeEventDelete, *event, msghelper.WithEventID(events[0].ID))
+	go action.storer.SendAndStoreResponse(i18n.Translatef(event.Room.Language, "Deleted event \"%s\"", events[0].Message), matrixdb.MessageTypeEventDelete, *event, msghelper.WithEventID(events[0].ID))
 }
 
 func getIDFromSentence(value string) (int, error) {
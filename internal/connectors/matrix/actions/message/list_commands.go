@@ -15,7 +15,7 @@ import (
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 )
 
-var listCommandsRegex = regexp.MustCompile("(?i)^(((show|list)( all| the| my|)( command| commands))|commands|help)[ ]*$")
+var listCommandsRegex = regexp.MustCompile("(?i)^(((show|list)( all| the| my|)( command| commands))|commands|help|((zeige|liste)( alle|) befehle)|befehle|hilfe)[ ]*$")
 
 // ListCommandsAction sets the time for the daily reminder.
 type ListCommandsAction struct {
@@ -46,7 +46,7 @@ func (action *ListCommandsAction) Name() string {
 func (action *ListCommandsAction) GetDocu() (title, explaination string, examples []string) {
 	return "List Commands",
 		"List available commands",
-		[]string{"show all commands", "list the commands", "commands", "list commands", "help"}
+		[]string{"show all commands", "list the commands", "commands", "list commands", "help", "hilfe"}
 }
 
 // Selector defines a regex on what messages the action should be used.
@@ -70,17 +70,34 @@ func (action *ListCommandsAction) listMessageCommands(event *matrix.MessageEvent
 	for _, action := range []interface {
 		GetDocu() (string, string, []string)
 	}{
+		&AddEmailOutputAction{},
+		&AddICalInputAction{},
 		&AddUserAction{},
+		&AddWebhookInputAction{},
+		&AddWebhookOutputAction{},
 		&ChangeEventAction{},
+		&ChangeLanguageAction{},
 		&ChangeTimezoneAction{},
+		&ChangeUserPreferencesAction{},
 		&DeleteEventAction{},
 		&EnableICalExportAction{},
 		&ListCommandsAction{},
+		&ListEmailOutputsAction{},
 		&ListEventsAction{},
+		&ListICalInputsAction{},
+		&ListWebhookOutputsAction{},
 		&NewEventAction{},
 		&RegenICalTokenAction{},
+		&RemoveEmailOutputAction{},
+		&RemoveICalInputAction{},
+		&RemoveWebhookInputAction{},
+		&RemoveWebhookOutputAction{},
 		&SetDailyReminderAction{},
 		&SetDefaultReminderTimeAction{},
+		&SetDigestAction{},
+		&SetEscalationAction{},
+		&SetPreNotificationsAction{},
+		&SetQuietHoursAction{},
 	} {
 		title, explain, examples := action.GetDocu()
 		msg.BoldLine(title)
@@ -108,8 +125,10 @@ func (action *ListCommandsAction) listReplyCommands(event *matrix.MessageEvent)
 		GetDocu() (string, string, []string)
 	}{
 		&reply.ChangeTimeAction{},
+		&reply.AddPreNotificationsAction{},
 		&reply.DeleteEventAction{},
 		&reply.MakeRecurringAction{},
+		&reply.MarkDoneAction{},
 	} {
 		title, explain, examples := action.GetDocu()
 		msg.BoldLine(title)
@@ -0,0 +1,119 @@
+package message
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+var listEmailOutputsActionRegex = regexp.MustCompile("(?i)^(list|show)(| all| the| my)[ ]+(emails|e-mails|mails|email recipients|e-mail recipients)[ ]*$")
+
+// ListEmailOutputsAction lists the e-mail recipients of a channel.
+type ListEmailOutputsAction struct {
+	logger      *slog.Logger
+	client      mautrixcl.Client
+	messenger   messenger.Messenger
+	matrixDB    matrixdb.Service
+	db          database.Service
+	emailBridge matrix.BridgeServiceEmail
+	storer      *msghelper.Storer
+}
+
+// Configure is called on startup and sets all dependencies.
+func (action *ListEmailOutputsAction) Configure(
+	logger *slog.Logger,
+	client mautrixcl.Client,
+	messenger messenger.Messenger,
+	matrixDB matrixdb.Service,
+	db database.Service,
+	bridgeServices *matrix.BridgeServices,
+) {
+	action.logger = logger
+	action.client = client
+	action.matrixDB = matrixDB
+	action.db = db
+	action.messenger = messenger
+	action.emailBridge = bridgeServices.Email
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
+}
+
+// Name of the action.
+func (action *ListEmailOutputsAction) Name() string {
+	return "List e-mail recipients"
+}
+
+// GetDocu returns the documentation for the action.
+func (action *ListEmailOutputsAction) GetDocu() (title, explaination string, examples []string) {
+	return "List e-mail recipients",
+		"List all e-mail addresses reminders are mailed to.",
+		[]string{"list emails", "show my e-mail recipients"}
+}
+
+// Selector defines a regex on what messages the action should be used.
+func (action *ListEmailOutputsAction) Selector() *regexp.Regexp {
+	return listEmailOutputsActionRegex
+}
+
+// HandleEvent is where the message event get's send to if it matches the Selector.
+func (action *ListEmailOutputsAction) HandleEvent(event *matrix.MessageEvent) {
+	dbMsg := mapping.MessageFromEvent(event)
+	dbMsg.Type = matrixdb.MessageTypeEmailOutputList
+
+	_, err := action.matrixDB.NewMessage(dbMsg)
+	if err != nil {
+		action.logger.Error("failed to save message to database", "error", err)
+	}
+
+	if action.emailBridge == nil {
+		msg := "Sending e-mails is not enabled on this server."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeEmailOutputList, *event)
+
+		return
+	}
+
+	items := []string{}
+
+	for _, output := range event.Channel.Outputs {
+		if output.OutputType != email.OutputType {
+			continue
+		}
+
+		emailOutput, err := action.emailBridge.GetOutput(output.OutputID)
+		if err != nil {
+			action.logger.Error("failed to get e-mail output", "error", err, "email.output_id", output.OutputID)
+
+			msg := "Sorry, an error appeared."
+			go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeEmailOutputList, *event)
+
+			return
+		}
+
+		items = append(items, fmt.Sprintf("ID %d: %s", emailOutput.ID, strings.ReplaceAll(emailOutput.Recipients, ",", ", ")))
+	}
+
+	if len(items) == 0 {
+		msg := "This channel does not mail reminders to anyone. Add recipients with \"add email me@example.com\"."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeEmailOutputList, *event)
+
+		return
+	}
+
+	msg := format.Formater{}
+	msg.Title("Your E-Mail Recipients")
+	msg.List(items)
+	msg.TextLine("To stop mailing reminders message me with \"remove email ID\".")
+
+	message, messageFormatted := msg.Build()
+	go action.storer.SendAndStoreMessage(message, messageFormatted, matrixdb.MessageTypeEmailOutputList, *event)
+}
@@ -0,0 +1,230 @@
+package message_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email"
+	emaildb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/message"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestListEmailOutputsAction(t *testing.T) {
+	action := &message.ListEmailOutputsAction{}
+
+	assert.NotEmpty(t, action.Name())
+
+	title, desc, examples := action.GetDocu()
+	assert.NotEmpty(t, title)
+	assert.NotEmpty(t, desc)
+	assert.NotEmpty(t, examples)
+
+	assert.NotNil(t, action.Selector())
+}
+
+func TestListEmailOutputsAction_Selector(t *testing.T) {
+	action := &message.ListEmailOutputsAction{}
+	r := action.Selector()
+
+	_, _, examples := action.GetDocu()
+	for _, example := range examples {
+		assert.True(t, r.MatchString(example))
+	}
+}
+
+func testListEmailOutputsAction(t *testing.T) (*message.ListEmailOutputsAction, *matrixdb.MockService, *messenger.MockMessenger, *email.MockService) {
+	t.Helper()
+
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+	emailBridge := email.NewMockService(t)
+
+	action := &message.ListEmailOutputsAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		&matrix.BridgeServices{
+			Email: emailBridge,
+		},
+	)
+
+	return action, matrixDB, msngr, emailBridge
+}
+
+func TestListEmailOutputsAction_HandleEvent(t *testing.T) {
+	action, matrixDB, msngr, emailBridge := testListEmailOutputsAction(t)
+	event := tests.TestEvent(
+		tests.MessageWithBody("list emails", "list emails"),
+		tests.MessageWithOutput(database.Output{
+			OutputType: "matrix",
+			OutputID:   1,
+		}),
+		tests.MessageWithOutput(database.Output{
+			OutputType: email.OutputType,
+			OutputID:   3,
+		}),
+	)
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeEmailOutputList)
+
+	emailBridge.EXPECT().GetOutput(uint(3)).Return(&emaildb.EmailOutput{
+		Model: gorm.Model{
+			ID: 3,
+		},
+		Recipients: "a@example.com,b@example.com",
+	}, nil)
+
+	msngr.EXPECT().SendMessage(messenger.HTMLMessage(
+		"== YOUR E-MAIL RECIPIENTS ==\n"+
+			"- ID 3: a@example.com, b@example.com\n"+
+			"To stop mailing reminders message me with \"remove email ID\".\n",
+		"<h3>Your E-Mail Recipients</h3><br><ul>"+
+			"<li>ID 3: a@example.com, b@example.com</li>"+
+			"</ul><br>To stop mailing reminders message me with \"remove email ID\".<br>",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:     "resp1",
+		UserID: new("@user:example.com"),
+		Body: "== YOUR E-MAIL RECIPIENTS ==\n" +
+			"- ID 3: a@example.com, b@example.com\n" +
+			"To stop mailing reminders message me with \"remove email ID\".\n",
+		BodyFormatted: "<h3>Your E-Mail Recipients</h3><br><ul>" +
+			"<li>ID 3: a@example.com, b@example.com</li>" +
+			"</ul><br>To stop mailing reminders message me with \"remove email ID\".<br>",
+		Type: matrixdb.MessageTypeEmailOutputList,
+	}).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestListEmailOutputsAction_HandleEventWithoutOutputs(t *testing.T) {
+	action, matrixDB, msngr, _ := testListEmailOutputsAction(t)
+	event := tests.TestEvent(tests.MessageWithBody("list emails", "list emails"))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeEmailOutputList)
+
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		"This channel does not mail reminders to anyone. Add recipients with \"add email me@example.com\".",
+		"evt1",
+		"list emails",
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "resp1",
+		UserID:        new("@user:example.com"),
+		Body:          "This channel does not mail reminders to anyone. Add recipients with \"add email me@example.com\".",
+		BodyFormatted: "This channel does not mail reminders to anyone. Add recipients with \"add email me@example.com\".",
+		Type:          matrixdb.MessageTypeEmailOutputList,
+	}).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestListEmailOutputsAction_HandleEventWithError(t *testing.T) {
+	action, matrixDB, msngr, emailBridge := testListEmailOutputsAction(t)
+	event := tests.TestEvent(
+		tests.MessageWithBody("list emails", "list emails"),
+		tests.MessageWithOutput(database.Output{
+			OutputType: email.OutputType,
+			OutputID:   3,
+		}),
+	)
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeEmailOutputList)
+
+	emailBridge.EXPECT().GetOutput(uint(3)).Return(nil, errors.New("test"))
+
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		"Sorry, an error appeared.",
+		"evt1",
+		"list emails",
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "resp1",
+		UserID:        new("@user:example.com"),
+		Body:          "Sorry, an error appeared.",
+		BodyFormatted: "Sorry, an error appeared.",
+		Type:          matrixdb.MessageTypeEmailOutputList,
+	}).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestListEmailOutputsAction_HandleEventWithEmailDisabled(t *testing.T) {
+	matrixDB := matrixdb.NewMockService(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.ListEmailOutputsAction{}
+	action.Configure(
+		slog.Default(),
+		mautrixcl.NewMockClient(t),
+		msngr,
+		matrixDB,
+		database.NewMockService(t),
+		&matrix.BridgeServices{},
+	)
+
+	event := tests.TestEvent(tests.MessageWithBody("list emails", "list emails"))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeEmailOutputList)
+
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		"Sending e-mails is not enabled on this server.",
+		"evt1",
+		"list emails",
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "resp1",
+		UserID:        new("@user:example.com"),
+		Body:          "Sending e-mails is not enabled on this server.",
+		BodyFormatted: "Sending e-mails is not enabled on this server.",
+		Type:          matrixdb.MessageTypeEmailOutputList,
+	}).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
 	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
@@ -14,7 +15,8 @@ import (
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 )
 
-var listEventsActionRegex = regexp.MustCompile("(?i)^((list|show)(| all| the)(| reminders| my reminders)(| please)|^reminders|^reminder)[ ]*$")
+var listOwnEventsRegex = regexp.MustCompile("(?i)(assigned to me|mir zugewiesenen?)")
+var listEventsActionRegex = regexp.MustCompile("(?i)^((list|show)(| all| the)(| reminders| my reminders| reminders assigned to me)(| please)|^reminders|^reminder|(zeige|liste)(| alle| meine| alle mir zugewiesenen| mir zugewiesenen)[ ]+(termine|erinnerungen)|^termine|^erinnerungen)[ ]*$")
 
 // ListEventsAction lists all events.
 type ListEventsAction struct {
@@ -44,8 +46,8 @@ func (action *ListEventsAction) Name() string {
 // GetDocu returns the documentation for the action.
 func (action *ListEventsAction) GetDocu() (title, explaination string, examples []string) {
 	return "List All Events",
-		"List all events in this channel.",
-		[]string{"list", "list reminders", "show", "show reminders", "list my reminders", "reminders"}
+		"List all events in this channel. Ask for \"reminders assigned to me\" to only list events assigned to you or created by you without an assignee.",
+		[]string{"list", "list reminders", "show", "show reminders", "list my reminders", "list reminders assigned to me", "reminders", "zeige meine termine", "zeige mir zugewiesenen termine"}
 }
 
 // Selector defines a regex on what messages the action should be used.
@@ -55,12 +57,17 @@ func (action *ListEventsAction) Selector() *regexp.Regexp {
 
 // HandleEvent is where the message event get's send to if it matches the Selector.
 func (action *ListEventsAction) HandleEvent(event *matrix.MessageEvent) {
-	events, err := action.db.ListEvents(&database.ListEventsOpts{
+	opts := &database.ListEventsOpts{
 		ChannelID: &event.Channel.ID,
-	})
+	}
+	if listOwnEventsRegex.MatchString(event.Content.Body) {
+		opts.User = new(event.Event.Sender.String())
+	}
+
+	events, err := action.db.ListEvents(opts)
 	if err != nil {
 		err2 := action.messenger.SendResponseAsync(messenger.PlainTextResponse(
-			"There was an issue accessing the data 🤨",
+			i18n.Translate(event.Room.Language, "There was an issue accessing the data 🤨"),
 			event.Event.ID.String(),
 			event.Content.Body,
 			event.Event.Sender.String(),
@@ -84,9 +91,9 @@ func (action *ListEventsAction) HandleEvent(event *matrix.MessageEvent) {
 	}
 
 	msg := format.Formater{}
-	msg.Title("Your Events")
+	msg.Title(i18n.Translate(event.Room.Language, "Your Events"))
 
-	msgEvts, msgEvtsFormatted := format.InfoFromEvents(events, event.Room.TimeZone)
+	msgEvts, msgEvtsFormatted := format.InfoFromEvents(events, event.TimeZone(), event.Clock())
 
 	msgOut, msgOutFormatted := msg.Build()
 
@@ -16,6 +16,7 @@ import (
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 	dbtests "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database/tests"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestListEventsAction_Meta(t *testing.T) {
@@ -141,3 +142,44 @@ func TestListEventsAction_HandleEventWithError(t *testing.T) {
 
 	action.HandleEvent(tests.TestEvent())
 }
+
+func TestListEventsAction_HandleEventWithOwnEvents(t *testing.T) {
+	testCases := []struct {
+		body string
+		user *string
+	}{
+		{body: "list reminders assigned to me", user: new("@user:example.com")},
+		{body: "zeige mir zugewiesenen termine", user: new("@user:example.com")},
+		{body: "list my reminders"},
+		{body: "zeige meine termine"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.body, func(t *testing.T) {
+			// Setup
+			db := database.NewMockService(t)
+			matrixDB := matrixdb.NewMockService(t)
+			client := mautrixcl.NewMockClient(t)
+			msngr := messenger.NewMockMessenger(t)
+
+			action := &message.ListEventsAction{}
+			action.Configure(
+				slog.Default(),
+				client,
+				msngr,
+				matrixDB,
+				db,
+				nil,
+			)
+
+			db.EXPECT().ListEvents(&database.ListEventsOpts{
+				ChannelID: new(uint(68272)),
+				User:      tc.user,
+			}).Return(nil, errors.New("test"))
+
+			msngr.EXPECT().SendResponseAsync(mock.Anything).Return(nil)
+
+			action.HandleEvent(tests.TestEvent(tests.MessageWithBody(tc.body, tc.body)))
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package message
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/ical"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+var listICalInputsActionRegex = regexp.MustCompile("(?i)^(list|show)(| all| the| my)[ ]+(calendars|icals|cals)[ ]*$")
+
+// ListICalInputsAction lists the iCal resources a channel is subscribed to.
+type ListICalInputsAction struct {
+	logger     *slog.Logger
+	client     mautrixcl.Client
+	messenger  messenger.Messenger
+	matrixDB   matrixdb.Service
+	db         database.Service
+	icalBridge matrix.BridgeServiceICal
+	storer     *msghelper.Storer
+}
+
+// Configure is called on startup and sets all dependencies.
+func (action *ListICalInputsAction) Configure(
+	logger *slog.Logger,
+	client mautrixcl.Client,
+	messenger messenger.Messenger,
+	matrixDB matrixdb.Service,
+	db database.Service,
+	bridgeServices *matrix.BridgeServices,
+) {
+	action.logger = logger
+	action.client = client
+	action.matrixDB = matrixDB
+	action.db = db
+	action.messenger = messenger
+	action.icalBridge = bridgeServices.ICal
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
+}
+
+// Name of the action.
+func (action *ListICalInputsAction) Name() string {
+	return "List iCal calendars"
+}
+
+// GetDocu returns the documentation for the action.
+func (action *ListICalInputsAction) GetDocu() (title, explaination string, examples []string) {
+	return "List iCal calendars",
+		"List all calendars events are imported from.",
+		[]string{"list calendars", "show my calendars"}
+}
+
+// Selector defines a regex on what messages the action should be used.
+func (action *ListICalInputsAction) Selector() *regexp.Regexp {
+	return listICalInputsActionRegex
+}
+
+// HandleEvent is where the message event get's send to if it matches the Selector.
+func (action *ListICalInputsAction) HandleEvent(event *matrix.MessageEvent) {
+	dbMsg := mapping.MessageFromEvent(event)
+	dbMsg.Type = matrixdb.MessageTypeIcalInputList
+
+	_, err := action.matrixDB.NewMessage(dbMsg)
+	if err != nil {
+		action.logger.Error("failed to save message to database", "error", err)
+	}
+
+	items := []string{}
+
+	for _, input := range event.Channel.Inputs {
+		if input.InputType != ical.InputType {
+			continue
+		}
+
+		icalInput, err := action.icalBridge.GetInput(input.InputID)
+		if err != nil {
+			action.logger.Error("failed to get iCal input", "error", err, "ical.input_id", input.InputID)
+
+			msg := "Sorry, an error appeared."
+			go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeIcalInputList, *event)
+
+			return
+		}
+
+		status := "not fetched yet"
+		if icalInput.LastRefresh != nil {
+			status = "last fetched " + format.ToLocalTime(*icalInput.LastRefresh, event.TimeZone(), event.Clock())
+		}
+
+		if icalInput.Disabled {
+			status += ", disabled as fetching failed for too long"
+		}
+
+		items = append(items, fmt.Sprintf("ID %d: %s (%s)", icalInput.ID, icalInput.URL, status))
+	}
+
+	if len(items) == 0 {
+		msg := "This channel is not subscribed to any calendar. Add one with \"add calendar https://...\"."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeIcalInputList, *event)
+
+		return
+	}
+
+	msg := format.Formater{}
+	msg.Title("Your Calendars")
+	msg.List(items)
+	msg.TextLine("To remove a calendar message me with \"remove calendar ID\".")
+
+	message, messageFormatted := msg.Build()
+	go action.storer.SendAndStoreMessage(message, messageFormatted, matrixdb.MessageTypeIcalInputList, *event)
+}
@@ -0,0 +1,205 @@
+package message_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/ical"
+	icaldb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/ical/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/message"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestListICalInputsAction(t *testing.T) {
+	action := &message.ListICalInputsAction{}
+
+	assert.NotEmpty(t, action.Name())
+
+	title, desc, examples := action.GetDocu()
+	assert.NotEmpty(t, title)
+	assert.NotEmpty(t, desc)
+	assert.NotEmpty(t, examples)
+
+	assert.NotNil(t, action.Selector())
+}
+
+func TestListICalInputsAction_Selector(t *testing.T) {
+	action := &message.ListICalInputsAction{}
+	r := action.Selector()
+
+	_, _, examples := action.GetDocu()
+	for _, example := range examples {
+		assert.True(t, r.MatchString(example))
+	}
+}
+
+func testListICalInputsAction(t *testing.T) (*message.ListICalInputsAction, *matrixdb.MockService, *messenger.MockMessenger, *ical.MockService) {
+	t.Helper()
+
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+	icalBridge := ical.NewMockService(t)
+
+	action := &message.ListICalInputsAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		&matrix.BridgeServices{
+			ICal: icalBridge,
+		},
+	)
+
+	return action, matrixDB, msngr, icalBridge
+}
+
+func TestListICalInputsAction_HandleEvent(t *testing.T) {
+	action, matrixDB, msngr, icalBridge := testListICalInputsAction(t)
+	event := tests.TestEvent(
+		tests.MessageWithBody("list calendars", "list calendars"),
+		tests.MessageWithInput(database.Input{
+			InputType: "matrix",
+			InputID:   1,
+		}),
+		tests.MessageWithInput(database.Input{
+			InputType: ical.InputType,
+			InputID:   3,
+		}),
+		tests.MessageWithInput(database.Input{
+			InputType: ical.InputType,
+			InputID:   4,
+		}),
+	)
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeIcalInputList)
+
+	lastRefresh := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	icalBridge.EXPECT().GetInput(uint(3)).Return(&icaldb.IcalInput{
+		Model: gorm.Model{
+			ID: 3,
+		},
+		URL:         "https://example.com/1.ics",
+		LastRefresh: &lastRefresh,
+	}, nil)
+	icalBridge.EXPECT().GetInput(uint(4)).Return(&icaldb.IcalInput{
+		Model: gorm.Model{
+			ID: 4,
+		},
+		URL:      "https://example.com/2.ics",
+		Disabled: true,
+	}, nil)
+
+	msngr.EXPECT().SendMessage(messenger.HTMLMessage(
+		"== YOUR CALENDARS ==\n"+
+			"- ID 3: https://example.com/1.ics (last fetched 10:30 01.05.2024 (UTC))\n"+
+			"- ID 4: https://example.com/2.ics (not fetched yet, disabled as fetching failed for too long)\n"+
+			"To remove a calendar message me with \"remove calendar ID\".\n",
+		"<h3>Your Calendars</h3><br><ul>"+
+			"<li>ID 3: https://example.com/1.ics (last fetched 10:30 01.05.2024 (UTC))</li>"+
+			"<li>ID 4: https://example.com/2.ics (not fetched yet, disabled as fetching failed for too long)</li>"+
+			"</ul><br>To remove a calendar message me with \"remove calendar ID\".<br>",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:     "resp1",
+		UserID: new("@user:example.com"),
+		Body: "== YOUR CALENDARS ==\n" +
+			"- ID 3: https://example.com/1.ics (last fetched 10:30 01.05.2024 (UTC))\n" +
+			"- ID 4: https://example.com/2.ics (not fetched yet, disabled as fetching failed for too long)\n" +
+			"To remove a calendar message me with \"remove calendar ID\".\n",
+		BodyFormatted: "<h3>Your Calendars</h3><br><ul>" +
+			"<li>ID 3: https://example.com/1.ics (last fetched 10:30 01.05.2024 (UTC))</li>" +
+			"<li>ID 4: https://example.com/2.ics (not fetched yet, disabled as fetching failed for too long)</li>" +
+			"</ul><br>To remove a calendar message me with \"remove calendar ID\".<br>",
+		Type: matrixdb.MessageTypeIcalInputList,
+	}).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestListICalInputsAction_HandleEventWithoutInputs(t *testing.T) {
+	action, matrixDB, msngr, _ := testListICalInputsAction(t)
+	event := tests.TestEvent(tests.MessageWithBody("list calendars", "list calendars"))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeIcalInputList)
+
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		"This channel is not subscribed to any calendar. Add one with \"add calendar https://...\".",
+		"evt1",
+		"list calendars",
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "resp1",
+		UserID:        new("@user:example.com"),
+		Body:          "This channel is not subscribed to any calendar. Add one with \"add calendar https://...\".",
+		BodyFormatted: "This channel is not subscribed to any calendar. Add one with \"add calendar https://...\".",
+		Type:          matrixdb.MessageTypeIcalInputList,
+	}).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestListICalInputsAction_HandleEventWithError(t *testing.T) {
+	action, matrixDB, msngr, icalBridge := testListICalInputsAction(t)
+	event := tests.TestEvent(
+		tests.MessageWithBody("list calendars", "list calendars"),
+		tests.MessageWithInput(database.Input{
+			InputType: ical.InputType,
+			InputID:   3,
+		}),
+	)
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeIcalInputList)
+
+	icalBridge.EXPECT().GetInput(uint(3)).Return(nil, errors.New("test"))
+
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		"Sorry, an error appeared.",
+		"evt1",
+		"list calendars",
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "resp1",
+		UserID:        new("@user:example.com"),
+		Body:          "Sorry, an error appeared.",
+		BodyFormatted: "Sorry, an error appeared.",
+		Type:          matrixdb.MessageTypeIcalInputList,
+	}).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
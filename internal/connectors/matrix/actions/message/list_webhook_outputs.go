@@ -0,0 +1,111 @@
+package message
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+var listWebhookOutputsActionRegex = regexp.MustCompile("(?i)^(list|show)(| all| the| my)[ ]+(webhooks|web hooks)[ ]*$")
+
+// ListWebhookOutputsAction lists the webhooks of a channel.
+type ListWebhookOutputsAction struct {
+	logger        *slog.Logger
+	client        mautrixcl.Client
+	messenger     messenger.Messenger
+	matrixDB      matrixdb.Service
+	db            database.Service
+	webhookBridge matrix.BridgeServiceWebhook
+	storer        *msghelper.Storer
+}
+
+// Configure is called on startup and sets all dependencies.
+func (action *ListWebhookOutputsAction) Configure(
+	logger *slog.Logger,
+	client mautrixcl.Client,
+	messenger messenger.Messenger,
+	matrixDB matrixdb.Service,
+	db database.Service,
+	bridgeServices *matrix.BridgeServices,
+) {
+	action.logger = logger
+	action.client = client
+	action.matrixDB = matrixDB
+	action.db = db
+	action.messenger = messenger
+	action.webhookBridge = bridgeServices.Webhook
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
+}
+
+// Name of the action.
+func (action *ListWebhookOutputsAction) Name() string {
+	return "List webhooks"
+}
+
+// GetDocu returns the documentation for the action.
+func (action *ListWebhookOutputsAction) GetDocu() (title, explaination string, examples []string) {
+	return "List webhooks",
+		"List all webhooks reminders are posted to.",
+		[]string{"list webhooks", "show my webhooks"}
+}
+
+// Selector defines a regex on what messages the action should be used.
+func (action *ListWebhookOutputsAction) Selector() *regexp.Regexp {
+	return listWebhookOutputsActionRegex
+}
+
+// HandleEvent is where the message event get's send to if it matches the Selector.
+func (action *ListWebhookOutputsAction) HandleEvent(event *matrix.MessageEvent) {
+	dbMsg := mapping.MessageFromEvent(event)
+	dbMsg.Type = matrixdb.MessageTypeWebhookOutputList
+
+	_, err := action.matrixDB.NewMessage(dbMsg)
+	if err != nil {
+		action.logger.Error("failed to save message to database", "error", err)
+	}
+
+	items := []string{}
+
+	for _, output := range event.Channel.Outputs {
+		if output.OutputType != webhook.OutputType {
+			continue
+		}
+
+		webhookOutput, err := action.webhookBridge.GetOutput(output.OutputID)
+		if err != nil {
+			action.logger.Error("failed to get webhook output", "error", err, "webhook.output_id", output.OutputID)
+
+			msg := "Sorry, an error appeared."
+			go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeWebhookOutputList, *event)
+
+			return
+		}
+
+		items = append(items, fmt.Sprintf("ID %d: %s", webhookOutput.ID, webhookOutput.URL))
+	}
+
+	if len(items) == 0 {
+		msg := "This channel has no webhooks. Add one with \"add webhook https://...\"."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeWebhookOutputList, *event)
+
+		return
+	}
+
+	msg := format.Formater{}
+	msg.Title("Your Webhooks")
+	msg.List(items)
+	msg.TextLine("To remove a webhook message me with \"remove webhook ID\".")
+
+	message, messageFormatted := msg.Build()
+	go action.storer.SendAndStoreMessage(message, messageFormatted, matrixdb.MessageTypeWebhookOutputList, *event)
+}
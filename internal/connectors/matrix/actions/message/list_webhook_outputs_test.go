@@ -0,0 +1,188 @@
+package message_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/message"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook"
+	webhookdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestListWebhookOutputsAction(t *testing.T) {
+	action := &message.ListWebhookOutputsAction{}
+
+	assert.NotEmpty(t, action.Name())
+
+	title, desc, examples := action.GetDocu()
+	assert.NotEmpty(t, title)
+	assert.NotEmpty(t, desc)
+	assert.NotEmpty(t, examples)
+
+	assert.NotNil(t, action.Selector())
+}
+
+func TestListWebhookOutputsAction_Selector(t *testing.T) {
+	action := &message.ListWebhookOutputsAction{}
+	r := action.Selector()
+
+	_, _, examples := action.GetDocu()
+	for _, example := range examples {
+		assert.True(t, r.MatchString(example))
+	}
+}
+
+func testListWebhookOutputsAction(t *testing.T) (*message.ListWebhookOutputsAction, *matrixdb.MockService, *messenger.MockMessenger, *webhook.MockService) {
+	t.Helper()
+
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+	webhookBridge := webhook.NewMockService(t)
+
+	action := &message.ListWebhookOutputsAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		&matrix.BridgeServices{
+			Webhook: webhookBridge,
+		},
+	)
+
+	return action, matrixDB, msngr, webhookBridge
+}
+
+func TestListWebhookOutputsAction_HandleEvent(t *testing.T) {
+	action, matrixDB, msngr, webhookBridge := testListWebhookOutputsAction(t)
+	event := tests.TestEvent(
+		tests.MessageWithBody("list webhooks", "list webhooks"),
+		tests.MessageWithOutput(database.Output{
+			OutputType: "matrix",
+			OutputID:   1,
+		}),
+		tests.MessageWithOutput(database.Output{
+			OutputType: webhook.OutputType,
+			OutputID:   3,
+		}),
+	)
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeWebhookOutputList)
+
+	webhookBridge.EXPECT().GetOutput(uint(3)).Return(&webhookdb.WebhookOutput{
+		Model: gorm.Model{
+			ID: 3,
+		},
+		URL: "https://example.com/hook",
+	}, nil)
+
+	msngr.EXPECT().SendMessage(messenger.HTMLMessage(
+		"== YOUR WEBHOOKS ==\n"+
+			"- ID 3: https://example.com/hook\n"+
+			"To remove a webhook message me with \"remove webhook ID\".\n",
+		"<h3>Your Webhooks</h3><br><ul>"+
+			"<li>ID 3: https://example.com/hook</li>"+
+			"</ul><br>To remove a webhook message me with \"remove webhook ID\".<br>",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:     "resp1",
+		UserID: new("@user:example.com"),
+		Body: "== YOUR WEBHOOKS ==\n" +
+			"- ID 3: https://example.com/hook\n" +
+			"To remove a webhook message me with \"remove webhook ID\".\n",
+		BodyFormatted: "<h3>Your Webhooks</h3><br><ul>" +
+			"<li>ID 3: https://example.com/hook</li>" +
+			"</ul><br>To remove a webhook message me with \"remove webhook ID\".<br>",
+		Type: matrixdb.MessageTypeWebhookOutputList,
+	}).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestListWebhookOutputsAction_HandleEventWithoutOutputs(t *testing.T) {
+	action, matrixDB, msngr, _ := testListWebhookOutputsAction(t)
+	event := tests.TestEvent(tests.MessageWithBody("list webhooks", "list webhooks"))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeWebhookOutputList)
+
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		"This channel has no webhooks. Add one with \"add webhook https://...\".",
+		"evt1",
+		"list webhooks",
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "resp1",
+		UserID:        new("@user:example.com"),
+		Body:          "This channel has no webhooks. Add one with \"add webhook https://...\".",
+		BodyFormatted: "This channel has no webhooks. Add one with \"add webhook https://...\".",
+		Type:          matrixdb.MessageTypeWebhookOutputList,
+	}).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestListWebhookOutputsAction_HandleEventWithError(t *testing.T) {
+	action, matrixDB, msngr, webhookBridge := testListWebhookOutputsAction(t)
+	event := tests.TestEvent(
+		tests.MessageWithBody("list webhooks", "list webhooks"),
+		tests.MessageWithOutput(database.Output{
+			OutputType: webhook.OutputType,
+			OutputID:   3,
+		}),
+	)
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeWebhookOutputList)
+
+	webhookBridge.EXPECT().GetOutput(uint(3)).Return(nil, errors.New("test"))
+
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		"Sorry, an error appeared.",
+		"evt1",
+		"list webhooks",
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "resp1",
+		UserID:        new("@user:example.com"),
+		Body:          "Sorry, an error appeared.",
+		BodyFormatted: "Sorry, an error appeared.",
+		Type:          matrixdb.MessageTypeWebhookOutputList,
+	}).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
@@ -1,23 +1,26 @@
 package message
 
 import (
-	"fmt"
 	"log/slog"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
 	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 )
 
 var DefaultEventTime = time.Minute
 var ReminderRequestReactions = []string{"❌", "▶️", "⏩", "1️⃣", "4️⃣", "⚠️"}
 var newEventActionRegex = regexp.MustCompile(".*")
+var listItemRegex = regexp.MustCompile(`^[ \t]*([-*•+]|[0-9]+[.)])[ \t]+`)
 
 // NewEventAction for new events. Should be the default message handler.
 type NewEventAction struct {
@@ -26,6 +29,7 @@ type NewEventAction struct {
 	messenger messenger.Messenger
 	matrixDB  matrixdb.Service
 	db        database.Service
+	storer    *msghelper.Storer
 }
 
 // Configure is called on startup and sets all dependencies.
@@ -35,6 +39,7 @@ func (action *NewEventAction) Configure(logger *slog.Logger, client mautrixcl.Cl
 	action.matrixDB = matrixDB
 	action.db = db
 	action.messenger = messenger
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
 }
 
 // Name of the action
@@ -44,8 +49,8 @@ func (action *NewEventAction) Name() string {
 
 func (action *NewEventAction) GetDocu() (title, explaination string, examples []string) {
 	return "New event",
-		"Add a new reminder.",
-		[]string{"go shopping at monday", "buy milk at 5 pm", "ask boss for pay raise in 1 year"}
+		"Add a new reminder. Send a list with one reminder per line to add multiple at once. Start with \"remind @user to\" to assign the reminder to someone else.",
+		[]string{"go shopping at monday", "buy milk at 5 pm", "ask boss for pay raise in 1 year", "remind @alice:example.com to call bob tomorrow"}
 }
 
 // Selector defines a regex on what messages the action should be used.
@@ -55,11 +60,19 @@ func (action *NewEventAction) Selector() *regexp.Regexp {
 
 // HandleEvent is where the message event get's send to if it matches the Selector.
 func (action *NewEventAction) HandleEvent(event *matrix.MessageEvent) {
-	remindTime, err := format.ParseTime(event.Channel, event.Content.Body, event.Room.TimeZone, false)
+	items := listItems(event.Content.Body)
+	if len(items) > 1 {
+		action.handleEvents(event, items)
+		return
+	}
+
+	assignee, body := format.ParseAssignment(event.Content.Body, event.Content.FormattedBody, event.Event.Sender.String())
+
+	parsed, err := format.ParseTimeAndText(event.Channel, body, event.TimeZoneFor(assignee), event.Room.Language)
 	if err != nil {
 		action.logger.Error("failed to extract time from message", "error", err)
 		_ = action.messenger.SendResponseAsync(messenger.PlainTextResponse(
-			"Sorry I was not able to understand the remind date and time from this message",
+			i18n.Translate(event.Room.Language, "Sorry I was not able to understand the remind date and time from this message"),
 			event.Event.ID.String(),
 			event.Content.Body,
 			event.Event.Sender.String(),
@@ -69,13 +82,17 @@ func (action *NewEventAction) HandleEvent(event *matrix.MessageEvent) {
 		return
 	}
 
+	// The time expression is not part of the event message, the full body stays
+	// available in the matrix message linked to the event.
 	dbEvent, err := action.db.NewEvent(&database.Event{
-		Time:      remindTime,
-		Duration:  DefaultEventTime,
-		Message:   event.Content.Body,
-		Active:    true,
-		ChannelID: event.Channel.ID,
-		InputID:   &event.Input.ID,
+		Time:       parsed.Time,
+		Duration:   DefaultEventTime,
+		Message:    parsed.Text,
+		Active:     true,
+		ChannelID:  event.Channel.ID,
+		InputID:    &event.Input.ID,
+		CreatedBy:  event.Event.Sender.String(),
+		AssignedTo: assignee,
 	})
 	if err != nil {
 		action.logger.Error("failed to save event to database", "error", err)
@@ -93,7 +110,7 @@ func (action *NewEventAction) HandleEvent(event *matrix.MessageEvent) {
 	}
 
 	go func(evt *matrix.MessageEvent, dbEvent *database.Event) {
-		msg := fmt.Sprintf("Successfully added new reminder (ID: %d) for %s", dbEvent.ID, format.ToLocalTime(dbEvent.Time, event.Room.TimeZone))
+		msg := i18n.Translatef(event.Room.Language, "Successfully added new reminder (ID: %d) for %s", dbEvent.ID, format.ToLocalTime(dbEvent.Time, event.TimeZone(), event.Clock()))
 
 		response := messenger.PlainTextResponse(
 			msg,
@@ -137,3 +154,109 @@ func (action *NewEventAction) HandleEvent(event *matrix.MessageEvent) {
 		}
 	}
 }
+
+// handleEvents adds an event per list item. If one item can not be parsed no event is added.
+func (action *NewEventAction) handleEvents(event *matrix.MessageEvent, lines []string) {
+	events := make([]database.Event, 0, len(lines))
+
+	for i, line := range lines {
+		// Mentions can not be mapped to lines, only matrix user IDs are supported.
+		assignee, line := format.ParseAssignment(line, "", event.Event.Sender.String())
+
+		parsed, err := format.ParseTimeAndText(event.Channel, line, event.TimeZoneFor(assignee), event.Room.Language)
+		if err != nil {
+			action.logger.Info("failed to extract time from line", "line", i+1, "error", err)
+			_ = action.messenger.SendResponseAsync(messenger.PlainTextResponse(
+				i18n.Translatef(event.Room.Language, "Sorry I was not able to understand the remind date and time in line %d, no reminders were added.", i+1),
+				event.Event.ID.String(),
+				event.Content.Body,
+				event.Event.Sender.String(),
+				event.Room.RoomID,
+			))
+
+			return
+		}
+
+		events = append(events, database.Event{
+			Time:       parsed.Time,
+			Duration:   DefaultEventTime,
+			Message:    parsed.Text,
+			Active:     true,
+			ChannelID:  event.Channel.ID,
+			InputID:    &event.Input.ID,
+			CreatedBy:  event.Event.Sender.String(),
+			AssignedTo: assignee,
+		})
+	}
+
+	err := action.db.NewEvents(events)
+	if err != nil {
+		action.logger.Error("failed to save events to database", "error", err)
+		_ = action.messenger.SendResponseAsync(messenger.PlainTextResponse(
+			i18n.Translate(event.Room.Language, "Sorry, an error appeared."),
+			event.Event.ID.String(),
+			event.Content.Body,
+			event.Event.Sender.String(),
+			event.Room.RoomID,
+		))
+
+		return
+	}
+
+	// The request message relates to multiple events, each list item is linked to its event instead.
+	message := mapping.MessageFromEvent(event)
+	message.Type = matrixdb.MessageTypeNewEvent
+
+	_, err = action.matrixDB.NewMessage(message)
+	if err != nil {
+		action.logger.Error("failed to save message to database", "error", err)
+	} else {
+		entries := make([]matrixdb.MatrixDigestEntry, 0, len(events))
+		for i := range events {
+			entries = append(entries, matrixdb.MatrixDigestEntry{
+				MessageID: message.ID,
+				Position:  uint(i + 1),
+				EventID:   events[i].ID,
+			})
+		}
+
+		err = action.matrixDB.NewDigestEntries(entries)
+		if err != nil {
+			action.logger.Error("failed to save message entries to database", "error", err)
+		}
+	}
+
+	msg := strings.Builder{}
+	msg.WriteString(i18n.Translatef(event.Room.Language, "Successfully added %d new reminders:", len(events)))
+
+	for i := range events {
+		msg.WriteString("\n")
+		msg.WriteString(i18n.Translatef(event.Room.Language, "%s (ID: %d) for %s", events[i].Message, events[i].ID, format.ToLocalTime(events[i].Time, event.TimeZone(), event.Clock())))
+	}
+
+	go action.storer.SendAndStoreResponse(msg.String(), matrixdb.MessageTypeNewEvent, *event)
+}
+
+// listItems returns the items of a message listing multiple events, one per line with a list marker. Messages with
+// lines without list marker are no list, nil is returned.
+func listItems(body string) []string {
+	items := []string{}
+
+	for line := range strings.SplitSeq(body, "\n") {
+		if strings.HasPrefix(line, ">") || strings.TrimSpace(line) == "" {
+			// Quoted reply or empty line.
+			continue
+		}
+
+		if !listItemRegex.MatchString(line) {
+			return nil
+		}
+
+		item := strings.TrimSpace(listItemRegex.ReplaceAllString(line, ""))
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
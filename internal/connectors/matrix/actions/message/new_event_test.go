@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
 
@@ -59,21 +61,22 @@ func TestNewEventAction_HandleEvent(t *testing.T) {
 		nil,
 	)
 
-	msgs := []string{
-		"my test reminder at monday 1:11",
-		"my +#ä§$&7(&$==§ é reminder in 100 years",
+	msgs := map[string]string{
+		"my test reminder at monday 1:11":          "my test reminder",
+		"my +#ä§$&7(&$==§ é reminder in 100 years": "my é reminder",
 	}
 
-	for _, msg := range msgs {
+	for msg, text := range msgs {
 		t.Run(msg, func(_ *testing.T) {
 			// Expectations
 			matcher := &eventMatcher{
 				evt: &database.Event{
 					Duration:  message.DefaultEventTime,
-					Message:   msg,
+					Message:   text,
 					Active:    true,
 					ChannelID: tests.TestEvent().Channel.ID,
 					InputID:   &tests.TestEvent().Input.ID,
+					CreatedBy: "@user:example.com",
 				},
 			}
 			db.EXPECT().NewEvent(mock.MatchedBy(matcher.Matches)).Return(&database.Event{
@@ -81,7 +84,7 @@ func TestNewEventAction_HandleEvent(t *testing.T) {
 					ID: 1,
 				},
 				Duration:  message.DefaultEventTime,
-				Message:   msg,
+				Message:   text,
 				Active:    true,
 				ChannelID: tests.TestEvent().Channel.ID,
 				InputID:   &tests.TestEvent().Input.ID,
@@ -126,6 +129,75 @@ func TestNewEventAction_HandleEvent(t *testing.T) {
 	time.Sleep(time.Millisecond * 10) // wait for goroutine to finish
 }
 
+func TestNewEventAction_HandleEventWithAssignment(t *testing.T) {
+	// Setup
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.NewEventAction{}
+	action.Configure(
+		slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		nil,
+	)
+
+	// Expectations
+	matcher := &eventMatcher{
+		evt: &database.Event{
+			Duration:   message.DefaultEventTime,
+			Message:    "call bob",
+			Active:     true,
+			ChannelID:  tests.TestEvent().Channel.ID,
+			InputID:    &tests.TestEvent().Input.ID,
+			CreatedBy:  "@user:example.com",
+			AssignedTo: "@alice:example.com",
+		},
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	db.EXPECT().NewEvent(mock.MatchedBy(func(evt *database.Event) bool {
+		// Parsed in the timezone of the assignee.
+		return matcher.Matches(evt) && evt.Time.In(newYork).Hour() == 9
+	})).Return(&database.Event{
+		Model: gorm.Model{
+			ID: 1,
+		},
+		Message:    "call bob",
+		AssignedTo: "@alice:example.com",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(mock.Anything).Return(nil, nil)
+
+	for _, reaction := range message.ReminderRequestReactions {
+		msngr.EXPECT().SendReactionAsync(&messenger.Reaction{
+			Reaction:                  reaction,
+			MessageExternalIdentifier: tests.TestEvent().Event.ID.String(),
+			ChannelExternalIdentifier: tests.TestEvent().Room.RoomID,
+		}).Return(nil)
+	}
+
+	msngr.EXPECT().SendResponse(mock.Anything).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "ext1",
+	}, nil)
+
+	// Execute
+	action.HandleEvent(tests.TestEvent(
+		tests.MessageWithBody(
+			"remind Alice to call bob tomorrow at 9am",
+			`remind <a href="https://matrix.to/#/@alice:example.com">Alice</a> to call bob tomorrow at 9am`,
+		),
+		tests.MessageWithUserInRoom(matrixdb.MatrixUser{ID: "@alice:example.com", TimeZone: "America/New_York"}),
+	))
+
+	time.Sleep(time.Millisecond * 10) // wait for goroutine to finish
+}
+
 func TestNewEventAction_HandleEventWithNewMessageError(t *testing.T) {
 	// Setup
 	db := database.NewMockService(t)
@@ -147,10 +219,11 @@ func TestNewEventAction_HandleEventWithNewMessageError(t *testing.T) {
 	matcher := &eventMatcher{
 		evt: &database.Event{
 			Duration:  message.DefaultEventTime,
-			Message:   "my test reminder at monday 1:11",
+			Message:   "my test reminder",
 			Active:    true,
 			ChannelID: tests.TestEvent().Channel.ID,
 			InputID:   &tests.TestEvent().Input.ID,
+			CreatedBy: "@user:example.com",
 		},
 	}
 	db.EXPECT().NewEvent(mock.MatchedBy(matcher.Matches)).Return(&database.Event{
@@ -158,7 +231,7 @@ func TestNewEventAction_HandleEventWithNewMessageError(t *testing.T) {
 			ID: 1,
 		},
 		Duration:  message.DefaultEventTime,
-		Message:   "my test reminder at monday 1:11",
+		Message:   "my test reminder",
 		Active:    true,
 		ChannelID: tests.TestEvent().Channel.ID,
 		InputID:   &tests.TestEvent().Input.ID,
@@ -199,10 +272,11 @@ func TestNewEventAction_HandleEventWithNewEventError(t *testing.T) {
 	matcher := &eventMatcher{
 		evt: &database.Event{
 			Duration:  message.DefaultEventTime,
-			Message:   "my test reminder at monday 1:11",
+			Message:   "my test reminder",
 			Active:    true,
 			ChannelID: tests.TestEvent().Channel.ID,
 			InputID:   &tests.TestEvent().Input.ID,
+			CreatedBy: "@user:example.com",
 		},
 	}
 	db.EXPECT().NewEvent(mock.MatchedBy(matcher.Matches)).Return(nil, errors.New("test"))
@@ -218,6 +292,174 @@ func TestNewEventAction_HandleEventWithNewEventError(t *testing.T) {
 	time.Sleep(time.Millisecond * 10) // wait for goroutine to finish
 }
 
+func TestNewEventAction_HandleEventWithMultipleLines(t *testing.T) {
+	// Setup
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.NewEventAction{}
+	action.Configure(
+		slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		nil,
+	)
+
+	body := "- call dentist tomorrow 9am\n\n- remind @alice:example.com to pay rent at monday 1:11\n"
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Expectations
+	db.EXPECT().NewEvents(mock.MatchedBy(func(events []database.Event) bool {
+		return len(events) == 2 &&
+			(&eventMatcher{evt: &database.Event{
+				Duration:  message.DefaultEventTime,
+				Message:   "call dentist",
+				Active:    true,
+				ChannelID: tests.TestEvent().Channel.ID,
+				InputID:   &tests.TestEvent().Input.ID,
+				CreatedBy: "@user:example.com",
+			}}).Matches(&events[0]) &&
+			(&eventMatcher{evt: &database.Event{
+				Duration:   message.DefaultEventTime,
+				Message:    "pay rent",
+				Active:     true,
+				ChannelID:  tests.TestEvent().Channel.ID,
+				InputID:    &tests.TestEvent().Input.ID,
+				CreatedBy:  "@user:example.com",
+				AssignedTo: "@alice:example.com",
+			}}).Matches(&events[1]) &&
+			events[1].Time.In(newYork).Hour() == 1
+	})).Run(func(events []database.Event) {
+		events[0].ID = 1
+		events[1].ID = 2
+	}).Return(nil)
+
+	matrixDB.EXPECT().NewMessage(mock.MatchedBy(func(msg *matrixdb.MatrixMessage) bool {
+		return msg.ID == "evt1" && msg.EventID == nil && msg.Type == matrixdb.MessageTypeNewEvent
+	})).Return(nil, nil)
+	matrixDB.EXPECT().NewDigestEntries([]matrixdb.MatrixDigestEntry{
+		{MessageID: "evt1", Position: 1, EventID: 1},
+		{MessageID: "evt1", Position: 2, EventID: 2},
+	}).Return(nil)
+
+	msngr.EXPECT().SendResponse(mock.MatchedBy(func(response *messenger.Response) bool {
+		return strings.HasPrefix(response.Message, "Successfully added 2 new reminders:\ncall dentist (ID: 1) for ") &&
+			strings.Contains(response.Message, "\npay rent (ID: 2) for ")
+	})).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "ext1",
+	}, nil)
+	matrixDB.EXPECT().NewMessage(mock.MatchedBy(func(msg *matrixdb.MatrixMessage) bool {
+		return msg.ID == "ext1"
+	})).Return(nil, nil)
+
+	// Execute
+	action.HandleEvent(tests.TestEvent(
+		tests.MessageWithBody(body, body),
+		tests.MessageWithUserInRoom(matrixdb.MatrixUser{ID: "@alice:example.com", TimeZone: "America/New_York"}),
+	))
+
+	time.Sleep(time.Millisecond * 10) // wait for goroutine to finish
+}
+
+func TestNewEventAction_HandleEventWithMultipleLinesAndParseError(t *testing.T) {
+	// Setup
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.NewEventAction{}
+	action.Configure(
+		slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		nil,
+	)
+
+	body := "1. call dentist tomorrow 9am\n2. tomorrow: 19:45"
+
+	// Expectations
+	msngr.EXPECT().SendResponseAsync(messenger.PlainTextResponse(
+		"Sorry I was not able to understand the remind date and time in line 2, no reminders were added.",
+		"evt1",
+		body,
+		"@user:example.com",
+		"!room123",
+	)).Return(nil)
+
+	// Execute
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody(body, body)))
+}
+
+func TestNewEventAction_HandleEventWithMultipleLinesAndNewEventsError(t *testing.T) {
+	// Setup
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.NewEventAction{}
+	action.Configure(
+		slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		nil,
+	)
+
+	body := "* call dentist tomorrow 9am\n* pay rent at monday 1:11"
+
+	// Expectations
+	db.EXPECT().NewEvents(mock.Anything).Return(errors.New("test"))
+	msngr.EXPECT().SendResponseAsync(messenger.PlainTextResponse(
+		"Sorry, an error appeared.",
+		"evt1",
+		body,
+		"@user:example.com",
+		"!room123",
+	)).Return(nil)
+
+	// Execute
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody(body, body)))
+}
+
+func TestNewEventAction_HandleEventWithMultipleLinesWithoutList(t *testing.T) {
+	// Setup
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.NewEventAction{}
+	action.Configure(
+		slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		nil,
+	)
+
+	body := "- call dentist\nabout the broken tooth tomorrow 9am"
+
+	// Expectations
+	db.EXPECT().NewEvent(mock.MatchedBy(func(event *database.Event) bool {
+		return event.Time.Hour() == 9 && event.Time.After(time.Now())
+	})).Return(nil, errors.New("test"))
+
+	// Execute
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody(body, body)))
+}
+
 type eventMatcher struct {
 	evt *database.Event
 }
@@ -243,7 +485,9 @@ func (matcher *eventMatcher) Matches(x any) bool {
 	if matcher.evt.Duration != evt.Duration ||
 		matcher.evt.Message != evt.Message ||
 		matcher.evt.Active != evt.Active ||
-		matcher.evt.ChannelID != evt.ChannelID {
+		matcher.evt.ChannelID != evt.ChannelID ||
+		matcher.evt.CreatedBy != evt.CreatedBy ||
+		matcher.evt.AssignedTo != evt.AssignedTo {
 		return false
 	}
 
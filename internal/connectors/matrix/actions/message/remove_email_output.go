@@ -0,0 +1,103 @@
+package message
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+var removeEmailOutputActionRegex = regexp.MustCompile("(?i)^(remove|delete)[ ]+(the|)[ ]*(email|e-mail|mail|email recipients|e-mail recipients)[ ]+[0-9]+[ ]*$")
+
+// RemoveEmailOutputAction stops mailing reminders of a channel to recipients.
+type RemoveEmailOutputAction struct {
+	logger    *slog.Logger
+	client    mautrixcl.Client
+	messenger messenger.Messenger
+	matrixDB  matrixdb.Service
+	db        database.Service
+	storer    *msghelper.Storer
+}
+
+// Configure is called on startup and sets all dependencies.
+func (action *RemoveEmailOutputAction) Configure(logger *slog.Logger, client mautrixcl.Client, messenger messenger.Messenger, matrixDB matrixdb.Service, db database.Service, _ *matrix.BridgeServices) {
+	action.logger = logger
+	action.client = client
+	action.matrixDB = matrixDB
+	action.db = db
+	action.messenger = messenger
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
+}
+
+// Name of the action.
+func (action *RemoveEmailOutputAction) Name() string {
+	return "Remove e-mail recipients"
+}
+
+// GetDocu returns the documentation for the action.
+func (action *RemoveEmailOutputAction) GetDocu() (title, explaination string, examples []string) {
+	return "Remove e-mail recipients",
+		"Stop mailing reminders. Use the ID shown by \"list emails\".",
+		[]string{"remove email 2", "delete the e-mail recipients 5"}
+}
+
+// Selector defines a regex on what messages the action should be used.
+func (action *RemoveEmailOutputAction) Selector() *regexp.Regexp {
+	return removeEmailOutputActionRegex
+}
+
+// HandleEvent is where the message event get's send to if it matches the Selector.
+func (action *RemoveEmailOutputAction) HandleEvent(event *matrix.MessageEvent) {
+	dbMsg := mapping.MessageFromEvent(event)
+	dbMsg.Type = matrixdb.MessageTypeEmailOutputRemove
+
+	_, err := action.matrixDB.NewMessage(dbMsg)
+	if err != nil {
+		action.logger.Error("failed to save message to database", "error", err)
+	}
+
+	id, err := getIDFromSentence(event.Content.Body)
+	if err != nil {
+		msg := "Ups, can not find an ID in there."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeEmailOutputRemove, *event)
+
+		return
+	}
+
+	var output *database.Output
+
+	for i := range event.Channel.Outputs {
+		if event.Channel.Outputs[i].OutputType == email.OutputType && event.Channel.Outputs[i].OutputID == uint(id) {
+			output = &event.Channel.Outputs[i]
+			break
+		}
+	}
+
+	if output == nil {
+		msg := "I could not find these e-mail recipients in this channel."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeEmailOutputRemove, *event)
+
+		return
+	}
+
+	err = action.db.RemoveOutputFromChannel(event.Channel.ID, output.ID)
+	if err != nil {
+		action.logger.Error("failed to remove e-mail output", "error", err)
+
+		msg := "Sorry, an error appeared."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeEmailOutputRemove, *event)
+
+		return
+	}
+
+	msg := fmt.Sprintf("Removed the e-mail recipients with ID %d, no more reminders will be mailed to them.", id)
+	go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeEmailOutputRemove, *event)
+}
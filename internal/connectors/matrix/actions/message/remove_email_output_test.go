@@ -0,0 +1,160 @@
+package message_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/message"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestRemoveEmailOutputAction(t *testing.T) {
+	action := &message.RemoveEmailOutputAction{}
+
+	assert.NotEmpty(t, action.Name())
+
+	title, desc, examples := action.GetDocu()
+	assert.NotEmpty(t, title)
+	assert.NotEmpty(t, desc)
+	assert.NotEmpty(t, examples)
+
+	assert.NotNil(t, action.Selector())
+}
+
+func TestRemoveEmailOutputAction_Selector(t *testing.T) {
+	action := &message.RemoveEmailOutputAction{}
+	r := action.Selector()
+
+	_, _, examples := action.GetDocu()
+	for _, example := range examples {
+		assert.True(t, r.MatchString(example))
+	}
+}
+
+func testRemoveEmailOutputAction(t *testing.T) (*message.RemoveEmailOutputAction, *database.MockService, *matrixdb.MockService, *messenger.MockMessenger) {
+	t.Helper()
+
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.RemoveEmailOutputAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		&matrix.BridgeServices{},
+	)
+
+	return action, db, matrixDB, msngr
+}
+
+func expectRemoveEmailOutputResponse(matrixDB *matrixdb.MockService, msngr *messenger.MockMessenger, body, response string) {
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		response,
+		"evt1",
+		body,
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "resp1",
+		UserID:        new("@user:example.com"),
+		Body:          response,
+		BodyFormatted: response,
+		Type:          matrixdb.MessageTypeEmailOutputRemove,
+	}).Return(nil, nil)
+}
+
+func TestRemoveEmailOutputAction_HandleEvent(t *testing.T) {
+	action, db, matrixDB, msngr := testRemoveEmailOutputAction(t)
+	event := tests.TestEvent(
+		tests.MessageWithBody("remove email 3", "remove email 3"),
+		tests.MessageWithOutput(database.Output{
+			Model: gorm.Model{
+				ID: 10,
+			},
+			OutputType: "matrix",
+			OutputID:   3,
+		}),
+		tests.MessageWithOutput(database.Output{
+			Model: gorm.Model{
+				ID: 11,
+			},
+			OutputType: email.OutputType,
+			OutputID:   3,
+		}),
+	)
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeEmailOutputRemove)
+	db.EXPECT().RemoveOutputFromChannel(uint(68272), uint(11)).Return(nil)
+	expectRemoveEmailOutputResponse(matrixDB, msngr, "remove email 3",
+		"Removed the e-mail recipients with ID 3, no more reminders will be mailed to them.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestRemoveEmailOutputAction_HandleEventWithUnknownOutput(t *testing.T) {
+	action, _, matrixDB, msngr := testRemoveEmailOutputAction(t)
+	event := tests.TestEvent(
+		tests.MessageWithBody("remove email 3", "remove email 3"),
+		tests.MessageWithOutput(database.Output{
+			Model: gorm.Model{
+				ID: 10,
+			},
+			OutputType: "matrix",
+			OutputID:   3,
+		}),
+	)
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeEmailOutputRemove)
+	expectRemoveEmailOutputResponse(matrixDB, msngr, "remove email 3",
+		"I could not find these e-mail recipients in this channel.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestRemoveEmailOutputAction_HandleEventWithError(t *testing.T) {
+	action, db, matrixDB, msngr := testRemoveEmailOutputAction(t)
+	event := tests.TestEvent(
+		tests.MessageWithBody("remove email 3", "remove email 3"),
+		tests.MessageWithOutput(database.Output{
+			Model: gorm.Model{
+				ID: 11,
+			},
+			OutputType: email.OutputType,
+			OutputID:   3,
+		}),
+	)
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeEmailOutputRemove)
+	db.EXPECT().RemoveOutputFromChannel(uint(68272), uint(11)).Return(errors.New("test"))
+	expectRemoveEmailOutputResponse(matrixDB, msngr, "remove email 3", "Sorry, an error appeared.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
@@ -0,0 +1,103 @@
+package message
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/ical"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+var removeICalInputActionRegex = regexp.MustCompile("(?i)^(remove|delete|unsubscribe|unsubscribe from)[ ]+(the|)[ ]*(calendar|ical|cal)[ ]+[0-9]+[ ]*$")
+
+// RemoveICalInputAction unsubscribes a channel from an iCal resource.
+type RemoveICalInputAction struct {
+	logger    *slog.Logger
+	client    mautrixcl.Client
+	messenger messenger.Messenger
+	matrixDB  matrixdb.Service
+	db        database.Service
+	storer    *msghelper.Storer
+}
+
+// Configure is called on startup and sets all dependencies.
+func (action *RemoveICalInputAction) Configure(logger *slog.Logger, client mautrixcl.Client, messenger messenger.Messenger, matrixDB matrixdb.Service, db database.Service, _ *matrix.BridgeServices) {
+	action.logger = logger
+	action.client = client
+	action.matrixDB = matrixDB
+	action.db = db
+	action.messenger = messenger
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
+}
+
+// Name of the action.
+func (action *RemoveICalInputAction) Name() string {
+	return "Remove iCal calendar"
+}
+
+// GetDocu returns the documentation for the action.
+func (action *RemoveICalInputAction) GetDocu() (title, explaination string, examples []string) {
+	return "Remove iCal calendar",
+		"Stop importing events from a calendar. Use the ID shown by \"list calendars\".",
+		[]string{"remove calendar 2", "unsubscribe from calendar 5"}
+}
+
+// Selector defines a regex on what messages the action should be used.
+func (action *RemoveICalInputAction) Selector() *regexp.Regexp {
+	return removeICalInputActionRegex
+}
+
+// HandleEvent is where the message event get's send to if it matches the Selector.
+func (action *RemoveICalInputAction) HandleEvent(event *matrix.MessageEvent) {
+	dbMsg := mapping.MessageFromEvent(event)
+	dbMsg.Type = matrixdb.MessageTypeIcalInputRemove
+
+	_, err := action.matrixDB.NewMessage(dbMsg)
+	if err != nil {
+		action.logger.Error("failed to save message to database", "error", err)
+	}
+
+	id, err := getIDFromSentence(event.Content.Body)
+	if err != nil {
+		msg := "Ups, can not find an ID in there."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeIcalInputRemove, *event)
+
+		return
+	}
+
+	var input *database.Input
+
+	for i := range event.Channel.Inputs {
+		if event.Channel.Inputs[i].InputType == ical.InputType && event.Channel.Inputs[i].InputID == uint(id) {
+			input = &event.Channel.Inputs[i]
+			break
+		}
+	}
+
+	if input == nil {
+		msg := "I could not find that calendar in this channel."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeIcalInputRemove, *event)
+
+		return
+	}
+
+	err = action.db.RemoveInputFromChannel(event.Channel.ID, input.ID)
+	if err != nil {
+		action.logger.Error("failed to remove iCal input", "error", err)
+
+		msg := "Sorry, an error appeared."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeIcalInputRemove, *event)
+
+		return
+	}
+
+	msg := fmt.Sprintf("Removed the calendar with ID %d, no new events will be imported from it.", id)
+	go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeIcalInputRemove, *event)
+}
@@ -0,0 +1,160 @@
+package message_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/ical"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/message"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestRemoveICalInputAction(t *testing.T) {
+	action := &message.RemoveICalInputAction{}
+
+	assert.NotEmpty(t, action.Name())
+
+	title, desc, examples := action.GetDocu()
+	assert.NotEmpty(t, title)
+	assert.NotEmpty(t, desc)
+	assert.NotEmpty(t, examples)
+
+	assert.NotNil(t, action.Selector())
+}
+
+func TestRemoveICalInputAction_Selector(t *testing.T) {
+	action := &message.RemoveICalInputAction{}
+	r := action.Selector()
+
+	_, _, examples := action.GetDocu()
+	for _, example := range examples {
+		assert.True(t, r.MatchString(example))
+	}
+}
+
+func testRemoveICalInputAction(t *testing.T) (*message.RemoveICalInputAction, *database.MockService, *matrixdb.MockService, *messenger.MockMessenger) {
+	t.Helper()
+
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.RemoveICalInputAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		&matrix.BridgeServices{},
+	)
+
+	return action, db, matrixDB, msngr
+}
+
+func expectRemoveICalInputResponse(matrixDB *matrixdb.MockService, msngr *messenger.MockMessenger, body, response string) {
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		response,
+		"evt1",
+		body,
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "resp1",
+		UserID:        new("@user:example.com"),
+		Body:          response,
+		BodyFormatted: response,
+		Type:          matrixdb.MessageTypeIcalInputRemove,
+	}).Return(nil, nil)
+}
+
+func TestRemoveICalInputAction_HandleEvent(t *testing.T) {
+	action, db, matrixDB, msngr := testRemoveICalInputAction(t)
+	event := tests.TestEvent(
+		tests.MessageWithBody("remove calendar 3", "remove calendar 3"),
+		tests.MessageWithInput(database.Input{
+			Model: gorm.Model{
+				ID: 10,
+			},
+			InputType: "matrix",
+			InputID:   3,
+		}),
+		tests.MessageWithInput(database.Input{
+			Model: gorm.Model{
+				ID: 11,
+			},
+			InputType: ical.InputType,
+			InputID:   3,
+		}),
+	)
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeIcalInputRemove)
+	db.EXPECT().RemoveInputFromChannel(uint(68272), uint(11)).Return(nil)
+	expectRemoveICalInputResponse(matrixDB, msngr, "remove calendar 3",
+		"Removed the calendar with ID 3, no new events will be imported from it.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestRemoveICalInputAction_HandleEventWithUnknownInput(t *testing.T) {
+	action, _, matrixDB, msngr := testRemoveICalInputAction(t)
+	event := tests.TestEvent(
+		tests.MessageWithBody("remove calendar 3", "remove calendar 3"),
+		tests.MessageWithInput(database.Input{
+			Model: gorm.Model{
+				ID: 10,
+			},
+			InputType: "matrix",
+			InputID:   3,
+		}),
+	)
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeIcalInputRemove)
+	expectRemoveICalInputResponse(matrixDB, msngr, "remove calendar 3",
+		"I could not find that calendar in this channel.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestRemoveICalInputAction_HandleEventWithError(t *testing.T) {
+	action, db, matrixDB, msngr := testRemoveICalInputAction(t)
+	event := tests.TestEvent(
+		tests.MessageWithBody("remove calendar 3", "remove calendar 3"),
+		tests.MessageWithInput(database.Input{
+			Model: gorm.Model{
+				ID: 11,
+			},
+			InputType: ical.InputType,
+			InputID:   3,
+		}),
+	)
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeIcalInputRemove)
+	db.EXPECT().RemoveInputFromChannel(uint(68272), uint(11)).Return(errors.New("test"))
+	expectRemoveICalInputResponse(matrixDB, msngr, "remove calendar 3", "Sorry, an error appeared.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
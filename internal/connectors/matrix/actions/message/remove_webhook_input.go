@@ -0,0 +1,103 @@
+package message
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+var removeWebhookInputActionRegex = regexp.MustCompile("(?i)^(remove|delete)[ ]+(the|)[ ]*(incoming|inbound)[ ]+(webhook|web hook)[ ]+[0-9]+[ ]*$")
+
+// RemoveWebhookInputAction removes an incoming webhook from a channel.
+type RemoveWebhookInputAction struct {
+	logger    *slog.Logger
+	client    mautrixcl.Client
+	messenger messenger.Messenger
+	matrixDB  matrixdb.Service
+	db        database.Service
+	storer    *msghelper.Storer
+}
+
+// Configure is called on startup and sets all dependencies.
+func (action *RemoveWebhookInputAction) Configure(logger *slog.Logger, client mautrixcl.Client, messenger messenger.Messenger, matrixDB matrixdb.Service, db database.Service, _ *matrix.BridgeServices) {
+	action.logger = logger
+	action.client = client
+	action.matrixDB = matrixDB
+	action.db = db
+	action.messenger = messenger
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
+}
+
+// Name of the action.
+func (action *RemoveWebhookInputAction) Name() string {
+	return "Remove incoming webhook"
+}
+
+// GetDocu returns the documentation for the action.
+func (action *RemoveWebhookInputAction) GetDocu() (title, explaination string, examples []string) {
+	return "Remove incoming webhook",
+		"Revoke the URL of an incoming webhook, events created through it are kept.",
+		[]string{"remove incoming webhook 2", "delete the inbound webhook 5"}
+}
+
+// Selector defines a regex on what messages the action should be used.
+func (action *RemoveWebhookInputAction) Selector() *regexp.Regexp {
+	return removeWebhookInputActionRegex
+}
+
+// HandleEvent is where the message event get's send to if it matches the Selector.
+func (action *RemoveWebhookInputAction) HandleEvent(event *matrix.MessageEvent) {
+	dbMsg := mapping.MessageFromEvent(event)
+	dbMsg.Type = matrixdb.MessageTypeWebhookInputRemove
+
+	_, err := action.matrixDB.NewMessage(dbMsg)
+	if err != nil {
+		action.logger.Error("failed to save message to database", "error", err)
+	}
+
+	id, err := getIDFromSentence(event.Content.Body)
+	if err != nil {
+		msg := "Ups, can not find an ID in there."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeWebhookInputRemove, *event)
+
+		return
+	}
+
+	var input *database.Input
+
+	for i := range event.Channel.Inputs {
+		if event.Channel.Inputs[i].InputType == webhook.InputType && event.Channel.Inputs[i].InputID == uint(id) {
+			input = &event.Channel.Inputs[i]
+			break
+		}
+	}
+
+	if input == nil {
+		msg := "I could not find that incoming webhook in this channel."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeWebhookInputRemove, *event)
+
+		return
+	}
+
+	err = action.db.RemoveInputFromChannel(event.Channel.ID, input.ID)
+	if err != nil {
+		action.logger.Error("failed to remove webhook input", "error", err)
+
+		msg := "Sorry, an error appeared."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeWebhookInputRemove, *event)
+
+		return
+	}
+
+	msg := fmt.Sprintf("Removed the incoming webhook with ID %d, its URL does not work anymore.", id)
+	go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeWebhookInputRemove, *event)
+}
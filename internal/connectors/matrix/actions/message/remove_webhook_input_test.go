@@ -0,0 +1,160 @@
+package message_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/message"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestRemoveWebhookInputAction(t *testing.T) {
+	action := &message.RemoveWebhookInputAction{}
+
+	assert.NotEmpty(t, action.Name())
+
+	title, desc, examples := action.GetDocu()
+	assert.NotEmpty(t, title)
+	assert.NotEmpty(t, desc)
+	assert.NotEmpty(t, examples)
+
+	assert.NotNil(t, action.Selector())
+}
+
+func TestRemoveWebhookInputAction_Selector(t *testing.T) {
+	action := &message.RemoveWebhookInputAction{}
+	r := action.Selector()
+
+	_, _, examples := action.GetDocu()
+	for _, example := range examples {
+		assert.True(t, r.MatchString(example))
+	}
+}
+
+func testRemoveWebhookInputAction(t *testing.T) (*message.RemoveWebhookInputAction, *database.MockService, *matrixdb.MockService, *messenger.MockMessenger) {
+	t.Helper()
+
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.RemoveWebhookInputAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		&matrix.BridgeServices{},
+	)
+
+	return action, db, matrixDB, msngr
+}
+
+func expectRemoveWebhookInputResponse(matrixDB *matrixdb.MockService, msngr *messenger.MockMessenger, body, response string) {
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		response,
+		"evt1",
+		body,
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "resp1",
+		UserID:        new("@user:example.com"),
+		Body:          response,
+		BodyFormatted: response,
+		Type:          matrixdb.MessageTypeWebhookInputRemove,
+	}).Return(nil, nil)
+}
+
+func TestRemoveWebhookInputAction_HandleEvent(t *testing.T) {
+	action, db, matrixDB, msngr := testRemoveWebhookInputAction(t)
+	event := tests.TestEvent(
+		tests.MessageWithBody("remove incoming webhook 3", "remove incoming webhook 3"),
+		tests.MessageWithInput(database.Input{
+			Model: gorm.Model{
+				ID: 10,
+			},
+			InputType: "matrix",
+			InputID:   3,
+		}),
+		tests.MessageWithInput(database.Input{
+			Model: gorm.Model{
+				ID: 11,
+			},
+			InputType: webhook.InputType,
+			InputID:   3,
+		}),
+	)
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeWebhookInputRemove)
+	db.EXPECT().RemoveInputFromChannel(uint(68272), uint(11)).Return(nil)
+	expectRemoveWebhookInputResponse(matrixDB, msngr, "remove incoming webhook 3",
+		"Removed the incoming webhook with ID 3, its URL does not work anymore.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestRemoveWebhookInputAction_HandleEventWithUnknownInput(t *testing.T) {
+	action, _, matrixDB, msngr := testRemoveWebhookInputAction(t)
+	event := tests.TestEvent(
+		tests.MessageWithBody("remove incoming webhook 3", "remove incoming webhook 3"),
+		tests.MessageWithInput(database.Input{
+			Model: gorm.Model{
+				ID: 10,
+			},
+			InputType: "matrix",
+			InputID:   3,
+		}),
+	)
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeWebhookInputRemove)
+	expectRemoveWebhookInputResponse(matrixDB, msngr, "remove incoming webhook 3",
+		"I could not find that incoming webhook in this channel.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestRemoveWebhookInputAction_HandleEventWithError(t *testing.T) {
+	action, db, matrixDB, msngr := testRemoveWebhookInputAction(t)
+	event := tests.TestEvent(
+		tests.MessageWithBody("remove incoming webhook 3", "remove incoming webhook 3"),
+		tests.MessageWithInput(database.Input{
+			Model: gorm.Model{
+				ID: 11,
+			},
+			InputType: webhook.InputType,
+			InputID:   3,
+		}),
+	)
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeWebhookInputRemove)
+	db.EXPECT().RemoveInputFromChannel(uint(68272), uint(11)).Return(errors.New("test"))
+	expectRemoveWebhookInputResponse(matrixDB, msngr, "remove incoming webhook 3", "Sorry, an error appeared.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
@@ -0,0 +1,103 @@
+package message
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+var removeWebhookOutputActionRegex = regexp.MustCompile("(?i)^(remove|delete)[ ]+(the|)[ ]*(webhook|web hook)[ ]+[0-9]+[ ]*$")
+
+// RemoveWebhookOutputAction removes a webhook from a channel.
+type RemoveWebhookOutputAction struct {
+	logger    *slog.Logger
+	client    mautrixcl.Client
+	messenger messenger.Messenger
+	matrixDB  matrixdb.Service
+	db        database.Service
+	storer    *msghelper.Storer
+}
+
+// Configure is called on startup and sets all dependencies.
+func (action *RemoveWebhookOutputAction) Configure(logger *slog.Logger, client mautrixcl.Client, messenger messenger.Messenger, matrixDB matrixdb.Service, db database.Service, _ *matrix.BridgeServices) {
+	action.logger = logger
+	action.client = client
+	action.matrixDB = matrixDB
+	action.db = db
+	action.messenger = messenger
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
+}
+
+// Name of the action.
+func (action *RemoveWebhookOutputAction) Name() string {
+	return "Remove webhook"
+}
+
+// GetDocu returns the documentation for the action.
+func (action *RemoveWebhookOutputAction) GetDocu() (title, explaination string, examples []string) {
+	return "Remove webhook",
+		"Stop posting reminders to a webhook. Use the ID shown by \"list webhooks\".",
+		[]string{"remove webhook 2", "delete the webhook 5"}
+}
+
+// Selector defines a regex on what messages the action should be used.
+func (action *RemoveWebhookOutputAction) Selector() *regexp.Regexp {
+	return removeWebhookOutputActionRegex
+}
+
+// HandleEvent is where the message event get's send to if it matches the Selector.
+func (action *RemoveWebhookOutputAction) HandleEvent(event *matrix.MessageEvent) {
+	dbMsg := mapping.MessageFromEvent(event)
+	dbMsg.Type = matrixdb.MessageTypeWebhookOutputRemove
+
+	_, err := action.matrixDB.NewMessage(dbMsg)
+	if err != nil {
+		action.logger.Error("failed to save message to database", "error", err)
+	}
+
+	id, err := getIDFromSentence(event.Content.Body)
+	if err != nil {
+		msg := "Ups, can not find an ID in there."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeWebhookOutputRemove, *event)
+
+		return
+	}
+
+	var output *database.Output
+
+	for i := range event.Channel.Outputs {
+		if event.Channel.Outputs[i].OutputType == webhook.OutputType && event.Channel.Outputs[i].OutputID == uint(id) {
+			output = &event.Channel.Outputs[i]
+			break
+		}
+	}
+
+	if output == nil {
+		msg := "I could not find that webhook in this channel."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeWebhookOutputRemove, *event)
+
+		return
+	}
+
+	err = action.db.RemoveOutputFromChannel(event.Channel.ID, output.ID)
+	if err != nil {
+		action.logger.Error("failed to remove webhook output", "error", err)
+
+		msg := "Sorry, an error appeared."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeWebhookOutputRemove, *event)
+
+		return
+	}
+
+	msg := fmt.Sprintf("Removed the webhook with ID %d, no more reminders will be posted to it.", id)
+	go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeWebhookOutputRemove, *event)
+}
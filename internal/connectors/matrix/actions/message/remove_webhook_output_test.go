@@ -0,0 +1,160 @@
+package message_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/message"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestRemoveWebhookOutputAction(t *testing.T) {
+	action := &message.RemoveWebhookOutputAction{}
+
+	assert.NotEmpty(t, action.Name())
+
+	title, desc, examples := action.GetDocu()
+	assert.NotEmpty(t, title)
+	assert.NotEmpty(t, desc)
+	assert.NotEmpty(t, examples)
+
+	assert.NotNil(t, action.Selector())
+}
+
+func TestRemoveWebhookOutputAction_Selector(t *testing.T) {
+	action := &message.RemoveWebhookOutputAction{}
+	r := action.Selector()
+
+	_, _, examples := action.GetDocu()
+	for _, example := range examples {
+		assert.True(t, r.MatchString(example))
+	}
+}
+
+func testRemoveWebhookOutputAction(t *testing.T) (*message.RemoveWebhookOutputAction, *database.MockService, *matrixdb.MockService, *messenger.MockMessenger) {
+	t.Helper()
+
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.RemoveWebhookOutputAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		&matrix.BridgeServices{},
+	)
+
+	return action, db, matrixDB, msngr
+}
+
+func expectRemoveWebhookOutputResponse(matrixDB *matrixdb.MockService, msngr *messenger.MockMessenger, body, response string) {
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		response,
+		"evt1",
+		body,
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "resp1",
+		UserID:        new("@user:example.com"),
+		Body:          response,
+		BodyFormatted: response,
+		Type:          matrixdb.MessageTypeWebhookOutputRemove,
+	}).Return(nil, nil)
+}
+
+func TestRemoveWebhookOutputAction_HandleEvent(t *testing.T) {
+	action, db, matrixDB, msngr := testRemoveWebhookOutputAction(t)
+	event := tests.TestEvent(
+		tests.MessageWithBody("remove webhook 3", "remove webhook 3"),
+		tests.MessageWithOutput(database.Output{
+			Model: gorm.Model{
+				ID: 10,
+			},
+			OutputType: "matrix",
+			OutputID:   3,
+		}),
+		tests.MessageWithOutput(database.Output{
+			Model: gorm.Model{
+				ID: 11,
+			},
+			OutputType: webhook.OutputType,
+			OutputID:   3,
+		}),
+	)
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeWebhookOutputRemove)
+	db.EXPECT().RemoveOutputFromChannel(uint(68272), uint(11)).Return(nil)
+	expectRemoveWebhookOutputResponse(matrixDB, msngr, "remove webhook 3",
+		"Removed the webhook with ID 3, no more reminders will be posted to it.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestRemoveWebhookOutputAction_HandleEventWithUnknownOutput(t *testing.T) {
+	action, _, matrixDB, msngr := testRemoveWebhookOutputAction(t)
+	event := tests.TestEvent(
+		tests.MessageWithBody("remove webhook 3", "remove webhook 3"),
+		tests.MessageWithOutput(database.Output{
+			Model: gorm.Model{
+				ID: 10,
+			},
+			OutputType: "matrix",
+			OutputID:   3,
+		}),
+	)
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeWebhookOutputRemove)
+	expectRemoveWebhookOutputResponse(matrixDB, msngr, "remove webhook 3",
+		"I could not find that webhook in this channel.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestRemoveWebhookOutputAction_HandleEventWithError(t *testing.T) {
+	action, db, matrixDB, msngr := testRemoveWebhookOutputAction(t)
+	event := tests.TestEvent(
+		tests.MessageWithBody("remove webhook 3", "remove webhook 3"),
+		tests.MessageWithOutput(database.Output{
+			Model: gorm.Model{
+				ID: 11,
+			},
+			OutputType: webhook.OutputType,
+			OutputID:   3,
+		}),
+	)
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeWebhookOutputRemove)
+	db.EXPECT().RemoveOutputFromChannel(uint(68272), uint(11)).Return(errors.New("test"))
+	expectRemoveWebhookOutputResponse(matrixDB, msngr, "remove webhook 3", "Sorry, an error appeared.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
@@ -16,7 +16,7 @@ import (
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 )
 
-var setDailyReminderRegex = regexp.MustCompile("(?i)^(set|update|change|)[ ]*(the|a|my|)[ ]*(daily reminder|daily info|daily message).*")
+var setDailyReminderRegex = regexp.MustCompile("(?i)^(set|update|change|setze|ändere|)[ ]*(the|a|my|die|meine|)[ ]*(daily reminder|daily info|daily message|tägliche erinnerung|tagesübersicht).*")
 
 // SetDailyReminderAction sets the time for the daily reminder.
 type SetDailyReminderAction struct {
@@ -47,7 +47,7 @@ func (action *SetDailyReminderAction) Name() string {
 func (action *SetDailyReminderAction) GetDocu() (title, explaination string, examples []string) {
 	return "Set Daily Reminder",
 		"Set the time to send a reminder of todays events",
-		[]string{"daily reminder at 9am", "daily reminder at 13:00", "set the daily info at 4:00"}
+		[]string{"daily reminder at 9am", "daily reminder at 13:00", "set the daily info at 4:00", "tägliche Erinnerung um 8 Uhr"}
 }
 
 // Selector defines a regex on what messages the action should be used.
@@ -65,7 +65,7 @@ func (action *SetDailyReminderAction) HandleEvent(event *matrix.MessageEvent) {
 		action.logger.Error("failed to store message to database", "error", err)
 	}
 
-	timeRemind, err := format.ParseTime(event.Channel, event.Content.Body, event.Room.TimeZone, true)
+	timeRemind, err := format.ParseTime(event.Channel, event.Content.Body, event.Room.TimeZone, event.Room.Language, true)
 	if err != nil {
 		action.logger.Error("failed to parse time", "error", err)
 
@@ -82,6 +82,8 @@ func (action *SetDailyReminderAction) HandleEvent(event *matrix.MessageEvent) {
 
 	minutesSinceMidnight := uint(timeRemind.In(loc).Hour()*60 + timeRemind.In(loc).Minute())
 	event.Channel.DailyReminder = &minutesSinceMidnight
+	// The daily reminder time is evaluated in the timezone of the channel.
+	event.Channel.TimeZone = event.Room.TimeZone
 
 	_, err = action.db.UpdateChannel(event.Channel)
 	if err != nil {
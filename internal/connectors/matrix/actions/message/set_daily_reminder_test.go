@@ -75,6 +75,7 @@ func TestSetDailyReminderAction_HandleEvent(t *testing.T) {
 
 	channel := tests.TestEvent().Channel
 	channel.DailyReminder = new(uint(600))
+	channel.TimeZone = "UTC"
 	db.EXPECT().UpdateChannel(channel).Return(nil, nil)
 
 	msngr.EXPECT().SendResponse(&messenger.Response{
@@ -137,6 +138,7 @@ func TestSetDailyReminderAction_HandleEvent_WithTimezone(t *testing.T) {
 
 	channel := tests.TestEvent().Channel
 	channel.DailyReminder = new(uint(600))
+	channel.TimeZone = "Etc/GMT-2"
 	db.EXPECT().UpdateChannel(channel).Return(nil, nil)
 
 	msngr.EXPECT().SendResponse(&messenger.Response{
@@ -202,6 +204,7 @@ func TestSetDailyReminderAction_HandleEventWithUpdateChannelError(t *testing.T)
 
 	channel := tests.TestEvent().Channel
 	channel.DailyReminder = new(uint(600))
+	channel.TimeZone = "UTC"
 	db.EXPECT().UpdateChannel(channel).Return(nil, errors.New("test"))
 
 	msngr.EXPECT().SendMessage(&messenger.Message{
@@ -260,6 +263,7 @@ func TestSetDailyReminderAction_HandleEventWithNewMessageError(t *testing.T) {
 
 	channel := tests.TestEvent().Channel
 	channel.DailyReminder = new(uint(600))
+	channel.TimeZone = "UTC"
 	db.EXPECT().UpdateChannel(channel).Return(nil, nil)
 
 	msngr.EXPECT().SendResponse(&messenger.Response{
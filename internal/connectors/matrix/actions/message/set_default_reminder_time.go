@@ -65,7 +65,7 @@ func (action *SetDefaultReminderTimeAction) HandleEvent(event *matrix.MessageEve
 		action.logger.Error("failed to store message to database", "error", err)
 	}
 
-	timeRemind, err := format.ParseTime(event.Channel, event.Content.Body, event.Room.TimeZone, true)
+	timeRemind, err := format.ParseTime(event.Channel, event.Content.Body, event.Room.TimeZone, event.Room.Language, true)
 	if err != nil {
 		action.logger.Error("failed to parse time", "error", err)
 
@@ -0,0 +1,218 @@
+package message
+
+import (
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+// maxDigestLookaheadDays limits the range of upcoming events so digests stay readable.
+const maxDigestLookaheadDays = 90
+
+var setDigestActionRegex = regexp.MustCompile("(?i)^(digest|übersicht)[ ]+.+$")
+var digestDailyRegex = regexp.MustCompile(`(?i)(every day|daily|täglich|jeden tag)`)
+var digestWeeklyRegex = regexp.MustCompile(`(?i)(weekly|wöchentlich)`)
+var digestWorkdaysRegex = regexp.MustCompile(`(?i)(workdays|weekdays|werktags|werktagen)`)
+var digestWeekdayRegex = regexp.MustCompile(`(?i)\b(monday|tuesday|wednesday|thursday|friday|saturday|sunday|montag|dienstag|mittwoch|donnerstag|freitag|samstag|sonntag)s?\b`)
+var digestLookaheadRegex = regexp.MustCompile(`(?i)\b(?:for|für) ([0-9]+) (days?|weeks?|tagen?|wochen?)\b`)
+var digestOverdueRegex = regexp.MustCompile(`(?i)\b(with|mit|without|ohne) (overdue|überfällige[n]?)`)
+var digestSkipEmptyRegex = regexp.MustCompile(`(?i)(skip empty|überspringe leere|leere überspringen)`)
+var digestAlwaysRegex = regexp.MustCompile(`(?i)\b(always|immer)\b`)
+
+var digestWeekdays = map[string]time.Weekday{
+	"monday":     time.Monday,
+	"tuesday":    time.Tuesday,
+	"wednesday":  time.Wednesday,
+	"thursday":   time.Thursday,
+	"friday":     time.Friday,
+	"saturday":   time.Saturday,
+	"sunday":     time.Sunday,
+	"montag":     time.Monday,
+	"dienstag":   time.Tuesday,
+	"mittwoch":   time.Wednesday,
+	"donnerstag": time.Thursday,
+	"freitag":    time.Friday,
+	"samstag":    time.Saturday,
+	"sonntag":    time.Sunday,
+}
+
+// SetDigestAction sets on which days the daily reminder is sent and which events it contains.
+type SetDigestAction struct {
+	logger    *slog.Logger
+	client    mautrixcl.Client
+	messenger messenger.Messenger
+	matrixDB  matrixdb.Service
+	db        database.Service
+	storer    *msghelper.Storer
+}
+
+// Configure is called on startup and sets all dependencies.
+func (action *SetDigestAction) Configure(logger *slog.Logger, client mautrixcl.Client, messenger messenger.Messenger, matrixDB matrixdb.Service, db database.Service, _ *matrix.BridgeServices) {
+	action.logger = logger
+	action.client = client
+	action.matrixDB = matrixDB
+	action.db = db
+	action.messenger = messenger
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
+}
+
+// Name of the action
+func (action *SetDigestAction) Name() string {
+	return "Set Digest"
+}
+
+// GetDocu returns the documentation for the action.
+func (action *SetDigestAction) GetDocu() (title, explaination string, examples []string) {
+	return "Set Digest",
+		"Choose the days the daily reminder is sent on, how far it looks ahead, whether it lists overdue events and whether it is skipped if there are no events. \"weekly\" sends a summary of the week on monday.",
+		[]string{"digest weekly", "digest on workdays skip empty", "digest on monday and thursday for 4 days", "digest every day with overdue", "digest always", "Übersicht werktags ohne überfällige"}
+}
+
+// Selector defines a regex on what messages the action should be used.
+func (action *SetDigestAction) Selector() *regexp.Regexp {
+	return setDigestActionRegex
+}
+
+// HandleEvent is where the message event get's send to if it matches the Selector.
+func (action *SetDigestAction) HandleEvent(event *matrix.MessageEvent) {
+	dbMsg := mapping.MessageFromEvent(event)
+	dbMsg.Type = matrixdb.MessageTypeSetDigest
+
+	_, err := action.matrixDB.NewMessage(dbMsg)
+	if err != nil {
+		action.logger.Error("failed to store message to database", "error", err)
+	}
+
+	if !applyDigest(&event.Channel.Digest, event.Content.Body) {
+		msg := i18n.Translate(event.Room.Language, "Sorry, I did not understand the digest settings.")
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeSetDigest, *event)
+
+		return
+	}
+
+	// Digest days are evaluated in the timezone of the channel.
+	event.Channel.TimeZone = event.Room.TimeZone
+
+	_, err = action.db.UpdateChannel(event.Channel)
+	if err != nil {
+		action.logger.Error("failed to update channel", "error", err)
+
+		msg := i18n.Translate(event.Room.Language, "Whups, could not save that change. Sorry, try again later.")
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeSetDigest, *event)
+
+		return
+	}
+
+	go action.storer.SendAndStoreResponse(digestSummary(event.Channel, event.Room.Language), matrixdb.MessageTypeSetDigest, *event)
+}
+
+// applyDigest sets all digest settings found in the message. Returns false if the message contains none or an
+// invalid one.
+func applyDigest(digest *database.DigestConfig, body string) bool {
+	found := false
+
+	switch {
+	case digestWeeklyRegex.MatchString(body):
+		digest.Weekdays = database.WeekdayMask(time.Monday)
+		digest.Lookahead = 7 * 24 * time.Hour
+		found = true
+	case digestWorkdaysRegex.MatchString(body):
+		digest.Weekdays = database.WeekdaysWorkdays
+		found = true
+	case digestDailyRegex.MatchString(body):
+		digest.Weekdays = database.WeekdaysAll
+		found = true
+	}
+
+	if matches := digestWeekdayRegex.FindAllStringSubmatch(body, -1); len(matches) > 0 {
+		weekdays := []time.Weekday{}
+		for _, match := range matches {
+			weekdays = append(weekdays, digestWeekdays[strings.ToLower(match[1])])
+		}
+
+		digest.Weekdays = database.WeekdayMask(weekdays...)
+		found = true
+	}
+
+	if matches := digestLookaheadRegex.FindStringSubmatch(body); matches != nil {
+		days, err := strconv.Atoi(matches[1])
+		if err != nil {
+			return false
+		}
+
+		if unit := strings.ToLower(matches[2]); strings.HasPrefix(unit, "week") || strings.HasPrefix(unit, "woche") {
+			days *= 7
+		}
+
+		if days < 1 || days > maxDigestLookaheadDays {
+			return false
+		}
+
+		digest.Lookahead = time.Duration(days) * 24 * time.Hour
+		found = true
+	}
+
+	if matches := digestOverdueRegex.FindStringSubmatch(body); matches != nil {
+		with := strings.ToLower(matches[1])
+		digest.Overdue = with == "with" || with == "mit"
+		found = true
+	}
+
+	switch {
+	case digestSkipEmptyRegex.MatchString(body):
+		digest.SkipEmpty = true
+		found = true
+	case digestAlwaysRegex.MatchString(body):
+		digest.SkipEmpty = false
+		found = true
+	}
+
+	return found
+}
+
+// digestSummary describes the digest settings of the channel.
+func digestSummary(channel *database.Channel, language string) string {
+	days := i18n.Translate(language, "every day")
+	if channel.Digest.Weekdays != database.WeekdaysAll {
+		names := []string{}
+		for _, weekday := range channel.Digest.WeekdayList() {
+			names = append(names, i18n.Translate(language, weekday.String()))
+		}
+
+		days = i18n.Translatef(language, "on %s", strings.Join(names, ", "))
+	}
+
+	var msg string
+
+	lookahead := channel.Digest.LookaheadOrDefault()
+	if lookahead <= database.DefaultDigestLookahead {
+		msg = i18n.Translatef(language, "I will send the daily reminder %s with the events of the next 24 hours.", days)
+	} else {
+		msg = i18n.Translatef(language, "I will send the daily reminder %s with the events of the next %d days.", days, int(lookahead/(24*time.Hour)))
+	}
+
+	if channel.Digest.Overdue {
+		msg += " " + i18n.Translate(language, "Overdue events are included.")
+	}
+
+	if channel.Digest.SkipEmpty {
+		msg += " " + i18n.Translate(language, "I will not send it if there are no events.")
+	}
+
+	if channel.DailyReminder == nil {
+		msg += " " + i18n.Translate(language, "Set the time with \"daily reminder at 9am\" to turn it on.")
+	}
+
+	return msg
+}
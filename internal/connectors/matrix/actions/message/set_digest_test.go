@@ -0,0 +1,219 @@
+package message_test
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/message"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSetDigestAction(t *testing.T) {
+	action := &message.SetDigestAction{}
+
+	assert.NotEmpty(t, action.Name())
+
+	title, desc, examples := action.GetDocu()
+	assert.NotEmpty(t, title)
+	assert.NotEmpty(t, desc)
+	assert.NotEmpty(t, examples)
+
+	assert.NotNil(t, action.Selector())
+}
+
+func TestSetDigestAction_Selector(t *testing.T) {
+	action := &message.SetDigestAction{}
+	r := action.Selector()
+
+	_, _, examples := action.GetDocu()
+	for _, example := range examples {
+		assert.True(t, r.MatchString(example), example)
+	}
+
+	assert.False(t, r.MatchString("digestion tomorrow at 5pm"))
+}
+
+func testSetDigestAction(t *testing.T) (*message.SetDigestAction, *database.MockService, *matrixdb.MockService, *messenger.MockMessenger) {
+	t.Helper()
+
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.SetDigestAction{}
+	action.Configure(
+		slog.Default(),
+		mautrixcl.NewMockClient(t),
+		msngr,
+		matrixDB,
+		db,
+		&matrix.BridgeServices{},
+	)
+
+	return action, db, matrixDB, msngr
+}
+
+func TestSetDigestAction_HandleEventWeekly(t *testing.T) {
+	action, db, matrixDB, msngr := testSetDigestAction(t)
+
+	body := "digest weekly"
+	expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetDigest, body,
+		"I will send the daily reminder on Monday with the events of the next 7 days. Set the time with \"daily reminder at 9am\" to turn it on.")
+
+	channel := tests.TestEvent().Channel
+	channel.Digest = database.DigestConfig{
+		Weekdays:  database.WeekdayMask(time.Monday),
+		Lookahead: 7 * 24 * time.Hour,
+	}
+	channel.TimeZone = "UTC"
+	db.EXPECT().UpdateChannel(channel).Return(nil, nil)
+
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody(body, body)))
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetDigestAction_HandleEventWithDaysAndOverdue(t *testing.T) {
+	action, db, matrixDB, msngr := testSetDigestAction(t)
+
+	body := "digest on friday and monday for 2 weeks with overdue"
+	expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetDigest, body,
+		"I will send the daily reminder on Monday, Friday with the events of the next 14 days. Overdue events are included.")
+
+	event := tests.TestEvent(tests.MessageWithBody(body, body))
+	event.Channel.DailyReminder = new(uint(480))
+
+	channel := tests.TestEvent().Channel
+	channel.DailyReminder = new(uint(480))
+	channel.Digest = database.DigestConfig{
+		Weekdays:  database.WeekdayMask(time.Monday, time.Friday),
+		Lookahead: 14 * 24 * time.Hour,
+		Overdue:   true,
+	}
+	channel.TimeZone = "UTC"
+	db.EXPECT().UpdateChannel(channel).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetDigestAction_HandleEventInGerman(t *testing.T) {
+	action, db, matrixDB, msngr := testSetDigestAction(t)
+
+	body := "Übersicht werktags ohne überfällige"
+	expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetDigest, body,
+		"Ich sende die Übersicht am Montag, Dienstag, Mittwoch, Donnerstag, Freitag mit den Terminen der nächsten 24 Stunden.")
+
+	event := tests.TestEvent(tests.MessageWithBody(body, body))
+	event.Room.Language = "de"
+	event.Channel.DailyReminder = new(uint(480))
+	event.Channel.Digest.Overdue = true
+
+	channel := tests.TestEvent().Channel
+	channel.DailyReminder = new(uint(480))
+	channel.Digest.Weekdays = database.WeekdaysWorkdays
+	channel.TimeZone = "UTC"
+	db.EXPECT().UpdateChannel(channel).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetDigestAction_HandleEventSkipEmpty(t *testing.T) {
+	action, db, matrixDB, msngr := testSetDigestAction(t)
+
+	body := "digest on workdays skip empty"
+	expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetDigest, body,
+		"I will send the daily reminder on Monday, Tuesday, Wednesday, Thursday, Friday with the events of the next 24 hours. I will not send it if there are no events.")
+
+	event := tests.TestEvent(tests.MessageWithBody(body, body))
+	event.Channel.DailyReminder = new(uint(480))
+
+	channel := tests.TestEvent().Channel
+	channel.DailyReminder = new(uint(480))
+	channel.Digest = database.DigestConfig{
+		Weekdays:  database.WeekdaysWorkdays,
+		SkipEmpty: true,
+	}
+	channel.TimeZone = "UTC"
+	db.EXPECT().UpdateChannel(channel).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetDigestAction_HandleEventAlways(t *testing.T) {
+	action, db, matrixDB, msngr := testSetDigestAction(t)
+
+	body := "Übersicht immer"
+	expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetDigest, body,
+		"Ich sende die Übersicht jeden Tag mit den Terminen der nächsten 24 Stunden.")
+
+	event := tests.TestEvent(tests.MessageWithBody(body, body))
+	event.Room.Language = "de"
+	event.Channel.DailyReminder = new(uint(480))
+	event.Channel.Digest.SkipEmpty = true
+
+	channel := tests.TestEvent().Channel
+	channel.DailyReminder = new(uint(480))
+	channel.TimeZone = "UTC"
+	db.EXPECT().UpdateChannel(channel).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetDigestAction_HandleEventWithTooLongLookahead(t *testing.T) {
+	action, _, matrixDB, msngr := testSetDigestAction(t)
+
+	body := "digest for 20 weeks"
+	expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetDigest, body, "Sorry, I did not understand the digest settings.")
+
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody(body, body)))
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetDigestAction_HandleEventWithUnknownSetting(t *testing.T) {
+	action, _, matrixDB, msngr := testSetDigestAction(t)
+
+	body := "digest sometimes"
+	expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetDigest, body, "Sorry, I did not understand the digest settings.")
+
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody(body, body)))
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetDigestAction_HandleEventWithUpdateChannelError(t *testing.T) {
+	action, db, matrixDB, msngr := testSetDigestAction(t)
+
+	body := "digest every day"
+	expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetDigest, body, "Whups, could not save that change. Sorry, try again later.")
+
+	db.EXPECT().UpdateChannel(mock.Anything).Return(nil, assert.AnError)
+
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody(body, body)))
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
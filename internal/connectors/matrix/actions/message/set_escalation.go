@@ -0,0 +1,160 @@
+package message
+
+import (
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"maunium.net/go/mautrix/id"
+)
+
+var setEscalationActionRegex = regexp.MustCompile("(?i)^((escalate|eskaliere) (after|nach) [0-9]+.*|(stop|disable|delete|remove|no|keine|lösche) (escalations?|eskalation(en)?))[ ]*$")
+var escalationRegex = regexp.MustCompile("(?i)^(?:escalate|eskaliere) (?:after|nach) ([0-9]+)(?: reminders?| resends?| erinnerungen| wiederholungen|)(?: (?:to|an) (![^ ]+))?(?: (?:mentioning|und erwähne) (.+?))?[ ]*$")
+var escalationOffRegex = regexp.MustCompile("(?i)^(stop|disable|delete|remove|no|keine|lösche) (escalations?|eskalation(en)?)[ ]*$")
+
+// SetEscalationAction sets how unacknowledged important events of a channel are escalated.
+type SetEscalationAction struct {
+	logger    *slog.Logger
+	client    mautrixcl.Client
+	messenger messenger.Messenger
+	matrixDB  matrixdb.Service
+	db        database.Service
+	storer    *msghelper.Storer
+}
+
+// Configure is called on startup and sets all dependencies.
+func (action *SetEscalationAction) Configure(logger *slog.Logger, client mautrixcl.Client, messenger messenger.Messenger, matrixDB matrixdb.Service, db database.Service, _ *matrix.BridgeServices) {
+	action.logger = logger
+	action.client = client
+	action.matrixDB = matrixDB
+	action.db = db
+	action.messenger = messenger
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
+}
+
+// Name of the action
+func (action *SetEscalationAction) Name() string {
+	return "Set Escalation"
+}
+
+// GetDocu returns the documentation for the action.
+func (action *SetEscalationAction) GetDocu() (title, explaination string, examples []string) {
+	return "Set Escalation",
+		"Escalate important reminders (⚠️) that are not acknowledged after the given number of resends to another room and mention users. Escalations repeat with every n-th resend.",
+		[]string{"escalate after 3 reminders", "escalate after 2 to !oncall:example.com mentioning @alice:example.com", "stop escalations", "eskaliere nach 3 Erinnerungen und erwähne @alice:example.com"}
+}
+
+// Selector defines a regex on what messages the action should be used.
+func (action *SetEscalationAction) Selector() *regexp.Regexp {
+	return setEscalationActionRegex
+}
+
+// HandleEvent is where the message event get's send to if it matches the Selector.
+func (action *SetEscalationAction) HandleEvent(event *matrix.MessageEvent) {
+	dbMsg := mapping.MessageFromEvent(event)
+	dbMsg.Type = matrixdb.MessageTypeSetEscalation
+
+	_, err := action.matrixDB.NewMessage(dbMsg)
+	if err != nil {
+		action.logger.Error("failed to store message to database", "error", err)
+	}
+
+	var msg string
+
+	if escalationOffRegex.MatchString(event.Content.Body) {
+		event.Channel.EscalateAfter = nil
+		event.Channel.EscalationOutputID = nil
+		event.Channel.EscalationChannelID = nil
+		event.Channel.EscalationMentions = ""
+		msg = i18n.Translate(event.Room.Language, "Escalations are disabled.")
+	} else {
+		var ok bool
+
+		msg, ok = action.applyEscalation(event)
+		if !ok {
+			go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeSetEscalation, *event)
+			return
+		}
+	}
+
+	_, err = action.db.UpdateChannel(event.Channel)
+	if err != nil {
+		action.logger.Error("failed to update channel", "error", err)
+
+		msg := i18n.Translate(event.Room.Language, "Whups, could not save that change. Sorry, try again later.")
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeSetEscalation, *event)
+
+		return
+	}
+
+	go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeSetEscalation, *event)
+}
+
+// applyEscalation sets the escalation policy from the message to the channel. Returns the response and false if
+// the message is invalid.
+func (action *SetEscalationAction) applyEscalation(event *matrix.MessageEvent) (string, bool) {
+	lang := event.Room.Language
+
+	matches := escalationRegex.FindStringSubmatch(event.Content.Body)
+	if matches == nil {
+		return i18n.Translate(lang, "Sorry, I did not understand the escalation."), false
+	}
+
+	after, err := strconv.ParseUint(matches[1], 10, 32)
+	if err != nil || after == 0 {
+		return i18n.Translate(lang, "Sorry, I did not understand the escalation."), false
+	}
+
+	event.Channel.EscalateAfter = new(uint(after))
+	event.Channel.EscalationOutputID = nil
+	event.Channel.EscalationChannelID = nil
+	msg := i18n.Translatef(lang, "I will escalate important reminders that are not acknowledged after %d resends.", after)
+
+	if roomID := matches[2]; roomID != "" {
+		room, err := action.matrixDB.GetRoomByRoomID(roomID)
+		if err != nil {
+			action.logger.Info("failed to get escalation room", "error", err, "room.id", roomID)
+			return i18n.Translatef(lang, "Sorry, I am not in the room %s.", roomID), false
+		}
+
+		output, err := action.db.GetOutputByType(room.ID, matrix.OutputType)
+		if err != nil {
+			action.logger.Info("failed to get escalation output", "error", err, "room.id", roomID)
+			return i18n.Translatef(lang, "Sorry, I am not in the room %s.", roomID), false
+		}
+
+		event.Channel.EscalationOutputID = &output.ID
+		msg += " " + i18n.Translatef(lang, "Escalations are sent to %s.", roomID)
+	}
+
+	mentions := []string{}
+	if matches[3] != "" {
+		mentions = format.ParseMentions(matches[3], event.Content.FormattedBody)
+		if len(mentions) == 0 {
+			return i18n.Translatef(lang, "Sorry, %s is not a valid Matrix user ID.", matches[3]), false
+		}
+	}
+
+	for _, user := range mentions {
+		if _, _, err := id.UserID(user).ParseAndValidate(); err != nil {
+			return i18n.Translatef(lang, "Sorry, %s is not a valid Matrix user ID.", user), false
+		}
+	}
+
+	event.Channel.EscalationMentions = strings.Join(mentions, ",")
+	if len(mentions) > 0 {
+		msg += " " + i18n.Translatef(lang, "I will mention %s.", strings.Join(mentions, ", "))
+	}
+
+	return msg, true
+}
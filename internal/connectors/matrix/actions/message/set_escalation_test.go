@@ -0,0 +1,199 @@
+package message_test
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/message"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestSetEscalationAction(t *testing.T) {
+	action := &message.SetEscalationAction{}
+
+	assert.NotEmpty(t, action.Name())
+
+	title, desc, examples := action.GetDocu()
+	assert.NotEmpty(t, title)
+	assert.NotEmpty(t, desc)
+	assert.NotEmpty(t, examples)
+
+	assert.NotNil(t, action.Selector())
+}
+
+func TestSetEscalationAction_Selector(t *testing.T) {
+	action := &message.SetEscalationAction{}
+	r := action.Selector()
+
+	_, _, examples := action.GetDocu()
+	for _, example := range examples {
+		assert.True(t, r.MatchString(example), example)
+	}
+
+	assert.False(t, r.MatchString("escalate the issue tomorrow"))
+}
+
+func testSetEscalationAction(t *testing.T) (*message.SetEscalationAction, *database.MockService, *matrixdb.MockService, *messenger.MockMessenger) {
+	t.Helper()
+
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.SetEscalationAction{}
+	action.Configure(
+		slog.Default(),
+		mautrixcl.NewMockClient(t),
+		msngr,
+		matrixDB,
+		db,
+		&matrix.BridgeServices{},
+	)
+
+	return action, db, matrixDB, msngr
+}
+
+func TestSetEscalationAction_HandleEvent(t *testing.T) {
+	action, db, matrixDB, msngr := testSetEscalationAction(t)
+
+	body := "escalate after 3 reminders"
+	expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetEscalation, body, "I will escalate important reminders that are not acknowledged after 3 resends.")
+
+	channel := tests.TestEvent().Channel
+	channel.EscalateAfter = new(uint(3))
+	db.EXPECT().UpdateChannel(channel).Return(nil, nil)
+
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody(body, body)))
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetEscalationAction_HandleEventWithRoomAndMentions(t *testing.T) {
+	action, db, matrixDB, msngr := testSetEscalationAction(t)
+
+	body := "escalate after 2 to !oncall:example.com mentioning Alice and @bob:example.com"
+	formattedBody := `escalate after 2 to !oncall:example.com mentioning <a href="https://matrix.to/#/@alice:example.com">Alice</a> and @bob:example.com`
+
+	matrixDB.EXPECT().NewMessage(mock.Anything).Return(nil, nil)
+	msngr.EXPECT().SendResponse(mock.MatchedBy(func(resp *messenger.Response) bool {
+		return resp.Message == "I will escalate important reminders that are not acknowledged after 2 resends. "+
+			"Escalations are sent to !oncall:example.com. I will mention @alice:example.com, @bob:example.com."
+	})).Return(&messenger.MessageResponse{}, nil)
+
+	matrixDB.EXPECT().GetRoomByRoomID("!oncall:example.com").Return(&matrixdb.MatrixRoom{
+		Model: gorm.Model{ID: 4},
+	}, nil)
+
+	output := &database.Output{}
+	output.ID = 9
+	db.EXPECT().GetOutputByType(uint(4), "matrix").Return(output, nil)
+
+	channel := tests.TestEvent().Channel
+	channel.EscalateAfter = new(uint(2))
+	channel.EscalationOutputID = new(uint(9))
+	channel.EscalationMentions = "@alice:example.com,@bob:example.com"
+	db.EXPECT().UpdateChannel(channel).Return(nil, nil)
+
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody(body, formattedBody)))
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetEscalationAction_HandleEventWithUnknownRoom(t *testing.T) {
+	action, _, matrixDB, msngr := testSetEscalationAction(t)
+
+	body := "escalate after 2 to !unknown:example.com"
+	expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetEscalation, body, "Sorry, I am not in the room !unknown:example.com.")
+
+	matrixDB.EXPECT().GetRoomByRoomID("!unknown:example.com").Return(nil, matrixdb.ErrNotFound)
+
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody(body, body)))
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetEscalationAction_HandleEventWithInvalidMentions(t *testing.T) {
+	testCases := []struct {
+		body     string
+		response string
+	}{
+		{
+			body:     "escalate after 2 mentioning bob",
+			response: "Sorry, bob is not a valid Matrix user ID.",
+		},
+		{
+			body:     "escalate after 2 mentioning @Bob$:example.com",
+			response: "Sorry, @Bob$:example.com is not a valid Matrix user ID.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.body, func(t *testing.T) {
+			action, _, matrixDB, msngr := testSetEscalationAction(t)
+
+			expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetEscalation, tc.body, tc.response)
+
+			action.HandleEvent(tests.TestEvent(tests.MessageWithBody(tc.body, tc.body)))
+
+			// Wait for async message sending.
+			time.Sleep(time.Millisecond * 10)
+		})
+	}
+}
+
+func TestSetEscalationAction_HandleEventWithZeroResends(t *testing.T) {
+	action, _, matrixDB, msngr := testSetEscalationAction(t)
+
+	body := "escalate after 0 reminders"
+	expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetEscalation, body, "Sorry, I did not understand the escalation.")
+
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody(body, body)))
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetEscalationAction_HandleEventStopEscalations(t *testing.T) {
+	action, db, matrixDB, msngr := testSetEscalationAction(t)
+
+	body := "stop escalations"
+	expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetEscalation, body, "Escalations are disabled.")
+
+	event := tests.TestEvent(tests.MessageWithBody(body, body))
+	event.Channel.EscalateAfter = new(uint(3))
+	event.Channel.EscalationOutputID = new(uint(9))
+	event.Channel.EscalationMentions = "@alice:example.com"
+
+	db.EXPECT().UpdateChannel(tests.TestEvent().Channel).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetEscalationAction_HandleEventWithUpdateChannelError(t *testing.T) {
+	action, db, matrixDB, msngr := testSetEscalationAction(t)
+
+	body := "eskaliere nach 3 Erinnerungen"
+	expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetEscalation, body, "Whups, could not save that change. Sorry, try again later.")
+
+	db.EXPECT().UpdateChannel(mock.Anything).Return(nil, assert.AnError)
+
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody(body, body)))
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
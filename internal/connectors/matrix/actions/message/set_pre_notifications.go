@@ -0,0 +1,128 @@
+package message
+
+import (
+	"log/slog"
+	"regexp"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+var (
+	setPreNotificationsActionRegex     = regexp.MustCompile("(?i)^((notify|remind|ping|alert)( me| us|)[ ]+.+[ ]+before|(do not|don't|dont|never|stop)[ ]+(notify|notifying|remind|reminding)( me| us|)[ ]+before)[ ]+(all |)(events|reminders|meetings)[ ]*$")
+	disablePreNotificationsActionRegex = regexp.MustCompile("(?i)^(do not|don't|dont|never|stop)[ ]+")
+)
+
+// SetPreNotificationsAction sets the lead times to notify about all events of a channel before they start.
+type SetPreNotificationsAction struct {
+	logger    *slog.Logger
+	client    mautrixcl.Client
+	messenger messenger.Messenger
+	matrixDB  matrixdb.Service
+	db        database.Service
+	storer    *msghelper.Storer
+}
+
+// Configure is called on startup and sets all dependencies.
+func (action *SetPreNotificationsAction) Configure(logger *slog.Logger, client mautrixcl.Client, messenger messenger.Messenger, matrixDB matrixdb.Service, db database.Service, _ *matrix.BridgeServices) {
+	action.logger = logger
+	action.client = client
+	action.matrixDB = matrixDB
+	action.db = db
+	action.messenger = messenger
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
+}
+
+// Name of the action
+func (action *SetPreNotificationsAction) Name() string {
+	return "Set Pre-Notifications"
+}
+
+// GetDocu returns the documentation for the action.
+func (action *SetPreNotificationsAction) GetDocu() (title, explaination string, examples []string) {
+	return "Set Pre-Notifications",
+		"Get notified ahead of all events, e.g. for meetings imported from a calendar",
+		[]string{"notify me 1 day and 15 minutes before events", "remind us 10 minutes before meetings", "stop notifying me before events"}
+}
+
+// Selector defines a regex on what messages the action should be used.
+func (action *SetPreNotificationsAction) Selector() *regexp.Regexp {
+	return setPreNotificationsActionRegex
+}
+
+// HandleEvent is where the message event get's send to if it matches the Selector.
+func (action *SetPreNotificationsAction) HandleEvent(event *matrix.MessageEvent) {
+	dbMsg := mapping.MessageFromEvent(event)
+	dbMsg.Type = matrixdb.MessageTypeSetPreNotifications
+
+	_, err := action.matrixDB.NewMessage(dbMsg)
+	if err != nil {
+		action.logger.Error("failed to store message to database", "error", err)
+	}
+
+	leadTimes := format.ParseLeadTimes(event.Content.Body)
+	disable := disablePreNotificationsActionRegex.MatchString(event.Content.Body)
+
+	if len(leadTimes) == 0 && !disable {
+		msg := "Sorry, I was not able to understand how long before the events you want to be notified."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeSetPreNotifications, *event)
+
+		return
+	}
+
+	if disable {
+		leadTimes = nil
+	}
+
+	err = action.replacePreNotifications(event.Channel.ID, leadTimes)
+	if err != nil {
+		action.logger.Error("failed to set pre-notifications", "error", err)
+
+		msg := "Whups, could not save that change. Sorry, try again later."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeSetPreNotifications, *event)
+
+		return
+	}
+
+	msg := "I will no longer notify you before events."
+	if len(leadTimes) > 0 {
+		msg = "I will notify you " + format.ToNiceLeadTimes(leadTimes) + " before events. To disable this message me with \"stop notifying me before events\"."
+	}
+
+	go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeSetPreNotifications, *event)
+}
+
+func (action *SetPreNotificationsAction) replacePreNotifications(channelID uint, leadTimes []time.Duration) error {
+	preNotifications, err := action.db.ListPreNotifications(&database.ListPreNotificationsOpts{
+		ChannelID: &channelID,
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range preNotifications {
+		err = action.db.DeletePreNotification(&preNotifications[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, leadTime := range leadTimes {
+		_, err = action.db.NewPreNotification(&database.PreNotification{
+			ChannelID: &channelID,
+			LeadTime:  leadTime,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package message_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/message"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestSetPreNotificationsAction(t *testing.T) {
+	action := &message.SetPreNotificationsAction{}
+
+	assert.NotEmpty(t, action.Name())
+
+	title, desc, examples := action.GetDocu()
+	assert.NotEmpty(t, title)
+	assert.NotEmpty(t, desc)
+	assert.NotEmpty(t, examples)
+
+	assert.NotNil(t, action.Selector())
+}
+
+func TestSetPreNotificationsAction_Selector(t *testing.T) {
+	action := &message.SetPreNotificationsAction{}
+	r := action.Selector()
+
+	_, _, examples := action.GetDocu()
+	for _, example := range examples {
+		assert.True(t, r.MatchString(example))
+	}
+
+	assert.False(t, r.MatchString("remind me tomorrow to buy milk"))
+	assert.False(t, r.MatchString("notify me before"))
+}
+
+func testSetPreNotificationsAction(t *testing.T) (*message.SetPreNotificationsAction, *database.MockService, *matrixdb.MockService, *messenger.MockMessenger) {
+	t.Helper()
+
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.SetPreNotificationsAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		&matrix.BridgeServices{},
+	)
+
+	return action, db, matrixDB, msngr
+}
+
+func expectSetPreNotificationsResponse(matrixDB *matrixdb.MockService, msngr *messenger.MockMessenger, body, response string) {
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		response,
+		"evt1",
+		body,
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "resp1",
+		UserID:        new("@user:example.com"),
+		Body:          response,
+		BodyFormatted: response,
+		Type:          matrixdb.MessageTypeSetPreNotifications,
+	}).Return(nil, nil)
+}
+
+func TestSetPreNotificationsAction_HandleEvent(t *testing.T) {
+	action, db, matrixDB, msngr := testSetPreNotificationsAction(t)
+	body := "notify me 1 day and 15 minutes before events"
+	event := tests.TestEvent(tests.MessageWithBody(body, body))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeSetPreNotifications)
+
+	db.EXPECT().ListPreNotifications(&database.ListPreNotificationsOpts{
+		ChannelID: new(uint(68272)),
+	}).Return([]database.PreNotification{
+		{
+			Model: gorm.Model{
+				ID: 3,
+			},
+			ChannelID: new(uint(68272)),
+			LeadTime:  time.Hour,
+		},
+	}, nil)
+	db.EXPECT().DeletePreNotification(&database.PreNotification{
+		Model: gorm.Model{
+			ID: 3,
+		},
+		ChannelID: new(uint(68272)),
+		LeadTime:  time.Hour,
+	}).Return(nil)
+	db.EXPECT().NewPreNotification(&database.PreNotification{
+		ChannelID: new(uint(68272)),
+		LeadTime:  24 * time.Hour,
+	}).Return(nil, nil)
+	db.EXPECT().NewPreNotification(&database.PreNotification{
+		ChannelID: new(uint(68272)),
+		LeadTime:  15 * time.Minute,
+	}).Return(nil, nil)
+
+	expectSetPreNotificationsResponse(matrixDB, msngr, body,
+		"I will notify you 24 hours and 15 minutes before events. To disable this message me with \"stop notifying me before events\".")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetPreNotificationsAction_HandleEventDisable(t *testing.T) {
+	action, db, matrixDB, msngr := testSetPreNotificationsAction(t)
+	body := "stop notifying me before events"
+	event := tests.TestEvent(tests.MessageWithBody(body, body))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeSetPreNotifications)
+
+	db.EXPECT().ListPreNotifications(&database.ListPreNotificationsOpts{
+		ChannelID: new(uint(68272)),
+	}).Return([]database.PreNotification{}, nil)
+
+	expectSetPreNotificationsResponse(matrixDB, msngr, body, "I will no longer notify you before events.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetPreNotificationsAction_HandleEventWithoutDuration(t *testing.T) {
+	action, _, matrixDB, msngr := testSetPreNotificationsAction(t)
+	body := "notify me shortly before events"
+	event := tests.TestEvent(tests.MessageWithBody(body, body))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeSetPreNotifications)
+
+	expectSetPreNotificationsResponse(matrixDB, msngr, body,
+		"Sorry, I was not able to understand how long before the events you want to be notified.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetPreNotificationsAction_HandleEventWithDatabaseError(t *testing.T) {
+	action, db, matrixDB, msngr := testSetPreNotificationsAction(t)
+	body := "notify me 15 minutes before events"
+	event := tests.TestEvent(tests.MessageWithBody(body, body))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeSetPreNotifications)
+
+	db.EXPECT().ListPreNotifications(&database.ListPreNotificationsOpts{
+		ChannelID: new(uint(68272)),
+	}).Return(nil, errors.New("test"))
+
+	expectSetPreNotificationsResponse(matrixDB, msngr, body, "Whups, could not save that change. Sorry, try again later.")
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
@@ -0,0 +1,146 @@
+package message
+
+import (
+	"log/slog"
+	"regexp"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+var setQuietHoursActionRegex = regexp.MustCompile("(?i)^((set |setze |)(quiet hours|ruhezeiten?) .+|(stop|disable|delete|remove|no|keine|lösche) (quiet hours|ruhezeiten?)|(dnd|do not disturb|nicht stören) (until|bis|off|aus).*)[ ]*$")
+var quietHoursRangeRegex = regexp.MustCompile("(?i)^(?:set |setze |)(?:quiet hours|ruhezeiten?)[ ]+(?:from |von |)(.+?)(?:[ ]+(?:to|until|bis)[ ]+|[ ]*-[ ]*)(.+?)[ ]*$")
+var quietHoursOffRegex = regexp.MustCompile("(?i)^((stop|disable|delete|remove|no|keine|lösche) (quiet hours|ruhezeiten?)|(quiet hours|ruhezeiten?) (off|aus))[ ]*$")
+var doNotDisturbRegex = regexp.MustCompile("(?i)^(?:dnd|do not disturb|nicht stören)[ ]+(?:until|bis)[ ]+(.+?)[ ]*$")
+var doNotDisturbOffRegex = regexp.MustCompile("(?i)^(dnd|do not disturb|nicht stören) (off|aus)[ ]*$")
+
+// SetQuietHoursAction sets the quiet hours and do not disturb window of a channel.
+type SetQuietHoursAction struct {
+	logger    *slog.Logger
+	client    mautrixcl.Client
+	messenger messenger.Messenger
+	matrixDB  matrixdb.Service
+	db        database.Service
+	storer    *msghelper.Storer
+}
+
+// Configure is called on startup and sets all dependencies.
+func (action *SetQuietHoursAction) Configure(logger *slog.Logger, client mautrixcl.Client, messenger messenger.Messenger, matrixDB matrixdb.Service, db database.Service, _ *matrix.BridgeServices) {
+	action.logger = logger
+	action.client = client
+	action.matrixDB = matrixDB
+	action.db = db
+	action.messenger = messenger
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
+}
+
+// Name of the action
+func (action *SetQuietHoursAction) Name() string {
+	return "Set Quiet Hours"
+}
+
+// GetDocu returns the documentation for the action.
+func (action *SetQuietHoursAction) GetDocu() (title, explaination string, examples []string) {
+	return "Set Quiet Hours",
+		"Hold back reminders during the quiet hours of every day or until the given time, they are sent afterwards. Urgent reminders (🚨) are sent anyway.",
+		[]string{"quiet hours 22:00 to 7:00", "stop quiet hours", "dnd until monday 9am", "dnd off", "Ruhezeiten 22:00 bis 7:00", "nicht stören bis morgen 8 Uhr"}
+}
+
+// Selector defines a regex on what messages the action should be used.
+func (action *SetQuietHoursAction) Selector() *regexp.Regexp {
+	return setQuietHoursActionRegex
+}
+
+// HandleEvent is where the message event get's send to if it matches the Selector.
+func (action *SetQuietHoursAction) HandleEvent(event *matrix.MessageEvent) {
+	dbMsg := mapping.MessageFromEvent(event)
+	dbMsg.Type = matrixdb.MessageTypeSetQuietHours
+
+	_, err := action.matrixDB.NewMessage(dbMsg)
+	if err != nil {
+		action.logger.Error("failed to store message to database", "error", err)
+	}
+
+	// Quiet hours are evaluated in the timezone of the channel, times are given in the one of the room.
+	timeZone := event.Room.TimeZone
+	event.Channel.TimeZone = timeZone
+
+	var msg string
+
+	switch {
+	case quietHoursOffRegex.MatchString(event.Content.Body):
+		event.Channel.QuietHoursStart = nil
+		event.Channel.QuietHoursEnd = nil
+		msg = i18n.Translate(event.Room.Language, "Quiet hours are disabled.")
+	case doNotDisturbOffRegex.MatchString(event.Content.Body):
+		event.Channel.DoNotDisturbUntil = nil
+		msg = i18n.Translate(event.Room.Language, "Do not disturb is disabled.")
+	case doNotDisturbRegex.MatchString(event.Content.Body):
+		matches := doNotDisturbRegex.FindStringSubmatch(event.Content.Body)
+
+		until, err := format.ParseTime(event.Channel, matches[1], timeZone, event.Room.Language, false)
+		if err != nil {
+			action.logger.Info("failed to parse time", "error", err)
+			action.sendTimeError(event)
+
+			return
+		}
+
+		event.Channel.DoNotDisturbUntil = &until
+		msg = i18n.Translatef(event.Room.Language, "I will hold back reminders until %s, urgent ones are sent anyway. To disable it message me with \"dnd off\".", format.ToLocalTime(until, timeZone, event.Clock()))
+	default:
+		matches := quietHoursRangeRegex.FindStringSubmatch(event.Content.Body)
+		if len(matches) < 3 {
+			action.sendTimeError(event)
+
+			return
+		}
+
+		start, errStart := format.ParseTime(event.Channel, matches[1], timeZone, event.Room.Language, true)
+		end, errEnd := format.ParseTime(event.Channel, matches[2], timeZone, event.Room.Language, true)
+		if errStart != nil || errEnd != nil {
+			action.logger.Info("failed to parse time", "error_start", errStart, "error_end", errEnd)
+			action.sendTimeError(event)
+
+			return
+		}
+
+		event.Channel.QuietHoursStart = new(minutesOfDay(start, timeZone))
+		event.Channel.QuietHoursEnd = new(minutesOfDay(end, timeZone))
+		msg = i18n.Translatef(event.Room.Language, "I will hold back reminders between %s and %s, urgent ones are sent anyway. To disable it message me with \"stop quiet hours\".", format.TimeToHourAndMinute(start), format.TimeToHourAndMinute(end))
+	}
+
+	_, err = action.db.UpdateChannel(event.Channel)
+	if err != nil {
+		action.logger.Error("failed to update channel", "error", err)
+
+		msg := i18n.Translate(event.Room.Language, "Whups, could not save that change. Sorry, try again later.")
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeSetQuietHours, *event)
+
+		return
+	}
+
+	go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeSetQuietHours, *event)
+}
+
+func (action *SetQuietHoursAction) sendTimeError(event *matrix.MessageEvent) {
+	msg := i18n.Translate(event.Room.Language, "Sorry, I was not able to understand the time.")
+	go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeSetQuietHours, *event)
+}
+
+func minutesOfDay(t time.Time, timeZone string) uint {
+	loc := time.UTC
+	if parsedLoc, err := time.LoadLocation(timeZone); err == nil {
+		loc = parsedLoc
+	}
+
+	return uint(t.In(loc).Hour()*60 + t.In(loc).Minute())
+}
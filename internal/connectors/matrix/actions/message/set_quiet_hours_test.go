@@ -0,0 +1,220 @@
+package message_test
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/message"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSetQuietHoursAction(t *testing.T) {
+	action := &message.SetQuietHoursAction{}
+
+	assert.NotEmpty(t, action.Name())
+
+	title, desc, examples := action.GetDocu()
+	assert.NotEmpty(t, title)
+	assert.NotEmpty(t, desc)
+	assert.NotEmpty(t, examples)
+
+	assert.NotNil(t, action.Selector())
+}
+
+func TestSetQuietHoursAction_Selector(t *testing.T) {
+	action := &message.SetQuietHoursAction{}
+	r := action.Selector()
+
+	_, _, examples := action.GetDocu()
+	for _, example := range examples {
+		assert.True(t, r.MatchString(example), example)
+	}
+
+	assert.False(t, r.MatchString("do not disturb mom at 5pm"))
+}
+
+func testSetQuietHoursAction(t *testing.T) (*message.SetQuietHoursAction, *database.MockService, *matrixdb.MockService, *messenger.MockMessenger) {
+	t.Helper()
+
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &message.SetQuietHoursAction{}
+	action.Configure(
+		slog.Default(),
+		mautrixcl.NewMockClient(t),
+		msngr,
+		matrixDB,
+		db,
+		&matrix.BridgeServices{},
+	)
+
+	return action, db, matrixDB, msngr
+}
+
+func expectStoredResponse(matrixDB *matrixdb.MockService, msngr *messenger.MockMessenger, msgType matrixdb.MatrixMessageType, body, response string) {
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "evt1",
+		UserID:        new("@user:example.com"),
+		Body:          body,
+		BodyFormatted: body,
+		Type:          msgType,
+		Incoming:      true,
+		SendAt:        time.UnixMilli(tests.TestEvent().Event.Timestamp),
+	}).Return(nil, nil)
+
+	msngr.EXPECT().SendResponse(&messenger.Response{
+		Message:                   response,
+		MessageFormatted:          response,
+		RespondToMessage:          body,
+		RespondToMessageFormatted: body,
+		RespondToUserID:           "@user:example.com",
+		RespondToEventID:          "evt1",
+		ChannelExternalIdentifier: "!room123",
+	}).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "id1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(&matrixdb.MatrixMessage{
+		ID:            "id1",
+		UserID:        new("@user:example.com"),
+		Body:          response,
+		BodyFormatted: response,
+		Type:          msgType,
+	}).Return(nil, nil)
+}
+
+func TestSetQuietHoursAction_HandleEvent(t *testing.T) {
+	action, db, matrixDB, msngr := testSetQuietHoursAction(t)
+
+	body := "quiet hours 22:00 to 7:00"
+	expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetQuietHours, body, "I will hold back reminders between 22:00 and 7:00, urgent ones are sent anyway. To disable it message me with \"stop quiet hours\".")
+
+	channel := tests.TestEvent().Channel
+	channel.TimeZone = "UTC"
+	channel.QuietHoursStart = new(uint(1320))
+	channel.QuietHoursEnd = new(uint(420))
+	db.EXPECT().UpdateChannel(channel).Return(nil, nil)
+
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody(body, body)))
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetQuietHoursAction_HandleEventWithGermanRange(t *testing.T) {
+	action, db, matrixDB, msngr := testSetQuietHoursAction(t)
+
+	body := "Ruhezeiten von 23:30 bis 6:15"
+	expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetQuietHours, body, "Ich halte Erinnerungen zwischen 23:30 und 6:15 zurück, dringende sende ich trotzdem. Zum Ausschalten schreibe mir \"Ruhezeiten aus\".")
+
+	event := tests.TestEvent(tests.MessageWithBody(body, body))
+	event.Room.Language = "de"
+
+	channel := tests.TestEvent().Channel
+	channel.TimeZone = "UTC"
+	channel.QuietHoursStart = new(uint(1410))
+	channel.QuietHoursEnd = new(uint(375))
+	db.EXPECT().UpdateChannel(channel).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetQuietHoursAction_HandleEventStopQuietHours(t *testing.T) {
+	action, db, matrixDB, msngr := testSetQuietHoursAction(t)
+
+	body := "stop quiet hours"
+	expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetQuietHours, body, "Quiet hours are disabled.")
+
+	event := tests.TestEvent(tests.MessageWithBody(body, body))
+	event.Channel.QuietHoursStart = new(uint(1320))
+	event.Channel.QuietHoursEnd = new(uint(420))
+
+	channel := tests.TestEvent().Channel
+	channel.TimeZone = "UTC"
+	db.EXPECT().UpdateChannel(channel).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetQuietHoursAction_HandleEventDoNotDisturb(t *testing.T) {
+	action, db, matrixDB, msngr := testSetQuietHoursAction(t)
+
+	body := "dnd until tomorrow 9am"
+
+	matrixDB.EXPECT().NewMessage(mock.Anything).Return(nil, nil)
+	msngr.EXPECT().SendResponse(mock.Anything).Return(&messenger.MessageResponse{}, nil)
+
+	db.EXPECT().UpdateChannel(mock.Anything).Run(func(channel *database.Channel) {
+		if assert.NotNil(t, channel.DoNotDisturbUntil) {
+			assert.Equal(t, 9, channel.DoNotDisturbUntil.UTC().Hour())
+			assert.Equal(t, "UTC", channel.TimeZone)
+			assert.True(t, channel.DoNotDisturbUntil.After(time.Now()))
+		}
+	}).Return(nil, nil)
+
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody(body, body)))
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetQuietHoursAction_HandleEventDoNotDisturbOff(t *testing.T) {
+	action, db, matrixDB, msngr := testSetQuietHoursAction(t)
+
+	body := "dnd off"
+	expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetQuietHours, body, "Do not disturb is disabled.")
+
+	event := tests.TestEvent(tests.MessageWithBody(body, body))
+	event.Channel.DoNotDisturbUntil = new(time.Now().Add(time.Hour))
+
+	channel := tests.TestEvent().Channel
+	channel.TimeZone = "UTC"
+	db.EXPECT().UpdateChannel(channel).Return(nil, nil)
+
+	action.HandleEvent(event)
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetQuietHoursAction_HandleEventWithInvalidTime(t *testing.T) {
+	action, _, matrixDB, msngr := testSetQuietHoursAction(t)
+
+	body := "quiet hours whenever"
+	expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetQuietHours, body, "Sorry, I was not able to understand the time.")
+
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody(body, body)))
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestSetQuietHoursAction_HandleEventWithUpdateChannelError(t *testing.T) {
+	action, db, matrixDB, msngr := testSetQuietHoursAction(t)
+
+	body := "quiet hours off"
+	expectStoredResponse(matrixDB, msngr, matrixdb.MessageTypeSetQuietHours, body, "Whups, could not save that change. Sorry, try again later.")
+
+	db.EXPECT().UpdateChannel(mock.Anything).Return(nil, assert.AnError)
+
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody(body, body)))
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
@@ -81,7 +81,7 @@ func (action *AddTimeAction) HandleEvent(event *matrix.ReactionEvent, reactionTo
 	err = action.messenger.SendResponseAsync(messenger.PlainTextResponse(
 		fmt.Sprintf(
 			"I rescheduled this reminder to %s!",
-			format.ToLocalTime(evt.Time, event.Room.TimeZone),
+			format.ToLocalTime(evt.Time, event.TimeZone(), event.Clock()),
 		),
 		reactionToMessage.ID,
 		reactionToMessage.Body,
@@ -59,7 +59,7 @@ func (action *MarkDoneAction) HandleEvent(event *matrix.ReactionEvent, reactionT
 	}
 
 	evt := reactionToMessage.Event
-	if evt.RepeatInterval == nil && evt.RepeatUntil == nil {
+	if !evt.IsRecurring() && evt.RepeatUntil == nil {
 		evt.Active = false
 
 		_, err := action.db.UpdateEvent(evt)
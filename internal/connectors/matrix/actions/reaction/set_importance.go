@@ -37,13 +37,13 @@ func (action *SetImportanceAction) Name() string {
 // GetDocu returns the documentation for the action.
 func (action *SetImportanceAction) GetDocu() (title, explaination string, examples []string) {
 	return "Mark Event Done",
-		"React with a ⚠️ to set the event as important or with a ℹ️ to mark it as default.",
-		[]string{"⚠️", "ℹ️"}
+		"React with a ⚠️ to set the event as important, with a 🚨 to mark it as urgent so it is sent during quiet hours too or with a ℹ️ to mark it as default.",
+		[]string{"⚠️", "🚨", "ℹ️"}
 }
 
 // Selector defines on which reactions this action should be called.
 func (action *SetImportanceAction) Selector() []string {
-	return []string{"⚠️", "ℹ️"}
+	return []string{"⚠️", "🚨", "ℹ️"}
 }
 
 // HandleEvent is where the reaction event and the related message get's send to if it matches the Selector.
@@ -101,6 +101,8 @@ func eventKeyToImportance(key string) (database.Importance, error) {
 	switch key {
 	case "⚠️":
 		return database.ImportanceImportant, nil
+	case "🚨":
+		return database.ImportanceUrgent, nil
 	case "ℹ️":
 		return database.ImportanceDefault, nil
 	default:
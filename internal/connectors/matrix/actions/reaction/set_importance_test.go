@@ -80,6 +80,24 @@ func TestSetImportanceAction_HandleEvent(t *testing.T) {
 		), msg)
 	})
 
+	t.Run("set to urgent", func(_ *testing.T) {
+		msg := tests.TestMessage(
+			tests.WithTestEvent(),
+		)
+
+		// Expectations
+		db.EXPECT().UpdateEvent(mock.MatchedBy(func(evt *database.Event) bool {
+			return evt.Importance == database.ImportanceUrgent
+		})).Return(nil, nil)
+
+		msngr.EXPECT().SendResponseAsync(mock.Anything).Return(nil)
+
+		// Execute
+		action.HandleEvent(tests.TestReactionEvent(
+			tests.ReactionWithKey("🚨"),
+		), msg)
+	})
+
 	t.Run("set to default", func(_ *testing.T) {
 		msg := tests.TestMessage(
 			tests.WithTestEvent(),
@@ -0,0 +1,98 @@
+package reply
+
+import (
+	"log/slog"
+	"regexp"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+var addPreNotificationsActionRegex = regexp.MustCompile("(?i)^(also |)(notify|remind|ping|alert)( me| us|)[ ]+.+[ ]+before( it| that| the event| the meeting|)[ ]*$")
+
+// AddPreNotificationsAction adds lead times to notify about an event before it starts.
+type AddPreNotificationsAction struct {
+	logger    *slog.Logger
+	client    mautrixcl.Client
+	messenger messenger.Messenger
+	matrixDB  matrixdb.Service
+	db        database.Service
+	storer    *msghelper.Storer
+}
+
+// Configure is called on startup and sets all dependencies.
+func (action *AddPreNotificationsAction) Configure(logger *slog.Logger, client mautrixcl.Client, messenger messenger.Messenger, matrixDB matrixdb.Service, db database.Service, _ *matrix.BridgeServices) {
+	action.logger = logger
+	action.client = client
+	action.matrixDB = matrixDB
+	action.db = db
+	action.messenger = messenger
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
+}
+
+// Name of the action
+func (action *AddPreNotificationsAction) Name() string {
+	return "Add Pre-Notifications"
+}
+
+// GetDocu returns the documentation for the action.
+func (action *AddPreNotificationsAction) GetDocu() (title, explaination string, examples []string) {
+	return "Add Pre-Notifications",
+		"Get notified ahead of an event by replying to it",
+		[]string{"remind me 15 minutes before", "also notify me 1 day and 1 hour before"}
+}
+
+// Selector defines a regex on what messages the action should be used.
+func (action *AddPreNotificationsAction) Selector() *regexp.Regexp {
+	return addPreNotificationsActionRegex
+}
+
+// HandleEvent is where the message event get's send to if it matches the Selector.
+func (action *AddPreNotificationsAction) HandleEvent(event *matrix.MessageEvent, replyToMessage *matrixdb.MatrixMessage) {
+	if replyToMessage.EventID == nil || replyToMessage.Event == nil {
+		// No event given, can not update anything
+		action.logger.Debug("can not add pre-notifications to event with event ID nil")
+		return
+	}
+
+	message := mapping.MessageFromEvent(event)
+	message.Type = matrixdb.MessageTypeAddPreNotifications
+	message.EventID = replyToMessage.EventID
+
+	_, err := action.matrixDB.NewMessage(message)
+	if err != nil {
+		action.logger.Error("failed to save message to database", "error", err)
+	}
+
+	leadTimes := format.ParseLeadTimes(event.Content.Body)
+	if len(leadTimes) == 0 {
+		msg := "Sorry, I was not able to understand how long before the event you want to be notified."
+		go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeAddPreNotifications, *event, msghelper.WithEventID(*replyToMessage.EventID))
+
+		return
+	}
+
+	for _, leadTime := range leadTimes {
+		_, err = action.db.NewPreNotification(&database.PreNotification{
+			EventID:  replyToMessage.EventID,
+			LeadTime: leadTime,
+		})
+		if err != nil {
+			action.logger.Error("failed to save pre-notification", "error", err)
+
+			msg := "Sorry, that failed :/."
+			go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeAddPreNotifications, *event, msghelper.WithEventID(*replyToMessage.EventID))
+
+			return
+		}
+	}
+
+	msg := "I will notify you " + format.ToNiceLeadTimes(leadTimes) + " before the event."
+	go action.storer.SendAndStoreResponse(msg, matrixdb.MessageTypeAddPreNotifications, *event, msghelper.WithEventID(*replyToMessage.EventID))
+}
@@ -0,0 +1,150 @@
+package reply_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/reply"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddPreNotificationsAction(t *testing.T) {
+	action := &reply.AddPreNotificationsAction{}
+
+	assert.NotEmpty(t, action.Name())
+
+	title, desc, examples := action.GetDocu()
+	assert.NotEmpty(t, title)
+	assert.NotEmpty(t, desc)
+	assert.NotEmpty(t, examples)
+
+	assert.NotNil(t, action.Selector())
+}
+
+func TestAddPreNotificationsAction_Selector(t *testing.T) {
+	action := &reply.AddPreNotificationsAction{}
+	r := action.Selector()
+
+	_, _, examples := action.GetDocu()
+	for _, example := range examples {
+		assert.True(t, r.MatchString(example))
+	}
+
+	assert.False(t, r.MatchString("tomorrow at 10"))
+	assert.False(t, r.MatchString("every 2 hours"))
+}
+
+func testAddPreNotificationsAction(t *testing.T) (*reply.AddPreNotificationsAction, *database.MockService, *matrixdb.MockService, *messenger.MockMessenger) {
+	t.Helper()
+
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &reply.AddPreNotificationsAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		nil,
+	)
+
+	return action, db, matrixDB, msngr
+}
+
+func expectAddPreNotificationsResponse(matrixDB *matrixdb.MockService, msngr *messenger.MockMessenger, body, response string) {
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		response,
+		"evt1",
+		body,
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "resp1",
+	}, nil)
+
+	matrixDB.EXPECT().NewMessage(mock.MatchedBy(func(msg *matrixdb.MatrixMessage) bool {
+		return msg.ID == "resp1" &&
+			msg.Body == response &&
+			msg.Type == matrixdb.MessageTypeAddPreNotifications &&
+			*msg.EventID == 1
+	})).Return(nil, nil)
+}
+
+func TestAddPreNotificationsAction_HandleEvent(t *testing.T) {
+	action, db, matrixDB, msngr := testAddPreNotificationsAction(t)
+	body := "remind me 1 hour and 10 minutes before"
+	event := tests.TestEvent(tests.MessageWithBody(body, body))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeAddPreNotifications, tests.MsgWithDBEventID(1))
+
+	db.EXPECT().NewPreNotification(&database.PreNotification{
+		EventID:  new(uint(1)),
+		LeadTime: time.Hour,
+	}).Return(nil, nil)
+	db.EXPECT().NewPreNotification(&database.PreNotification{
+		EventID:  new(uint(1)),
+		LeadTime: 10 * time.Minute,
+	}).Return(nil, nil)
+
+	expectAddPreNotificationsResponse(matrixDB, msngr, body, "I will notify you 1 hours and 10 minutes before the event.")
+
+	action.HandleEvent(event, tests.TestMessage(tests.WithFromTestEvent(), tests.WithTestEvent()))
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestAddPreNotificationsAction_HandleEventWithoutDuration(t *testing.T) {
+	action, _, matrixDB, msngr := testAddPreNotificationsAction(t)
+	body := "remind me shortly before"
+	event := tests.TestEvent(tests.MessageWithBody(body, body))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeAddPreNotifications, tests.MsgWithDBEventID(1))
+
+	expectAddPreNotificationsResponse(matrixDB, msngr, body,
+		"Sorry, I was not able to understand how long before the event you want to be notified.")
+
+	action.HandleEvent(event, tests.TestMessage(tests.WithFromTestEvent(), tests.WithTestEvent()))
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestAddPreNotificationsAction_HandleEventWithDatabaseError(t *testing.T) {
+	action, db, matrixDB, msngr := testAddPreNotificationsAction(t)
+	body := "remind me 10 minutes before"
+	event := tests.TestEvent(tests.MessageWithBody(body, body))
+
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeAddPreNotifications, tests.MsgWithDBEventID(1))
+
+	db.EXPECT().NewPreNotification(&database.PreNotification{
+		EventID:  new(uint(1)),
+		LeadTime: 10 * time.Minute,
+	}).Return(nil, errors.New("test"))
+
+	expectAddPreNotificationsResponse(matrixDB, msngr, body, "Sorry, that failed :/.")
+
+	action.HandleEvent(event, tests.TestMessage(tests.WithFromTestEvent(), tests.WithTestEvent()))
+
+	// Wait for async message sending.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestAddPreNotificationsAction_HandleEventWithoutEvent(t *testing.T) {
+	action, _, _, _ := testAddPreNotificationsAction(t)
+	body := "remind me 10 minutes before"
+
+	action.HandleEvent(tests.TestEvent(tests.MessageWithBody(body, body)), tests.TestMessage(tests.WithoutEvent()))
+}
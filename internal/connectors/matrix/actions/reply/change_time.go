@@ -1,13 +1,13 @@
 package reply
 
 import (
-	"fmt"
 	"log/slog"
 	"regexp"
 
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
 	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mapping"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
@@ -45,8 +45,8 @@ func (action *ChangeTimeAction) Name() string {
 // GetDocu returns the documentation for the action.
 func (action *ChangeTimeAction) GetDocu() (title, explaination string, examples []string) {
 	return "Change time",
-		"Change the time of a reminder by replying to a reminder message.",
-		[]string{"January, 5th", "at 5 pm", "tomorrow"}
+		"Change the time of a reminder by replying to a reminder message. When replying to a daily reminder start with the number of the event.",
+		[]string{"January, 5th", "at 5 pm", "tomorrow", "2 tomorrow"}
 }
 
 // Selector defines a regex on what messages the action should be used.
@@ -56,17 +56,51 @@ func (action *ChangeTimeAction) Selector() *regexp.Regexp {
 
 // HandleEvent is where the message event get's send to if it matches the Selector.
 func (action *ChangeTimeAction) HandleEvent(event *matrix.MessageEvent, replyToMessage *matrixdb.MatrixMessage) {
-	if replyToMessage.EventID == nil || replyToMessage.Event == nil {
+	body := event.Content.Body
+	evt := replyToMessage.Event
+
+	if replyToMessage.Type == matrixdb.MessageTypeDailyReminder {
+		match := dailyReminderEntryRegex.FindStringSubmatch(body)
+		if match == nil {
+			_ = action.messenger.SendResponseAsync(messenger.PlainTextResponse(
+				i18n.Translate(event.Room.Language, "Please start your reply with the number of the event you want to reschedule, e.g. \"2 tomorrow\"."),
+				event.Event.ID.String(),
+				event.Content.Body,
+				event.Event.Sender.String(),
+				event.Room.RoomID,
+			))
+
+			return
+		}
+
+		var err error
+
+		evt, err = eventFromDailyReminder(action.matrixDB, replyToMessage, match[1])
+		if err != nil {
+			action.logger.Info("failed to get event from daily reminder", "error", err)
+			_ = action.messenger.SendResponseAsync(messenger.PlainTextResponse(
+				i18n.Translatef(event.Room.Language, "Sorry, there is no event with the number %s in this message.", match[1]),
+				event.Event.ID.String(),
+				event.Content.Body,
+				event.Event.Sender.String(),
+				event.Room.RoomID,
+			))
+
+			return
+		}
+
+		body = match[2]
+	} else if replyToMessage.EventID == nil || evt == nil {
 		// No event given, can not update anything
 		action.logger.Debug("can not update event with event ID nil")
 		return
 	}
 
-	remindTime, err := format.ParseTime(event.Channel, event.Content.Body, event.Room.TimeZone, false)
+	remindTime, err := format.ParseTime(event.Channel, body, event.TimeZone(), event.Room.Language, false)
 	if err != nil {
 		action.logger.Error("failed to parse time", "error", err)
 		_ = action.messenger.SendResponseAsync(messenger.PlainTextResponse(
-			"Sorry I was not able to understand the remind date and time from this message.",
+			i18n.Translate(event.Room.Language, "Sorry I was not able to understand the remind date and time from this message."),
 			event.Event.ID.String(),
 			event.Content.Body,
 			event.Event.Sender.String(),
@@ -78,7 +112,7 @@ func (action *ChangeTimeAction) HandleEvent(event *matrix.MessageEvent, replyToM
 
 	message := mapping.MessageFromEvent(event)
 	message.Type = matrixdb.MessageTypeChangeEvent
-	message.EventID = replyToMessage.EventID
+	message.EventID = &evt.ID
 
 	_, err = action.matrixDB.NewMessage(message)
 	if err != nil {
@@ -86,19 +120,24 @@ func (action *ChangeTimeAction) HandleEvent(event *matrix.MessageEvent, replyToM
 		return
 	}
 
-	replyToMessage.Event.Time = remindTime
-	replyToMessage.Event.Active = true
+	err = evt.Reschedule(remindTime)
+	if err != nil {
+		action.logger.Error("failed to reschedule event", "error", err)
+		return
+	}
+
+	evt.Active = true
 
-	_, err = action.db.UpdateEvent(replyToMessage.Event)
+	_, err = action.db.UpdateEvent(evt)
 	if err != nil {
 		action.logger.Error("failed to update event in database", "error", err)
 		return
 	}
 
 	go action.storer.SendAndStoreResponse(
-		fmt.Sprintf("I rescheduled your reminder \"%s\" to %s.", replyToMessage.Event.Message, format.ToLocalTime(replyToMessage.Event.Time, event.Room.TimeZone)),
+		i18n.Translatef(event.Room.Language, "I rescheduled your reminder \"%s\" to %s.", evt.Message, format.ToLocalTime(evt.Time, event.TimeZone(), event.Clock())),
 		matrixdb.MessageTypeChangeEvent,
 		*event,
-		msghelper.WithEventID(replyToMessage.Event.ID),
+		msghelper.WithEventID(evt.ID),
 	)
 }
@@ -154,3 +154,126 @@ func TestChangeTimeAction_HandleEventWithNewMessageError(t *testing.T) {
 	// Execute
 	action.HandleEvent(event, tests.TestMessage())
 }
+
+func TestChangeTimeAction_HandleEventWithDailyReminder(t *testing.T) {
+	// Setup
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &reply.ChangeTimeAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		nil,
+	)
+
+	event := tests.TestEvent(
+		tests.MessageWithBody(
+			"2 tomorrow",
+			"2 tomorrow",
+		))
+	dailyReminder := tests.TestMessage(tests.WithoutEvent(), tests.WithMessageType(matrixdb.MessageTypeDailyReminder))
+
+	// Expectations
+	matrixDB.EXPECT().GetDigestEntry("msg1", uint(2)).Return(&matrixdb.MatrixDigestEntry{
+		MessageID: "msg1",
+		Position:  2,
+		EventID:   1,
+		Event:     *tests.TestMessage(tests.WithTestEvent()).Event,
+	}, nil)
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeChangeEvent, tests.MsgWithDBEventID(1))
+
+	db.EXPECT().UpdateEvent(mock.MatchedBy(func(evt *database.Event) bool {
+		return evt.ID == 1 && evt.Active && evt.Time.After(time.Now())
+	})).Return(nil, nil)
+
+	msngr.EXPECT().SendResponse(mock.Anything).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "abcde",
+	}, nil)
+	matrixDB.EXPECT().NewMessage(mock.Anything).Return(nil, nil)
+
+	// Execute
+	action.HandleEvent(event, dailyReminder)
+	// Wait for async message processing.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestChangeTimeAction_HandleEventWithDailyReminderWithoutNumber(t *testing.T) {
+	// Setup
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &reply.ChangeTimeAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		nil,
+	)
+
+	event := tests.TestEvent(
+		tests.MessageWithBody(
+			"tomorrow",
+			"tomorrow",
+		))
+	dailyReminder := tests.TestMessage(tests.WithoutEvent(), tests.WithMessageType(matrixdb.MessageTypeDailyReminder))
+
+	// Expectations
+	msngr.EXPECT().SendResponseAsync(messenger.PlainTextResponse(
+		"Please start your reply with the number of the event you want to reschedule, e.g. \"2 tomorrow\".",
+		"evt1",
+		"tomorrow",
+		"@user:example.com",
+		"!room123",
+	)).Return(nil)
+
+	// Execute
+	action.HandleEvent(event, dailyReminder)
+}
+
+func TestChangeTimeAction_HandleEventWithDailyReminderUnknownNumber(t *testing.T) {
+	// Setup
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &reply.ChangeTimeAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		nil,
+	)
+
+	event := tests.TestEvent(
+		tests.MessageWithBody(
+			"7 tomorrow",
+			"7 tomorrow",
+		))
+	dailyReminder := tests.TestMessage(tests.WithoutEvent(), tests.WithMessageType(matrixdb.MessageTypeDailyReminder))
+
+	// Expectations
+	matrixDB.EXPECT().GetDigestEntry("msg1", uint(7)).Return(nil, matrixdb.ErrNotFound)
+	msngr.EXPECT().SendResponseAsync(messenger.PlainTextResponse(
+		"Sorry, there is no event with the number 7 in this message.",
+		"evt1",
+		"7 tomorrow",
+		"@user:example.com",
+		"!room123",
+	)).Return(nil)
+
+	// Execute
+	action.HandleEvent(event, dailyReminder)
+}
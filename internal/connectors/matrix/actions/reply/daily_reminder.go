@@ -0,0 +1,27 @@
+package reply
+
+import (
+	"regexp"
+	"strconv"
+
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+// Replies to daily reminders start with the number of the entry they refer to, e.g. "2 tomorrow".
+var dailyReminderEntryRegex = regexp.MustCompile("^[ ]*([0-9]+)[ ]*(.*)$")
+
+// eventFromDailyReminder returns the event listed at the given position of a daily reminder message.
+func eventFromDailyReminder(matrixDB matrixdb.Service, dailyReminder *matrixdb.MatrixMessage, position string) (*database.Event, error) {
+	pos, err := strconv.ParseUint(position, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := matrixDB.GetDigestEntry(dailyReminder.ID, uint(pos))
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry.Event, nil
+}
@@ -6,13 +6,14 @@ import (
 
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
 	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 )
 
-var deleteEventActionRegex = regexp.MustCompile("(?i)^(delete|remove|cancel)[ ]*$")
+var deleteEventActionRegex = regexp.MustCompile("(?i)^(delete|remove|cancel|löschen|entfernen|absagen)[ ]*$")
 
 // DeleteEventAction deletes an event.
 type DeleteEventAction struct {
@@ -43,7 +44,7 @@ func (action *DeleteEventAction) Name() string {
 func (action *DeleteEventAction) GetDocu() (title, explaination string, examples []string) {
 	return "Delete Event",
 		"Delete an Event by replying to it",
-		[]string{"delete", "remove", "cancel"}
+		[]string{"delete", "remove", "cancel", "löschen"}
 }
 
 // Selector defines a regex on what messages the action should be used.
@@ -65,7 +66,7 @@ func (action *DeleteEventAction) HandleEvent(event *matrix.MessageEvent, replyTo
 		return
 	}
 
-	go action.storer.SendAndStoreResponse("Deleted event \""+replyToMessage.Event.Message+"\"", matrixdb.MessageTypeEventDelete, *event, msghelper.WithEventID(replyToMessage.Event.ID))
+	go action.storer.SendAndStoreResponse(i18n.Translatef(event.Room.Language, "Deleted event \"%s\"", replyToMessage.Event.Message), matrixdb.MessageTypeEventDelete, *event, msghelper.WithEventID(replyToMessage.Event.ID))
 
 	// Best effort approach to delete messages related to that event.
 	messages, err := action.matrixDB.ListMessages(matrixdb.ListMessageOpts{
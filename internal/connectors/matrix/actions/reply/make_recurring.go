@@ -17,7 +17,7 @@ import (
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 )
 
-var makeRecurringActionRegex = regexp.MustCompile("(?i)^(repeat|every|each|always|recurring|all|any).*(second|minute|hour|day|week|month|year)(|s)[ ]*$")
+var makeRecurringActionRegex = regexp.MustCompile("(?i)^(repeat|every|each|always|recurring|all|any).*(second|minute|hour|day|week|weekend|month|year|monday|tuesday|wednesday|thursday|friday|saturday|sunday|[0-9](st|nd|rd|th))(|s)[ ]*$")
 
 // MakeRecurringAction makes an event recurring.
 type MakeRecurringAction struct {
@@ -47,8 +47,8 @@ func (action *MakeRecurringAction) Name() string {
 // GetDocu returns the documentation for the action.
 func (action *MakeRecurringAction) GetDocu() (title, explaination string, examples []string) {
 	return "Make Event Recurring",
-		"Make an event recurring by replying with a duration.",
-		[]string{"every 10 days", "each twenty two hours and five seconds"}
+		"Make an event recurring by replying with a duration or a calendar based rule.",
+		[]string{"every 10 days", "each twenty two hours and five seconds", "every month on the 1st", "every last friday", "every weekday"}
 }
 
 // Selector defines a regex on what messages the action should be used.
@@ -64,9 +64,8 @@ func (action *MakeRecurringAction) HandleEvent(event *matrix.MessageEvent, reply
 		return
 	}
 
-	// Get duration from message
-	duration := gonaturalduration.ParseNumber(event.Content.Body)
-	if duration <= time.Minute {
+	repetition, ok := action.applyRepetition(event, replyToMessage.Event)
+	if !ok {
 		action.logger.Info("missing duration in message")
 		_ = action.messenger.SendResponseAsync(messenger.PlainTextResponse(
 			"Sorry I was not able to understand the duration from this message.",
@@ -89,10 +88,6 @@ func (action *MakeRecurringAction) HandleEvent(event *matrix.MessageEvent, reply
 		return
 	}
 
-	defaultRepeatUntil := time.Now().Add((5 * 365 * 24 * time.Hour))
-	replyToMessage.Event.RepeatInterval = &duration
-	replyToMessage.Event.RepeatUntil = &defaultRepeatUntil
-
 	dbEvent, err := action.db.UpdateEvent(replyToMessage.Event)
 	if err != nil {
 		action.logger.Error("failed to update event", "error", err)
@@ -108,9 +103,9 @@ func (action *MakeRecurringAction) HandleEvent(event *matrix.MessageEvent, reply
 	}
 
 	msg := fmt.Sprintf(
-		"Updated the event to remind you every %s until %s",
-		format.ToNiceDuration(duration),
-		format.ToLocalTime(*dbEvent.RepeatUntil, event.Room.TimeZone),
+		"Updated the event to remind you %s until %s",
+		repetition,
+		format.ToLocalTime(*dbEvent.RepeatUntil, event.TimeZone(), event.Clock()),
 	)
 	go action.storer.SendAndStoreResponse(
 		msg,
@@ -119,3 +114,36 @@ func (action *MakeRecurringAction) HandleEvent(event *matrix.MessageEvent, reply
 		msghelper.WithEventID(*replyToMessage.EventID),
 	)
 }
+
+// applyRepetition sets the repetition from the message on the event and returns a human readable description of it.
+// Calendar based rules are preferred as they do not drift over months or DST switches.
+func (action *MakeRecurringAction) applyRepetition(event *matrix.MessageEvent, evt *database.Event) (string, bool) {
+	defaultRepeatUntil := time.Now().Add((5 * 365 * 24 * time.Hour))
+	evt.RepeatUntil = &defaultRepeatUntil
+
+	rule, err := format.ParseRepeatRule(event.Content.Body, evt.Time, event.TimeZone())
+	if err == nil {
+		evt.RepeatRule = rule
+		evt.RepeatInterval = nil
+
+		// Move the event to the first occurrence matching the rule.
+		set, err := evt.RepeatRuleSet()
+		if err == nil {
+			if first := set.After(evt.Time, true); !first.IsZero() {
+				evt.Time = first
+			}
+		}
+
+		return format.ToNiceRepeatRule(rule), true
+	}
+
+	duration := gonaturalduration.ParseNumber(event.Content.Body)
+	if duration <= time.Minute {
+		return "", false
+	}
+
+	evt.RepeatInterval = &duration
+	evt.RepeatRule = ""
+
+	return "every " + format.ToNiceDuration(duration), true
+}
@@ -155,3 +155,58 @@ func TestMakeRecurringAction_HandleEventWithDurationError(t *testing.T) {
 	action.HandleEvent(event, tests.TestMessage(tests.WithFromTestEvent(), tests.WithTestEvent()))
 	time.Sleep(time.Millisecond * 10)
 }
+
+func TestMakeRecurringAction_HandleEventWithRepeatRule(t *testing.T) {
+	// Setup
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	client := mautrixcl.NewMockClient(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &reply.MakeRecurringAction{}
+	action.Configure(
+		slog.Default(),
+		client,
+		msngr,
+		matrixDB,
+		db,
+		nil,
+	)
+
+	msg := "every month on the 1st"
+	event := tests.TestEvent(
+		tests.MessageWithBody(
+			msg,
+			msg,
+		))
+
+	// Expectations
+	tests.ExpectNewMessageFromEvent(matrixDB, event, matrixdb.MessageTypeChangeEvent, tests.MsgWithDBEventID(1))
+
+	db.EXPECT().UpdateEvent(mock.MatchedBy(func(evt *database.Event) bool {
+		return evt.RepeatRule == "DTSTART:19700102T014728Z\nRRULE:FREQ=MONTHLY;BYMONTHDAY=1" &&
+			evt.RepeatInterval == nil &&
+			evt.RepeatUntil != nil &&
+			evt.Time.Equal(time.Date(1970, 2, 1, 1, 47, 28, 0, time.UTC))
+	})).
+		Return(&database.Event{
+			RepeatUntil: new(time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)),
+		}, nil)
+
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		"Updated the event to remind you every month on the 1st until 10:00 01.01.2030 (UTC)",
+		"evt1",
+		msg,
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{}, nil)
+	matrixDB.EXPECT().NewMessage(mock.Anything).Return(nil, nil)
+
+	// Execute
+	action.HandleEvent(event, tests.TestMessage(
+		tests.WithFromTestEvent(),
+		tests.WithTestEvent(),
+		tests.WithRecurringEvent(time.Hour),
+	))
+	time.Sleep(time.Millisecond * 10)
+}
@@ -0,0 +1,116 @@
+package reply
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/msghelper"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+var markDoneActionRegex = regexp.MustCompile("(?i)^[ ]*(done|finished|complete|completed|erledigt|fertig)([ ]+[0-9]+)?[ ]*$")
+
+// MarkDoneAction marks an event as done.
+type MarkDoneAction struct {
+	logger    *slog.Logger
+	client    mautrixcl.Client
+	messenger messenger.Messenger
+	matrixDB  matrixdb.Service
+	db        database.Service
+	storer    *msghelper.Storer
+}
+
+// Configure is called on startup and sets all dependencies.
+func (action *MarkDoneAction) Configure(logger *slog.Logger, client mautrixcl.Client, messenger messenger.Messenger, matrixDB matrixdb.Service, db database.Service, _ *matrix.BridgeServices) {
+	action.logger = logger
+	action.client = client
+	action.matrixDB = matrixDB
+	action.db = db
+	action.messenger = messenger
+	action.storer = msghelper.NewStorer(matrixDB, messenger, logger)
+}
+
+// Name of the action
+func (action *MarkDoneAction) Name() string {
+	return "Mark Event Done"
+}
+
+// GetDocu returns the documentation for the action.
+func (action *MarkDoneAction) GetDocu() (title, explaination string, examples []string) {
+	return "Mark Event Done",
+		"Mark an event as done by replying to it. When replying to a daily reminder add the number of the event.",
+		[]string{"done", "finished", "done 2", "erledigt 2"}
+}
+
+// Selector defines a regex on what messages the action should be used.
+func (action *MarkDoneAction) Selector() *regexp.Regexp {
+	return markDoneActionRegex
+}
+
+// HandleEvent is where the message event get's send to if it matches the Selector.
+func (action *MarkDoneAction) HandleEvent(event *matrix.MessageEvent, replyToMessage *matrixdb.MatrixMessage) {
+	evt := replyToMessage.Event
+
+	if replyToMessage.Type == matrixdb.MessageTypeDailyReminder {
+		position := ""
+		if match := markDoneActionRegex.FindStringSubmatch(event.Content.Body); match != nil {
+			position = strings.TrimSpace(match[2])
+		}
+
+		if position == "" {
+			_ = action.messenger.SendResponseAsync(messenger.PlainTextResponse(
+				i18n.Translate(event.Room.Language, "Please add the number of the event you completed, e.g. \"done 2\"."),
+				event.Event.ID.String(),
+				event.Content.Body,
+				event.Event.Sender.String(),
+				event.Room.RoomID,
+			))
+
+			return
+		}
+
+		var err error
+
+		evt, err = eventFromDailyReminder(action.matrixDB, replyToMessage, position)
+		if err != nil {
+			action.logger.Info("failed to get event from daily reminder", "error", err)
+			_ = action.messenger.SendResponseAsync(messenger.PlainTextResponse(
+				i18n.Translatef(event.Room.Language, "Sorry, there is no event with the number %s in this message.", position),
+				event.Event.ID.String(),
+				event.Content.Body,
+				event.Event.Sender.String(),
+				event.Room.RoomID,
+			))
+
+			return
+		}
+	} else if replyToMessage.EventID == nil || evt == nil {
+		// No event given, can not update anything
+		action.logger.Debug("can not mark event with event ID nil as done")
+		return
+	}
+
+	// Recurring events continue with their next occurrence.
+	if !evt.IsRecurring() && evt.RepeatUntil == nil {
+		evt.Active = false
+
+		_, err := action.db.UpdateEvent(evt)
+		if err != nil {
+			action.logger.Error("failed to update event in database", "error", err)
+			return
+		}
+	}
+
+	go action.storer.SendAndStoreResponse(
+		i18n.Translatef(event.Room.Language, "Marked event \"%s\" as done.", evt.Message),
+		matrixdb.MessageTypeEventDone,
+		*event,
+		msghelper.WithEventID(evt.ID),
+	)
+}
@@ -0,0 +1,193 @@
+package reply_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/actions/reply"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/tests"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testMarkDoneAction(t *testing.T) (*reply.MarkDoneAction, *database.MockService, *matrixdb.MockService, *messenger.MockMessenger) {
+	t.Helper()
+
+	db := database.NewMockService(t)
+	matrixDB := matrixdb.NewMockService(t)
+	msngr := messenger.NewMockMessenger(t)
+
+	action := &reply.MarkDoneAction{}
+	action.Configure(
+		slog.Default(),
+		mautrixcl.NewMockClient(t),
+		msngr,
+		matrixDB,
+		db,
+		nil,
+	)
+
+	return action, db, matrixDB, msngr
+}
+
+func TestMarkDoneAction(t *testing.T) {
+	action := &reply.MarkDoneAction{}
+
+	assert.NotEmpty(t, action.Name())
+
+	title, desc, examples := action.GetDocu()
+	assert.NotEmpty(t, title)
+	assert.NotEmpty(t, desc)
+	assert.NotEmpty(t, examples)
+
+	assert.NotNil(t, action.Selector())
+}
+
+func TestMarkDoneAction_Selector(t *testing.T) {
+	action := &reply.MarkDoneAction{}
+	r := action.Selector()
+
+	_, _, examples := action.GetDocu()
+	for _, example := range examples {
+		assert.True(t, r.MatchString(example))
+	}
+
+	assert.False(t, r.MatchString("done tomorrow"))
+	assert.False(t, r.MatchString("2 tomorrow"))
+}
+
+func TestMarkDoneAction_HandleEvent(t *testing.T) {
+	action, db, matrixDB, msngr := testMarkDoneAction(t)
+
+	event := tests.TestEvent(tests.MessageWithBody("done", "done"))
+
+	// Expectations
+	db.EXPECT().UpdateEvent(mock.MatchedBy(func(evt *database.Event) bool {
+		return evt.ID == 1 && !evt.Active
+	})).Return(nil, nil)
+	msngr.EXPECT().SendResponse(messenger.PlainTextResponse(
+		"Marked event \"test event\" as done.",
+		"evt1",
+		"done",
+		"@user:example.com",
+		"!room123",
+	)).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "abcde",
+	}, nil)
+	matrixDB.EXPECT().NewMessage(mock.Anything).Return(nil, nil)
+
+	// Execute
+	action.HandleEvent(event, tests.TestMessage())
+	// Wait for async message processing.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestMarkDoneAction_HandleEventWithRecurringEvent(t *testing.T) {
+	action, _, matrixDB, msngr := testMarkDoneAction(t)
+
+	event := tests.TestEvent(tests.MessageWithBody("done", "done"))
+
+	// Expectations
+	msngr.EXPECT().SendResponse(mock.Anything).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "abcde",
+	}, nil)
+	matrixDB.EXPECT().NewMessage(mock.Anything).Return(nil, nil)
+
+	// Execute
+	action.HandleEvent(event, tests.TestMessage(tests.WithRecurringEvent(time.Hour)))
+	// Wait for async message processing.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestMarkDoneAction_HandleEventWithDailyReminder(t *testing.T) {
+	action, db, matrixDB, msngr := testMarkDoneAction(t)
+
+	event := tests.TestEvent(tests.MessageWithBody("done 2", "done 2"))
+	dailyReminder := tests.TestMessage(tests.WithoutEvent(), tests.WithMessageType(matrixdb.MessageTypeDailyReminder))
+
+	// Expectations
+	matrixDB.EXPECT().GetDigestEntry("msg1", uint(2)).Return(&matrixdb.MatrixDigestEntry{
+		MessageID: "msg1",
+		Position:  2,
+		EventID:   1,
+		Event:     *tests.TestMessage().Event,
+	}, nil)
+	db.EXPECT().UpdateEvent(mock.MatchedBy(func(evt *database.Event) bool {
+		return evt.ID == 1 && !evt.Active
+	})).Return(nil, nil)
+	msngr.EXPECT().SendResponse(mock.Anything).Return(&messenger.MessageResponse{
+		ExternalIdentifier: "abcde",
+	}, nil)
+	matrixDB.EXPECT().NewMessage(mock.Anything).Return(nil, nil)
+
+	// Execute
+	action.HandleEvent(event, dailyReminder)
+	// Wait for async message processing.
+	time.Sleep(time.Millisecond * 10)
+}
+
+func TestMarkDoneAction_HandleEventWithDailyReminderWithoutNumber(t *testing.T) {
+	action, _, _, msngr := testMarkDoneAction(t)
+
+	event := tests.TestEvent(tests.MessageWithBody("done", "done"))
+	dailyReminder := tests.TestMessage(tests.WithoutEvent(), tests.WithMessageType(matrixdb.MessageTypeDailyReminder))
+
+	// Expectations
+	msngr.EXPECT().SendResponseAsync(messenger.PlainTextResponse(
+		"Please add the number of the event you completed, e.g. \"done 2\".",
+		"evt1",
+		"done",
+		"@user:example.com",
+		"!room123",
+	)).Return(nil)
+
+	// Execute
+	action.HandleEvent(event, dailyReminder)
+}
+
+func TestMarkDoneAction_HandleEventWithDailyReminderUnknownNumber(t *testing.T) {
+	action, _, matrixDB, msngr := testMarkDoneAction(t)
+
+	event := tests.TestEvent(tests.MessageWithBody("done 9", "done 9"))
+	dailyReminder := tests.TestMessage(tests.WithoutEvent(), tests.WithMessageType(matrixdb.MessageTypeDailyReminder))
+
+	// Expectations
+	matrixDB.EXPECT().GetDigestEntry("msg1", uint(9)).Return(nil, matrixdb.ErrNotFound)
+	msngr.EXPECT().SendResponseAsync(messenger.PlainTextResponse(
+		"Sorry, there is no event with the number 9 in this message.",
+		"evt1",
+		"done 9",
+		"@user:example.com",
+		"!room123",
+	)).Return(nil)
+
+	// Execute
+	action.HandleEvent(event, dailyReminder)
+}
+
+func TestMarkDoneAction_HandleEventWithoutEvent(t *testing.T) {
+	action, _, _, _ := testMarkDoneAction(t)
+
+	event := tests.TestEvent(tests.MessageWithBody("done", "done"))
+
+	// Execute
+	action.HandleEvent(event, tests.TestMessage(tests.WithoutEvent()))
+}
+
+func TestMarkDoneAction_HandleEventWithUpdateError(t *testing.T) {
+	action, db, _, _ := testMarkDoneAction(t)
+
+	event := tests.TestEvent(tests.MessageWithBody("done", "done"))
+
+	// Expectations
+	db.EXPECT().UpdateEvent(mock.Anything).Return(nil, errors.New("test"))
+
+	// Execute
+	action.HandleEvent(event, tests.TestMessage())
+}
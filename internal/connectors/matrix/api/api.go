@@ -20,6 +20,10 @@ type Config struct {
 	MatrixDB matrixdb.Service
 
 	DefaultAuthProvider gin.HandlerFunc
+
+	// AppService receives transactions from the homeserver, nil if not running as application service.
+	AppService AppService
+	HSToken    string
 }
 
 // New assembles a new API.
@@ -38,5 +42,12 @@ func (api *api) RegisterRoutes(r *gin.Engine) error {
 	channels.GET("/:id/inputs/rooms", apictx.RequireIDInURI(), api.listInputRoomsHandler)
 	channels.GET("/:id/outputs/rooms", apictx.RequireIDInURI(), api.listOutputRoomsHandler)
 
+	if api.config.AppService != nil {
+		appService := r.Group("/_matrix/app/v1")
+		appService.Use(api.hsTokenAuth)
+		appService.PUT("/transactions/:txnID", api.transactionHandler)
+		appService.POST("/ping", api.pingHandler)
+	}
+
 	return nil
 }
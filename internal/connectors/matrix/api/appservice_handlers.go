@@ -0,0 +1,68 @@
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"maunium.net/go/mautrix/event"
+)
+
+// matrixError is the error format of the matrix specification.
+type matrixError struct {
+	Code    string `json:"errcode"`
+	Message string `json:"error"`
+}
+
+type transactionRequest struct {
+	Events []*event.Event `json:"events"`
+}
+
+// hsTokenAuth authenticates the homeserver, errors follow the matrix specification.
+func (api *api) hsTokenAuth(ctx *gin.Context) {
+	token := strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+	if token == "" {
+		token = ctx.Query("access_token")
+	}
+
+	if token == "" {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, matrixError{
+			Code:    "M_UNAUTHORIZED",
+			Message: "missing token",
+		})
+
+		return
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(api.config.HSToken)) != 1 {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, matrixError{
+			Code:    "M_FORBIDDEN",
+			Message: "invalid token",
+		})
+
+		return
+	}
+}
+
+func (api *api) transactionHandler(ctx *gin.Context) {
+	var request transactionRequest
+
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, matrixError{
+			Code:    "M_NOT_JSON",
+			Message: "invalid transaction",
+		})
+
+		return
+	}
+
+	api.config.AppService.HandleTransaction(ctx.Param("txnID"), request.Events)
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+func (api *api) pingHandler(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,135 @@
+package api_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/api"
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+func testAppServiceServer(t *testing.T) (*api.MockAppService, *httptest.Server) {
+	t.Helper()
+
+	appService := api.NewMockAppService(t)
+
+	api := api.New(&api.Config{
+		Database:            database.NewMockService(t),
+		MatrixDB:            matrixdb.NewMockService(t),
+		DefaultAuthProvider: func(_ *gin.Context) {},
+		AppService:          appService,
+		HSToken:             "hstoken",
+	}, slog.Default())
+
+	r := gin.New()
+
+	err := api.RegisterRoutes(r)
+	if err != nil {
+		panic(err)
+	}
+
+	return appService, httptest.NewServer(r)
+}
+
+func doAppServiceRequest(t *testing.T, method, url, token, body string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), method, url, strings.NewReader(body))
+	require.NoError(t, err)
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(respBody)
+}
+
+func TestAPI_TransactionHandler(t *testing.T) {
+	appService, server := testAppServiceServer(t)
+
+	appService.EXPECT().HandleTransaction("txn1", mock.Anything).Run(func(_ string, events []*event.Event) {
+		require.Len(t, events, 1)
+		assert.Equal(t, id.EventID("$evt1"), events[0].ID)
+		assert.Equal(t, id.RoomID("!room:example.com"), events[0].RoomID)
+		assert.Equal(t, event.EventMessage.Type, events[0].Type.Type)
+	})
+
+	status, body := doAppServiceRequest(t, http.MethodPut, server.URL+"/_matrix/app/v1/transactions/txn1", "hstoken",
+		`{"events":[{"event_id":"$evt1","room_id":"!room:example.com","sender":"@user:example.com","type":"m.room.message","origin_server_ts":1000,"content":{"msgtype":"m.text","body":"hello"}}]}`)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{}`, body)
+}
+
+func TestAPI_TransactionHandlerWithTokenInQuery(t *testing.T) {
+	appService, server := testAppServiceServer(t)
+
+	appService.EXPECT().HandleTransaction("txn1", mock.Anything)
+
+	status, _ := doAppServiceRequest(t, http.MethodPut, server.URL+"/_matrix/app/v1/transactions/txn1?access_token=hstoken", "", `{"events":[]}`)
+
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestAPI_TransactionHandlerWithInvalidBody(t *testing.T) {
+	_, server := testAppServiceServer(t)
+
+	status, body := doAppServiceRequest(t, http.MethodPut, server.URL+"/_matrix/app/v1/transactions/txn1", "hstoken", `{"events":`)
+
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.JSONEq(t, `{"errcode":"M_NOT_JSON","error":"invalid transaction"}`, body)
+}
+
+func TestAPI_TransactionHandlerWithWrongToken(t *testing.T) {
+	_, server := testAppServiceServer(t)
+
+	status, body := doAppServiceRequest(t, http.MethodPut, server.URL+"/_matrix/app/v1/transactions/txn1", "wrong", `{"events":[]}`)
+
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.JSONEq(t, `{"errcode":"M_FORBIDDEN","error":"invalid token"}`, body)
+}
+
+func TestAPI_TransactionHandlerWithoutToken(t *testing.T) {
+	_, server := testAppServiceServer(t)
+
+	status, body := doAppServiceRequest(t, http.MethodPut, server.URL+"/_matrix/app/v1/transactions/txn1", "", `{"events":[]}`)
+
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.JSONEq(t, `{"errcode":"M_UNAUTHORIZED","error":"missing token"}`, body)
+}
+
+func TestAPI_PingHandler(t *testing.T) {
+	_, server := testAppServiceServer(t)
+
+	status, body := doAppServiceRequest(t, http.MethodPost, server.URL+"/_matrix/app/v1/ping", "hstoken", `{}`)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{}`, body)
+}
+
+func TestAPI_TransactionHandlerWithoutAppService(t *testing.T) {
+	_, _, server := testServer(t)
+
+	status, _ := doAppServiceRequest(t, http.MethodPut, server.URL+"/_matrix/app/v1/transactions/txn1", "hstoken", `{"events":[]}`)
+
+	assert.Equal(t, http.StatusNotFound, status)
+}
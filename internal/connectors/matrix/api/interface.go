@@ -1,8 +1,16 @@
 package api
 
-import "github.com/gin-gonic/gin"
+import (
+	"github.com/gin-gonic/gin"
+	"maunium.net/go/mautrix/event"
+)
 
 // API provides an interface for the matrix connector API.
 type API interface {
 	RegisterRoutes(*gin.Engine) error
 }
+
+// AppService handles the events a homeserver pushes to an application service.
+type AppService interface {
+	HandleTransaction(txnID string, events []*event.Event)
+}
@@ -0,0 +1,128 @@
+package matrix
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/event"
+)
+
+// Amount of handled transaction IDs remembered to ignore retries from the homeserver.
+const rememberedTransactions = 100
+
+// AppServiceConfig configures the bot to run as a matrix application service.
+type AppServiceConfig struct {
+	ID      string
+	ASToken string
+	HSToken string
+	// URL the homeserver pushes transactions to.
+	URL string
+}
+
+// Registration is the application service registration file for the homeserver.
+type Registration struct {
+	ID              string                 `yaml:"id"`
+	URL             string                 `yaml:"url"`
+	ASToken         string                 `yaml:"as_token"`
+	HSToken         string                 `yaml:"hs_token"`
+	SenderLocalpart string                 `yaml:"sender_localpart"`
+	RateLimited     bool                   `yaml:"rate_limited"`
+	Namespaces      RegistrationNamespaces `yaml:"namespaces"`
+}
+
+// RegistrationNamespaces lists the namespaces the application service is interested in.
+type RegistrationNamespaces struct {
+	Users   []RegistrationNamespace `yaml:"users"`
+	Aliases []RegistrationNamespace `yaml:"aliases"`
+	Rooms   []RegistrationNamespace `yaml:"rooms"`
+}
+
+// RegistrationNamespace is a single namespace of the registration.
+type RegistrationNamespace struct {
+	Exclusive bool   `yaml:"exclusive"`
+	Regex     string `yaml:"regex"`
+}
+
+// NewRegistration assembles the registration for the bot user.
+func NewRegistration(config *Config) *Registration {
+	botname := format.FullUsername(config.Username, config.Homeserver)
+	localpart, _, _ := strings.Cut(strings.TrimPrefix(botname, "@"), ":")
+
+	return &Registration{
+		ID:              config.AppService.ID,
+		URL:             config.AppService.URL,
+		ASToken:         config.AppService.ASToken,
+		HSToken:         config.AppService.HSToken,
+		SenderLocalpart: localpart,
+		Namespaces: RegistrationNamespaces{
+			Users: []RegistrationNamespace{
+				{
+					Exclusive: true,
+					Regex:     "^" + regexp.QuoteMeta(botname) + "$",
+				},
+			},
+			Aliases: []RegistrationNamespace{},
+			Rooms:   []RegistrationNamespace{},
+		},
+	}
+}
+
+type appServiceState struct {
+	transactionsMutex sync.Mutex
+	transactions      []string
+}
+
+// HandleTransaction processes the events the homeserver pushes to an application service.
+func (service *service) HandleTransaction(txnID string, events []*event.Event) {
+	if !service.appService.markTransaction(txnID) {
+		service.logger.Debug("ignoring transaction", "reason", "already handled", "matrix.transaction.id", txnID)
+		return
+	}
+
+	handlers := service.eventHandlers()
+
+	for _, evt := range events {
+		source := mautrix.EventSourceJoin | mautrix.EventSourceTimeline
+		evt.Type.Class = event.MessageEventType
+
+		if evt.StateKey != nil {
+			source = mautrix.EventSourceJoin | mautrix.EventSourceState
+			evt.Type.Class = event.StateEventType
+		}
+
+		handler, ok := handlers[evt.Type]
+		if !ok {
+			continue
+		}
+
+		err := evt.Content.ParseRaw(evt.Type)
+		if err != nil && !errors.Is(err, event.ErrContentAlreadyParsed) {
+			service.logger.Info("ignoring event", "reason", "invalid content", "matrix.event.id", evt.ID, "error", err)
+			continue
+		}
+
+		handler(source, evt)
+	}
+}
+
+// markTransaction returns false if the transaction was handled already.
+func (state *appServiceState) markTransaction(txnID string) bool {
+	state.transactionsMutex.Lock()
+	defer state.transactionsMutex.Unlock()
+
+	if slices.Contains(state.transactions, txnID) {
+		return false
+	}
+
+	state.transactions = append(state.transactions, txnID)
+	if len(state.transactions) > rememberedTransactions {
+		state.transactions = state.transactions[1:]
+	}
+
+	return true
+}
@@ -0,0 +1,84 @@
+package matrix
+
+import (
+	"encoding/json"
+	"testing"
+
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maunium.net/go/mautrix/event"
+)
+
+func testTransactionEvents(t *testing.T, raw string) []*event.Event {
+	t.Helper()
+
+	events := []*event.Event{}
+	require.NoError(t, json.Unmarshal([]byte(raw), &events))
+
+	return events
+}
+
+func TestService_HandleTransaction(t *testing.T) {
+	service, fx := testService(t)
+	service.appService = &appServiceState{}
+
+	fx.matrixDB.EXPECT().GetEventByID("123").Return(nil, matrixdb.ErrNotFound).Once()
+	fx.matrixDB.EXPECT().GetRoomCount().Return(int64(0), nil).Once()
+	fx.matrixDB.EXPECT().GetUserByID("@user:example.com").Return(nil, matrixdb.ErrNotFound).Once()
+	fx.matrixDB.EXPECT().GetRoomByRoomID("!abc:example.com").Return(nil, assert.AnError).Once()
+
+	events := `[
+		{"event_id":"123","room_id":"!abc:example.com","sender":"@user:example.com","type":"m.room.member","state_key":"@bot:example.com","origin_server_ts":5000,"content":{"membership":"join"}},
+		{"event_id":"456","room_id":"!abc:example.com","sender":"@user:example.com","type":"m.typing","origin_server_ts":5000,"content":{}}
+	]`
+
+	service.HandleTransaction("txn1", testTransactionEvents(t, events))
+
+	// Retries of the homeserver are ignored.
+	service.HandleTransaction("txn1", testTransactionEvents(t, events))
+}
+
+func TestAppServiceState_MarkTransaction(t *testing.T) {
+	state := &appServiceState{}
+
+	assert.True(t, state.markTransaction("txn1"))
+	assert.False(t, state.markTransaction("txn1"))
+
+	for i := range rememberedTransactions {
+		assert.True(t, state.markTransaction(string(rune('a'+i))))
+	}
+
+	assert.True(t, state.markTransaction("txn1"))
+}
+
+func TestNewRegistration(t *testing.T) {
+	registration := NewRegistration(&Config{
+		Username:   "bot",
+		Homeserver: "https://matrix.example.com/",
+		AppService: &AppServiceConfig{
+			ID:      "remindme",
+			ASToken: "astoken",
+			HSToken: "hstoken",
+			URL:     "https://remindme.example.com",
+		},
+	})
+
+	assert.Equal(t, &Registration{
+		ID:              "remindme",
+		URL:             "https://remindme.example.com",
+		ASToken:         "astoken",
+		HSToken:         "hstoken",
+		SenderLocalpart: "bot",
+		Namespaces: RegistrationNamespaces{
+			Users: []RegistrationNamespace{
+				{
+					Exclusive: true,
+					Regex:     `^@bot:matrix\.example\.com$`,
+				},
+			},
+			Aliases: []RegistrationNamespace{},
+			Rooms:   []RegistrationNamespace{},
+		},
+	}, registration)
+}
@@ -28,6 +28,7 @@ type Service interface {
 
 	GetUserByID(userID string) (*MatrixUser, error)
 	NewUser(user *MatrixUser) (*MatrixUser, error)
+	UpdateUser(user *MatrixUser) (*MatrixUser, error)
 	RemoveDanglingUsers() (int64, error)
 
 	GetLastMessage() (*MatrixMessage, error)
@@ -42,6 +43,12 @@ type Service interface {
 	NewEvent(event *MatrixEvent) (*MatrixEvent, error)
 	DeleteAllEventsFromRoom(roomID uint) error
 
+	NewDigestEntries(entries []MatrixDigestEntry) error
+	GetDigestEntry(messageID string, position uint) (*MatrixDigestEntry, error)
+
+	GetSession(userID string) (*MatrixSession, error)
+	SaveSession(session *MatrixSession) (*MatrixSession, error)
+
 	Cleanup() error
 }
 
@@ -52,6 +59,9 @@ type MatrixRoom struct {
 	RoomID   string       `gorm:"unique"`
 	Users    []MatrixUser `gorm:"many2many:matrix_rooms_matrix_users;"`
 	TimeZone string
+	Language string `gorm:"size:10"`
+	// Encrypted is set once the room enabled end to end encryption.
+	Encrypted bool
 	// TODO somehow get roles back
 }
 
@@ -60,6 +70,9 @@ type MatrixUser struct {
 	ID      string       `gorm:"primary,size:255"`
 	Rooms   []MatrixRoom `gorm:"many2many:matrix_rooms_matrix_users;"`
 	Blocked bool
+	// Optional preferences overriding the ones of the room.
+	TimeZone string `gorm:"size:255"`
+	Clock    string `gorm:"size:10"`
 }
 
 type MatrixMessageType string
@@ -69,6 +82,7 @@ var (
 	MessageTypeListCommands                = MatrixMessageType("LIST_COMMANDS")
 	MessageTypeNewEvent                    = MatrixMessageType("EVENT_NEW")
 	MessageTypeEvent                       = MatrixMessageType("EVENT")
+	MessageTypeEventPreNotification        = MatrixMessageType("EVENT_PRE_NOTIFICATION")
 	MessageTypeEventDelete                 = MatrixMessageType("EVENT_DELETE")
 	MessageTypeAddUser                     = MatrixMessageType("USER_ADD")
 	MessageTypeChangeEvent                 = MatrixMessageType("EVENT_CHANGE")
@@ -77,11 +91,30 @@ var (
 	MessageTypeIcalRegenToken              = MatrixMessageType("ICAL_REGEN")
 	MessageTypeEventList                   = MatrixMessageType("EVENT_LIST")
 	MessageTypeTimezoneChange              = MatrixMessageType("TIMEZONE_CHANGE")
+	MessageTypeLanguageChange              = MatrixMessageType("LANGUAGE_CHANGE")
+	MessageTypeUserPreferencesChange       = MatrixMessageType("USER_PREFERENCES_CHANGE")
 	MessageTypeSetDailyReminderError       = MatrixMessageType("SET_DAILY_REMINDER_ERROR")
 	MessageTypeSetDailyReminder            = MatrixMessageType("SET_DAILY_REMINDER")
 	MessageTypeSetDefaultReminderTime      = MatrixMessageType("SET_DEFAULT_REMINDER_TIME")
 	MessageTypeSetDefaultReminderTimeError = MatrixMessageType("SET_DEFAULT_REMINDER_TIME_ERROR")
 	MessageTypeDailyReminder               = MatrixMessageType("DAILY_REMINDER")
+	MessageTypeIcalInputAdd                = MatrixMessageType("ICAL_INPUT_ADD")
+	MessageTypeIcalInputList               = MatrixMessageType("ICAL_INPUT_LIST")
+	MessageTypeIcalInputRemove             = MatrixMessageType("ICAL_INPUT_REMOVE")
+	MessageTypeSetPreNotifications         = MatrixMessageType("PRE_NOTIFICATIONS_SET")
+	MessageTypeAddPreNotifications         = MatrixMessageType("PRE_NOTIFICATIONS_ADD")
+	MessageTypeSetQuietHours               = MatrixMessageType("QUIET_HOURS_SET")
+	MessageTypeSetEscalation               = MatrixMessageType("ESCALATION_SET")
+	MessageTypeSetDigest                   = MatrixMessageType("DIGEST_SET")
+	MessageTypeWebhookOutputAdd            = MatrixMessageType("WEBHOOK_OUTPUT_ADD")
+	MessageTypeWebhookOutputList           = MatrixMessageType("WEBHOOK_OUTPUT_LIST")
+	MessageTypeWebhookOutputRemove         = MatrixMessageType("WEBHOOK_OUTPUT_REMOVE")
+	MessageTypeWebhookInputAdd             = MatrixMessageType("WEBHOOK_INPUT_ADD")
+	MessageTypeWebhookInputRemove          = MatrixMessageType("WEBHOOK_INPUT_REMOVE")
+	MessageTypeEmailOutputAdd              = MatrixMessageType("EMAIL_OUTPUT_ADD")
+	MessageTypeEmailOutputList             = MatrixMessageType("EMAIL_OUTPUT_LIST")
+	MessageTypeEmailOutputRemove           = MatrixMessageType("EMAIL_OUTPUT_REMOVE")
+	MessageTypeEventDone                   = MatrixMessageType("EVENT_DONE")
 )
 
 // MatrixMessage holds information about a matrix message.
@@ -113,6 +146,24 @@ type MatrixEvent struct {
 	SendAt time.Time
 }
 
+// MatrixDigestEntry maps the numbered entries of a message listing multiple events to their events, e.g. a daily
+// reminder or a request adding a list of reminders.
+type MatrixDigestEntry struct {
+	MessageID string        `gorm:"primaryKey;size:255"`
+	Message   MatrixMessage `gorm:"constraint:OnDelete:CASCADE;"`
+	Position  uint          `gorm:"primaryKey;autoIncrement:false"`
+	EventID   uint
+	Event     database.Event `gorm:"constraint:OnDelete:CASCADE;"`
+}
+
+// MatrixSession holds the access token of a logged in matrix user, so it can be reused on restarts.
+type MatrixSession struct {
+	UserID      string `gorm:"primaryKey;size:255"`
+	DeviceID    string `gorm:"size:255"`
+	AccessToken string `gorm:"size:255"`
+	UpdatedAt   time.Time
+}
+
 // Timezone returns the timezone of the channel.
 func (room *MatrixRoom) Timezone() *time.Location {
 	if room.TimeZone == "" {
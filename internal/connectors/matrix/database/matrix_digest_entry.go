@@ -0,0 +1,36 @@
+package database
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+func (service *service) NewDigestEntries(entries []MatrixDigestEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	return service.db.Omit("Message", "Event").Create(entries).Error
+}
+
+func (service *service) GetDigestEntry(messageID string, position uint) (*MatrixDigestEntry, error) {
+	var entry MatrixDigestEntry
+
+	err := service.db.Preload("Event").
+		First(&entry, "matrix_digest_entries.message_id = ? AND matrix_digest_entries.position = ?", messageID, position).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	if entry.Event.ID == 0 {
+		// The event got deleted in the meantime.
+		return nil, ErrNotFound
+	}
+
+	return &entry, nil
+}
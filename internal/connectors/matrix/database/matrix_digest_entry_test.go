@@ -0,0 +1,80 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDigestEvent(t *testing.T) *database.Event {
+	t.Helper()
+
+	c := database.Channel{}
+	require.NoError(t, gormDB.Save(&c).Error)
+
+	evt := &database.Event{
+		Channel: c,
+		Time:    time.Now(),
+		Message: "digest event",
+	}
+	require.NoError(t, gormDB.Save(evt).Error)
+
+	return evt
+}
+
+func TestService_GetDigestEntry(t *testing.T) {
+	evt1 := testDigestEvent(t)
+	evt2 := testDigestEvent(t)
+
+	message := testMessage()
+	message.Type = matrixdb.MessageTypeDailyReminder
+	message, err := service.NewMessage(message)
+	require.NoError(t, err)
+
+	err = service.NewDigestEntries([]matrixdb.MatrixDigestEntry{
+		{MessageID: message.ID, Position: 1, EventID: evt1.ID},
+		{MessageID: message.ID, Position: 2, EventID: evt2.ID},
+	})
+	require.NoError(t, err)
+
+	entry, err := service.GetDigestEntry(message.ID, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, evt2.ID, entry.EventID)
+	assert.Equal(t, evt2.ID, entry.Event.ID)
+	assert.Equal(t, "digest event", entry.Event.Message)
+}
+
+func TestService_GetDigestEntryWithNotFound(t *testing.T) {
+	message, err := service.NewMessage(testMessage())
+	require.NoError(t, err)
+
+	_, err = service.GetDigestEntry(message.ID, 1)
+	require.ErrorIs(t, err, matrixdb.ErrNotFound)
+}
+
+func TestService_NewDigestEntriesWithoutEntries(t *testing.T) {
+	require.NoError(t, service.NewDigestEntries(nil))
+}
+
+func TestService_DeleteAllMessagesFromRoomWithDigestEntries(t *testing.T) {
+	evt := testDigestEvent(t)
+
+	message, err := service.NewMessage(testMessage())
+	require.NoError(t, err)
+
+	err = service.NewDigestEntries([]matrixdb.MatrixDigestEntry{
+		{MessageID: message.ID, Position: 1, EventID: evt.ID},
+	})
+	require.NoError(t, err)
+
+	err = service.DeleteAllMessagesFromRoom(message.RoomID)
+	require.NoError(t, err)
+
+	_, err = service.GetDigestEntry(message.ID, 1)
+	require.ErrorIs(t, err, matrixdb.ErrNotFound)
+}
@@ -0,0 +1,28 @@
+package database
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+func (service *service) GetSession(userID string) (*MatrixSession, error) {
+	var session MatrixSession
+
+	err := service.db.First(&session, "matrix_sessions.user_id = ?", userID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (service *service) SaveSession(session *MatrixSession) (*MatrixSession, error) {
+	err := service.db.Save(session).Error
+
+	return session, err
+}
@@ -0,0 +1,49 @@
+package database_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSession() *matrixdb.MatrixSession {
+	return &matrixdb.MatrixSession{
+		UserID:      fmt.Sprintf("@bot%d:example.com", rand.Int()), //nolint:gosec
+		DeviceID:    "DEVICE",
+		AccessToken: "token1",
+	}
+}
+
+func TestService_GetSession(t *testing.T) {
+	sessionBefore, err := service.SaveSession(testSession())
+	require.NoError(t, err)
+
+	sessionAfter, err := service.GetSession(sessionBefore.UserID)
+	require.NoError(t, err)
+
+	assert.Equal(t, sessionBefore.UserID, sessionAfter.UserID)
+	assert.Equal(t, "DEVICE", sessionAfter.DeviceID)
+	assert.Equal(t, "token1", sessionAfter.AccessToken)
+}
+
+func TestService_GetSessionWithNotFound(t *testing.T) {
+	_, err := service.GetSession("@unknown:example.com")
+	require.ErrorIs(t, err, matrixdb.ErrNotFound)
+}
+
+func TestService_SaveSessionOverwrites(t *testing.T) {
+	session, err := service.SaveSession(testSession())
+	require.NoError(t, err)
+
+	session.AccessToken = "token2"
+	_, err = service.SaveSession(session)
+	require.NoError(t, err)
+
+	sessionAfter, err := service.GetSession(session.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, "token2", sessionAfter.AccessToken)
+}
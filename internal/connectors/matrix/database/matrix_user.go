@@ -27,6 +27,13 @@ func (service *service) NewUser(user *MatrixUser) (*MatrixUser, error) {
 	return user, err
 }
 
+// UpdateUser updates the user without touching its rooms.
+func (service *service) UpdateUser(user *MatrixUser) (*MatrixUser, error) {
+	err := service.db.Omit("Rooms").Save(user).Error
+
+	return user, err
+}
+
 func (service *service) RemoveDanglingUsers() (int64, error) {
 	res := service.db.Exec(`
 DELETE 
@@ -38,6 +38,25 @@ func TestService_GetUserByID(t *testing.T) {
 	assertUsersEqual(t, userBefore, userAfter)
 }
 
+func TestService_UpdateUser(t *testing.T) {
+	userBefore, err := service.NewUser(testUser())
+	require.NoError(t, err)
+
+	userBefore.TimeZone = "America/New_York"
+	userBefore.Clock = "12h"
+	userBefore.Rooms = nil
+
+	_, err = service.UpdateUser(userBefore)
+	require.NoError(t, err)
+
+	userAfter, err := service.GetUserByID(userBefore.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, "America/New_York", userAfter.TimeZone)
+	assert.Equal(t, "12h", userAfter.Clock)
+	assert.Len(t, userAfter.Rooms, 1)
+}
+
 func TestService_GetUserByIDWithUserNotFound(t *testing.T) {
 	_, err := service.GetUserByID("abc")
 	assert.ErrorIs(t, err, matrixdb.ErrNotFound)
@@ -0,0 +1,260 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database/migration"
+	"gorm.io/gorm"
+)
+
+// Migrations returns the versioned schema migrations of the matrix connector.
+//
+// Migrations work on snapshots of the models as of their version, changes to
+// the models require a new migration with a new snapshot.
+func Migrations() []migration.Migration {
+	return []migration.Migration{
+		{
+			Version: 1,
+			Name:    "initial schema",
+			Up: migration.AutoMigrate(
+				&matrixRoomV1{},
+				&matrixUserV1{},
+				&matrixRoomUserV1{},
+				&matrixMessageV1{},
+				&matrixEventV1{},
+			),
+		},
+		{
+			Version: 2,
+			Name:    "keep matrix messages of deleted events",
+			Up:      fixMatrixMessageEventFK,
+		},
+		{
+			Version: 3,
+			Name:    "matrix sessions",
+			Up:      migration.AutoMigrate(&matrixSessionV3{}),
+		},
+		{
+			Version: 4,
+			Name:    "daily reminder entries",
+			Up:      migration.AutoMigrate(&matrixDigestEntryV4{}),
+		},
+		{
+			Version: 5,
+			Name:    "room language",
+			Up:      migration.AutoMigrate(&matrixRoomV5{}),
+		},
+		{
+			Version: 6,
+			Name:    "user time preferences",
+			Up:      migration.AutoMigrate(&matrixUserV6{}),
+		},
+		{
+			Version: 7,
+			Name:    "room encryption",
+			Up:      migration.AutoMigrate(&matrixRoomV7{}),
+		},
+		{
+			Version: 8,
+			Name:    "channel time zones",
+			Up:      copyRoomTimeZonesToChannels,
+		},
+	}
+}
+
+// eventRef references the events table of the core module.
+type eventRef struct {
+	ID uint `gorm:"primarykey"`
+}
+
+func (eventRef) TableName() string { return "events" }
+
+// Version 1
+
+type matrixRoomV1 struct {
+	gorm.Model
+
+	RoomID   string `gorm:"unique"`
+	TimeZone string
+}
+
+func (matrixRoomV1) TableName() string { return "matrix_rooms" }
+
+type matrixUserV1 struct {
+	ID      string `gorm:"primary,size:255"`
+	Blocked bool
+}
+
+func (matrixUserV1) TableName() string { return "matrix_users" }
+
+type matrixRoomUserV1 struct {
+	MatrixUserID string `gorm:"primaryKey;size:255"`
+	MatrixUser   matrixUserV1
+	MatrixRoomID uint `gorm:"primaryKey;autoIncrement:false"`
+	MatrixRoom   matrixRoomV1
+}
+
+func (matrixRoomUserV1) TableName() string { return "matrix_rooms_matrix_users" }
+
+type matrixMessageV1 struct {
+	ID               string  `gorm:"primary,size:255"`
+	UserID           *string `gorm:"size:255"`
+	User             *matrixUserV1
+	RoomID           uint
+	Room             matrixRoomV1
+	Body             string
+	BodyFormatted    string
+	SendAt           time.Time
+	Type             string
+	Incoming         bool
+	EventID          *uint
+	Event            *eventRef `gorm:"constraint:OnDelete:SET NULL;"`
+	ReplyToMessageID *string   `gorm:"size:255"`
+	ReplyToMessage   *matrixMessageV1
+}
+
+func (matrixMessageV1) TableName() string { return "matrix_messages" }
+
+type matrixEventV1 struct {
+	ID     string `gorm:"primary,size:255"`
+	UserID string `gorm:"size:255"`
+	User   matrixUserV1
+	RoomID uint
+	Room   matrixRoomV1
+	Type   string
+	SendAt time.Time
+}
+
+func (matrixEventV1) TableName() string { return "matrix_events" }
+
+// Version 2
+
+// fixMatrixMessageEventFK replaces the foreign key of matrix messages to events created by versions
+// prior to the migrations, it deleted messages together with their event.
+func fixMatrixMessageEventFK(db *gorm.DB) error {
+	switch db.Name() {
+	case "sqlite":
+		return fixMatrixMessageEventFKSQLite(db)
+	case "postgres":
+		return fixMatrixMessageEventFKWith(db,
+			`SELECT delete_rule FROM information_schema.referential_constraints WHERE constraint_name = 'fk_matrix_messages_event'`,
+			`ALTER TABLE matrix_messages DROP CONSTRAINT fk_matrix_messages_event`,
+		)
+	default:
+		return fixMatrixMessageEventFKWith(db,
+			`SELECT DELETE_RULE FROM information_schema.REFERENTIAL_CONSTRAINTS WHERE CONSTRAINT_NAME = 'fk_matrix_messages_event'`,
+			`ALTER TABLE matrix_messages DROP FOREIGN KEY fk_matrix_messages_event`,
+		)
+	}
+}
+
+func fixMatrixMessageEventFKWith(db *gorm.DB, deleteRuleQuery, dropQuery string) error {
+	var deleteRule string
+
+	err := db.Raw(deleteRuleQuery).Row().Scan(&deleteRule)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	if deleteRule == "SET NULL" {
+		return nil
+	}
+
+	if deleteRule != "" {
+		err = db.Exec(dropQuery).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return db.Exec(`ALTER TABLE matrix_messages ADD CONSTRAINT fk_matrix_messages_event FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE SET NULL ON UPDATE RESTRICT`).Error
+}
+
+// fixMatrixMessageEventFKSQLite recreates the table as SQLite can not alter constraints in place.
+func fixMatrixMessageEventFKSQLite(db *gorm.DB) error {
+	var ddl string
+
+	err := db.Raw(`SELECT sql FROM sqlite_master WHERE type = 'table' AND name = 'matrix_messages'`).Row().Scan(&ddl)
+	if err != nil {
+		return err
+	}
+
+	if strings.Contains(ddl, "ON DELETE SET NULL") {
+		return nil
+	}
+
+	err = db.Migrator().DropConstraint(&matrixMessageV1{}, "fk_matrix_messages_event")
+	if err != nil {
+		return err
+	}
+
+	return db.Migrator().CreateConstraint(&matrixMessageV1{}, "Event")
+}
+
+// Version 3
+
+type matrixSessionV3 struct {
+	UserID      string `gorm:"primaryKey;size:255"`
+	DeviceID    string `gorm:"size:255"`
+	AccessToken string `gorm:"size:255"`
+	UpdatedAt   time.Time
+}
+
+func (matrixSessionV3) TableName() string { return "matrix_sessions" }
+
+// Version 4
+
+type matrixDigestEntryV4 struct {
+	MessageID string          `gorm:"primaryKey;size:255"`
+	Message   matrixMessageV1 `gorm:"constraint:OnDelete:CASCADE;"`
+	Position  uint            `gorm:"primaryKey;autoIncrement:false"`
+	EventID   uint
+	Event     eventRef `gorm:"constraint:OnDelete:CASCADE;"`
+}
+
+func (matrixDigestEntryV4) TableName() string { return "matrix_digest_entries" }
+
+// Version 5
+
+type matrixRoomV5 struct {
+	Language string `gorm:"size:10"`
+}
+
+func (matrixRoomV5) TableName() string { return "matrix_rooms" }
+
+// Version 6
+
+type matrixUserV6 struct {
+	TimeZone string `gorm:"size:255"`
+	Clock    string `gorm:"size:10"`
+}
+
+func (matrixUserV6) TableName() string { return "matrix_users" }
+
+// Version 7
+
+type matrixRoomV7 struct {
+	Encrypted bool
+}
+
+func (matrixRoomV7) TableName() string { return "matrix_rooms" }
+
+// Version 8
+
+// copyRoomTimeZonesToChannels sets the timezone of the channels the rooms output to, the daemon evaluates times of day
+// in it.
+func copyRoomTimeZonesToChannels(db *gorm.DB) error {
+	return db.Exec(`UPDATE channels SET time_zone = COALESCE((
+		SELECT matrix_rooms.time_zone FROM matrix_rooms
+		JOIN outputs ON outputs.output_id = matrix_rooms.id
+		WHERE outputs.channel_id = channels.id
+			AND outputs.output_type = ?
+			AND outputs.deleted_at IS NULL
+			AND matrix_rooms.time_zone <> ''
+		ORDER BY outputs.id
+		LIMIT 1
+	), '') WHERE time_zone = ''`, "matrix").Error
+}
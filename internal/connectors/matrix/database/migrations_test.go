@@ -0,0 +1,44 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	coredb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	dbtests "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrations_MatchModels(t *testing.T) {
+	dbtests.AssertSchema(t, gormDB,
+		&database.MatrixRoom{},
+		&database.MatrixUser{},
+		&database.MatrixMessage{},
+		&database.MatrixEvent{},
+		&database.MatrixDigestEntry{},
+		&database.MatrixSession{},
+	)
+}
+
+func TestMigrations_CopyRoomTimeZonesToChannels(t *testing.T) {
+	room, err := service.NewRoom(&database.MatrixRoom{
+		RoomID:   "!timezone:example.com",
+		TimeZone: "Europe/Berlin",
+	})
+	require.NoError(t, err)
+
+	channel := &coredb.Channel{Description: "timezone"}
+	require.NoError(t, gormDB.Create(channel).Error)
+	require.NoError(t, gormDB.Create(&coredb.Output{
+		ChannelID:  channel.ID,
+		OutputType: "matrix",
+		OutputID:   room.ID,
+	}).Error)
+
+	migrations := database.Migrations()
+	require.NoError(t, migrations[len(migrations)-1].Up(gormDB))
+
+	require.NoError(t, gormDB.First(channel, channel.ID).Error)
+	assert.Equal(t, "Europe/Berlin", channel.TimeZone)
+}
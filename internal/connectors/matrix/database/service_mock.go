@@ -300,6 +300,74 @@ func (_c *MockService_DeleteRoom_Call) RunAndReturn(run func(roomID uint) error)
 	return _c
 }
 
+// GetDigestEntry provides a mock function for the type MockService
+func (_mock *MockService) GetDigestEntry(messageID string, position uint) (*MatrixDigestEntry, error) {
+	ret := _mock.Called(messageID, position)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDigestEntry")
+	}
+
+	var r0 *MatrixDigestEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, uint) (*MatrixDigestEntry, error)); ok {
+		return returnFunc(messageID, position)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, uint) *MatrixDigestEntry); ok {
+		r0 = returnFunc(messageID, position)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*MatrixDigestEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, uint) error); ok {
+		r1 = returnFunc(messageID, position)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetDigestEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDigestEntry'
+type MockService_GetDigestEntry_Call struct {
+	*mock.Call
+}
+
+// GetDigestEntry is a helper method to define mock.On call
+//   - messageID string
+//   - position uint
+func (_e *MockService_Expecter) GetDigestEntry(messageID interface{}, position interface{}) *MockService_GetDigestEntry_Call {
+	return &MockService_GetDigestEntry_Call{Call: _e.mock.On("GetDigestEntry", messageID, position)}
+}
+
+func (_c *MockService_GetDigestEntry_Call) Run(run func(messageID string, position uint)) *MockService_GetDigestEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 uint
+		if args[1] != nil {
+			arg1 = args[1].(uint)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetDigestEntry_Call) Return(matrixDigestEntry *MatrixDigestEntry, err error) *MockService_GetDigestEntry_Call {
+	_c.Call.Return(matrixDigestEntry, err)
+	return _c
+}
+
+func (_c *MockService_GetDigestEntry_Call) RunAndReturn(run func(messageID string, position uint) (*MatrixDigestEntry, error)) *MockService_GetDigestEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetEventByID provides a mock function for the type MockService
 func (_mock *MockService) GetEventByID(eventID string) (*MatrixEvent, error) {
 	ret := _mock.Called(eventID)
@@ -785,6 +853,68 @@ func (_c *MockService_GetRoomCount_Call) RunAndReturn(run func() (int64, error))
 	return _c
 }
 
+// GetSession provides a mock function for the type MockService
+func (_mock *MockService) GetSession(userID string) (*MatrixSession, error) {
+	ret := _mock.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSession")
+	}
+
+	var r0 *MatrixSession
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*MatrixSession, error)); ok {
+		return returnFunc(userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *MatrixSession); ok {
+		r0 = returnFunc(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*MatrixSession)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSession'
+type MockService_GetSession_Call struct {
+	*mock.Call
+}
+
+// GetSession is a helper method to define mock.On call
+//   - userID string
+func (_e *MockService_Expecter) GetSession(userID interface{}) *MockService_GetSession_Call {
+	return &MockService_GetSession_Call{Call: _e.mock.On("GetSession", userID)}
+}
+
+func (_c *MockService_GetSession_Call) Run(run func(userID string)) *MockService_GetSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetSession_Call) Return(matrixSession *MatrixSession, err error) *MockService_GetSession_Call {
+	_c.Call.Return(matrixSession, err)
+	return _c
+}
+
+func (_c *MockService_GetSession_Call) RunAndReturn(run func(userID string) (*MatrixSession, error)) *MockService_GetSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUserByID provides a mock function for the type MockService
 func (_mock *MockService) GetUserByID(userID string) (*MatrixUser, error) {
 	ret := _mock.Called(userID)
@@ -1033,6 +1163,57 @@ func (_c *MockService_ListOutputRoomsByChannel_Call) RunAndReturn(run func(chann
 	return _c
 }
 
+// NewDigestEntries provides a mock function for the type MockService
+func (_mock *MockService) NewDigestEntries(entries []MatrixDigestEntry) error {
+	ret := _mock.Called(entries)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewDigestEntries")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func([]MatrixDigestEntry) error); ok {
+		r0 = returnFunc(entries)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_NewDigestEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewDigestEntries'
+type MockService_NewDigestEntries_Call struct {
+	*mock.Call
+}
+
+// NewDigestEntries is a helper method to define mock.On call
+//   - entries []MatrixDigestEntry
+func (_e *MockService_Expecter) NewDigestEntries(entries interface{}) *MockService_NewDigestEntries_Call {
+	return &MockService_NewDigestEntries_Call{Call: _e.mock.On("NewDigestEntries", entries)}
+}
+
+func (_c *MockService_NewDigestEntries_Call) Run(run func(entries []MatrixDigestEntry)) *MockService_NewDigestEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []MatrixDigestEntry
+		if args[0] != nil {
+			arg0 = args[0].([]MatrixDigestEntry)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_NewDigestEntries_Call) Return(err error) *MockService_NewDigestEntries_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_NewDigestEntries_Call) RunAndReturn(run func(entries []MatrixDigestEntry) error) *MockService_NewDigestEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewEvent provides a mock function for the type MockService
 func (_mock *MockService) NewEvent(event *MatrixEvent) (*MatrixEvent, error) {
 	ret := _mock.Called(event)
@@ -1334,6 +1515,68 @@ func (_c *MockService_RemoveDanglingUsers_Call) RunAndReturn(run func() (int64,
 	return _c
 }
 
+// SaveSession provides a mock function for the type MockService
+func (_mock *MockService) SaveSession(session *MatrixSession) (*MatrixSession, error) {
+	ret := _mock.Called(session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSession")
+	}
+
+	var r0 *MatrixSession
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*MatrixSession) (*MatrixSession, error)); ok {
+		return returnFunc(session)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*MatrixSession) *MatrixSession); ok {
+		r0 = returnFunc(session)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*MatrixSession)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*MatrixSession) error); ok {
+		r1 = returnFunc(session)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_SaveSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveSession'
+type MockService_SaveSession_Call struct {
+	*mock.Call
+}
+
+// SaveSession is a helper method to define mock.On call
+//   - session *MatrixSession
+func (_e *MockService_Expecter) SaveSession(session interface{}) *MockService_SaveSession_Call {
+	return &MockService_SaveSession_Call{Call: _e.mock.On("SaveSession", session)}
+}
+
+func (_c *MockService_SaveSession_Call) Run(run func(session *MatrixSession)) *MockService_SaveSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *MatrixSession
+		if args[0] != nil {
+			arg0 = args[0].(*MatrixSession)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_SaveSession_Call) Return(matrixSession *MatrixSession, err error) *MockService_SaveSession_Call {
+	_c.Call.Return(matrixSession, err)
+	return _c
+}
+
+func (_c *MockService_SaveSession_Call) RunAndReturn(run func(session *MatrixSession) (*MatrixSession, error)) *MockService_SaveSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateRoom provides a mock function for the type MockService
 func (_mock *MockService) UpdateRoom(room *MatrixRoom) (*MatrixRoom, error) {
 	ret := _mock.Called(room)
@@ -1395,3 +1638,65 @@ func (_c *MockService_UpdateRoom_Call) RunAndReturn(run func(room *MatrixRoom) (
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpdateUser provides a mock function for the type MockService
+func (_mock *MockService) UpdateUser(user *MatrixUser) (*MatrixUser, error) {
+	ret := _mock.Called(user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUser")
+	}
+
+	var r0 *MatrixUser
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*MatrixUser) (*MatrixUser, error)); ok {
+		return returnFunc(user)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*MatrixUser) *MatrixUser); ok {
+		r0 = returnFunc(user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*MatrixUser)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*MatrixUser) error); ok {
+		r1 = returnFunc(user)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
+type MockService_UpdateUser_Call struct {
+	*mock.Call
+}
+
+// UpdateUser is a helper method to define mock.On call
+//   - user *MatrixUser
+func (_e *MockService_Expecter) UpdateUser(user interface{}) *MockService_UpdateUser_Call {
+	return &MockService_UpdateUser_Call{Call: _e.mock.On("UpdateUser", user)}
+}
+
+func (_c *MockService_UpdateUser_Call) Run(run func(user *MatrixUser)) *MockService_UpdateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *MatrixUser
+		if args[0] != nil {
+			arg0 = args[0].(*MatrixUser)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_UpdateUser_Call) Return(matrixUser *MatrixUser, err error) *MockService_UpdateUser_Call {
+	_c.Call.Return(matrixUser, err)
+	return _c
+}
+
+func (_c *MockService_UpdateUser_Call) RunAndReturn(run func(user *MatrixUser) (*MatrixUser, error)) *MockService_UpdateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,246 @@
+package matrix
+
+import (
+	"errors"
+
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// Errors returned when setting up end to end encryption.
+var (
+	ErrEncryptionNotBuilt       = errors.New("built without end to end encryption support, rebuild with -tags e2ee")
+	ErrEncryptionWithAppService = errors.New("end to end encryption is not supported for application services")
+	ErrEncryptionMissingKey     = errors.New("end to end encryption needs a device key")
+	ErrEncryptionUnsupportedDB  = errors.New("end to end encryption needs a SQLite or PostgreSQL database")
+)
+
+// cryptoMachine encrypts and decrypts room events and handles key verification.
+// It is implemented on top of libolm in encryption_olm.go.
+type cryptoMachine interface {
+	Encrypt(roomID id.RoomID, eventType event.Type, content any) (*event.EncryptedEventContent, error)
+	Decrypt(evt *event.Event) (*event.Event, error)
+	ProcessSyncResponse(resp *mautrix.RespSync, since string) bool
+	HandleMemberEvent(evt *event.Event)
+	ProcessInRoomVerification(evt *event.Event) error
+}
+
+func (service *service) setupEncryption() error {
+	if !service.config.E2EE {
+		return nil
+	}
+
+	if service.config.AppService != nil {
+		return ErrEncryptionWithAppService
+	}
+
+	if service.config.DeviceKey == "" {
+		return ErrEncryptionMissingKey
+	}
+
+	machine, err := newCryptoMachine(service)
+	if err != nil {
+		return err
+	}
+
+	service.crypto = machine
+
+	return nil
+}
+
+// messengerClient returns the client messages are sent with, it encrypts them if needed.
+func (service *service) messengerClient() messenger.MatrixClient {
+	if service.crypto == nil {
+		return service.client
+	}
+
+	return &encryptingClient{
+		MatrixClient: service.client,
+		machine:      service.crypto,
+		rooms:        &encryptionStateStore{matrixDB: service.matrixDatabase},
+	}
+}
+
+// encryptingClient encrypts message events sent to encrypted rooms.
+type encryptingClient struct {
+	messenger.MatrixClient
+	machine cryptoMachine
+	rooms   *encryptionStateStore
+}
+
+func (client *encryptingClient) SendMessageEvent(roomID id.RoomID, eventType event.Type, contentJSON any, extra ...mautrix.ReqSendEvent) (*mautrix.RespSendEvent, error) {
+	if !client.rooms.IsEncrypted(roomID) {
+		return client.MatrixClient.SendMessageEvent(roomID, eventType, contentJSON, extra...)
+	}
+
+	encrypted, err := client.machine.Encrypt(roomID, eventType, contentJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.MatrixClient.SendMessageEvent(roomID, event.EventEncrypted, encrypted, extra...)
+}
+
+// encryptionStateStore answers the room state questions of the crypto machine from the database.
+type encryptionStateStore struct {
+	matrixDB matrixdb.Service
+}
+
+// IsEncrypted returns whether a room is encrypted.
+func (store *encryptionStateStore) IsEncrypted(roomID id.RoomID) bool {
+	room, err := store.matrixDB.GetRoomByRoomID(roomID.String())
+	if err != nil {
+		return false
+	}
+
+	return room.Encrypted
+}
+
+// GetEncryptionEvent returns the encryption settings of an encrypted room.
+func (store *encryptionStateStore) GetEncryptionEvent(roomID id.RoomID) *event.EncryptionEventContent {
+	if !store.IsEncrypted(roomID) {
+		return nil
+	}
+
+	return &event.EncryptionEventContent{Algorithm: id.AlgorithmMegolmV1}
+}
+
+// FindSharedRooms returns the encrypted rooms the bot shares with the user.
+func (store *encryptionStateStore) FindSharedRooms(userID id.UserID) []id.RoomID {
+	user, err := store.matrixDB.GetUserByID(userID.String())
+	if err != nil {
+		return nil
+	}
+
+	rooms := []id.RoomID{}
+	for i := range user.Rooms {
+		if user.Rooms[i].Encrypted {
+			rooms = append(rooms, id.RoomID(user.Rooms[i].RoomID))
+		}
+	}
+
+	return rooms
+}
+
+// encryptionEventHandlers returns the handlers needed on top of eventHandlers if encryption is enabled.
+func (service *service) encryptionEventHandlers() map[event.Type]mautrix.EventHandler {
+	handlers := map[event.Type]mautrix.EventHandler{
+		event.EventEncrypted:  service.EncryptedEventHandler,
+		event.StateEncryption: service.EncryptionStateHandler,
+		event.StateMember: func(_ mautrix.EventSource, evt *event.Event) {
+			service.crypto.HandleMemberEvent(evt)
+		},
+	}
+
+	for _, eventType := range []event.Type{
+		event.InRoomVerificationStart,
+		event.InRoomVerificationReady,
+		event.InRoomVerificationAccept,
+		event.InRoomVerificationKey,
+		event.InRoomVerificationMAC,
+		event.InRoomVerificationCancel,
+	} {
+		handlers[eventType] = service.VerificationEventHandler
+	}
+
+	return handlers
+}
+
+// EncryptionStateHandler marks rooms as encrypted once they enable encryption.
+func (service *service) EncryptionStateHandler(_ mautrix.EventSource, evt *event.Event) {
+	service.metricEventInCount.
+		WithLabelValues("encryption").
+		Inc()
+
+	room, err := service.matrixDatabase.GetRoomByRoomID(evt.RoomID.String())
+	if err != nil {
+		if !errors.Is(err, matrixdb.ErrNotFound) {
+			service.logger.Error("failed to get room", "error", err, "matrix.room.id", evt.RoomID)
+		}
+
+		return
+	}
+
+	if room.Encrypted {
+		return
+	}
+
+	room.Encrypted = true
+
+	_, err = service.matrixDatabase.UpdateRoom(room)
+	if err != nil {
+		service.logger.Error("failed to mark room as encrypted", "error", err, "matrix.room.id", evt.RoomID)
+	}
+}
+
+// EncryptedEventHandler decrypts room events and passes them on to the handler of the decrypted event type.
+func (service *service) EncryptedEventHandler(source mautrix.EventSource, evt *event.Event) {
+	logger := service.logger.With(
+		"matrix.sender", evt.Sender,
+		"matrix.room.id", evt.RoomID,
+		"matrix.event.timestamp", evt.Timestamp,
+	)
+
+	service.metricEventInCount.
+		WithLabelValues("encrypted").
+		Inc()
+
+	// Do not decrypt our own and old events
+	if evt.Sender.String() == service.botname || evt.Timestamp/1000 <= service.lastMessageFrom.Unix() {
+		return
+	}
+
+	decrypted, err := service.crypto.Decrypt(evt)
+	if err != nil {
+		logger.Info("failed to decrypt event", "error", err)
+		return
+	}
+
+	handler, ok := service.eventHandlers()[decrypted.Type]
+	if !ok {
+		handler, ok = service.encryptionEventHandlers()[decrypted.Type]
+	}
+
+	if !ok || decrypted.Type == event.EventEncrypted {
+		logger.Debug("ignoring decrypted event", "reason", "unknown type", "matrix.event.type", decrypted.Type.Type)
+		return
+	}
+
+	handler(source, decrypted)
+}
+
+// VerificationEventHandler passes in-room key verification events on to the crypto machine.
+func (service *service) VerificationEventHandler(_ mautrix.EventSource, evt *event.Event) {
+	if evt.Timestamp/1000 <= service.lastMessageFrom.Unix() {
+		return
+	}
+
+	service.handleVerification(evt)
+}
+
+// handleVerification handles in-room key verification events and reports whether the event was one.
+func (service *service) handleVerification(evt *event.Event) bool {
+	if service.crypto == nil || !isVerificationEvent(evt) {
+		return false
+	}
+
+	err := service.crypto.ProcessInRoomVerification(evt)
+	if err != nil {
+		service.logger.Info("failed to process key verification", "error", err, "matrix.sender", evt.Sender)
+	}
+
+	return true
+}
+
+func isVerificationEvent(evt *event.Event) bool {
+	if evt.Type.IsInRoomVerification() {
+		return true
+	}
+
+	content, ok := evt.Content.Parsed.(*event.MessageEventContent)
+
+	return ok && content.MsgType == event.MsgVerificationRequest
+}
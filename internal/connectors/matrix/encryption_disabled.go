@@ -0,0 +1,7 @@
+//go:build !e2ee
+
+package matrix
+
+func newCryptoMachine(_ *service) (cryptoMachine, error) {
+	return nil, ErrEncryptionNotBuilt
+}
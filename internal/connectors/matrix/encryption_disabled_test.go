@@ -0,0 +1,18 @@
+//go:build !e2ee
+
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_SetupEncryptionWithoutBuildTag(t *testing.T) {
+	service, _ := testService(t)
+	service.config.E2EE = true
+	service.config.DeviceKey = "key"
+
+	err := service.setupEncryption()
+	require.ErrorIs(t, err, ErrEncryptionNotBuilt)
+}
@@ -0,0 +1,262 @@
+//go:build e2ee
+
+package matrix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"maunium.net/go/mautrix/crypto"
+	"maunium.net/go/mautrix/crypto/ssss"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+	"maunium.net/go/mautrix/util/dbutil"
+)
+
+// sessionWaitTimeout is how long decryption waits for a missing room key to arrive.
+const sessionWaitTimeout = 5 * time.Second
+
+type olmMachine struct {
+	*crypto.OlmMachine
+	logger *slog.Logger
+}
+
+// newCryptoMachine sets up the olm machine with its store in the bots database.
+func newCryptoMachine(service *service) (cryptoMachine, error) {
+	logger := service.logger.With("component", "matrix-crypto")
+
+	store, err := newCryptoStore(service)
+	if err != nil {
+		return nil, err
+	}
+
+	stateStore := &encryptionStateStore{matrixDB: service.matrixDatabase}
+
+	mach := crypto.NewOlmMachine(service.client, &cryptoLogger{logger: logger}, store, stateStore)
+	// Users verify the bot, it has no one to verify other devices with.
+	mach.ShareKeysMinTrust = id.TrustStateUnset
+	mach.AcceptVerificationFrom = func(_ string, device *id.Device, roomID id.RoomID) (crypto.VerificationRequestResponse, crypto.VerificationHooks) {
+		if !service.userInWhitelist(device.UserID.String()) && len(stateStore.FindSharedRooms(device.UserID)) == 0 {
+			logger.Info("rejected key verification", "reason", "unknown user", "matrix.user", device.UserID, "matrix.room.id", roomID)
+			return crypto.RejectRequest, nil
+		}
+
+		return crypto.AcceptRequest, &verificationHooks{logger: logger.With("matrix.user", device.UserID, "matrix.device", device.DeviceID)}
+	}
+
+	err = mach.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	err = mach.ShareKeys(-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload device keys: %w", err)
+	}
+
+	machine := &olmMachine{
+		OlmMachine: mach,
+		logger:     logger,
+	}
+
+	err = machine.setupCrossSigning(service.config.Password, service.config.DeviceKey)
+	if err != nil {
+		// Encryption works without cross-signing, users only need to verify the device itself.
+		logger.Warn("cross-signing not available", "error", err)
+	}
+
+	logger.Info("end to end encryption enabled", "matrix.device", service.client.DeviceID, "fingerprint", mach.Fingerprint())
+
+	return machine, nil
+}
+
+// newCryptoStore opens the crypto store in the bots database, one account per device.
+func newCryptoStore(service *service) (*crypto.SQLCryptoStore, error) {
+	gormDB := service.database.GormDB()
+
+	var dialect string
+
+	switch gormDB.Dialector.Name() {
+	case "sqlite":
+		dialect = "sqlite3"
+	case "postgres":
+		dialect = "postgres"
+	default:
+		return nil, ErrEncryptionUnsupportedDB
+	}
+
+	sqlDB, err := gormDB.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := dbutil.NewWithDB(sqlDB, dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	accountID := fmt.Sprintf("%s/%s", service.client.UserID, service.client.DeviceID)
+
+	store := crypto.NewSQLCryptoStore(db, dbutil.NoopLogger, accountID, service.client.DeviceID, []byte(service.config.DeviceKey))
+
+	err = store.DB.Upgrade()
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate crypto store: %w", err)
+	}
+
+	return store, nil
+}
+
+// setupCrossSigning loads the cross-signing keys from secret storage and signs the device with them.
+// If the account has no secret storage yet the keys are generated, this needs the account password.
+// The device key is used as passphrase for the secret storage.
+func (machine *olmMachine) setupCrossSigning(password, passphrase string) error {
+	_, keyData, err := machine.SSSS.GetDefaultKeyData()
+
+	switch {
+	case err == nil:
+		key, err := keyData.VerifyPassphrase(passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to unlock secret storage with the device key: %w", err)
+		}
+
+		err = machine.FetchCrossSigningKeysFromSSSS(key)
+		if err != nil {
+			return err
+		}
+	case errors.Is(err, ssss.ErrNoDefaultKeyAccountDataEvent):
+		if password == "" {
+			return errors.New("generating cross-signing keys needs the account password")
+		}
+
+		_, err = machine.GenerateAndUploadCrossSigningKeys(password, passphrase)
+		if err != nil {
+			return err
+		}
+
+		machine.logger.Info("generated cross-signing keys, secret storage is protected by the device key")
+	default:
+		return err
+	}
+
+	err = machine.SignOwnMasterKey()
+	if err != nil {
+		return err
+	}
+
+	return machine.SignOwnDevice(machine.OwnIdentity())
+}
+
+// Encrypt encrypts the content for the room, sharing a new group session with the members if needed.
+func (machine *olmMachine) Encrypt(roomID id.RoomID, eventType event.Type, content any) (*event.EncryptedEventContent, error) {
+	encrypted, err := machine.EncryptMegolmEvent(roomID, eventType, content)
+	if !crypto.IsShareError(err) {
+		return withRelation(encrypted, content), err
+	}
+
+	members, err := machine.Client.JoinedMembers(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]id.UserID, 0, len(members.Joined))
+	for userID := range members.Joined {
+		users = append(users, userID)
+	}
+
+	err = machine.ShareGroupSession(roomID, users)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err = machine.EncryptMegolmEvent(roomID, eventType, content)
+
+	return withRelation(encrypted, content), err
+}
+
+// withRelation copies annotations and edits to the unencrypted content so servers can aggregate them.
+func withRelation(encrypted *event.EncryptedEventContent, content any) *event.EncryptedEventContent {
+	if encrypted == nil || encrypted.RelatesTo != nil {
+		return encrypted
+	}
+
+	raw, err := json.Marshal(content)
+	if err != nil {
+		return encrypted
+	}
+
+	var relation struct {
+		RelatesTo *event.RelatesTo `json:"m.relates_to"`
+	}
+
+	err = json.Unmarshal(raw, &relation)
+	if err == nil && relation.RelatesTo != nil && relation.RelatesTo.Type != "" {
+		encrypted.RelatesTo = relation.RelatesTo
+	}
+
+	return encrypted
+}
+
+// Decrypt decrypts a room event, it waits shortly for the room key if it did not arrive yet.
+func (machine *olmMachine) Decrypt(evt *event.Event) (*event.Event, error) {
+	decrypted, err := machine.DecryptMegolmEvent(evt)
+	if !errors.Is(err, crypto.NoSessionFound) {
+		return decrypted, err
+	}
+
+	content := evt.Content.AsEncrypted()
+	if !machine.WaitForSession(evt.RoomID, content.SenderKey, content.SessionID, sessionWaitTimeout) {
+		return nil, err
+	}
+
+	return machine.DecryptMegolmEvent(evt)
+}
+
+// verificationHooks accepts the key verification as soon as the user confirmed it on their device.
+type verificationHooks struct {
+	logger *slog.Logger
+}
+
+func (hooks *verificationHooks) VerifySASMatch(_ *id.Device, sas crypto.SASData) bool {
+	hooks.logger.Info("confirming key verification", "sas", fmt.Sprint(sas))
+	return true
+}
+
+func (hooks *verificationHooks) VerificationMethods() []crypto.VerificationMethod {
+	return []crypto.VerificationMethod{
+		crypto.VerificationMethodEmoji{},
+		crypto.VerificationMethodDecimal{},
+	}
+}
+
+func (hooks *verificationHooks) OnCancel(cancelledByUs bool, reason string, reasonCode event.VerificationCancelCode) {
+	hooks.logger.Info("key verification cancelled", "by_bot", cancelledByUs, "reason", reason, "code", reasonCode)
+}
+
+func (hooks *verificationHooks) OnSuccess() {
+	hooks.logger.Info("key verification succeeded")
+}
+
+// cryptoLogger passes log messages of the olm machine on to slog.
+type cryptoLogger struct {
+	logger *slog.Logger
+}
+
+func (log *cryptoLogger) Error(message string, args ...any) {
+	log.logger.Error(fmt.Sprintf(message, args...))
+}
+
+func (log *cryptoLogger) Warn(message string, args ...any) {
+	log.logger.Warn(fmt.Sprintf(message, args...))
+}
+
+func (log *cryptoLogger) Debug(message string, args ...any) {
+	log.logger.Debug(fmt.Sprintf(message, args...))
+}
+
+func (log *cryptoLogger) Trace(message string, args ...any) {
+	log.logger.Debug(fmt.Sprintf(message, args...))
+}
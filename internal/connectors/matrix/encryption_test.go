@@ -0,0 +1,251 @@
+package matrix
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+// testSendServer records the event types of sent room events.
+func testSendServer(t *testing.T) (*mautrix.Client, *[]string) {
+	t.Helper()
+
+	sent := []string{}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("PUT /_matrix/client/v3/rooms/{room}/send/{type}/{txn}", func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.PathValue("type"))
+
+		_, _ = w.Write([]byte(`{"event_id":"$event"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := mautrix.NewClient(server.URL, "@bot:example.com", "token")
+	require.NoError(t, err)
+
+	return client, &sent
+}
+
+func TestService_SetupEncryptionWhenDisabled(t *testing.T) {
+	service, _ := testService(t)
+
+	err := service.setupEncryption()
+	require.NoError(t, err)
+
+	assert.Nil(t, service.crypto)
+}
+
+func TestService_SetupEncryptionWithAppService(t *testing.T) {
+	service, _ := testService(t)
+	service.config.E2EE = true
+	service.config.DeviceKey = "key"
+	service.config.AppService = &AppServiceConfig{}
+
+	err := service.setupEncryption()
+	require.ErrorIs(t, err, ErrEncryptionWithAppService)
+}
+
+func TestService_SetupEncryptionWithoutDeviceKey(t *testing.T) {
+	service, _ := testService(t)
+	service.config.E2EE = true
+
+	err := service.setupEncryption()
+	require.ErrorIs(t, err, ErrEncryptionMissingKey)
+}
+
+func TestEncryptingClient_SendMessageEvent(t *testing.T) {
+	service, fx := testService(t)
+	client, sent := testSendServer(t)
+	machine := NewMockcryptoMachine(t)
+	service.client = client
+	service.crypto = machine
+
+	content := map[string]string{"body": "hello"}
+
+	fx.matrixDB.EXPECT().GetRoomByRoomID("!encrypted").Return(&matrixdb.MatrixRoom{Encrypted: true}, nil)
+	machine.EXPECT().Encrypt(id.RoomID("!encrypted"), event.EventMessage, content).Return(&event.EncryptedEventContent{
+		Algorithm:        id.AlgorithmMegolmV1,
+		MegolmCiphertext: []byte("secret"),
+	}, nil)
+
+	_, err := service.messengerClient().SendMessageEvent("!encrypted", event.EventMessage, content)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"m.room.encrypted"}, *sent)
+}
+
+func TestEncryptingClient_SendMessageEventWithUnencryptedRoom(t *testing.T) {
+	service, fx := testService(t)
+	client, sent := testSendServer(t)
+	service.client = client
+	service.crypto = NewMockcryptoMachine(t)
+
+	fx.matrixDB.EXPECT().GetRoomByRoomID("!plain").Return(&matrixdb.MatrixRoom{}, nil)
+
+	_, err := service.messengerClient().SendMessageEvent("!plain", event.EventMessage, map[string]string{"body": "hello"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"m.room.message"}, *sent)
+}
+
+func TestEncryptingClient_SendMessageEventWithEncryptError(t *testing.T) {
+	service, fx := testService(t)
+	client, sent := testSendServer(t)
+	machine := NewMockcryptoMachine(t)
+	service.client = client
+	service.crypto = machine
+
+	fx.matrixDB.EXPECT().GetRoomByRoomID("!encrypted").Return(&matrixdb.MatrixRoom{Encrypted: true}, nil)
+	machine.EXPECT().Encrypt(id.RoomID("!encrypted"), event.EventMessage, mock.Anything).Return(nil, errors.New("test"))
+
+	_, err := service.messengerClient().SendMessageEvent("!encrypted", event.EventMessage, map[string]string{"body": "hello"})
+	require.Error(t, err)
+
+	assert.Empty(t, *sent)
+}
+
+func TestEncryptionStateStore_FindSharedRooms(t *testing.T) {
+	_, fx := testService(t)
+	store := &encryptionStateStore{matrixDB: fx.matrixDB}
+
+	fx.matrixDB.EXPECT().GetUserByID("@user:example.com").Return(&matrixdb.MatrixUser{
+		Rooms: []matrixdb.MatrixRoom{
+			{RoomID: "!encrypted", Encrypted: true},
+			{RoomID: "!plain"},
+		},
+	}, nil)
+
+	rooms := store.FindSharedRooms("@user:example.com")
+
+	assert.Equal(t, []id.RoomID{"!encrypted"}, rooms)
+}
+
+func TestEncryptionStateStore_GetEncryptionEvent(t *testing.T) {
+	_, fx := testService(t)
+	store := &encryptionStateStore{matrixDB: fx.matrixDB}
+
+	fx.matrixDB.EXPECT().GetRoomByRoomID("!encrypted").Return(&matrixdb.MatrixRoom{Encrypted: true}, nil)
+	fx.matrixDB.EXPECT().GetRoomByRoomID("!unknown").Return(nil, matrixdb.ErrNotFound)
+
+	assert.Equal(t, id.AlgorithmMegolmV1, store.GetEncryptionEvent("!encrypted").Algorithm)
+	assert.Nil(t, store.GetEncryptionEvent("!unknown"))
+}
+
+func TestService_EncryptionStateHandler(t *testing.T) {
+	service, fx := testService(t)
+
+	fx.matrixDB.EXPECT().GetRoomByRoomID("abc").Return(testRoom(), nil)
+	fx.matrixDB.EXPECT().UpdateRoom(mock.MatchedBy(func(room *matrixdb.MatrixRoom) bool {
+		return room.RoomID == "abc" && room.Encrypted
+	})).Return(nil, nil)
+
+	service.EncryptionStateHandler(mautrix.EventSourceState, &event.Event{RoomID: "abc"})
+}
+
+func TestService_EncryptionStateHandlerWithUnknownRoom(t *testing.T) {
+	service, fx := testService(t)
+
+	fx.matrixDB.EXPECT().GetRoomByRoomID("abc").Return(nil, matrixdb.ErrNotFound)
+
+	service.EncryptionStateHandler(mautrix.EventSourceState, &event.Event{RoomID: "abc"})
+}
+
+func TestService_EncryptedEventHandler(t *testing.T) {
+	service, fx := testService(t)
+	machine := NewMockcryptoMachine(t)
+	service.crypto = machine
+
+	evt := &event.Event{
+		Sender:    "@user:example.com",
+		RoomID:    "abc",
+		Timestamp: 5000,
+		Type:      event.EventEncrypted,
+	}
+	decrypted := &event.Event{
+		Sender:    "@user:example.com",
+		RoomID:    "abc",
+		Timestamp: 5000,
+		Type:      event.EventMessage,
+		Content: event.Content{
+			Parsed: &event.MessageEventContent{Body: "msg"},
+		},
+	}
+
+	machine.EXPECT().Decrypt(evt).Return(decrypted, nil)
+	// The decrypted message is handled like any other message.
+	fx.matrixDB.EXPECT().GetRoomByRoomID("abc").Return(nil, matrixdb.ErrNotFound)
+
+	service.EncryptedEventHandler(mautrix.EventSourceTimeline, evt)
+}
+
+func TestService_EncryptedEventHandlerWithVerification(t *testing.T) {
+	service, _ := testService(t)
+	machine := NewMockcryptoMachine(t)
+	service.crypto = machine
+
+	evt := &event.Event{
+		Sender:    "@user:example.com",
+		RoomID:    "abc",
+		Timestamp: 5000,
+		Type:      event.EventEncrypted,
+	}
+	decrypted := &event.Event{
+		Sender:    "@user:example.com",
+		RoomID:    "abc",
+		Timestamp: 5000,
+		Type:      event.InRoomVerificationStart,
+	}
+
+	machine.EXPECT().Decrypt(evt).Return(decrypted, nil)
+	machine.EXPECT().ProcessInRoomVerification(decrypted).Return(nil)
+
+	service.EncryptedEventHandler(mautrix.EventSourceTimeline, evt)
+}
+
+func TestService_EncryptedEventHandlerWithDecryptError(t *testing.T) {
+	service, _ := testService(t)
+	machine := NewMockcryptoMachine(t)
+	service.crypto = machine
+
+	evt := &event.Event{
+		Sender:    "@user:example.com",
+		RoomID:    "abc",
+		Timestamp: 5000,
+		Type:      event.EventEncrypted,
+	}
+
+	machine.EXPECT().Decrypt(evt).Return(nil, errors.New("test"))
+
+	service.EncryptedEventHandler(mautrix.EventSourceTimeline, evt)
+}
+
+func TestService_MessageEventHandlerWithVerificationRequest(t *testing.T) {
+	service, _ := testService(t)
+	machine := NewMockcryptoMachine(t)
+	service.crypto = machine
+
+	evt := &event.Event{
+		Sender:    "@user:example.com",
+		RoomID:    "abc",
+		Timestamp: 5000,
+		Type:      event.EventMessage,
+		Content: event.Content{
+			Parsed: &event.MessageEventContent{MsgType: event.MsgVerificationRequest},
+		},
+	}
+
+	machine.EXPECT().ProcessInRoomVerification(evt).Return(nil)
+
+	service.MessageEventHandler(mautrix.EventSourceTimeline, evt)
+}
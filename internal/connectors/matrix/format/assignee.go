@@ -0,0 +1,71 @@
+package format
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var assignmentRegex = regexp.MustCompile(`(?i)^[ ]*(remind|erinnere)[ ]+(.+?)[ ]+(to|an|daran)[ ]+(.+)$`)
+var userIDRegex = regexp.MustCompile(`^@[^ :]+:[^ ]+$`)
+var userLinkRegex = regexp.MustCompile(`https://matrix.to/#/((?:@|%40)[^"'>]+)`)
+
+// ParseAssignment extracts the assigned user from messages like "remind @alice:example.com to call bob".
+// The user can be referenced by "me", a matrix user ID or a mention (pill) in the formatted message.
+// Returns an empty assignee and the unchanged message if the message does not assign a user.
+func ParseAssignment(msg, msgFormatted, sender string) (assignee, text string) {
+	match := assignmentRegex.FindStringSubmatch(msg)
+	if match == nil {
+		return "", msg
+	}
+
+	user := strings.TrimSpace(match[2])
+
+	switch {
+	case strings.EqualFold(user, "me") || strings.EqualFold(user, "mich"):
+		assignee = sender
+	case userIDRegex.MatchString(user):
+		assignee = user
+	default:
+		assignee = GetUsernameFromLink(msgFormatted)
+		if unescaped, err := url.PathUnescape(assignee); err == nil {
+			assignee = unescaped
+		}
+	}
+
+	if assignee == "" {
+		return "", msg
+	}
+
+	return assignee, strings.TrimSpace(match[4])
+}
+
+// ParseMentions returns the users mentioned by their matrix user ID or a pill in the formatted message.
+func ParseMentions(msg, msgFormatted string) []string {
+	users := []string{}
+	seen := map[string]bool{}
+
+	add := func(user string) {
+		if unescaped, err := url.PathUnescape(user); err == nil {
+			user = unescaped
+		}
+
+		if !seen[user] {
+			seen[user] = true
+			users = append(users, user)
+		}
+	}
+
+	for _, match := range userLinkRegex.FindAllStringSubmatch(msgFormatted, -1) {
+		add(match[1])
+	}
+
+	for word := range strings.FieldsSeq(msg) {
+		word = strings.TrimRight(word, ",.")
+		if userIDRegex.MatchString(word) {
+			add(word)
+		}
+	}
+
+	return users
+}
@@ -0,0 +1,76 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAssignment(t *testing.T) {
+	type testCase struct {
+		name             string
+		msg              string
+		msgFormatted     string
+		expectedAssignee string
+		expectedText     string
+	}
+
+	testCases := []testCase{
+		{
+			name:         "no assignment",
+			msg:          "call bob tomorrow",
+			expectedText: "call bob tomorrow",
+		},
+		{
+			name:             "me",
+			msg:              "remind me to call bob tomorrow",
+			expectedAssignee: "@user:example.com",
+			expectedText:     "call bob tomorrow",
+		},
+		{
+			name:             "german",
+			msg:              "Erinnere mich an Bob anrufen morgen",
+			expectedAssignee: "@user:example.com",
+			expectedText:     "Bob anrufen morgen",
+		},
+		{
+			name:             "user ID",
+			msg:              "remind @alice:example.com to go to the store at 5 pm",
+			expectedAssignee: "@alice:example.com",
+			expectedText:     "go to the store at 5 pm",
+		},
+		{
+			name:             "mention",
+			msg:              "remind Alice Smith to call bob tomorrow",
+			msgFormatted:     `remind <a href="https://matrix.to/#/%40alice%3Aexample.com">Alice Smith</a> to call bob tomorrow`,
+			expectedAssignee: "@alice:example.com",
+			expectedText:     "call bob tomorrow",
+		},
+		{
+			name:         "unknown user",
+			msg:          "remind alice to call bob tomorrow",
+			expectedText: "remind alice to call bob tomorrow",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assignee, text := format.ParseAssignment(testCase.msg, testCase.msgFormatted, "@user:example.com")
+			assert.Equal(t, testCase.expectedAssignee, assignee)
+			assert.Equal(t, testCase.expectedText, text)
+		})
+	}
+}
+
+func TestParseMentions(t *testing.T) {
+	assert.Empty(t, format.ParseMentions("escalate after 3 reminders", ""))
+
+	assert.Equal(t,
+		[]string{"@alice:example.com", "@bob:example.com"},
+		format.ParseMentions(
+			"escalate after 3 mentioning Alice, @bob:example.com and @alice:example.com",
+			`escalate after 3 mentioning <a href="https://matrix.to/#/%40alice%3Aexample.com">Alice</a>, @bob:example.com and @alice:example.com`,
+		),
+	)
+}
@@ -0,0 +1,50 @@
+package format
+
+import (
+	"errors"
+	"strings"
+)
+
+// Clocks times can be displayed in. An empty clock is treated as 24-hour clock.
+const (
+	Clock24h = "24h"
+	Clock12h = "12h"
+)
+
+// ErrUnknownClock is returned if a clock is not supported.
+var ErrUnknownClock = errors.New("unknown clock")
+
+var clockNames = map[string]string{
+	"24":      Clock24h,
+	"24h":     Clock24h,
+	"24 h":    Clock24h,
+	"12":      Clock12h,
+	"12h":     Clock12h,
+	"12 h":    Clock12h,
+	"am/pm":   Clock12h,
+	"am pm":   Clock12h,
+	"ampm":    Clock12h,
+	"24-h":    Clock24h,
+	"12-h":    Clock12h,
+	"24-hour": Clock24h,
+	"12-hour": Clock12h,
+}
+
+// ParseClock returns the clock for the given name, e.g. "12h" or "am/pm".
+func ParseClock(name string) (string, error) {
+	clock, ok := clockNames[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return "", ErrUnknownClock
+	}
+
+	return clock, nil
+}
+
+// clockLayout adapts a 24-hour time layout to the clock.
+func clockLayout(layout string, clock string) string {
+	if clock != Clock12h {
+		return layout
+	}
+
+	return strings.Replace(layout, "15:04", "3:04 PM", 1)
+}
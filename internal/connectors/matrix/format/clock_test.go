@@ -0,0 +1,27 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClock(t *testing.T) {
+	for name, expected := range map[string]string{
+		"12h":     format.Clock12h,
+		" AM/PM ": format.Clock12h,
+		"24":      format.Clock24h,
+		"24-hour": format.Clock24h,
+	} {
+		clock, err := format.ParseClock(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, expected, clock, name)
+	}
+}
+
+func TestParseClockWithUnknownClock(t *testing.T) {
+	_, err := format.ParseClock("36h")
+	require.ErrorIs(t, err, format.ErrUnknownClock)
+}
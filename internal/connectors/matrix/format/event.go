@@ -6,30 +6,76 @@ import (
 	"strings"
 	"time"
 
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/daemon"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 )
 
 // MessageFromEvent creates a nicely formatted matrix message for the given event.
-func MessageFromEvent(event *daemon.Event, timeZone string) (string, string, error) {
+func MessageFromEvent(event *daemon.Event, timeZone string, clock string) (string, string, error) {
+	if event.StartsIn != nil {
+		return preNotificationFromEvent(event, timeZone, clock)
+	}
+
 	f := Formater{}
 
+	escalationFromEvent(&f, event)
 	f.Text("🔔 ")
+	assigneeFromEvent(&f, event)
+	f.Bold(event.Message)
+	f.Text(" (#")
+	f.Text(strconv.Itoa(int(event.ID)))
+	f.Text(")")
+	f.NewLine()
+
+	f.Italic(ToShortLocalTime(event.EventTime, timeZone, clock))
+	f.Text(" ")
+
+	if event.IsRecurring() {
+		f.Text("🔁")
+	}
+
+	switch event.Importance {
+	case daemon.ImportanceImportant:
+		f.Text("⚠️")
+	case daemon.ImportanceUrgent:
+		f.Text("🚨")
+	}
+
+	msg, msgFormatted := f.Build()
+
+	return msg, msgFormatted, nil
+}
+
+// preNotificationFromEvent creates the "starts in 15 minutes" variant of the event message.
+func preNotificationFromEvent(event *daemon.Event, timeZone string, clock string) (string, string, error) {
+	f := Formater{}
+
+	f.Text("⏰ ")
+	assigneeFromEvent(&f, event)
 	f.Bold(event.Message)
 	f.Text(" (#")
 	f.Text(strconv.Itoa(int(event.ID)))
 	f.Text(")")
 	f.NewLine()
 
-	f.Italic(ToShortLocalTime(event.EventTime, timeZone))
+	startTime := ToShortLocalTime(event.EventTime, timeZone, clock)
+	if *event.StartsIn >= 24*time.Hour {
+		startTime = ToLocalTime(event.EventTime, timeZone, clock)
+	}
+
+	f.Italic("starts in " + ToNiceDuration(*event.StartsIn) + " at " + startTime)
 	f.Text(" ")
 
-	if event.RepeatInterval != nil {
+	if event.IsRecurring() {
 		f.Text("🔁")
 	}
 
-	if event.Importance == daemon.ImportanceImportant {
+	switch event.Importance {
+	case daemon.ImportanceImportant:
 		f.Text("⚠️")
+	case daemon.ImportanceUrgent:
+		f.Text("🚨")
 	}
 
 	msg, msgFormatted := f.Build()
@@ -37,23 +83,50 @@ func MessageFromEvent(event *daemon.Event, timeZone string) (string, string, err
 	return msg, msgFormatted, nil
 }
 
+// assigneeFromEvent mentions the user the event is assigned to.
+func assigneeFromEvent(f *Formater, event *daemon.Event) {
+	if event.AssignedTo == "" {
+		return
+	}
+
+	f.Username(event.AssignedTo)
+	f.Text(": ")
+}
+
+// escalationFromEvent adds a line mentioning the users to notify if the event is escalated.
+func escalationFromEvent(f *Formater, event *daemon.Event) {
+	if event.Escalation == nil {
+		return
+	}
+
+	f.Text("🔺 ")
+
+	for _, user := range event.Escalation.Mentions {
+		f.Username(user)
+		f.Text(" ")
+	}
+
+	f.Italic("not acknowledged after " + strconv.Itoa(int(event.Escalation.Resends)+1) + " reminders")
+	f.NewLine()
+}
+
 // InfoFromEvent translates a database event into a nice human readable format.
-func InfoFromEvent(event *database.Event, timeZone string) (string, string) {
+func InfoFromEvent(event *database.Event, timeZone string, clock string) (string, string) {
 	loc := tzFromString(timeZone)
-	return infoFromEvent(event, loc)
+	return infoFromEvent(event, loc, clock)
 }
 
-func infoFromEvent(event *database.Event, loc *time.Location) (string, string) {
+func infoFromEvent(event *database.Event, loc *time.Location, clock string) (string, string) {
 	f := Formater{}
 	f.Text("➡️ ")
 	f.BoldLine(event.Message)
 	f.Text("at ")
-	f.Text(toLocalTime(event.Time, loc))
+	f.Text(toLocalTime(event.Time, loc, clock))
 	f.Text(" (ID: ")
 	f.Text(strconv.Itoa(int(event.ID)))
 	f.Text(") ")
 
-	if event.RepeatInterval != nil {
+	if event.IsRecurring() {
 		f.Italic("🔁 ")
 	}
 
@@ -63,11 +136,17 @@ func infoFromEvent(event *database.Event, loc *time.Location) (string, string) {
 
 	f.NewLine()
 
+	if len(event.Escalations) > 0 {
+		last := event.Escalations[len(event.Escalations)-1]
+		f.Italic("🔺 escalated " + strconv.Itoa(len(event.Escalations)) + "x, last at " + toLocalTime(last.CreatedAt, loc, clock))
+		f.NewLine()
+	}
+
 	return f.Build()
 }
 
 // InfoFromEvents translates multiple database events into a nice human readable format.
-func InfoFromEvents(events []database.Event, timeZone string) (string, string) {
+func InfoFromEvents(events []database.Event, timeZone string, clock string) (string, string) {
 	if len(events) == 0 {
 		return "no pending events found", "<i>no pending events found</i>"
 	}
@@ -103,7 +182,7 @@ func InfoFromEvents(events []database.Event, timeZone string) (string, string) {
 		strFormatted.WriteString("</b><br>\n")
 
 		for i := range eventsByHeader[header] {
-			msg, msgF := infoFromEvent(&eventsByHeader[header][len(eventsByHeader[header])-i-1], loc)
+			msg, msgF := infoFromEvent(&eventsByHeader[header][len(eventsByHeader[header])-i-1], loc, clock)
 			str.WriteString(msg)
 			strFormatted.WriteString(msgF)
 		}
@@ -113,15 +192,23 @@ func InfoFromEvents(events []database.Event, timeZone string) (string, string) {
 }
 
 // InfoFromDaemonEvents translates multiple daemon events into a nice human readable format.
-func InfoFromDaemonEvents(events []daemon.Event, timeZone string) (string, string) {
+// Events are numbered so they can be referenced in replies.
+func InfoFromDaemonEvents(events []daemon.Event, timeZone string, clock string, language string) (string, string) {
+	return InfoFromDaemonEventsFrom(events, 1, timeZone, clock, language)
+}
+
+// InfoFromDaemonEventsFrom translates multiple daemon events into a nice human readable format numbered starting
+// with first. Used to continue the numbering over several sections of one message.
+func InfoFromDaemonEventsFrom(events []daemon.Event, first int, timeZone string, clock string, language string) (string, string) {
 	if len(events) == 0 {
-		return "no pending events found", "<i>no pending events found</i>"
+		msg := i18n.Translate(language, "no pending events found")
+		return msg, "<i>" + msg + "</i>"
 	}
 
 	var str, strFormatted strings.Builder
 
 	for i := range events {
-		msg, msgF := InfoFromDaemonEvent(&events[i], timeZone)
+		msg, msgF := infoFromDaemonEvent(&events[i], timeZone, clock, language, strconv.Itoa(first+i)+". ")
 		str.WriteString(msg)
 		strFormatted.WriteString(msgF)
 	}
@@ -129,22 +216,38 @@ func InfoFromDaemonEvents(events []daemon.Event, timeZone string) (string, strin
 	return str.String(), strFormatted.String()
 }
 
+// RecurringCountFromDaemonEvents summarizes how many of the events of a daily reminder are recurring. Returns empty
+// strings if none are.
+func RecurringCountFromDaemonEvents(reminder *daemon.DailyReminder, language string) (string, string) {
+	count := reminder.RecurringCount()
+	if count == 0 {
+		return "", ""
+	}
+
+	msg := i18n.Translate(language, "🔁 1 recurring event")
+	if count > 1 {
+		msg = i18n.Translatef(language, "🔁 %d recurring events", count)
+	}
+
+	return msg, "<i>" + msg + "</i>"
+}
+
 // InfoFromDaemonEvent translates a daemon event into a nice human readable format.
-func InfoFromDaemonEvent(event *daemon.Event, timeZone string) (string, string) {
+func InfoFromDaemonEvent(event *daemon.Event, timeZone string, clock string, language string) (string, string) {
+	return infoFromDaemonEvent(event, timeZone, clock, language, "➡️ ")
+}
+
+func infoFromDaemonEvent(event *daemon.Event, timeZone string, clock string, language string, bullet string) (string, string) {
 	if event == nil {
 		return "", ""
 	}
 
 	f := Formater{}
-	f.Text("➡️ ")
+	f.Text(bullet)
 	f.BoldLine(event.Message)
-	f.Text("at ")
-	f.Text(ToLocalTime(event.EventTime, timeZone))
-	f.Text(" (ID: ")
-	f.Text(strconv.Itoa(int(event.ID)))
-	f.Text(") ")
+	f.Text(i18n.Translatef(language, "at %s (ID: %d) ", ToLocalTime(event.EventTime, timeZone, clock), event.ID))
 
-	if event.RepeatInterval != nil {
+	if event.IsRecurring() {
 		f.Italic("🔁 ")
 	}
 
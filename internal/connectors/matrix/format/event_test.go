@@ -17,7 +17,7 @@ func TestMessageFromEvent(t *testing.T) {
 		Message:   "my event",
 		ID:        1,
 		EventTime: refTime(),
-	}, "")
+	}, "", format.Clock24h)
 	require.NoError(t, err)
 	assert.Equal(
 		t,
@@ -38,7 +38,7 @@ func TestMessageFromEventWithRecurring(t *testing.T) {
 		ID:             1,
 		EventTime:      refTime(),
 		RepeatInterval: &dur,
-	}, "")
+	}, "", format.Clock24h)
 	require.NoError(t, err)
 	assert.Equal(
 		t,
@@ -53,6 +53,99 @@ func TestMessageFromEventWithRecurring(t *testing.T) {
 	)
 }
 
+func TestMessageFromEventWithAssignee(t *testing.T) {
+	msg, msgF, err := format.MessageFromEvent(&daemon.Event{
+		Message:    "my event",
+		ID:         1,
+		EventTime:  refTime(),
+		AssignedTo: "@alice:example.com",
+	}, "", format.Clock24h)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`🔔 alice: MY EVENT (#1)
+11:45 (UTC) `,
+		msg,
+	)
+	assert.Equal(
+		t,
+		`🔔 <a href="https://matrix.to/#/@alice:example.com">alice</a>: <b>my event</b> (#1)<br><i>11:45 (UTC)</i> `,
+		msgF,
+	)
+}
+
+func TestMessageFromEventWithEscalation(t *testing.T) {
+	msg, msgF, err := format.MessageFromEvent(&daemon.Event{
+		Message:    "my event",
+		ID:         1,
+		EventTime:  refTime(),
+		Importance: daemon.ImportanceImportant,
+		Escalation: &daemon.Escalation{
+			Level:    1,
+			Resends:  3,
+			Mentions: []string{"@alice:example.com"},
+		},
+	}, "", format.Clock24h)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`🔺 alice not acknowledged after 4 reminders
+🔔 MY EVENT (#1)
+11:45 (UTC) ⚠️`,
+		msg,
+	)
+	assert.Equal(
+		t,
+		`🔺 <a href="https://matrix.to/#/@alice:example.com">alice</a> <i>not acknowledged after 4 reminders</i><br>🔔 <b>my event</b> (#1)<br><i>11:45 (UTC)</i> ⚠️`,
+		msgF,
+	)
+}
+
+func TestMessageFromEventWithUrgentEvent(t *testing.T) {
+	msg, _, err := format.MessageFromEvent(&daemon.Event{
+		Message:    "my event",
+		ID:         1,
+		EventTime:  refTime(),
+		Importance: daemon.ImportanceUrgent,
+	}, "", format.Clock24h)
+	require.NoError(t, err)
+	assert.Equal(t, "🔔 MY EVENT (#1)\n11:45 (UTC) 🚨", msg)
+}
+
+func TestMessageFromEventWithPreNotification(t *testing.T) {
+	dur := time.Hour
+	msg, msgF, err := format.MessageFromEvent(&daemon.Event{
+		Message:        "my event",
+		ID:             1,
+		EventTime:      refTime(),
+		RepeatInterval: &dur,
+		StartsIn:       new(15 * time.Minute),
+	}, "", format.Clock24h)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`⏰ MY EVENT (#1)
+starts in 15 minutes at 11:45 (UTC) 🔁`,
+		msg,
+	)
+	assert.Equal(
+		t,
+		`⏰ <b>my event</b> (#1)<br><i>starts in 15 minutes at 11:45 (UTC)</i> 🔁`,
+		msgF,
+	)
+}
+
+func TestMessageFromEventWithPreNotificationDaysAhead(t *testing.T) {
+	msg, _, err := format.MessageFromEvent(&daemon.Event{
+		Message:   "my event",
+		ID:        1,
+		EventTime: refTime(),
+		StartsIn:  new(48 * time.Hour),
+	}, "", format.Clock24h)
+	require.NoError(t, err)
+	assert.Contains(t, msg, "starts in 2 days at 11:45 ")
+}
+
 func TestInfoFromEvent(t *testing.T) {
 	msg, msgF := format.InfoFromEvent(&database.Event{
 		Model: gorm.Model{
@@ -60,7 +153,7 @@ func TestInfoFromEvent(t *testing.T) {
 		},
 		Message: "my event",
 		Time:    refTime(),
-	}, "")
+	}, "", format.Clock24h)
 
 	assert.Equal(
 		t,
@@ -76,6 +169,29 @@ at 11:45 12.11.2014 (UTC) (ID: 1)
 	)
 }
 
+func TestInfoFromEventWithEscalations(t *testing.T) {
+	escalation := database.EventEscalation{Level: 1}
+	escalation.CreatedAt = refTime().Add(time.Hour)
+
+	msg, _ := format.InfoFromEvent(&database.Event{
+		Model: gorm.Model{
+			ID: 1,
+		},
+		Message:     "my event",
+		Time:        refTime(),
+		Escalations: []database.EventEscalation{{}, escalation},
+	}, "", format.Clock24h)
+
+	assert.Equal(
+		t,
+		`➡️ MY EVENT
+at 11:45 12.11.2014 (UTC) (ID: 1) 
+🔺 escalated 2x, last at 12:45 12.11.2014 (UTC)
+`,
+		msg,
+	)
+}
+
 func TestInfoFromEventWithRecurring(t *testing.T) {
 	dur := time.Hour
 	msg, msgF := format.InfoFromEvent(&database.Event{
@@ -85,7 +201,7 @@ func TestInfoFromEventWithRecurring(t *testing.T) {
 		Message:        "my event",
 		Time:           refTime(),
 		RepeatInterval: &dur,
-	}, "")
+	}, "", format.Clock24h)
 
 	assert.Equal(
 		t,
@@ -131,7 +247,7 @@ func TestInfoFromEvents(t *testing.T) {
 			Message: "my event 4",
 			Time:    refTime().Add(time.Hour * 24 * 30),
 		},
-	}, "")
+	}, "", format.Clock24h)
 
 	assert.Equal(
 		t,
@@ -146,7 +262,7 @@ func TestInfoFromEvents(t *testing.T) {
 }
 
 func TestInfoFromEventsWithNoEvent(t *testing.T) {
-	msg, msgF := format.InfoFromEvents(nil, "")
+	msg, msgF := format.InfoFromEvents(nil, "", format.Clock24h)
 
 	assert.Equal(
 		t,
@@ -165,6 +281,7 @@ func TestInfoFromDaemonEvent(t *testing.T) {
 		name                 string
 		event                *daemon.Event
 		timeZone             string
+		language             string
 		expectedMsg          string
 		expectedFormattedMsg string
 	}{
@@ -200,11 +317,22 @@ func TestInfoFromDaemonEvent(t *testing.T) {
 			expectedMsg:          "➡️ MY EVENT\nat 06:45 12.11.2014 (EST) (ID: 0) \n",
 			expectedFormattedMsg: "➡️ <b>my event</b><br>at 06:45 12.11.2014 (EST) (ID: 0) <br>",
 		},
+		{
+			name: "simple event in german",
+			event: &daemon.Event{
+				ID:        3,
+				Message:   "my event",
+				EventTime: refTime(),
+			},
+			language:             "de",
+			expectedMsg:          "➡️ MY EVENT\num 11:45 12.11.2014 (UTC) (ID: 3) \n",
+			expectedFormattedMsg: "➡️ <b>my event</b><br>um 11:45 12.11.2014 (UTC) (ID: 3) <br>",
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			msg, msgFormatted := format.InfoFromDaemonEvent(tc.event, tc.timeZone)
+			msg, msgFormatted := format.InfoFromDaemonEvent(tc.event, tc.timeZone, format.Clock24h, tc.language)
 			assert.Equal(t, tc.expectedMsg, msg)
 			assert.Equal(t, tc.expectedFormattedMsg, msgFormatted)
 		})
@@ -223,20 +351,65 @@ func TestInfoFromDaemonEvents(t *testing.T) {
 				Message:   "my event2",
 				EventTime: refTime(),
 			},
-		}, "",
+		}, "", format.Clock24h, "",
+	)
+
+	assert.Equal(t, "1. MY EVENT\nat 11:45 12.11.2014 (UTC) (ID: 0) 🔁 \n2. MY EVENT2\nat 11:45 12.11.2014 (UTC) (ID: 0) \n", msg)
+	assert.Equal(t, "1. <b>my event</b><br>at 11:45 12.11.2014 (UTC) (ID: 0) <i>🔁 </i><br>2. <b>my event2</b><br>at 11:45 12.11.2014 (UTC) (ID: 0) <br>", formattedMsg)
+}
+
+func TestInfoFromDaemonEventsFrom(t *testing.T) {
+	msg, formattedMsg := format.InfoFromDaemonEventsFrom(
+		[]daemon.Event{
+			{
+				Message:   "my event",
+				EventTime: refTime(),
+			},
+		}, 3, "", format.Clock24h, "",
 	)
 
-	assert.Equal(t, "➡️ MY EVENT\nat 11:45 12.11.2014 (UTC) (ID: 0) 🔁 \n➡️ MY EVENT2\nat 11:45 12.11.2014 (UTC) (ID: 0) \n", msg)
-	assert.Equal(t, "➡️ <b>my event</b><br>at 11:45 12.11.2014 (UTC) (ID: 0) <i>🔁 </i><br>➡️ <b>my event2</b><br>at 11:45 12.11.2014 (UTC) (ID: 0) <br>", formattedMsg)
+	assert.Equal(t, "3. MY EVENT\nat 11:45 12.11.2014 (UTC) (ID: 0) \n", msg)
+	assert.Equal(t, "3. <b>my event</b><br>at 11:45 12.11.2014 (UTC) (ID: 0) <br>", formattedMsg)
+}
+
+func TestRecurringCountFromDaemonEvents(t *testing.T) {
+	reminder := &daemon.DailyReminder{
+		Events: []daemon.Event{
+			{Message: "my event", RepeatInterval: toP(time.Hour)},
+			{Message: "my event2"},
+		},
+	}
+
+	msg, formattedMsg := format.RecurringCountFromDaemonEvents(reminder, "")
+	assert.Equal(t, "🔁 1 recurring event", msg)
+	assert.Equal(t, "<i>🔁 1 recurring event</i>", formattedMsg)
+
+	reminder.Overdue = []daemon.Event{{Message: "my event3", RepeatRule: "FREQ=DAILY"}}
+
+	msg, _ = format.RecurringCountFromDaemonEvents(reminder, "de")
+	assert.Equal(t, "🔁 2 wiederkehrende Termine", msg)
+}
+
+func TestRecurringCountFromDaemonEventsWithoutRecurringEvents(t *testing.T) {
+	msg, formattedMsg := format.RecurringCountFromDaemonEvents(&daemon.DailyReminder{}, "")
+	assert.Empty(t, msg)
+	assert.Empty(t, formattedMsg)
 }
 
 func TestInfoFromDaemonEventsWithNoEvents(t *testing.T) {
 	msg, formattedMsg := format.InfoFromDaemonEvents(
-		nil, "",
+		nil, "", format.Clock24h, "",
 	)
 
 	assert.Equal(t, "no pending events found", msg)
 	assert.Equal(t, "<i>no pending events found</i>", formattedMsg)
+
+	msg, formattedMsg = format.InfoFromDaemonEvents(
+		nil, "", format.Clock24h, "de",
+	)
+
+	assert.Equal(t, "keine anstehenden Termine gefunden", msg)
+	assert.Equal(t, "<i>keine anstehenden Termine gefunden</i>", formattedMsg)
 }
 
 func toP[T any](elem T) *T {
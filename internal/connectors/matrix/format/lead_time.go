@@ -0,0 +1,42 @@
+package format
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/CubicrootXYZ/gonaturalduration"
+)
+
+var leadTimeSeparatorRegex = regexp.MustCompile(`(?i),| and | & `)
+
+// ParseLeadTimes parses the durations from messages like "remind me 1 day and 15 minutes before".
+// Durations shorter than a minute are ignored. The result is sorted from the longest to the shortest duration.
+func ParseLeadTimes(msg string) []time.Duration {
+	leadTimes := []time.Duration{}
+
+	for _, part := range leadTimeSeparatorRegex.Split(StripReply(msg), -1) {
+		leadTime := gonaturalduration.ParseNumber(part)
+		if leadTime < time.Minute || slices.Contains(leadTimes, leadTime) {
+			continue
+		}
+
+		leadTimes = append(leadTimes, leadTime)
+	}
+
+	slices.Sort(leadTimes)
+	slices.Reverse(leadTimes)
+
+	return leadTimes
+}
+
+// ToNiceLeadTimes formats the lead times into a string like "1 day and 15 minutes".
+func ToNiceLeadTimes(leadTimes []time.Duration) string {
+	durations := make([]string, 0, len(leadTimes))
+	for _, leadTime := range leadTimes {
+		durations = append(durations, ToNiceDuration(leadTime))
+	}
+
+	return strings.TrimSpace(joinWords(durations))
+}
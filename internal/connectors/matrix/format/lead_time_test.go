@@ -0,0 +1,31 @@
+package format_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLeadTimes(t *testing.T) {
+	testCases := map[string][]time.Duration{
+		"remind me 15 minutes before":                   {15 * time.Minute},
+		"notify me 15 minutes and 2 days before events": {48 * time.Hour, 15 * time.Minute},
+		"notify us 1 hour, 10 minutes & 1 hour before":  {time.Hour, 10 * time.Minute},
+		"remind me 10 seconds before":                   {},
+		"remind me before":                              {},
+	}
+
+	for msg, expected := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			assert.Equal(t, expected, format.ParseLeadTimes(msg))
+		})
+	}
+}
+
+func TestToNiceLeadTimes(t *testing.T) {
+	assert.Equal(t, "15 minutes", format.ToNiceLeadTimes([]time.Duration{15 * time.Minute}))
+	assert.Equal(t, "3 days, 2 hours and 15 minutes", format.ToNiceLeadTimes([]time.Duration{72 * time.Hour, 2 * time.Hour, 15 * time.Minute}))
+	assert.Empty(t, format.ToNiceLeadTimes(nil))
+}
@@ -0,0 +1,339 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/teambition/rrule-go"
+)
+
+// ErrNoRepeatRule is returned if a message does not describe a calendar based recurrence.
+var ErrNoRepeatRule = errors.New("no repeat rule found in message")
+
+var (
+	weekdayNames = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+	weekdayCodes = []string{"MO", "TU", "WE", "TH", "FR", "SA", "SU"}
+
+	ordinals = map[string]int{
+		"first": 1, "1st": 1,
+		"second": 2, "2nd": 2,
+		"third": 3, "3rd": 3,
+		"fourth": 4, "4th": 4,
+		"last": -1,
+	}
+	intervalWords = map[string]int{
+		"other": 2, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+		"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+	}
+
+	workdaysRegex       = regexp.MustCompile(`\b(week ?day|work ?day|working day|business day)s?\b`)
+	weekendRegex        = regexp.MustCompile(`\bweekends?\b`)
+	lastDayOfMonthRegex = regexp.MustCompile(`\blast day\b`)
+	ordinalWeekdayRegex = regexp.MustCompile(`\b(first|1st|second|2nd|third|3rd|fourth|4th|last) (monday|tuesday|wednesday|thursday|friday|saturday|sunday)\b`)
+	weekdayRegex        = regexp.MustCompile(`\b(monday|tuesday|wednesday|thursday|friday|saturday|sunday)s?\b`)
+	monthDayRegex       = regexp.MustCompile(`\b([0-9]{1,2})(st|nd|rd|th)\b`)
+	frequencyRegex      = regexp.MustCompile(`^(repeat |repeat every |every |each |always |all |any )?(?:(other|[0-9]+|[0-9]+(?:st|nd|rd|th)|[a-z]+) )?(day|week|month|year)s?$`)
+	monthIntervalRegex  = regexp.MustCompile(`\b(other|[0-9]+|[a-z]+) months\b`)
+	simpleFrequencies   = map[string]string{
+		"daily":    "DAILY",
+		"weekly":   "WEEKLY",
+		"monthly":  "MONTHLY",
+		"yearly":   "YEARLY",
+		"annually": "YEARLY",
+	}
+)
+
+// ParseRepeatRule parses calendar based recurrences like "every month on the 1st",
+// "every last friday" or "every weekday" from the message. The returned rule starts
+// at the given time in the given time zone.
+func ParseRepeatRule(msg string, start time.Time, timeZone string) (string, error) {
+	rule := repeatRuleFromMessage(msg)
+	if rule == "" {
+		return "", ErrNoRepeatRule
+	}
+
+	return database.NewRepeatRule(rule, start.In(tzFromString(timeZone)))
+}
+
+func repeatRuleFromMessage(msg string) string {
+	msg = strings.Join(strings.Fields(strings.ToLower(strings.Trim(msg, " .!"))), " ")
+
+	if freq, ok := simpleFrequencies[strings.TrimPrefix(msg, "repeat ")]; ok {
+		return "FREQ=" + freq
+	}
+
+	switch {
+	case workdaysRegex.MatchString(msg):
+		return "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"
+	case weekendRegex.MatchString(msg):
+		return "FREQ=WEEKLY;BYDAY=SA,SU"
+	case lastDayOfMonthRegex.MatchString(msg):
+		return "FREQ=MONTHLY" + monthIntervalFromMessage(msg) + ";BYMONTHDAY=-1"
+	}
+
+	if rule := frequencyRuleFromMessage(msg); rule != "" {
+		return rule
+	}
+
+	if rule := weekdayRuleFromMessage(msg); rule != "" {
+		return rule
+	}
+
+	return monthDayRuleFromMessage(msg)
+}
+
+// frequencyRuleFromMessage handles "every 3 days" like messages.
+func frequencyRuleFromMessage(msg string) string {
+	matches := frequencyRegex.FindStringSubmatch(msg)
+	if matches == nil {
+		return ""
+	}
+
+	interval := 1
+	if matches[2] != "" {
+		var ok bool
+
+		interval, ok = intervalFromString(matches[2])
+		if !ok {
+			return ""
+		}
+	}
+
+	rule := "FREQ=" + strings.ToUpper(matches[3]) + "LY"
+	if matches[3] == "day" {
+		rule = "FREQ=DAILY"
+	}
+
+	if interval > 1 {
+		rule += ";INTERVAL=" + strconv.Itoa(interval)
+	}
+
+	return rule
+}
+
+// weekdayRuleFromMessage handles "every last friday" or "every monday and thursday" like messages.
+func weekdayRuleFromMessage(msg string) string {
+	if matches := ordinalWeekdayRegex.FindStringSubmatch(msg); matches != nil {
+		// "every second monday" most likely means every other week if no month is mentioned.
+		if ordinals[matches[1]] != 2 || strings.Contains(msg, "month") {
+			return fmt.Sprintf(
+				"FREQ=MONTHLY%s;BYDAY=%d%s",
+				monthIntervalFromMessage(msg),
+				ordinals[matches[1]],
+				weekdayCodes[slices.Index(weekdayNames, matches[2])],
+			)
+		}
+
+		msg = strings.Replace(msg, matches[1], "other", 1)
+	}
+
+	matches := weekdayRegex.FindAllStringSubmatch(msg, -1)
+	if len(matches) == 0 {
+		return ""
+	}
+
+	days := make([]string, 0, len(matches))
+	for _, match := range matches {
+		day := weekdayCodes[slices.Index(weekdayNames, match[1])]
+		if !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+
+	rule := "FREQ=WEEKLY"
+	if strings.Contains(msg, "other") {
+		rule += ";INTERVAL=2"
+	}
+
+	return rule + ";BYDAY=" + strings.Join(days, ",")
+}
+
+// monthDayRuleFromMessage handles "every month on the 1st and 15th" like messages.
+func monthDayRuleFromMessage(msg string) string {
+	matches := monthDayRegex.FindAllStringSubmatch(msg, -1)
+	if len(matches) == 0 {
+		return ""
+	}
+
+	days := make([]string, 0, len(matches))
+
+	for _, match := range matches {
+		day, err := strconv.Atoi(match[1])
+		if err != nil || day < 1 || day > 31 {
+			return ""
+		}
+
+		days = append(days, strconv.Itoa(day))
+	}
+
+	return "FREQ=MONTHLY" + monthIntervalFromMessage(msg) + ";BYMONTHDAY=" + strings.Join(days, ",")
+}
+
+func monthIntervalFromMessage(msg string) string {
+	matches := monthIntervalRegex.FindStringSubmatch(msg)
+	if matches == nil {
+		return ""
+	}
+
+	interval, ok := intervalFromString(matches[1])
+	if !ok || interval < 2 {
+		return ""
+	}
+
+	return ";INTERVAL=" + strconv.Itoa(interval)
+}
+
+func intervalFromString(value string) (int, bool) {
+	if interval, ok := intervalWords[value]; ok {
+		return interval, true
+	}
+
+	interval, err := strconv.Atoi(strings.TrimRight(value, "stndrh"))
+	if err != nil || interval < 1 {
+		return 0, false
+	}
+
+	return interval, true
+}
+
+// ToNiceRepeatRule formats a repeat rule into a human readable string like "every month on the 1st".
+func ToNiceRepeatRule(rule string) string {
+	opts, err := rrule.StrToROption(rule)
+	if err != nil {
+		return "repeatedly"
+	}
+
+	interval := max(opts.Interval, 1)
+
+	switch opts.Freq {
+	case rrule.WEEKLY:
+		return weeklyRuleToString(opts.Byweekday, interval)
+	case rrule.MONTHLY:
+		return monthlyRuleToString(opts, interval)
+	case rrule.DAILY:
+		return everyInterval("day", interval)
+	case rrule.YEARLY:
+		return everyInterval("year", interval)
+	case rrule.HOURLY:
+		return everyInterval("hour", interval)
+	case rrule.MINUTELY:
+		return everyInterval("minute", interval)
+	case rrule.SECONDLY:
+		return everyInterval("second", interval)
+	}
+
+	return "repeatedly"
+}
+
+func weeklyRuleToString(weekdays []rrule.Weekday, interval int) string {
+	days := make([]string, 0, len(weekdays))
+	for _, weekday := range weekdays {
+		days = append(days, weekdayName(weekday))
+	}
+
+	switch {
+	case len(days) == 0:
+		return everyInterval("week", interval)
+	case interval == 1 && slices.Equal(days, []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"}):
+		return "every weekday"
+	case interval == 1 && slices.Equal(days, []string{"Saturday", "Sunday"}):
+		return "every weekend"
+	case interval == 1:
+		return "every " + joinWords(days)
+	case interval == 2:
+		return "every other " + joinWords(days)
+	}
+
+	return everyInterval("week", interval) + " on " + joinWords(days)
+}
+
+func monthlyRuleToString(opts *rrule.ROption, interval int) string {
+	prefix := everyInterval("month", interval)
+
+	if len(opts.Byweekday) > 0 {
+		days := make([]string, 0, len(opts.Byweekday))
+		for _, weekday := range opts.Byweekday {
+			days = append(days, strings.TrimSpace(ordinalName(weekday.N())+" "+weekdayName(weekday)))
+		}
+
+		return prefix + " on the " + joinWords(days)
+	}
+
+	if len(opts.Bymonthday) > 0 {
+		days := make([]string, 0, len(opts.Bymonthday))
+		for _, day := range opts.Bymonthday {
+			days = append(days, dayOfMonthName(day))
+		}
+
+		return prefix + " on the " + joinWords(days)
+	}
+
+	return prefix
+}
+
+func everyInterval(unit string, interval int) string {
+	switch interval {
+	case 1:
+		return "every " + unit
+	case 2:
+		return "every other " + unit
+	}
+
+	return fmt.Sprintf("every %d %ss", interval, unit)
+}
+
+func weekdayName(weekday rrule.Weekday) string {
+	name := weekdayNames[weekday.Day()]
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func ordinalName(n int) string {
+	switch n {
+	case -1:
+		return "last"
+	case 1:
+		return "first"
+	case 2:
+		return "second"
+	case 3:
+		return "third"
+	case 4:
+		return "fourth"
+	}
+
+	return ""
+}
+
+func dayOfMonthName(day int) string {
+	if day == -1 {
+		return "last day"
+	}
+
+	suffix := "th"
+
+	switch {
+	case day%100 >= 11 && day%100 <= 13:
+	case day%10 == 1:
+		suffix = "st"
+	case day%10 == 2:
+		suffix = "nd"
+	case day%10 == 3:
+		suffix = "rd"
+	}
+
+	return strconv.Itoa(day) + suffix
+}
+
+func joinWords(words []string) string {
+	if len(words) < 2 {
+		return strings.Join(words, "")
+	}
+
+	return strings.Join(words[:len(words)-1], ", ") + " and " + words[len(words)-1]
+}
@@ -0,0 +1,89 @@
+package format_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRepeatRule(t *testing.T) {
+	// Wednesday
+	start := time.Date(2124, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	testCases := map[string]string{
+		"every day":                        "FREQ=DAILY",
+		"daily":                            "FREQ=DAILY",
+		"every 3 days":                     "FREQ=DAILY;INTERVAL=3",
+		"every other week":                 "FREQ=WEEKLY;INTERVAL=2",
+		"each two weeks":                   "FREQ=WEEKLY;INTERVAL=2",
+		"every month":                      "FREQ=MONTHLY",
+		"every 6 months":                   "FREQ=MONTHLY;INTERVAL=6",
+		"every year":                       "FREQ=YEARLY",
+		"every weekday":                    "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+		"every working day":                "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+		"every weekend":                    "FREQ=WEEKLY;BYDAY=SA,SU",
+		"every monday":                     "FREQ=WEEKLY;BYDAY=MO",
+		"every Monday and Thursday":        "FREQ=WEEKLY;BYDAY=MO,TH",
+		"every other friday":               "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR",
+		"every second friday":              "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR",
+		"every second friday of the month": "FREQ=MONTHLY;BYDAY=+2FR",
+		"every last friday":                "FREQ=MONTHLY;BYDAY=-1FR",
+		"every first monday of the month":  "FREQ=MONTHLY;BYDAY=+1MO",
+		"every month on the 1st":           "FREQ=MONTHLY;BYMONTHDAY=1",
+		"every month on the 1st and 15th":  "FREQ=MONTHLY;BYMONTHDAY=1,15",
+		"every 3 months on the 15th":       "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=15",
+		"every month on the last day":      "FREQ=MONTHLY;BYMONTHDAY=-1",
+		"every 2nd day":                    "FREQ=DAILY;INTERVAL=2",
+	}
+
+	for msg, expectedRule := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			rule, err := format.ParseRepeatRule(msg, start, "UTC")
+			require.NoError(t, err)
+			assert.Equal(t, "DTSTART:21240301T093000Z\nRRULE:"+expectedRule, rule)
+		})
+	}
+}
+
+func TestParseRepeatRuleWithTimeZone(t *testing.T) {
+	rule, err := format.ParseRepeatRule("every month on the 1st", time.Date(2124, 3, 1, 9, 30, 0, 0, time.UTC), "Europe/Berlin")
+	require.NoError(t, err)
+	assert.Equal(t, "DTSTART;TZID=Europe/Berlin:21240301T103000\nRRULE:FREQ=MONTHLY;BYMONTHDAY=1", rule)
+}
+
+func TestParseRepeatRuleWithoutRule(t *testing.T) {
+	for _, msg := range []string{"every 2 hours", "each twenty two hours and five seconds", "every 10 minutes", "every 40th"} {
+		t.Run(msg, func(t *testing.T) {
+			_, err := format.ParseRepeatRule(msg, time.Now(), "UTC")
+			require.ErrorIs(t, err, format.ErrNoRepeatRule)
+		})
+	}
+}
+
+func TestToNiceRepeatRule(t *testing.T) {
+	testCases := map[string]string{
+		"RRULE:FREQ=DAILY":                                           "every day",
+		"RRULE:FREQ=DAILY;INTERVAL=3":                                "every 3 days",
+		"RRULE:FREQ=WEEKLY;INTERVAL=2":                               "every other week",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR":                     "every weekday",
+		"RRULE:FREQ=WEEKLY;BYDAY=SA,SU":                              "every weekend",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR":                           "every Monday, Wednesday and Friday",
+		"RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=FR":                      "every other Friday",
+		"RRULE:FREQ=WEEKLY;INTERVAL=3;BYDAY=FR":                      "every 3 weeks on Friday",
+		"RRULE:FREQ=MONTHLY;BYDAY=-1FR":                              "every month on the last Friday",
+		"RRULE:FREQ=MONTHLY;BYMONTHDAY=1,22":                         "every month on the 1st and 22nd",
+		"RRULE:FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=-1":                "every 3 months on the last day",
+		"DTSTART:21240301T093000Z\nRRULE:FREQ=YEARLY":                "every year",
+		"DTSTART:21240301T093000Z\nRRULE:FREQ=MONTHLY;BYMONTHDAY=11": "every month on the 11th",
+		"invalid": "repeatedly",
+	}
+
+	for rule, expected := range testCases {
+		t.Run(rule, func(t *testing.T) {
+			assert.Equal(t, expected, format.ToNiceRepeatRule(rule))
+		})
+	}
+}
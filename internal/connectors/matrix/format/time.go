@@ -2,9 +2,11 @@ package format
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 	"github.com/tj/go-naturaldate"
 
@@ -22,20 +24,54 @@ const (
 	DefaultReminderTime = 9 * time.Hour
 )
 
-// ParseTime parses the time from the input.
+// timePrefixes are words that belong to the time expression if they precede it, e.g. "at" in "at 5 pm".
+var timePrefixes = []string{"at", "on", "in", "by", "the", "next", "this"}
+
+// ParsedTime is a time parsed from a message.
+type ParsedTime struct {
+	Time time.Time
+	// Expression holds the words of the message describing the time, e.g. "at 5 pm".
+	Expression string
+	// Text is the message without the time expression, e.g. "buy milk".
+	Text string
+}
+
+// ParseTime parses the time from the input written in the given language.
 // If a timezone is given the returned time.Time will be in that timezone.
 // rawDate disabled will try to find a date in the future.
-func ParseTime(channel *database.Channel, msg string, timeZone string, rawDate bool) (time.Time, error) {
-	// Clear body from characters the library can not handle
-	msg = string(alphaNumericString([]byte(StripReply(msg))))
+func ParseTime(channel *database.Channel, msg string, timeZone string, language string, rawDate bool) (time.Time, error) {
+	loc := tzFromString(timeZone)
 
-	loc := time.UTC
-	if parsedLoc, err := time.LoadLocation(timeZone); err == nil {
-		loc = parsedLoc
-	}
+	return parseTime(channel, msg, language, time.Now().In(loc), rawDate)
+}
 
+// ParseTimeAndText parses the time like ParseTime and separates the time expression from the remaining text.
+// If the message consists of the time expression only the text is the whole message.
+func ParseTimeAndText(channel *database.Channel, msg string, timeZone string, language string) (*ParsedTime, error) {
+	loc := tzFromString(timeZone)
 	baseTime := time.Now().In(loc)
 
+	parsedTime, err := parseTime(channel, msg, language, baseTime, false)
+	if err != nil {
+		return nil, err
+	}
+
+	text, expression := splitTimeExpression(msg, language, baseTime)
+	if text == "" {
+		text = strings.TrimSpace(StripReply(msg))
+	}
+
+	return &ParsedTime{
+		Time:       parsedTime,
+		Expression: expression,
+		Text:       text,
+	}, nil
+}
+
+func parseTime(channel *database.Channel, msg string, language string, baseTime time.Time, rawDate bool) (time.Time, error) {
+	loc := baseTime.Location()
+	msg = normalizeTimeExpression(msg, language)
+
 	parsedTime, err := naturaldate.Parse(msg, baseTime, naturaldate.WithDirection(naturaldate.Future))
 	if err != nil {
 		return parsedTime, err
@@ -63,6 +99,57 @@ func ParseTime(channel *database.Channel, msg string, timeZone string, rawDate b
 	return parsedTime.In(loc), nil
 }
 
+// normalizeTimeExpression translates the message to English and clears it from characters the library can not handle.
+func normalizeTimeExpression(msg string, language string) string {
+	return string(alphaNumericString([]byte(i18n.DatesToEnglish(language, StripReply(msg)))))
+}
+
+// splitTimeExpression separates the words describing the time from the remaining text of the message.
+// A word belongs to the time expression if the parsed time changes without it.
+func splitTimeExpression(msg string, language string, baseTime time.Time) (text, expression string) {
+	words := strings.Fields(StripReply(msg))
+
+	expected, err := naturaldate.Parse(normalizeTimeExpression(msg, language), baseTime, naturaldate.WithDirection(naturaldate.Future))
+	if err != nil {
+		return strings.Join(words, " "), ""
+	}
+
+	isTime := make([]bool, len(words))
+
+	for i := range words {
+		withoutWord := strings.Join(slices.Concat(words[:i], words[i+1:]), " ")
+
+		parsed, err := naturaldate.Parse(normalizeTimeExpression(withoutWord, language), baseTime, naturaldate.WithDirection(naturaldate.Future))
+		isTime[i] = err != nil || !parsed.Equal(expected)
+	}
+
+	// Words without meaning for the parser still belong to the time expression around them.
+	for i := len(words) - 2; i >= 0; i-- {
+		if isTime[i+1] && slices.Contains(timePrefixes, strings.ToLower(i18n.DatesToEnglish(language, words[i]))) {
+			isTime[i] = true
+		}
+	}
+
+	for i := 1; i < len(words); i++ {
+		if isTime[i-1] && i18n.DatesToEnglish(language, words[i]) == "" {
+			isTime[i] = true
+		}
+	}
+
+	textWords := []string{}
+	expressionWords := []string{}
+
+	for i, word := range words {
+		if isTime[i] {
+			expressionWords = append(expressionWords, word)
+		} else {
+			textWords = append(textWords, word)
+		}
+	}
+
+	return strings.TrimRight(strings.Join(textWords, " "), " ,;:-"), strings.Join(expressionWords, " ")
+}
+
 func alphaNumericString(in []byte) []byte {
 	out := make([]byte, len(in))
 
@@ -80,36 +167,18 @@ func alphaNumericString(in []byte) []byte {
 	return out
 }
 
-// ToLocalTime converts the time object to a localized time string
-func ToLocalTime(datetime time.Time, timezone string) string {
-	if timezone == "" {
-		return datetime.UTC().Format(DateTimeFormatDefault)
-	}
-
-	loc, err := time.LoadLocation(timezone)
-	if err != nil {
-		return datetime.UTC().Format(DateTimeFormatDefault)
-	}
-
-	return datetime.In(loc).Format(DateTimeFormatDefault)
+// ToLocalTime converts the time object to a localized time string in the given clock.
+func ToLocalTime(datetime time.Time, timezone string, clock string) string {
+	return toLocalTime(datetime, tzFromString(timezone), clock)
 }
 
-// ToShortLocalTime converts the time object to a short localized time string
-func ToShortLocalTime(datetime time.Time, timezone string) string {
-	if timezone == "" {
-		return datetime.UTC().Format(TimeFormatShort)
-	}
-
-	loc, err := time.LoadLocation(timezone)
-	if err != nil {
-		return datetime.UTC().Format(TimeFormatShort)
-	}
-
-	return datetime.In(loc).Format(TimeFormatShort)
+// ToShortLocalTime converts the time object to a short localized time string in the given clock.
+func ToShortLocalTime(datetime time.Time, timezone string, clock string) string {
+	return datetime.In(tzFromString(timezone)).Format(clockLayout(TimeFormatShort, clock))
 }
 
-func toLocalTime(datetime time.Time, loc *time.Location) string {
-	return datetime.In(loc).Format(DateTimeFormatDefault)
+func toLocalTime(datetime time.Time, loc *time.Location, clock string) string {
+	return datetime.In(loc).Format(clockLayout(DateTimeFormatDefault, clock))
 }
 
 // TimeToHourAndMinute converts a time object to an string with the hour and minute in 24h format.
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -27,7 +28,7 @@ func TestParseTime(t *testing.T) {
 	testCases["Asia/Jakarta"] = "reminder for tomorrow 01-02-2022 18:45"
 
 	for timeZone, msg := range testCases {
-		is, err := format.ParseTime(&database.Channel{}, msg, timeZone, false)
+		is, err := format.ParseTime(&database.Channel{}, msg, timeZone, "", false)
 
 		require.NoError(t, err, "Can not parse "+msg+" / "+timeZone)
 		assert.Equal(t, "11:45", is.UTC().Format("15:04"), "Wrong date from "+msg+" / "+timeZone)
@@ -55,13 +56,67 @@ func TestParseTimeWithChannelDefault(t *testing.T) {
 	for timeZone, msg := range testCases {
 		is, err := format.ParseTime(&database.Channel{
 			DefaultReminderTime: new(uint(705)),
-		}, msg, timeZone, false)
+		}, msg, timeZone, "", false)
 
 		require.NoError(t, err, "Can not parse "+msg+" / "+timeZone)
 		assert.Equal(t, "11:45", is.UTC().Format("15:04"), "Wrong date from "+msg+" / "+timeZone)
 	}
 }
 
+func TestParseTimeInGerman(t *testing.T) {
+	testCases := []string{
+		"morgen um 11:45",
+		"Zahnarzt morgen um 11:45 Uhr",
+		"Zahnarzt nächsten Montag um 11:45",
+	}
+
+	for _, msg := range testCases {
+		is, err := format.ParseTime(&database.Channel{}, msg, "UTC", i18n.German, false)
+
+		require.NoError(t, err, "Can not parse "+msg)
+		assert.Equal(t, "11:45", is.UTC().Format("15:04"), "Wrong date from "+msg)
+		assert.True(t, is.After(time.Now()), "Date not in the future from "+msg)
+	}
+}
+
+func TestParseTimeAndText(t *testing.T) {
+	testCases := []struct {
+		msg        string
+		language   string
+		text       string
+		expression string
+	}{
+		{"buy milk at 5 pm", "", "buy milk", "at 5 pm"},
+		{"call mom in 2 hours", "", "call mom", "in 2 hours"},
+		{"tomorrow buy milk", "", "buy milk", "tomorrow"},
+		{"make laundry at sunday 16:00", "", "make laundry", "at sunday 16:00"},
+		{"brunch with alan at sunday", "", "brunch with alan", "at sunday"},
+		{"walking with the dog 6am", "", "walking with the dog", "6am"},
+		{"meeting with 3 people at monday 10:00", "", "meeting with 3 people", "at monday 10:00"},
+		{"pay rent, tomorrow 9:00", "", "pay rent", "tomorrow 9:00"},
+		{"> <@user:example.com> old message\nbuy milk tomorrow", "", "buy milk", "tomorrow"},
+		{"tomorrow at 9", "", "tomorrow at 9", "tomorrow at 9"},
+		{"Milch kaufen morgen um 9 Uhr", i18n.German, "Milch kaufen", "morgen um 9 Uhr"},
+		{"Zahnarzt nächsten Montag", i18n.German, "Zahnarzt", "nächsten Montag"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.msg, func(t *testing.T) {
+			parsed, err := format.ParseTimeAndText(&database.Channel{}, tc.msg, "UTC", tc.language)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.text, parsed.Text)
+			assert.Equal(t, tc.expression, parsed.Expression)
+			assert.True(t, parsed.Time.After(time.Now()))
+		})
+	}
+}
+
+func TestParseTimeAndTextWithFailure(t *testing.T) {
+	_, err := format.ParseTimeAndText(&database.Channel{}, "tomorrow: 19:45", "", "")
+	require.Error(t, err)
+}
+
 func TestParseTimeWithFailure(t *testing.T) {
 	testCases := []string{
 		"99:00:",
@@ -71,7 +126,7 @@ func TestParseTimeWithFailure(t *testing.T) {
 	}
 
 	for _, msg := range testCases {
-		_, err := format.ParseTime(&database.Channel{}, msg, "", false)
+		_, err := format.ParseTime(&database.Channel{}, msg, "", "", false)
 
 		assert.Error(t, err, "Should not parse "+msg)
 	}
@@ -89,7 +144,7 @@ func TestToLocalTime(t *testing.T) {
 	testCases["Asia/Jakarta"] = "18:45 12.11.2014 (WIB)"
 
 	for timeZone, should := range testCases {
-		is := format.ToLocalTime(refTime, timeZone)
+		is := format.ToLocalTime(refTime, timeZone, format.Clock24h)
 
 		assert.Equal(t, should, is)
 	}
@@ -107,12 +162,18 @@ func TestToShortLocalTime(t *testing.T) {
 	testCases["Asia/Jakarta"] = "18:45 (WIB)"
 
 	for timeZone, should := range testCases {
-		is := format.ToShortLocalTime(refTime, timeZone)
+		is := format.ToShortLocalTime(refTime, timeZone, format.Clock24h)
 
 		assert.Equal(t, should, is)
 	}
 }
 
+func TestToLocalTimeWith12HourClock(t *testing.T) {
+	assert.Equal(t, "11:45 AM 12.11.2014 (UTC)", format.ToLocalTime(refTime(), "UTC", format.Clock12h))
+	assert.Equal(t, "5:45 AM (CST)", format.ToShortLocalTime(refTime(), "America/Mexico_City", format.Clock12h))
+	assert.Equal(t, "6:45 PM (WIB)", format.ToShortLocalTime(refTime(), "Asia/Jakarta", format.Clock12h))
+}
+
 func TestTimeToHourAndMinute(t *testing.T) {
 	time1, _ := time.Parse("2006-01-02T15:04:05.000Z", "2014-11-12T17:01:26.371Z")
 
@@ -0,0 +1,173 @@
+package i18n
+
+import (
+	"strconv"
+	"strings"
+)
+
+// germanDateWords maps German date and time words to English ones understood by the date parser.
+var germanDateWords = map[string]string{
+	"heute":      "today",
+	"morgen":     "tomorrow",
+	"übermorgen": "in 2 days",
+	"früh":       "9am",
+	"mittag":     "noon",
+	"mittags":    "noon",
+	"um":         "at",
+	"am":         "on",
+	"im":         "in",
+	"uhr":        "",
+	"nächsten":   "next",
+	"nächste":    "next",
+	"nächster":   "next",
+	"kommenden":  "next",
+	"kommende":   "next",
+	"kommender":  "next",
+	"montag":     "monday",
+	"dienstag":   "tuesday",
+	"mittwoch":   "wednesday",
+	"donnerstag": "thursday",
+	"freitag":    "friday",
+	"samstag":    "saturday",
+	"sonnabend":  "saturday",
+	"sonntag":    "sunday",
+	"januar":     "january",
+	"februar":    "february",
+	"märz":       "march",
+	"mai":        "may",
+	"juni":       "june",
+	"juli":       "july",
+	"oktober":    "october",
+	"dezember":   "december",
+	"minute":     "minute",
+	"minuten":    "minutes",
+	"stunde":     "hour",
+	"stunden":    "hours",
+	"tag":        "day",
+	"tage":       "days",
+	"tagen":      "days",
+	"woche":      "week",
+	"wochen":     "weeks",
+	"monat":      "month",
+	"monate":     "months",
+	"monaten":    "months",
+	"jahr":       "year",
+	"jahre":      "years",
+	"jahren":     "years",
+}
+
+// germanDayPeriods are the German words for the periods of a day, "nachts" can be either.
+var germanDayPeriods = map[string]string{
+	"morgens":     "am",
+	"vormittags":  "am",
+	"nachmittags": "pm",
+	"abends":      "pm",
+	"nachts":      "",
+}
+
+// DatesToEnglish translates date and time expressions of the language to English.
+// Words without a translation are kept as they are.
+func DatesToEnglish(language, msg string) string {
+	if language != German {
+		return msg
+	}
+
+	words := strings.Fields(msg)
+	translated := make([]string, 0, len(words))
+	periods := periodsByHour(words)
+
+	for i, word := range words {
+		normalized := normalizeWord(word)
+		translation, ok := germanDateWords[normalized]
+
+		switch {
+		case isDayPeriod(normalized):
+			// Moved behind the hour it refers to.
+		case !ok:
+			translated = append(translated, word)
+		case translation != "":
+			translated = append(translated, translation)
+		}
+
+		if period, ok := periods[i]; ok {
+			translated = append(translated, period)
+		}
+	}
+
+	return strings.Join(translated, " ")
+}
+
+func normalizeWord(word string) string {
+	return strings.ToLower(strings.Trim(word, ".,!?;"))
+}
+
+func isDayPeriod(word string) bool {
+	_, ok := germanDayPeriods[word]
+	return ok
+}
+
+// periodsByHour maps the index of the hour closest to a period of the day to "am" or "pm", the date parser expects
+// them right after the hour. Periods of hours of the 24-hour clock and periods without hour are dropped.
+func periodsByHour(words []string) map[int]string {
+	periods := make(map[int]string)
+
+	for i, word := range words {
+		normalized := normalizeWord(word)
+		if !isDayPeriod(normalized) {
+			continue
+		}
+
+		index, hour, ok := closestHour(words, i)
+		if !ok || hour > 12 {
+			continue
+		}
+
+		period := germanDayPeriods[normalized]
+		if normalized == "nachts" {
+			// "um 3 Uhr nachts" is in the morning while "um 11 Uhr nachts" is in the evening.
+			switch {
+			case hour <= 5:
+				period = "am"
+			case hour < 12:
+				period = "pm"
+			default:
+				continue
+			}
+		}
+
+		periods[index] = period
+	}
+
+	return periods
+}
+
+// closestHour returns the index and the hour of the word closest to the index that starts with a number.
+func closestHour(words []string, index int) (int, int, bool) {
+	for distance := 1; distance < len(words); distance++ {
+		for _, i := range []int{index - distance, index + distance} {
+			if i < 0 || i >= len(words) {
+				continue
+			}
+
+			if hour, ok := leadingNumber(words[i]); ok {
+				return i, hour, true
+			}
+		}
+	}
+
+	return 0, 0, false
+}
+
+// leadingNumber returns the number the word starts with, e.g. 23 for "23:30".
+func leadingNumber(word string) (int, bool) {
+	end := strings.IndexFunc(word, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+	if end == -1 {
+		end = len(word)
+	}
+
+	number, err := strconv.Atoi(word[:end])
+
+	return number, err == nil
+}
@@ -0,0 +1,34 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatesToEnglish(t *testing.T) {
+	testCases := map[string]string{
+		"morgen um 9":                       "tomorrow at 9",
+		"Zahnarzt nächsten Montag um 8 Uhr": "Zahnarzt next monday at 8",
+		"Müll rausbringen übermorgen":       "Müll rausbringen in 2 days",
+		"in 3 Stunden":                      "in 3 hours",
+		"am Freitag, abends um 7":           "on friday at 7 pm",
+		"morgen nachts um 3":                "tomorrow at 3 am",
+		"heute um 11 Uhr nachts":            "today at 11 pm",
+		"heute um 23:30 nachts":             "today at 23:30",
+		"heute nachts":                      "today",
+		"Montag vormittags um 10":           "monday at 10 am",
+	}
+
+	for msg, expected := range testCases {
+		t.Run(msg, func(t *testing.T) {
+			assert.Equal(t, expected, i18n.DatesToEnglish(i18n.German, msg))
+		})
+	}
+}
+
+func TestDatesToEnglishWithEnglish(t *testing.T) {
+	assert.Equal(t, "morgen um 9", i18n.DatesToEnglish(i18n.English, "morgen um 9"))
+	assert.Equal(t, "tomorrow at 9", i18n.DatesToEnglish("", "tomorrow at 9"))
+}
@@ -0,0 +1,44 @@
+// Package i18n holds the languages the bot speaks besides English.
+package i18n
+
+import (
+	"errors"
+	"strings"
+)
+
+// Languages supported in rooms. An empty language is treated as English.
+const (
+	English = "en"
+	German  = "de"
+)
+
+// ErrUnknownLanguage is returned if a language is not supported.
+var ErrUnknownLanguage = errors.New("unknown language")
+
+var languageNames = map[string]string{
+	"en":       English,
+	"english":  English,
+	"englisch": English,
+	"de":       German,
+	"german":   German,
+	"deutsch":  German,
+}
+
+// ParseLanguage returns the language for the given name or code.
+func ParseLanguage(name string) (string, error) {
+	language, ok := languageNames[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return "", ErrUnknownLanguage
+	}
+
+	return language, nil
+}
+
+// Name returns the name of the language in the language itself.
+func Name(language string) string {
+	if language == German {
+		return "Deutsch"
+	}
+
+	return "English"
+}
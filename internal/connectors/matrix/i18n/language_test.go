@@ -0,0 +1,38 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLanguage(t *testing.T) {
+	testCases := map[string]string{
+		"en":       i18n.English,
+		"English":  i18n.English,
+		"de":       i18n.German,
+		"Deutsch":  i18n.German,
+		" german ": i18n.German,
+	}
+
+	for name, expected := range testCases {
+		t.Run(name, func(t *testing.T) {
+			language, err := i18n.ParseLanguage(name)
+			require.NoError(t, err)
+			assert.Equal(t, expected, language)
+		})
+	}
+}
+
+func TestParseLanguageWithUnknownLanguage(t *testing.T) {
+	_, err := i18n.ParseLanguage("klingon")
+	require.ErrorIs(t, err, i18n.ErrUnknownLanguage)
+}
+
+func TestName(t *testing.T) {
+	assert.Equal(t, "English", i18n.Name(""))
+	assert.Equal(t, "English", i18n.Name(i18n.English))
+	assert.Equal(t, "Deutsch", i18n.Name(i18n.German))
+}
@@ -0,0 +1,93 @@
+package i18n
+
+import "fmt"
+
+// german holds the German translations of the bot responses, keyed by the English text.
+var german = map[string]string{
+	// Generic errors
+	"Sorry, an error appeared.":               "Entschuldige, es ist ein Fehler aufgetreten.",
+	"There was an issue accessing the data 🤨": "Beim Zugriff auf die Daten ist ein Fehler aufgetreten 🤨",
+	"Ups, that did not work 😨":                "Ups, das hat nicht funktioniert 😨",
+
+	// Events
+	"Sorry I was not able to understand the remind date and time from this message":                    "Entschuldige, ich konnte Datum und Uhrzeit der Erinnerung in dieser Nachricht nicht verstehen",
+	"Sorry I was not able to understand the remind date and time from this message.":                   "Entschuldige, ich konnte Datum und Uhrzeit der Erinnerung in dieser Nachricht nicht verstehen.",
+	"Successfully added new reminder (ID: %d) for %s":                                                  "Neue Erinnerung (ID: %d) für %s angelegt",
+	"Successfully added %d new reminders:":                                                             "%d neue Erinnerungen angelegt:",
+	"%s (ID: %d) for %s":                                                                               "%s (ID: %d) für %s",
+	"Sorry I was not able to understand the remind date and time in line %d, no reminders were added.": "Entschuldige, ich konnte Datum und Uhrzeit in Zeile %d nicht verstehen, es wurden keine Erinnerungen angelegt.",
+	"I rescheduled your reminder \"%s\" to %s.":                                                        "Ich habe deine Erinnerung \"%s\" auf %s verschoben.",
+	"Marked event \"%s\" as done.":                                                                     "Termin \"%s\" als erledigt markiert.",
+	"Deleted event \"%s\"":                                                                             "Termin \"%s\" gelöscht",
+	"Ups, can not find an ID in there.":                                                                "Ups, ich kann darin keine ID finden.",
+	"I could not find that event in my database.":                                                      "Ich konnte diesen Termin nicht finden.",
+	"Your Events": "Deine Termine",
+
+	// Daily reminder
+	"Your Events for Today":            "Deine Termine für heute",
+	"Your Events for the next %d days": "Deine Termine der nächsten %d Tage",
+	"🔁 1 recurring event":              "🔁 1 wiederkehrender Termin",
+	"🔁 %d recurring events":            "🔁 %d wiederkehrende Termine",
+	"Overdue":                          "Überfällig",
+	"no pending events found":          "keine anstehenden Termine gefunden",
+	"at %s (ID: %d) ":                  "um %s (ID: %d) ",
+	`Reply with "2 tomorrow" to reschedule or "done 2" to complete an event.`:                           `Antworte mit "2 morgen" zum Verschieben oder "erledigt 2" zum Abschließen eines Termins.`,
+	"Please start your reply with the number of the event you want to reschedule, e.g. \"2 tomorrow\".": "Bitte beginne deine Antwort mit der Nummer des Termins, den du verschieben möchtest, z.B. \"2 morgen\".",
+	"Please add the number of the event you completed, e.g. \"done 2\".":                                "Bitte gib die Nummer des erledigten Termins an, z.B. \"erledigt 2\".",
+	"Sorry, there is no event with the number %s in this message.":                                      "Entschuldige, in dieser Nachricht gibt es keinen Termin mit der Nummer %s.",
+
+	// Settings
+	"Sorry, but I do not know what timezone this is.":                                                              "Entschuldige, diese Zeitzone kenne ich nicht.",
+	"Sorry, but I do not speak this language.":                                                                     "Entschuldige, diese Sprache spreche ich nicht.",
+	"I will speak %s in this channel from now on.":                                                                 "Ich spreche ab jetzt %s in diesem Kanal.",
+	"Sorry, I only know the 12h and 24h clock.":                                                                    "Entschuldige, ich kenne nur die 12h- und 24h-Uhr.",
+	"I will show you times in %s from now on.":                                                                     "Ich zeige dir Zeiten ab jetzt in %s an.",
+	"I will show you times in the %s clock from now on.":                                                           "Ich zeige dir Zeiten ab jetzt im %s-Format an.",
+	"I will show you times in the timezone of this channel from now on.":                                           "Ich zeige dir Zeiten ab jetzt in der Zeitzone dieses Kanals an.",
+	"Sorry, I was not able to understand the time.":                                                                "Entschuldige, ich konnte die Uhrzeit nicht verstehen.",
+	"Whups, could not save that change. Sorry, try again later.":                                                   "Hoppla, die Änderung konnte nicht gespeichert werden. Bitte versuche es später noch einmal.",
+	"Quiet hours are disabled.":                                                                                    "Die Ruhezeiten sind ausgeschaltet.",
+	"Do not disturb is disabled.":                                                                                  "Nicht stören ist ausgeschaltet.",
+	"I will hold back reminders until %s, urgent ones are sent anyway. To disable it message me with \"dnd off\".": "Ich halte Erinnerungen bis %s zurück, dringende sende ich trotzdem. Zum Ausschalten schreibe mir \"nicht stören aus\".",
+	"I will hold back reminders between %s and %s, urgent ones are sent anyway. To disable it message me with \"stop quiet hours\".": "Ich halte Erinnerungen zwischen %s und %s zurück, dringende sende ich trotzdem. Zum Ausschalten schreibe mir \"Ruhezeiten aus\".",
+	"Escalations are disabled.":                                                       "Eskalationen sind ausgeschaltet.",
+	"Sorry, I did not understand the escalation.":                                     "Entschuldige, ich habe die Eskalation nicht verstanden.",
+	"Sorry, I am not in the room %s.":                                                 "Entschuldige, ich bin nicht im Raum %s.",
+	"I will escalate important reminders that are not acknowledged after %d resends.": "Ich eskaliere wichtige Erinnerungen, die nach %d Wiederholungen nicht bestätigt wurden.",
+	"Escalations are sent to %s.":                                                     "Eskalationen werden an %s gesendet.",
+	"I will mention %s.":                                                              "Ich erwähne %s.",
+	"Sorry, %s is not a valid Matrix user ID.":                                        "Entschuldige, %s ist keine gültige Matrix-Benutzer-ID.",
+	"Sorry, I did not understand the digest settings.":                                "Entschuldige, ich habe die Einstellungen der Übersicht nicht verstanden.",
+	"I will send the daily reminder %s with the events of the next 24 hours.":         "Ich sende die Übersicht %s mit den Terminen der nächsten 24 Stunden.",
+	"I will send the daily reminder %s with the events of the next %d days.":          "Ich sende die Übersicht %s mit den Terminen der nächsten %d Tage.",
+	"I will not send it if there are no events.":                                      "Ohne Termine sende ich sie nicht.",
+	"Overdue events are included.":                                                    "Überfällige Termine sind enthalten.",
+	"Set the time with \"daily reminder at 9am\" to turn it on.":                      "Lege die Uhrzeit mit \"tägliche Erinnerung um 9 Uhr\" fest, um sie einzuschalten.",
+	"every day": "jeden Tag",
+	"on %s":     "am %s",
+	"Monday":    "Montag",
+	"Tuesday":   "Dienstag",
+	"Wednesday": "Mittwoch",
+	"Thursday":  "Donnerstag",
+	"Friday":    "Freitag",
+	"Saturday":  "Samstag",
+	"Sunday":    "Sonntag",
+}
+
+// Translate returns the text in the given language. Texts without a translation are returned in English.
+func Translate(language, text string) string {
+	if language != German {
+		return text
+	}
+
+	if translation, ok := german[text]; ok {
+		return translation
+	}
+
+	return text
+}
+
+// Translatef translates the format and formats it with the given arguments.
+func Translatef(language, format string, args ...any) string {
+	return fmt.Sprintf(Translate(language, format), args...)
+}
@@ -0,0 +1,23 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslate(t *testing.T) {
+	assert.Equal(t, "Deine Termine", i18n.Translate(i18n.German, "Your Events"))
+	assert.Equal(t, "Your Events", i18n.Translate(i18n.English, "Your Events"))
+	assert.Equal(t, "Your Events", i18n.Translate("", "Your Events"))
+}
+
+func TestTranslateWithoutTranslation(t *testing.T) {
+	assert.Equal(t, "not translated", i18n.Translate(i18n.German, "not translated"))
+}
+
+func TestTranslatef(t *testing.T) {
+	assert.Equal(t, "Termin \"test\" gelöscht", i18n.Translatef(i18n.German, "Deleted event \"%s\"", "test"))
+	assert.Equal(t, "Deleted event \"test\"", i18n.Translatef(i18n.English, "Deleted event \"%s\"", "test"))
+}
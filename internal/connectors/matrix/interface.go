@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/daemon"
+	"maunium.net/go/mautrix/event"
 )
 
 // The in- and output type provided by this package
@@ -15,7 +16,8 @@ const (
 
 // Errors exposed by the package.
 var (
-	ErrUnknowEvent = errors.New("unknown event")
+	ErrUnknowEvent            = errors.New("unknown event")
+	ErrMissingAppServiceToken = errors.New("application service needs an as and hs token")
 )
 
 // Service provides and interface for the matrix connector.
@@ -24,7 +26,9 @@ type Service interface {
 	Start() error
 	Stop() error
 
+	InputExists(inputType string, inputID uint) (bool, error)
 	InputRemoved(inputType string, inputID uint) error
+	OutputExists(outputType string, outputID uint) (bool, error)
 	OutputRemoved(outputType string, outputID uint) error
 
 	SendDailyReminder(*daemon.DailyReminder, *daemon.Output) error
@@ -33,4 +37,6 @@ type Service interface {
 	ToLocalTime(time.Time, *daemon.Output) time.Time
 
 	Cleanup() error
+
+	HandleTransaction(txnID string, events []*event.Event)
 }
@@ -8,14 +8,36 @@ import (
 )
 
 func (service *service) startListener() error {
+	if service.config.AppService != nil {
+		// The homeserver pushes events to the API, see HandleTransaction.
+		<-service.stop
+		return nil
+	}
+
 	syncer, ok := service.client.Syncer.(*mautrix.DefaultSyncer)
 	if !ok {
 		return errors.New("syncer of wrong type")
 	}
 
-	syncer.OnEventType(event.EventMessage, service.MessageEventHandler)
-	syncer.OnEventType(event.EventReaction, service.ReactionEventHandler)
-	syncer.OnEventType(event.StateMember, service.EventStateHandler)
+	for eventType, handler := range service.eventHandlers() {
+		syncer.OnEventType(eventType, handler)
+	}
+
+	if service.crypto != nil {
+		syncer.OnSync(service.crypto.ProcessSyncResponse)
+
+		for eventType, handler := range service.encryptionEventHandlers() {
+			syncer.OnEventType(eventType, handler)
+		}
+	}
 
 	return service.client.Sync()
 }
+
+func (service *service) eventHandlers() map[event.Type]mautrix.EventHandler {
+	return map[event.Type]mautrix.EventHandler{
+		event.EventMessage:  service.MessageEventHandler,
+		event.EventReaction: service.ReactionEventHandler,
+		event.StateMember:   service.EventStateHandler,
+	}
+}
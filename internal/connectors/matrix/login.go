@@ -0,0 +1,92 @@
+package matrix
+
+import (
+	"errors"
+
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"maunium.net/go/mautrix"
+	"maunium.net/go/mautrix/id"
+)
+
+// setupMautrixClient logs in with the first available method: application service token,
+// configured access token, stored session or password.
+func (service *service) setupMautrixClient() error {
+	service.logger.Debug("setting up mautrix client")
+
+	matrixClient, err := mautrix.NewClient(service.config.Homeserver, "", "")
+	if err != nil {
+		return err
+	}
+
+	service.client = matrixClient
+
+	switch {
+	case service.config.AppService != nil:
+		if service.config.AppService.ASToken == "" || service.config.AppService.HSToken == "" {
+			return ErrMissingAppServiceToken
+		}
+
+		service.client.SetCredentials(id.UserID(service.botname), service.config.AppService.ASToken)
+		service.client.AppServiceUserID = id.UserID(service.botname)
+	case service.config.AccessToken != "":
+		err = service.loginWithToken(service.config.AccessToken, service.config.DeviceID)
+	default:
+		err = service.loginWithSession()
+	}
+
+	service.logger.Debug("mautrix client setup finished")
+
+	return err
+}
+
+func (service *service) loginWithToken(accessToken, deviceID string) error {
+	service.client.SetCredentials(id.UserID(service.botname), accessToken)
+
+	resp, err := service.client.Whoami()
+	if err != nil {
+		return err
+	}
+
+	service.client.DeviceID = id.DeviceID(deviceID)
+	if deviceID == "" {
+		service.client.DeviceID = resp.DeviceID
+	}
+
+	return nil
+}
+
+// loginWithSession reuses the stored session and only logs in with the password if there is no valid one.
+func (service *service) loginWithSession() error {
+	session, err := service.matrixDatabase.GetSession(service.botname)
+
+	switch {
+	case err == nil:
+		err = service.loginWithToken(session.AccessToken, session.DeviceID)
+		if err == nil || !errors.Is(err, mautrix.MUnknownToken) {
+			return err
+		}
+
+		service.logger.Info("stored matrix session expired, logging in again")
+	case !errors.Is(err, matrixdb.ErrNotFound):
+		return err
+	}
+
+	resp, err := service.client.Login(&mautrix.ReqLogin{
+		Type:             "m.login.password",
+		Identifier:       mautrix.UserIdentifier{Type: mautrix.IdentifierTypeUser, User: service.config.Username},
+		Password:         service.config.Password,
+		DeviceID:         id.DeviceID(service.config.DeviceID),
+		StoreCredentials: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = service.matrixDatabase.SaveSession(&matrixdb.MatrixSession{
+		UserID:      service.botname,
+		DeviceID:    resp.DeviceID.String(),
+		AccessToken: resp.AccessToken,
+	})
+
+	return err
+}
@@ -0,0 +1,151 @@
+package matrix
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maunium.net/go/mautrix/id"
+)
+
+// testHomeserver accepts the access token "valid" and hands out "newtoken" on password logins.
+func testHomeserver(t *testing.T) (*httptest.Server, *int) {
+	t.Helper()
+
+	logins := 0
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /_matrix/client/v3/account/whoami", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"errcode":"M_UNKNOWN_TOKEN","error":"unknown token"}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"user_id":"@bot:example.com","device_id":"WHOAMIDEVICE"}`))
+	})
+	mux.HandleFunc("POST /_matrix/client/v3/login", func(w http.ResponseWriter, _ *http.Request) {
+		logins++
+
+		_, _ = w.Write([]byte(`{"user_id":"@bot:example.com","access_token":"newtoken","device_id":"LOGINDEVICE"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server, &logins
+}
+
+func TestService_SetupMautrixClientWithAccessToken(t *testing.T) {
+	service, _ := testService(t)
+	server, logins := testHomeserver(t)
+	service.config.Homeserver = server.URL
+	service.config.AccessToken = "valid"
+
+	err := service.setupMautrixClient()
+	require.NoError(t, err)
+
+	assert.Equal(t, "valid", service.client.AccessToken)
+	assert.Equal(t, id.DeviceID("WHOAMIDEVICE"), service.client.DeviceID)
+	assert.Zero(t, *logins)
+}
+
+func TestService_SetupMautrixClientWithInvalidAccessToken(t *testing.T) {
+	service, _ := testService(t)
+	server, _ := testHomeserver(t)
+	service.config.Homeserver = server.URL
+	service.config.AccessToken = "invalid"
+
+	err := service.setupMautrixClient()
+	require.Error(t, err)
+}
+
+func TestService_SetupMautrixClientWithStoredSession(t *testing.T) {
+	service, fx := testService(t)
+	server, logins := testHomeserver(t)
+	service.config.Homeserver = server.URL
+
+	fx.matrixDB.EXPECT().GetSession("@bot:example.com").Return(&matrixdb.MatrixSession{
+		UserID:      "@bot:example.com",
+		DeviceID:    "STOREDDEVICE",
+		AccessToken: "valid",
+	}, nil)
+
+	err := service.setupMautrixClient()
+	require.NoError(t, err)
+
+	assert.Equal(t, "valid", service.client.AccessToken)
+	assert.Equal(t, id.DeviceID("STOREDDEVICE"), service.client.DeviceID)
+	assert.Zero(t, *logins)
+}
+
+func TestService_SetupMautrixClientWithExpiredSession(t *testing.T) {
+	service, fx := testService(t)
+	server, logins := testHomeserver(t)
+	service.config.Homeserver = server.URL
+
+	fx.matrixDB.EXPECT().GetSession("@bot:example.com").Return(&matrixdb.MatrixSession{
+		UserID:      "@bot:example.com",
+		DeviceID:    "STOREDDEVICE",
+		AccessToken: "expired",
+	}, nil)
+	fx.matrixDB.EXPECT().SaveSession(&matrixdb.MatrixSession{
+		UserID:      "@bot:example.com",
+		DeviceID:    "LOGINDEVICE",
+		AccessToken: "newtoken",
+	}).Return(nil, nil)
+
+	err := service.setupMautrixClient()
+	require.NoError(t, err)
+
+	assert.Equal(t, "newtoken", service.client.AccessToken)
+	assert.Equal(t, 1, *logins)
+}
+
+func TestService_SetupMautrixClientWithoutSession(t *testing.T) {
+	service, fx := testService(t)
+	server, logins := testHomeserver(t)
+	service.config.Homeserver = server.URL
+
+	fx.matrixDB.EXPECT().GetSession("@bot:example.com").Return(nil, matrixdb.ErrNotFound)
+	fx.matrixDB.EXPECT().SaveSession(&matrixdb.MatrixSession{
+		UserID:      "@bot:example.com",
+		DeviceID:    "LOGINDEVICE",
+		AccessToken: "newtoken",
+	}).Return(nil, nil)
+
+	err := service.setupMautrixClient()
+	require.NoError(t, err)
+
+	assert.Equal(t, "newtoken", service.client.AccessToken)
+	assert.Equal(t, 1, *logins)
+}
+
+func TestService_SetupMautrixClientWithAppService(t *testing.T) {
+	service, _ := testService(t)
+	server, logins := testHomeserver(t)
+	service.config.Homeserver = server.URL
+	service.config.AppService = &AppServiceConfig{
+		ASToken: "astoken",
+		HSToken: "hstoken",
+	}
+
+	err := service.setupMautrixClient()
+	require.NoError(t, err)
+
+	assert.Equal(t, "astoken", service.client.AccessToken)
+	assert.Equal(t, id.UserID("@bot:example.com"), service.client.AppServiceUserID)
+	assert.Zero(t, *logins)
+}
+
+func TestService_SetupMautrixClientWithAppServiceWithoutTokens(t *testing.T) {
+	service, _ := testService(t)
+	service.config.AppService = &AppServiceConfig{}
+
+	err := service.setupMautrixClient()
+	require.ErrorIs(t, err, ErrMissingAppServiceToken)
+}
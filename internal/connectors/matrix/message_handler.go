@@ -37,6 +37,10 @@ func (service *service) MessageEventHandler(_ mautrix.EventSource, evt *event.Ev
 		return
 	}
 
+	if service.handleVerification(evt) {
+		return
+	}
+
 	room, err := service.matrixDatabase.GetRoomByRoomID(string(evt.RoomID))
 	if err != nil {
 		logger.Debug("ignoring message", "reason", "unknown room")
@@ -55,7 +55,7 @@ func NewMessenger(config *Config, db database.Service, matrixClient MatrixClient
 	}, nil
 }
 
-// sendMessageEvent sends a message event to matrix, will take care of encryption if available
+// sendMessageEvent sends a message event to matrix, the client encrypts it for encrypted rooms
 func (messenger *service) sendMessageEvent(messageEvent *messageEvent, roomID string, eventType event.Type) (*mautrix.RespSendEvent, error) {
 	messenger.logger.Info("sending message", "matrix.room.id", roomID)
 	return messenger.client.SendMessageEvent(id.RoomID(roomID), eventType, &messageEvent)
@@ -0,0 +1,68 @@
+package matrix
+
+import (
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+)
+
+// User returns the sender of the message, nil if the sender is not in the room.
+func (event *MessageEvent) User() *matrixdb.MatrixUser {
+	return userInRoom(event.Room, event.Event.Sender.String())
+}
+
+// TimeZone returns the timezone of the sender, falling back to the one of the room.
+func (event *MessageEvent) TimeZone() string {
+	return timeZone(event.Room, event.Event.Sender.String())
+}
+
+// TimeZoneFor returns the timezone of the given user in the room, falling back to the one of the room. Without a
+// user the timezone of the sender is returned.
+func (event *MessageEvent) TimeZoneFor(userID string) string {
+	if userID == "" {
+		return event.TimeZone()
+	}
+
+	return timeZone(event.Room, userID)
+}
+
+// Clock returns the clock the sender prefers times in.
+func (event *MessageEvent) Clock() string {
+	return clock(event.Room, event.Event.Sender.String())
+}
+
+// TimeZone returns the timezone of the sender, falling back to the one of the room.
+func (event *ReactionEvent) TimeZone() string {
+	return timeZone(event.Room, event.Event.Sender.String())
+}
+
+// Clock returns the clock the sender prefers times in.
+func (event *ReactionEvent) Clock() string {
+	return clock(event.Room, event.Event.Sender.String())
+}
+
+func timeZone(room *matrixdb.MatrixRoom, userID string) string {
+	user := userInRoom(room, userID)
+	if user != nil && user.TimeZone != "" {
+		return user.TimeZone
+	}
+
+	return room.TimeZone
+}
+
+func clock(room *matrixdb.MatrixRoom, userID string) string {
+	user := userInRoom(room, userID)
+	if user != nil {
+		return user.Clock
+	}
+
+	return ""
+}
+
+func userInRoom(room *matrixdb.MatrixRoom, userID string) *matrixdb.MatrixUser {
+	for i := range room.Users {
+		if room.Users[i].ID == userID {
+			return &room.Users[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package matrix
+
+import (
+	"testing"
+
+	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
+	"github.com/stretchr/testify/assert"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+)
+
+func TestMessageEvent_TimeZoneAndClock(t *testing.T) {
+	room := &matrixdb.MatrixRoom{
+		TimeZone: "Europe/Berlin",
+		Users: []matrixdb.MatrixUser{
+			{ID: "@berlin:example.com"},
+			{ID: "@newyork:example.com", TimeZone: "America/New_York", Clock: "12h"},
+		},
+	}
+
+	evt := &MessageEvent{Event: &event.Event{Sender: id.UserID("@berlin:example.com")}, Room: room}
+	assert.Equal(t, "Europe/Berlin", evt.TimeZone())
+	assert.Empty(t, evt.Clock())
+
+	evt = &MessageEvent{Event: &event.Event{Sender: id.UserID("@newyork:example.com")}, Room: room}
+	assert.Equal(t, "America/New_York", evt.TimeZone())
+	assert.Equal(t, "12h", evt.Clock())
+	assert.Equal(t, "Europe/Berlin", evt.TimeZoneFor("@berlin:example.com"))
+	assert.Equal(t, "America/New_York", evt.TimeZoneFor(""))
+
+	reaction := &ReactionEvent{Event: &event.Event{Sender: id.UserID("@unknown:example.com")}, Room: room}
+	assert.Equal(t, "Europe/Berlin", reaction.TimeZone())
+	assert.Empty(t, reaction.Clock())
+}
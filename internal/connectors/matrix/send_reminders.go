@@ -1,8 +1,12 @@
 package matrix
 
 import (
+	"math"
+	"time"
+
 	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/i18n"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/daemon"
 )
@@ -10,6 +14,8 @@ import (
 var ReminderReactions = []string{"✅", "▶️", "⏩", "1️⃣", "4️⃣"}
 var ReminderReactionsRecurring = []string{"🔂"}
 
+const dailyReminderHint = `Reply with "2 tomorrow" to reschedule or "done 2" to complete an event.`
+
 func (service *service) SendReminder(event *daemon.Event, output *daemon.Output) error {
 	room, err := service.matrixDatabase.GetRoomByID(output.OutputID)
 	if err != nil {
@@ -23,7 +29,8 @@ func (service *service) SendReminder(event *daemon.Event, output *daemon.Output)
 		originalMessage = nil
 	}
 
-	message, messageFormatted, err := format.MessageFromEvent(event, room.TimeZone)
+	// Reminders are shown in the time preferences of the assignee if there is one.
+	message, messageFormatted, err := format.MessageFromEvent(event, timeZone(room, event.AssignedTo), clock(room, event.AssignedTo))
 	if err != nil {
 		return err
 	}
@@ -51,13 +58,22 @@ func (service *service) SendReminder(event *daemon.Event, output *daemon.Output)
 		dbMsg.ReplyToMessageID = &originalMessage.ID
 	}
 
+	if event.StartsIn != nil {
+		dbMsg.Type = matrixdb.MessageTypeEventPreNotification
+	}
+
 	_, err = service.matrixDatabase.NewMessage(dbMsg)
 	if err != nil {
 		service.logger.Error("failed to save message to database", "error", err)
 	}
 
+	if event.StartsIn != nil {
+		// The event did not happen yet, reactions like marking it as done do not apply.
+		return nil
+	}
+
 	reactions := ReminderReactions
-	if event.RepeatInterval != nil {
+	if event.IsRecurring() {
 		reactions = append(reactions, ReminderReactionsRecurring...)
 	}
 
@@ -82,9 +98,28 @@ func (service *service) SendDailyReminder(reminder *daemon.DailyReminder, output
 		return err
 	}
 
-	msg, msgFormatted := format.InfoFromDaemonEvents(reminder.Events, room.TimeZone)
-	msg = "Your Events for Today\n\n" + msg
-	msgFormatted = "<h2>Your Events for Today</h2><br>\n" + msgFormatted
+	msg, msgFormatted := format.InfoFromDaemonEvents(reminder.Events, room.TimeZone, format.Clock24h, room.Language)
+	title := dailyReminderTitle(reminder.Lookahead, room.Language)
+	msg = title + "\n\n" + msg
+	msgFormatted = "<h2>" + title + "</h2><br>\n" + msgFormatted
+
+	if len(reminder.Overdue) > 0 {
+		overdueMsg, overdueMsgFormatted := format.InfoFromDaemonEventsFrom(reminder.Overdue, len(reminder.Events)+1, room.TimeZone, format.Clock24h, room.Language)
+		overdueTitle := i18n.Translate(room.Language, "Overdue")
+		msg += "\n" + overdueTitle + "\n\n" + overdueMsg
+		msgFormatted += "<br>\n<h3>" + overdueTitle + "</h3><br>\n" + overdueMsgFormatted
+	}
+
+	if recurring, recurringFormatted := format.RecurringCountFromDaemonEvents(reminder, room.Language); recurring != "" {
+		msg += "\n" + recurring + "\n"
+		msgFormatted += "<br>\n" + recurringFormatted + "<br>"
+	}
+
+	if !reminder.IsEmpty() {
+		hint := i18n.Translate(room.Language, dailyReminderHint)
+		msg += "\n" + hint
+		msgFormatted += "<br>\n<i>" + hint + "</i>"
+	}
 
 	resp, err := service.messenger.SendMessage(messenger.HTMLMessage(
 		msg,
@@ -108,7 +143,34 @@ func (service *service) SendDailyReminder(reminder *daemon.DailyReminder, output
 	_, err = service.matrixDatabase.NewMessage(dbMsg)
 	if err != nil {
 		service.logger.Error("failed to save message to database", "error", err)
+		return nil
+	}
+
+	// Store which event is behind which entry, so replies can reference them.
+	events := append(append([]daemon.Event{}, reminder.Events...), reminder.Overdue...)
+	entries := make([]matrixdb.MatrixDigestEntry, 0, len(events))
+	for i := range events {
+		entries = append(entries, matrixdb.MatrixDigestEntry{
+			MessageID: dbMsg.ID,
+			Position:  uint(i + 1),
+			EventID:   events[i].ID,
+		})
+	}
+
+	err = service.matrixDatabase.NewDigestEntries(entries)
+	if err != nil {
+		service.logger.Error("failed to save daily reminder entries to database", "error", err)
 	}
 
 	return nil
 }
+
+// dailyReminderTitle names the range of upcoming events covered by a daily reminder.
+func dailyReminderTitle(lookahead time.Duration, language string) string {
+	days := int(math.Ceil(lookahead.Hours() / 24))
+	if days <= 1 {
+		return i18n.Translate(language, "Your Events for Today")
+	}
+
+	return i18n.Translatef(language, "Your Events for the next %d days", days)
+}
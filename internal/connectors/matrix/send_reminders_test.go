@@ -29,11 +29,72 @@ func TestService_SendDailyReminder(t *testing.T) {
 	fx.messenger.EXPECT().SendMessage(messenger.HTMLMessage(
 		`Your Events for Today
 
-➡️ TEST EVENT
+1. TEST EVENT
 at 11:45 12.11.2014 (UTC) (ID: 56) 
-`,
+
+Reply with "2 tomorrow" to reschedule or "done 2" to complete an event.`,
 		`<h2>Your Events for Today</h2><br>
-➡️ <b>test event</b><br>at 11:45 12.11.2014 (UTC) (ID: 56) <br>`,
+1. <b>test event</b><br>at 11:45 12.11.2014 (UTC) (ID: 56) <br><br>
+<i>Reply with "2 tomorrow" to reschedule or "done 2" to complete an event.</i>`,
+		"!1234",
+	)).Return(
+		&messenger.MessageResponse{
+			ExternalIdentifier: "abcde",
+		},
+		nil,
+	)
+
+	fx.matrixDB.EXPECT().NewMessage(mock.Anything).Return(nil, nil)
+	fx.matrixDB.EXPECT().NewDigestEntries([]matrixdb.MatrixDigestEntry{
+		{
+			MessageID: "abcde",
+			Position:  1,
+			EventID:   56,
+		},
+	}).Return(nil)
+
+	err := service.SendDailyReminder(
+		&daemon.DailyReminder{
+			Events: []daemon.Event{
+				{
+					ID:        56,
+					Message:   "test event",
+					EventTime: refTime(),
+				},
+			},
+		},
+		&daemon.Output{
+			OutputType: "matrix",
+			OutputID:   78,
+		},
+	)
+	require.NoError(t, err)
+}
+
+func TestService_SendDailyReminderInGerman(t *testing.T) {
+	service, fx := testService(t)
+
+	fx.matrixDB.EXPECT().GetRoomByID(uint(78)).Return(
+		&matrixdb.MatrixRoom{
+			RoomID:   "!1234",
+			Language: "de",
+			Model: gorm.Model{
+				ID: 12,
+			},
+		},
+		nil,
+	)
+
+	fx.messenger.EXPECT().SendMessage(messenger.HTMLMessage(
+		`Deine Termine für heute
+
+1. TEST EVENT
+um 11:45 12.11.2014 (UTC) (ID: 56) 
+
+Antworte mit "2 morgen" zum Verschieben oder "erledigt 2" zum Abschließen eines Termins.`,
+		`<h2>Deine Termine für heute</h2><br>
+1. <b>test event</b><br>um 11:45 12.11.2014 (UTC) (ID: 56) <br><br>
+<i>Antworte mit "2 morgen" zum Verschieben oder "erledigt 2" zum Abschließen eines Termins.</i>`,
 		"!1234",
 	)).Return(
 		&messenger.MessageResponse{
@@ -43,6 +104,7 @@ at 11:45 12.11.2014 (UTC) (ID: 56)
 	)
 
 	fx.matrixDB.EXPECT().NewMessage(mock.Anything).Return(nil, nil)
+	fx.matrixDB.EXPECT().NewDigestEntries(mock.Anything).Return(nil)
 
 	err := service.SendDailyReminder(
 		&daemon.DailyReminder{
@@ -62,6 +124,142 @@ at 11:45 12.11.2014 (UTC) (ID: 56)
 	require.NoError(t, err)
 }
 
+func TestService_SendDailyReminderWithLookaheadAndOverdue(t *testing.T) {
+	service, fx := testService(t)
+
+	fx.matrixDB.EXPECT().GetRoomByID(uint(78)).Return(
+		&matrixdb.MatrixRoom{
+			RoomID: "!1234",
+			Model: gorm.Model{
+				ID: 12,
+			},
+		},
+		nil,
+	)
+
+	fx.messenger.EXPECT().SendMessage(messenger.HTMLMessage(
+		`Your Events for the next 7 days
+
+1. TEST EVENT
+at 11:45 12.11.2014 (UTC) (ID: 56) 
+
+Overdue
+
+2. OVERDUE EVENT
+at 11:45 12.11.2014 (UTC) (ID: 57) 
+
+Reply with "2 tomorrow" to reschedule or "done 2" to complete an event.`,
+		`<h2>Your Events for the next 7 days</h2><br>
+1. <b>test event</b><br>at 11:45 12.11.2014 (UTC) (ID: 56) <br><br>
+<h3>Overdue</h3><br>
+2. <b>overdue event</b><br>at 11:45 12.11.2014 (UTC) (ID: 57) <br><br>
+<i>Reply with "2 tomorrow" to reschedule or "done 2" to complete an event.</i>`,
+		"!1234",
+	)).Return(
+		&messenger.MessageResponse{
+			ExternalIdentifier: "abcde",
+		},
+		nil,
+	)
+
+	fx.matrixDB.EXPECT().NewMessage(mock.Anything).Return(nil, nil)
+	fx.matrixDB.EXPECT().NewDigestEntries([]matrixdb.MatrixDigestEntry{
+		{
+			MessageID: "abcde",
+			Position:  1,
+			EventID:   56,
+		},
+		{
+			MessageID: "abcde",
+			Position:  2,
+			EventID:   57,
+		},
+	}).Return(nil)
+
+	err := service.SendDailyReminder(
+		&daemon.DailyReminder{
+			Events: []daemon.Event{
+				{
+					ID:        56,
+					Message:   "test event",
+					EventTime: refTime(),
+				},
+			},
+			Overdue: []daemon.Event{
+				{
+					ID:        57,
+					Message:   "overdue event",
+					EventTime: refTime(),
+				},
+			},
+			Lookahead: 7 * 24 * time.Hour,
+		},
+		&daemon.Output{
+			OutputType: "matrix",
+			OutputID:   78,
+		},
+	)
+	require.NoError(t, err)
+}
+
+func TestService_SendDailyReminderWithRecurringEvents(t *testing.T) {
+	service, fx := testService(t)
+
+	fx.matrixDB.EXPECT().GetRoomByID(uint(78)).Return(
+		&matrixdb.MatrixRoom{
+			RoomID: "!1234",
+			Model: gorm.Model{
+				ID: 12,
+			},
+		},
+		nil,
+	)
+
+	interval := time.Hour * 24
+
+	fx.messenger.EXPECT().SendMessage(messenger.HTMLMessage(
+		`Your Events for Today
+
+1. TEST EVENT
+at 11:45 12.11.2014 (UTC) (ID: 56) 🔁 
+
+🔁 1 recurring event
+
+Reply with "2 tomorrow" to reschedule or "done 2" to complete an event.`,
+		`<h2>Your Events for Today</h2><br>
+1. <b>test event</b><br>at 11:45 12.11.2014 (UTC) (ID: 56) <i>🔁 </i><br><br>
+<i>🔁 1 recurring event</i><br><br>
+<i>Reply with "2 tomorrow" to reschedule or "done 2" to complete an event.</i>`,
+		"!1234",
+	)).Return(
+		&messenger.MessageResponse{
+			ExternalIdentifier: "abcde",
+		},
+		nil,
+	)
+
+	fx.matrixDB.EXPECT().NewMessage(mock.Anything).Return(nil, nil)
+	fx.matrixDB.EXPECT().NewDigestEntries(mock.Anything).Return(nil)
+
+	err := service.SendDailyReminder(
+		&daemon.DailyReminder{
+			Events: []daemon.Event{
+				{
+					ID:             56,
+					Message:        "test event",
+					EventTime:      refTime(),
+					RepeatInterval: &interval,
+				},
+			},
+		},
+		&daemon.Output{
+			OutputType: "matrix",
+			OutputID:   78,
+		},
+	)
+	require.NoError(t, err)
+}
+
 func TestService_SendDailyReminderWithNewMessageError(t *testing.T) {
 	service, fx := testService(t)
 
@@ -78,11 +276,13 @@ func TestService_SendDailyReminderWithNewMessageError(t *testing.T) {
 	fx.messenger.EXPECT().SendMessage(messenger.HTMLMessage(
 		`Your Events for Today
 
-➡️ TEST EVENT
+1. TEST EVENT
 at 11:45 12.11.2014 (UTC) (ID: 56) 
-`,
+
+Reply with "2 tomorrow" to reschedule or "done 2" to complete an event.`,
 		`<h2>Your Events for Today</h2><br>
-➡️ <b>test event</b><br>at 11:45 12.11.2014 (UTC) (ID: 56) <br>`,
+1. <b>test event</b><br>at 11:45 12.11.2014 (UTC) (ID: 56) <br><br>
+<i>Reply with "2 tomorrow" to reschedule or "done 2" to complete an event.</i>`,
 		"!1234",
 	)).Return(
 		&messenger.MessageResponse{
@@ -129,11 +329,13 @@ func TestService_SendDailyReminderWithSendMessageError(t *testing.T) {
 	fx.messenger.EXPECT().SendMessage(messenger.HTMLMessage(
 		`Your Events for Today
 
-➡️ TEST EVENT
+1. TEST EVENT
 at 11:45 12.11.2014 (UTC) (ID: 56) 
-`,
+
+Reply with "2 tomorrow" to reschedule or "done 2" to complete an event.`,
 		`<h2>Your Events for Today</h2><br>
-➡️ <b>test event</b><br>at 11:45 12.11.2014 (UTC) (ID: 56) <br>`,
+1. <b>test event</b><br>at 11:45 12.11.2014 (UTC) (ID: 56) <br><br>
+<i>Reply with "2 tomorrow" to reschedule or "done 2" to complete an event.</i>`,
 		"!1234",
 	)).Return(
 		nil, expectedErr,
@@ -195,3 +397,96 @@ func refTime() time.Time {
 
 	return refTime
 }
+
+func TestService_SendReminderWithPreNotification(t *testing.T) {
+	service, fx := testService(t)
+
+	fx.matrixDB.EXPECT().GetRoomByID(uint(78)).Return(
+		&matrixdb.MatrixRoom{
+			RoomID: "!1234",
+			Model: gorm.Model{
+				ID: 12,
+			},
+		},
+		nil,
+	)
+	fx.matrixDB.EXPECT().GetEventMessageByOutputAndEvent(uint(56), uint(78), "matrix").Return(nil, matrixdb.ErrNotFound)
+
+	fx.messenger.EXPECT().SendMessage(messenger.HTMLMessage(
+		`⏰ TEST EVENT (#56)
+starts in 15 minutes at 11:45 (UTC) `,
+		`⏰ <b>test event</b> (#56)<br><i>starts in 15 minutes at 11:45 (UTC)</i> `,
+		"!1234",
+	)).Return(
+		&messenger.MessageResponse{
+			ExternalIdentifier: "abcde",
+		},
+		nil,
+	)
+
+	fx.matrixDB.EXPECT().NewMessage(mock.MatchedBy(func(msg *matrixdb.MatrixMessage) bool {
+		return msg.ID == "abcde" && msg.Type == matrixdb.MessageTypeEventPreNotification && *msg.EventID == 56
+	})).Return(nil, nil)
+
+	err := service.SendReminder(
+		&daemon.Event{
+			ID:        56,
+			Message:   "test event",
+			EventTime: refTime(),
+			StartsIn:  new(15 * time.Minute),
+		},
+		&daemon.Output{
+			OutputType: "matrix",
+			OutputID:   78,
+		},
+	)
+	require.NoError(t, err)
+}
+
+func TestService_SendReminderWithAssignee(t *testing.T) {
+	service, fx := testService(t)
+
+	fx.matrixDB.EXPECT().GetRoomByID(uint(78)).Return(
+		&matrixdb.MatrixRoom{
+			RoomID:   "!1234",
+			TimeZone: "Europe/Berlin",
+			Users: []matrixdb.MatrixUser{
+				{ID: "@alice:example.com", TimeZone: "America/Mexico_City", Clock: "12h"},
+			},
+			Model: gorm.Model{
+				ID: 12,
+			},
+		},
+		nil,
+	)
+	fx.matrixDB.EXPECT().GetEventMessageByOutputAndEvent(uint(56), uint(78), "matrix").Return(nil, matrixdb.ErrNotFound)
+
+	fx.messenger.EXPECT().SendMessage(messenger.HTMLMessage(
+		`🔔 alice: TEST EVENT (#56)
+5:45 AM (CST) `,
+		`🔔 <a href="https://matrix.to/#/@alice:example.com">alice</a>: <b>test event</b> (#56)<br><i>5:45 AM (CST)</i> `,
+		"!1234",
+	)).Return(
+		&messenger.MessageResponse{
+			ExternalIdentifier: "abcde",
+		},
+		nil,
+	)
+
+	fx.matrixDB.EXPECT().NewMessage(mock.Anything).Return(nil, nil)
+	fx.messenger.EXPECT().SendReactionAsync(mock.Anything).Return(nil)
+
+	err := service.SendReminder(
+		&daemon.Event{
+			ID:         56,
+			Message:    "test event",
+			EventTime:  refTime(),
+			AssignedTo: "@alice:example.com",
+		},
+		&daemon.Output{
+			OutputType: "matrix",
+			OutputID:   78,
+		},
+	)
+	require.NoError(t, err)
+}
@@ -6,16 +6,17 @@ import (
 	"regexp"
 	"time"
 
+	emaildb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/email/database"
 	icaldb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/ical/database"
 	matrixdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/database"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/format"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/mautrixcl"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/matrix/messenger"
+	webhookdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook/database"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"maunium.net/go/mautrix"
-	"maunium.net/go/mautrix/id"
 )
 
 var (
@@ -35,7 +36,10 @@ type service struct {
 	botname        string
 
 	client          *mautrix.Client
+	crypto          cryptoMachine // Nil if end to end encryption is disabled.
 	lastMessageFrom time.Time
+	stop            chan struct{}
+	appService      *appServiceState
 
 	metricEventInCount *prometheus.CounterVec
 }
@@ -71,6 +75,12 @@ type Config struct {
 	Homeserver string
 	DeviceID   string
 	DeviceKey  string
+	// E2EE enables end to end encryption, the device key protects the crypto store.
+	E2EE bool
+	// AccessToken is used instead of a password login if set.
+	AccessToken string
+	// AppService runs the bot as application service if set.
+	AppService *AppServiceConfig
 
 	MessageActions       []MessageAction
 	DefaultMessageAction MessageAction
@@ -88,15 +98,33 @@ type Config struct {
 // BridgeServices contains services where the matrix connector acts as a bridge, e.g.
 // because they do not have any user interface.
 type BridgeServices struct {
-	ICal BridgeServiceICal
+	ICal    BridgeServiceICal
+	Webhook BridgeServiceWebhook
+	Email   BridgeServiceEmail // Nil if sending e-mails is disabled.
 }
 
 // BridgeServiceICal is an interface for a bridge to the iCal connector.
 type BridgeServiceICal interface {
+	NewInput(channelID uint, url string) (*icaldb.IcalInput, error)
+	GetInput(inputID uint) (*icaldb.IcalInput, error)
+	RefreshInput(inputID uint) error
 	NewOutput(channelID uint) (*icaldb.IcalOutput, string, error)
 	GetOutput(outputID uint, regenToken bool) (*icaldb.IcalOutput, string, error)
 }
 
+// BridgeServiceWebhook is an interface for a bridge to the webhook connector.
+type BridgeServiceWebhook interface {
+	NewOutput(channelID uint, url string, timeZone string) (*webhookdb.WebhookOutput, error)
+	GetOutput(outputID uint) (*webhookdb.WebhookOutput, error)
+	NewInput(channelID uint) (*webhookdb.WebhookInput, string, error)
+}
+
+// BridgeServiceEmail is an interface for a bridge to the e-mail connector.
+type BridgeServiceEmail interface {
+	NewOutput(channelID uint, recipients []string, timeZone string) (*emaildb.EmailOutput, error)
+	GetOutput(outputID uint) (*emaildb.EmailOutput, error)
+}
+
 // New sets up a new matrix connector.
 func New(config *Config, database database.Service, matrixDB matrixdb.Service, logger *slog.Logger) (Service, error) {
 	logger.Debug("setting up matrix connector")
@@ -107,6 +135,8 @@ func New(config *Config, database database.Service, matrixDB matrixdb.Service, l
 		database:       database,
 		matrixDatabase: matrixDB,
 		botname:        format.FullUsername(config.Username, config.Homeserver),
+		stop:           make(chan struct{}),
+		appService:     &appServiceState{},
 
 		metricEventInCount: metricEventInCount,
 	}
@@ -117,6 +147,12 @@ func New(config *Config, database database.Service, matrixDB matrixdb.Service, l
 		return nil, err
 	}
 
+	err = service.setupEncryption()
+	if err != nil {
+		service.logger.Error("failed to setup end to end encryption", "error", err)
+		return nil, err
+	}
+
 	err = service.setupMessenger()
 	if err != nil {
 		return nil, err
@@ -178,33 +214,10 @@ func (service *service) setupActions() {
 	}
 }
 
-func (service *service) setupMautrixClient() error {
-	service.logger.Debug("setting up mautrix client")
-
-	matrixClient, err := mautrix.NewClient(service.config.Homeserver, "", "")
-	if err != nil {
-		return err
-	}
-
-	service.client = matrixClient
-
-	_, err = service.client.Login(&mautrix.ReqLogin{
-		Type:             "m.login.password",
-		Identifier:       mautrix.UserIdentifier{Type: mautrix.IdentifierTypeUser, User: service.config.Username},
-		Password:         service.config.Password,
-		DeviceID:         id.DeviceID(service.config.DeviceID),
-		StoreCredentials: true,
-	})
-
-	service.logger.Debug("mautrix client setup finished")
-
-	return err
-}
-
 func (service *service) setupMessenger() error {
 	config := &messenger.Config{}
 
-	messenger, err := messenger.NewMessenger(config, service.matrixDatabase, service.client,
+	messenger, err := messenger.NewMessenger(config, service.matrixDatabase, service.messengerClient(),
 		service.logger.With("component", "matrix-messenger"))
 	if err != nil {
 		return err
@@ -229,11 +242,31 @@ func (service *service) Start() error {
 // This method will not block, wait for Stop() to return.
 func (service *service) Stop() error {
 	service.logger.Debug("stopping matrix connector ...")
+
+	if service.config.AppService != nil {
+		close(service.stop)
+		return nil
+	}
+
 	service.client.StopSync()
 
 	return nil
 }
 
+// InputExists tells whether the connector knows the input.
+func (service *service) InputExists(inputType string, inputID uint) (bool, error) {
+	if inputType != InputType {
+		return false, nil
+	}
+
+	_, err := service.matrixDatabase.GetRoomByID(inputID)
+	if errors.Is(err, matrixdb.ErrNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
 // InputRemoved to tell the connector an input got removed.
 func (service *service) InputRemoved(inputType string, inputID uint) error {
 	if inputType != InputType {
@@ -255,6 +288,20 @@ func (service *service) InputRemoved(inputType string, inputID uint) error {
 	return err
 }
 
+// OutputExists tells whether the connector knows the output.
+func (service *service) OutputExists(outputType string, outputID uint) (bool, error) {
+	if outputType != OutputType {
+		return false, nil
+	}
+
+	_, err := service.matrixDatabase.GetRoomByID(outputID)
+	if errors.Is(err, matrixdb.ErrNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
 // OutputRemoved to tell the connector an output got removed.
 func (service *service) OutputRemoved(outputType string, outputID uint) error {
 	if outputType != OutputType {
@@ -11,6 +11,7 @@ import (
 	db "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 	"maunium.net/go/mautrix"
 	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
 )
 
 // EventStateHandler handles state events from matrix.
@@ -114,7 +115,8 @@ func (service *service) handleInvite(evt *event.Event, content *event.MemberEven
 	}
 
 	room, err := service.matrixDatabase.NewRoom(&matrixdb.MatrixRoom{
-		RoomID: evt.RoomID.String(),
+		RoomID:    evt.RoomID.String(),
+		Encrypted: service.isRoomEncrypted(evt.RoomID),
 	})
 	if err != nil {
 		return err
@@ -147,6 +149,26 @@ func (service *service) handleInvite(evt *event.Event, content *event.MemberEven
 	return nil
 }
 
+// isRoomEncrypted checks the room state so the welcome message is already encrypted.
+func (service *service) isRoomEncrypted(roomID id.RoomID) bool {
+	if service.crypto == nil {
+		return false
+	}
+
+	content := event.EncryptionEventContent{}
+
+	err := service.client.StateEvent(roomID, event.StateEncryption, "", &content)
+	if err != nil {
+		if !errors.Is(err, mautrix.MNotFound) {
+			service.logger.Error("failed to get room encryption state", "error", err, "matrix.room.id", roomID)
+		}
+
+		return false
+	}
+
+	return content.Algorithm != ""
+}
+
 func (service *service) setupNewChannel(room *matrixdb.MatrixRoom, user *matrixdb.MatrixUser) error {
 	channel, err := service.database.NewChannel(&db.Channel{
 		Description: "auto generated channel for matrix room " + room.RoomID,
@@ -219,7 +241,7 @@ func getWelcomeMessage(room *matrixdb.MatrixRoom) (string, string) {
 	msg.Text("You want to now what I am capable of? Just text me ")
 	msg.BoldLine("list all commands")
 	msg.Text("Is this your current local time? ")
-	msg.Italic(format.ToLocalTime(time.Now(), room.TimeZone))
+	msg.Italic(format.ToLocalTime(time.Now(), room.TimeZone, format.Clock24h))
 	msg.NewLine()
 	msg.TextLine("If not, please adjust your timezone with ")
 	msg.BoldLine("set timezone Europe/Berlin")
@@ -42,6 +42,7 @@ func (matcher *EventMatcher) Matches(x any) bool {
 	if matcher.evt.Duration != evt.Duration ||
 		matcher.evt.Message != evt.Message ||
 		matcher.evt.Active != evt.Active ||
+		matcher.evt.RepeatRule != evt.RepeatRule ||
 		matcher.evt.ChannelID != evt.ChannelID {
 		return false
 	}
@@ -72,6 +72,16 @@ func MessageWithOutput(output database.Output) MessageEventOpt {
 	}
 }
 
+func MessageWithInput(input database.Input) MessageEventOpt {
+	return func(evt *matrix.MessageEvent) {
+		if evt.Channel.Inputs == nil {
+			evt.Channel.Inputs = make([]database.Input, 0)
+		}
+
+		evt.Channel.Inputs = append(evt.Channel.Inputs, input)
+	}
+}
+
 type MessageOpt func(msg *matrixdb.MatrixMessage)
 
 func TestMessage(opts ...MessageOpt) *matrixdb.MatrixMessage {
@@ -133,6 +143,14 @@ func WithRecurringEvent(duration time.Duration) MessageOpt {
 	}
 }
 
+func WithRecurringRuleEvent(rule string) MessageOpt {
+	return func(msg *matrixdb.MatrixMessage) {
+		defaultRepeatUntil := time.Now().Add((5 * 365 * 24 * time.Hour))
+		msg.Event.RepeatUntil = &defaultRepeatUntil
+		msg.Event.RepeatRule = rule
+	}
+}
+
 func WithMessageType(mt matrixdb.MatrixMessageType) MessageOpt {
 	return func(msg *matrixdb.MatrixMessage) {
 		msg.Type = mt
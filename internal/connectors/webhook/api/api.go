@@ -0,0 +1,39 @@
+package api
+
+import (
+	"log/slog"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/api/apictx"
+	webhookdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/gin-gonic/gin"
+)
+
+// Config holds information for the API service.
+type Config struct {
+	WebhookDB webhookdb.Service
+	Database  database.Service
+}
+
+type api struct {
+	webhookDB webhookdb.Service
+	database  database.Service
+	logger    *slog.Logger
+}
+
+// New assembles a new webhook API.
+func New(config *Config, logger *slog.Logger) API {
+	return &api{
+		webhookDB: config.WebhookDB,
+		logger:    logger,
+		database:  config.Database,
+	}
+}
+
+func (api *api) RegisterRoutes(r *gin.Engine) error {
+	router := r.Group("/webhook")
+	router.POST("/:id/events", apictx.RequireIDInURI(), api.putEventHandler)
+	router.DELETE("/:id/events/:reference", apictx.RequireIDInURI(), api.cancelEventHandler)
+
+	return nil
+}
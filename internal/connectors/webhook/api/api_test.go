@@ -0,0 +1,35 @@
+package api_test
+
+import (
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook/api"
+	webhookdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/gin-gonic/gin"
+)
+
+func testServer(t *testing.T) (*database.MockService, *webhookdb.MockService, *httptest.Server) {
+	t.Helper()
+	db := database.NewMockService(t)
+	webhookDB := webhookdb.NewMockService(t)
+
+	api := api.New(&api.Config{
+		Database:  db,
+		WebhookDB: webhookDB,
+	}, slog.Default())
+
+	r := gin.New()
+
+	err := api.RegisterRoutes(r)
+	if err != nil {
+		panic(err)
+	}
+
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	return db, webhookDB, server
+}
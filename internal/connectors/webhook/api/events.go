@@ -0,0 +1,259 @@
+package api
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/api/apictx"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/api/response"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook"
+	webhookdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/gin-gonic/gin"
+)
+
+const defaultEventDuration = time.Minute * 5
+
+// EventRequest describes an event pushed to a webhook input.
+type EventRequest struct {
+	Reference       string    // Events with the same reference are updated instead of created.
+	Message         string    `binding:"required"`
+	Time            time.Time `binding:"required"`
+	DurationMinutes uint      // Defaults to 5 minutes
+	Importance      string    `binding:"omitempty,oneof=default important urgent"`
+}
+
+// Event is an event created via a webhook input.
+type Event struct {
+	ID              uint
+	Reference       string
+	Message         string
+	Time            string // RFC 3339 formated time
+	DurationMinutes uint
+	Importance      string // "default", "important" or "urgent"
+	Active          bool
+}
+
+func eventToResponse(event *database.Event) Event {
+	importance := "default"
+
+	switch event.Importance {
+	case database.ImportanceImportant:
+		importance = "important"
+	case database.ImportanceUrgent:
+		importance = "urgent"
+	}
+
+	return Event{
+		ID:              event.ID,
+		Reference:       event.ExternalReference,
+		Message:         event.Message,
+		Time:            event.Time.UTC().Format(time.RFC3339),
+		DurationMinutes: uint(event.Duration / time.Minute),
+		Importance:      importance,
+		Active:          event.Active,
+	}
+}
+
+// putEventHandler godoc
+// @Summary Create or update event
+// @Description Creates an event in the channel of the webhook. If an event with the same reference exists it is updated instead.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook input ID"
+// @Param token query string false "authentication token, alternatively send it as bearer token"
+// @Param payload body EventRequest true "Event"
+// @Success 200 {object} response.DataResponse{data=Event}
+// @Failure 400 {object} response.MessageErrorResponse
+// @Failure 404 {object} response.MessageErrorResponse
+// @Failure 500 ""
+// @Router /webhook/{id}/events [post]
+func (api *api) putEventHandler(ctx *gin.Context) {
+	input, ok := api.inputFromRequest(ctx)
+	if !ok {
+		return
+	}
+
+	var request EventRequest
+
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.AbortWithBadRequestError(ctx, "Invalid event, message and time are required")
+		return
+	}
+
+	event := &database.Event{
+		ChannelID:         input.ChannelID,
+		InputID:           &input.ID,
+		ExternalReference: request.Reference,
+	}
+
+	if request.Reference != "" {
+		existingEvent, err := api.eventByReference(input, request.Reference)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			api.logger.Error("failed to list events", "error", err)
+			response.AbortWithInternalServerError(ctx)
+
+			return
+		}
+
+		if existingEvent != nil {
+			event = existingEvent
+		}
+	}
+
+	event.Message = request.Message
+	event.Time = request.Time.UTC()
+	event.Duration = defaultEventDuration
+	event.Active = true
+	event.Importance = database.ImportanceDefault
+
+	if request.DurationMinutes > 0 {
+		event.Duration = time.Duration(request.DurationMinutes) * time.Minute
+	}
+
+	switch request.Importance {
+	case "important":
+		event.Importance = database.ImportanceImportant
+	case "urgent":
+		event.Importance = database.ImportanceUrgent
+	}
+
+	if event.ID == 0 {
+		event, err = api.database.NewEvent(event)
+	} else {
+		event, err = api.database.UpdateEvent(event)
+	}
+
+	if err != nil {
+		api.logger.Error("failed to save event", "error", err)
+		response.AbortWithInternalServerError(ctx)
+
+		return
+	}
+
+	response.WithData(ctx, eventToResponse(event))
+}
+
+// cancelEventHandler godoc
+// @Summary Cancel event
+// @Description Cancels the event with the given reference, it will not be reminded about anymore.
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "Webhook input ID"
+// @Param reference path string true "Event reference"
+// @Param token query string false "authentication token, alternatively send it as bearer token"
+// @Success 200 {object} response.DataResponse{data=Event}
+// @Failure 404 {object} response.MessageErrorResponse
+// @Failure 500 ""
+// @Router /webhook/{id}/events/{reference} [delete]
+func (api *api) cancelEventHandler(ctx *gin.Context) {
+	input, ok := api.inputFromRequest(ctx)
+	if !ok {
+		return
+	}
+
+	event, err := api.eventByReference(input, ctx.Param("reference"))
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			response.AbortWithNotFoundError(ctx)
+			return
+		}
+
+		api.logger.Error("failed to list events", "error", err)
+		response.AbortWithInternalServerError(ctx)
+
+		return
+	}
+
+	event.Active = false
+
+	event, err = api.database.UpdateEvent(event)
+	if err != nil {
+		api.logger.Error("failed to cancel event", "error", err)
+		response.AbortWithInternalServerError(ctx)
+
+		return
+	}
+
+	response.WithData(ctx, eventToResponse(event))
+}
+
+// inputFromRequest returns the enabled input the request is authenticated for. Aborts the request otherwise.
+func (api *api) inputFromRequest(ctx *gin.Context) (*database.Input, bool) {
+	id, ok := apictx.GetUintFromContext(ctx, "id")
+	if !ok {
+		response.AbortWithNotFoundError(ctx)
+		return nil, false
+	}
+
+	token := ctx.Query("token")
+	if token == "" {
+		token = strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+	}
+
+	if token == "" {
+		// Use not found to not leak any information.
+		response.AbortWithNotFoundError(ctx)
+		return nil, false
+	}
+
+	webhookInput, err := api.webhookDB.GetWebhookInputByID(id)
+	if err != nil {
+		if errors.Is(err, webhookdb.ErrNotFound) {
+			response.AbortWithNotFoundError(ctx)
+			return nil, false
+		}
+
+		api.logger.Error("failed to get webhook input", "error", err, "webhook.input.id", id)
+		response.AbortWithInternalServerError(ctx)
+
+		return nil, false
+	}
+
+	if i := subtle.ConstantTimeCompare([]byte(webhookInput.Token), []byte(token)); i != 1 {
+		response.AbortWithNotFoundError(ctx)
+		return nil, false
+	}
+
+	input, err := api.database.GetInputByType(webhookInput.ID, webhook.InputType)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			response.AbortWithNotFoundError(ctx)
+			return nil, false
+		}
+
+		api.logger.Error("failed to get input from database", "error", err)
+		response.AbortWithInternalServerError(ctx)
+
+		return nil, false
+	}
+
+	if !input.Enabled || input.ChannelID == 0 {
+		// Events would end up in no or a disabled channel.
+		response.AbortWithNotFoundError(ctx)
+		return nil, false
+	}
+
+	return input, true
+}
+
+func (api *api) eventByReference(input *database.Input, reference string) (*database.Event, error) {
+	events, err := api.database.ListEvents(&database.ListEventsOpts{
+		InputID:           &input.ID,
+		ExternalReference: &reference,
+		IncludeInactive:   true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(events) == 0 {
+		return nil, database.ErrNotFound
+	}
+
+	return &events[0], nil
+}
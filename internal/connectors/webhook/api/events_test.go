@@ -0,0 +1,360 @@
+package api_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook"
+	webhookdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func expectAuthenticatedInput(db *database.MockService, webhookDB *webhookdb.MockService) {
+	webhookDB.EXPECT().GetWebhookInputByID(uint(1)).Return(
+		&webhookdb.WebhookInput{
+			Model: gorm.Model{
+				ID: 1,
+			},
+			Token: "1234",
+		},
+		nil,
+	)
+
+	db.EXPECT().GetInputByType(uint(1), webhook.InputType).Return(
+		&database.Input{
+			Model: gorm.Model{
+				ID: 7,
+			},
+			ChannelID: 34,
+			Enabled:   true,
+		},
+		nil,
+	)
+}
+
+func doRequest(t *testing.T, method, url, body string, header http.Header) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(
+		t.Context(),
+		method,
+		url,
+		strings.NewReader(body),
+	)
+	require.NoError(t, err)
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(respBody)
+}
+
+func TestAPI_PutEventHandlerCreatesEvent(t *testing.T) {
+	db, webhookDB, server := testServer(t)
+	expectAuthenticatedInput(db, webhookDB)
+
+	db.EXPECT().ListEvents(&database.ListEventsOpts{
+		InputID:           new(uint(7)),
+		ExternalReference: new("build-42"),
+		IncludeInactive:   true,
+	}).Return(nil, nil)
+
+	db.EXPECT().NewEvent(&database.Event{
+		ChannelID:         34,
+		InputID:           new(uint(7)),
+		ExternalReference: "build-42",
+		Message:           "deploy failed",
+		Time:              time.Date(2106, 1, 2, 8, 4, 5, 0, time.UTC),
+		Duration:          time.Minute * 30,
+		Active:            true,
+		Importance:        database.ImportanceImportant,
+	}).RunAndReturn(func(event *database.Event) (*database.Event, error) {
+		event.ID = 69
+		return event, nil
+	})
+
+	status, body := doRequest(t, http.MethodPost, server.URL+"/webhook/1/events?token=1234",
+		`{"Reference":"build-42","Message":"deploy failed","Time":"2106-01-02T15:04:05+07:00","DurationMinutes":30,"Importance":"important"}`,
+		nil,
+	)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"status":"success","data":{"ID":69,"Reference":"build-42","Message":"deploy failed","Time":"2106-01-02T08:04:05Z","DurationMinutes":30,"Importance":"important","Active":true}}`, body)
+}
+
+func TestAPI_PutEventHandlerCreatesEventWithoutReference(t *testing.T) {
+	db, webhookDB, server := testServer(t)
+	expectAuthenticatedInput(db, webhookDB)
+
+	db.EXPECT().NewEvent(mock.Anything).RunAndReturn(func(event *database.Event) (*database.Event, error) {
+		assert.Empty(t, event.ExternalReference)
+		assert.Equal(t, time.Minute*5, event.Duration)
+		assert.Equal(t, database.ImportanceDefault, event.Importance)
+
+		event.ID = 70
+
+		return event, nil
+	})
+
+	status, _ := doRequest(t, http.MethodPost, server.URL+"/webhook/1/events",
+		`{"Message":"disk full","Time":"2106-01-02T15:04:05Z"}`,
+		http.Header{"Authorization": {"Bearer 1234"}},
+	)
+
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestAPI_PutEventHandlerUpdatesEvent(t *testing.T) {
+	db, webhookDB, server := testServer(t)
+	expectAuthenticatedInput(db, webhookDB)
+
+	db.EXPECT().ListEvents(&database.ListEventsOpts{
+		InputID:           new(uint(7)),
+		ExternalReference: new("build-42"),
+		IncludeInactive:   true,
+	}).Return([]database.Event{
+		{
+			Model: gorm.Model{
+				ID: 69,
+			},
+			ChannelID:         34,
+			InputID:           new(uint(7)),
+			ExternalReference: "build-42",
+			Message:           "old",
+			Active:            false,
+			Importance:        database.ImportanceImportant,
+		},
+	}, nil)
+
+	db.EXPECT().UpdateEvent(&database.Event{
+		Model: gorm.Model{
+			ID: 69,
+		},
+		ChannelID:         34,
+		InputID:           new(uint(7)),
+		ExternalReference: "build-42",
+		Message:           "deploy fixed",
+		Time:              time.Date(2106, 1, 2, 15, 4, 5, 0, time.UTC),
+		Duration:          time.Minute * 5,
+		Active:            true,
+		Importance:        database.ImportanceDefault,
+	}).RunAndReturn(func(event *database.Event) (*database.Event, error) {
+		return event, nil
+	})
+
+	status, body := doRequest(t, http.MethodPost, server.URL+"/webhook/1/events?token=1234",
+		`{"Reference":"build-42","Message":"deploy fixed","Time":"2106-01-02T15:04:05Z"}`,
+		nil,
+	)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"status":"success","data":{"ID":69,"Reference":"build-42","Message":"deploy fixed","Time":"2106-01-02T15:04:05Z","DurationMinutes":5,"Importance":"default","Active":true}}`, body)
+}
+
+func TestAPI_PutEventHandlerWithInvalidBody(t *testing.T) {
+	for _, body := range []string{
+		`{"Time":"2106-01-02T15:04:05Z"}`,
+		`{"Message":"test"}`,
+		`{"Message":"test","Time":"2106-01-02T15:04:05Z","Importance":"critical"}`,
+		`not json`,
+	} {
+		t.Run(body, func(t *testing.T) {
+			db, webhookDB, server := testServer(t)
+			expectAuthenticatedInput(db, webhookDB)
+
+			status, _ := doRequest(t, http.MethodPost, server.URL+"/webhook/1/events?token=1234", body, nil)
+			assert.Equal(t, http.StatusBadRequest, status)
+		})
+	}
+}
+
+func TestAPI_PutEventHandlerWithDatabaseError(t *testing.T) {
+	db, webhookDB, server := testServer(t)
+	expectAuthenticatedInput(db, webhookDB)
+
+	db.EXPECT().NewEvent(mock.Anything).Return(nil, errors.New("test"))
+
+	status, _ := doRequest(t, http.MethodPost, server.URL+"/webhook/1/events?token=1234",
+		`{"Message":"disk full","Time":"2106-01-02T15:04:05Z"}`,
+		nil,
+	)
+
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
+
+func TestAPI_PutEventHandlerWithWrongToken(t *testing.T) {
+	_, webhookDB, server := testServer(t)
+
+	webhookDB.EXPECT().GetWebhookInputByID(uint(1)).Return(
+		&webhookdb.WebhookInput{
+			Token: "1234",
+		},
+		nil,
+	)
+
+	status, _ := doRequest(t, http.MethodPost, server.URL+"/webhook/1/events?token=123",
+		`{"Message":"disk full","Time":"2106-01-02T15:04:05Z"}`,
+		nil,
+	)
+
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestAPI_PutEventHandlerWithoutToken(t *testing.T) {
+	_, _, server := testServer(t)
+
+	status, _ := doRequest(t, http.MethodPost, server.URL+"/webhook/1/events",
+		`{"Message":"disk full","Time":"2106-01-02T15:04:05Z"}`,
+		nil,
+	)
+
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestAPI_PutEventHandlerWithInputNotFound(t *testing.T) {
+	_, webhookDB, server := testServer(t)
+
+	webhookDB.EXPECT().GetWebhookInputByID(uint(1)).Return(nil, webhookdb.ErrNotFound)
+
+	status, _ := doRequest(t, http.MethodPost, server.URL+"/webhook/1/events?token=1234", `{}`, nil)
+
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestAPI_PutEventHandlerWithRemovedInput(t *testing.T) {
+	db, webhookDB, server := testServer(t)
+
+	webhookDB.EXPECT().GetWebhookInputByID(uint(1)).Return(
+		&webhookdb.WebhookInput{
+			Model: gorm.Model{
+				ID: 1,
+			},
+			Token: "1234",
+		},
+		nil,
+	)
+	db.EXPECT().GetInputByType(uint(1), webhook.InputType).Return(nil, database.ErrNotFound)
+
+	status, _ := doRequest(t, http.MethodPost, server.URL+"/webhook/1/events?token=1234", `{}`, nil)
+
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestAPI_PutEventHandlerWithUnusableInput(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input *database.Input
+	}{
+		{
+			name: "disabled",
+			input: &database.Input{
+				Model:     gorm.Model{ID: 7},
+				ChannelID: 34,
+				Enabled:   false,
+			},
+		},
+		{
+			name: "not attached to a channel",
+			input: &database.Input{
+				Model:   gorm.Model{ID: 7},
+				Enabled: true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, webhookDB, server := testServer(t)
+
+			webhookDB.EXPECT().GetWebhookInputByID(uint(1)).Return(
+				&webhookdb.WebhookInput{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Token: "1234",
+				},
+				nil,
+			)
+			db.EXPECT().GetInputByType(uint(1), webhook.InputType).Return(tc.input, nil)
+
+			status, _ := doRequest(t, http.MethodPost, server.URL+"/webhook/1/events?token=1234",
+				`{"Message":"disk full","Time":"2106-01-02T15:04:05Z"}`,
+				nil,
+			)
+
+			assert.Equal(t, http.StatusNotFound, status)
+		})
+	}
+}
+
+func TestAPI_CancelEventHandler(t *testing.T) {
+	db, webhookDB, server := testServer(t)
+	expectAuthenticatedInput(db, webhookDB)
+
+	db.EXPECT().ListEvents(&database.ListEventsOpts{
+		InputID:           new(uint(7)),
+		ExternalReference: new("build-42"),
+		IncludeInactive:   true,
+	}).Return([]database.Event{
+		{
+			Model: gorm.Model{
+				ID: 69,
+			},
+			ExternalReference: "build-42",
+			Message:           "deploy failed",
+			Time:              time.Date(2106, 1, 2, 15, 4, 5, 0, time.UTC),
+			Duration:          time.Minute * 5,
+			Active:            true,
+		},
+	}, nil)
+
+	db.EXPECT().UpdateEvent(mock.MatchedBy(func(event *database.Event) bool {
+		return event.ID == 69 && !event.Active
+	})).RunAndReturn(func(event *database.Event) (*database.Event, error) {
+		return event, nil
+	})
+
+	status, body := doRequest(t, http.MethodDelete, server.URL+"/webhook/1/events/build-42?token=1234", "", nil)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"status":"success","data":{"ID":69,"Reference":"build-42","Message":"deploy failed","Time":"2106-01-02T15:04:05Z","DurationMinutes":5,"Importance":"default","Active":false}}`, body)
+}
+
+func TestAPI_CancelEventHandlerWithUnknownReference(t *testing.T) {
+	db, webhookDB, server := testServer(t)
+	expectAuthenticatedInput(db, webhookDB)
+
+	db.EXPECT().ListEvents(mock.Anything).Return(nil, nil)
+
+	status, _ := doRequest(t, http.MethodDelete, server.URL+"/webhook/1/events/build-42?token=1234", "", nil)
+
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestAPI_CancelEventHandlerWithDatabaseError(t *testing.T) {
+	db, webhookDB, server := testServer(t)
+	expectAuthenticatedInput(db, webhookDB)
+
+	db.EXPECT().ListEvents(mock.Anything).Return(nil, errors.New("test"))
+
+	status, _ := doRequest(t, http.MethodDelete, server.URL+"/webhook/1/events/build-42?token=1234", "", nil)
+
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
@@ -0,0 +1,8 @@
+package api
+
+import "github.com/gin-gonic/gin"
+
+// API provides an interface for the webhook connector API.
+type API interface {
+	RegisterRoutes(*gin.Engine) error
+}
@@ -0,0 +1,40 @@
+package database
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// List of common errors returned by the package.
+var (
+	ErrNotFound = errors.New("not found")
+)
+
+// WebhookInput holds information about an endpoint events can be pushed to.
+type WebhookInput struct {
+	gorm.Model
+
+	Token string
+}
+
+// WebhookOutput holds information about an URL channel events are posted to.
+type WebhookOutput struct {
+	gorm.Model
+
+	ChannelID uint
+	URL       string
+	Secret    string // Used to sign the payloads.
+	TimeZone  string // Daily reminders are sent based on this time zone.
+}
+
+// Service provides a database service for the webhook connector.
+type Service interface {
+	NewWebhookInput(*WebhookInput) (*WebhookInput, error)
+	GetWebhookInputByID(id uint) (*WebhookInput, error)
+	DeleteWebhookInput(id uint) error
+
+	NewWebhookOutput(*WebhookOutput) (*WebhookOutput, error)
+	GetWebhookOutputByID(id uint) (*WebhookOutput, error)
+	DeleteWebhookOutput(id uint) error
+}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database/migration"
+	"gorm.io/gorm"
+)
+
+// Migrations returns the versioned schema migrations of the webhook connector.
+//
+// Migrations work on snapshots of the models as of their version, changes to
+// the models require a new migration with a new snapshot.
+func Migrations() []migration.Migration {
+	return []migration.Migration{
+		{
+			Version: 1,
+			Name:    "initial schema",
+			Up:      migration.AutoMigrate(&webhookInputV1{}, &webhookOutputV1{}),
+		},
+	}
+}
+
+// Version 1
+
+type webhookInputV1 struct {
+	gorm.Model
+
+	Token string
+}
+
+func (webhookInputV1) TableName() string { return "webhook_inputs" }
+
+type webhookOutputV1 struct {
+	gorm.Model
+
+	ChannelID uint
+	URL       string
+	Secret    string
+	TimeZone  string
+}
+
+func (webhookOutputV1) TableName() string { return "webhook_outputs" }
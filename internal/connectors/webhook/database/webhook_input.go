@@ -0,0 +1,39 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/random"
+	"gorm.io/gorm"
+)
+
+func (service *service) NewWebhookInput(input *WebhookInput) (*WebhookInput, error) {
+	// New token with 30-40 characters length.
+	input.Token = random.URLSaveString(random.Intn(10) + 30)
+
+	err := service.db.Save(input).Error
+
+	return input, err
+}
+
+func (service *service) GetWebhookInputByID(id uint) (*WebhookInput, error) {
+	var entity WebhookInput
+
+	err := service.db.First(&entity, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+
+	return &entity, err
+}
+
+func (service *service) DeleteWebhookInput(id uint) error {
+	result := service.db.Delete(&WebhookInput{Model: gorm.Model{ID: id}})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_NewWebhookInput(t *testing.T) {
+	start := time.Now()
+
+	time.Sleep(time.Millisecond) // Avoids issues with database time representation being less accurate.
+
+	inputBefore, err := service.NewWebhookInput(&database.WebhookInput{})
+	require.NoError(t, err)
+
+	assert.NotZero(t, inputBefore.ID)
+	assert.GreaterOrEqual(t, inputBefore.CreatedAt, start)
+	assert.GreaterOrEqual(t, len(inputBefore.Token), 30)
+
+	inputAfter, err := service.GetWebhookInputByID(inputBefore.ID)
+	require.NoError(t, err)
+	assert.Equal(t, inputBefore.ID, inputAfter.ID)
+	assert.Equal(t, inputBefore.Token, inputAfter.Token)
+}
+
+func TestService_GetWebhookInputByIDWithNotFound(t *testing.T) {
+	_, err := service.GetWebhookInputByID(999999)
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func TestService_DeleteWebhookInput(t *testing.T) {
+	input, err := service.NewWebhookInput(&database.WebhookInput{})
+	require.NoError(t, err)
+	require.NotZero(t, input.ID)
+
+	err = service.DeleteWebhookInput(input.ID)
+	require.NoError(t, err)
+
+	_, err = service.GetWebhookInputByID(input.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func TestService_DeleteWebhookInputWithNotFound(t *testing.T) {
+	err := service.DeleteWebhookInput(999999)
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
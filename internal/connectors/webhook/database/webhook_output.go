@@ -0,0 +1,39 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/random"
+	"gorm.io/gorm"
+)
+
+func (service *service) NewWebhookOutput(output *WebhookOutput) (*WebhookOutput, error) {
+	// New secret with 40-50 characters length.
+	output.Secret = random.URLSaveString(random.Intn(10) + 40)
+
+	err := service.db.Save(output).Error
+
+	return output, err
+}
+
+func (service *service) GetWebhookOutputByID(id uint) (*WebhookOutput, error) {
+	var entity WebhookOutput
+
+	err := service.db.First(&entity, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+
+	return &entity, err
+}
+
+func (service *service) DeleteWebhookOutput(id uint) error {
+	result := service.db.Delete(&WebhookOutput{Model: gorm.Model{ID: id}})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testOutput() *database.WebhookOutput {
+	return &database.WebhookOutput{
+		ChannelID: 1,
+		URL:       "https://example.com/hook",
+		TimeZone:  "Europe/Berlin",
+	}
+}
+
+func TestService_NewWebhookOutput(t *testing.T) {
+	start := time.Now()
+
+	time.Sleep(time.Millisecond) // Avoids issues with database time representation being less accurate.
+
+	outputBefore, err := service.NewWebhookOutput(testOutput())
+	require.NoError(t, err)
+
+	assert.NotZero(t, outputBefore.ID)
+	assert.GreaterOrEqual(t, outputBefore.CreatedAt, start)
+	assert.GreaterOrEqual(t, len(outputBefore.Secret), 40)
+
+	outputAfter, err := service.GetWebhookOutputByID(outputBefore.ID)
+	require.NoError(t, err)
+	assert.Equal(t, outputBefore.ID, outputAfter.ID)
+	assert.Equal(t, uint(1), outputAfter.ChannelID)
+	assert.Equal(t, "https://example.com/hook", outputAfter.URL)
+	assert.Equal(t, outputBefore.Secret, outputAfter.Secret)
+	assert.Equal(t, "Europe/Berlin", outputAfter.TimeZone)
+}
+
+func TestService_GetWebhookOutputByIDWithNotFound(t *testing.T) {
+	_, err := service.GetWebhookOutputByID(999999)
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func TestService_DeleteWebhookOutput(t *testing.T) {
+	output, err := service.NewWebhookOutput(testOutput())
+	require.NoError(t, err)
+	require.NotZero(t, output.ID)
+
+	err = service.DeleteWebhookOutput(output.ID)
+	require.NoError(t, err)
+
+	_, err = service.GetWebhookOutputByID(output.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func TestService_DeleteWebhookOutputWithNotFound(t *testing.T) {
+	err := service.DeleteWebhookOutput(999999)
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"errors"
+	"time"
+
+	webhookdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/daemon"
+)
+
+// The in- and output type provided by this package
+const (
+	InputType  = "webhook"
+	OutputType = "webhook"
+)
+
+// Headers set on every request send to a webhook.
+const (
+	HeaderSignature = "X-RemindMe-Signature"
+	HeaderEvent     = "X-RemindMe-Event"
+)
+
+// Payload types send to webhooks.
+const (
+	PayloadTypeReminder        = "reminder"
+	PayloadTypePreNotification = "pre_notification"
+	PayloadTypeDailyReminder   = "daily_reminder"
+	PayloadTypeEscalation      = "escalation"
+)
+
+// List of commonly used errors in this package.
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrInvalidURL    = errors.New("invalid url")
+	ErrBadStatusCode = errors.New("bad status code")
+)
+
+// Service provides an interface for the webhook connector.
+// The connector is suitable for in- and output.
+type Service interface {
+	Start() error
+	Stop() error
+
+	InputExists(inputType string, inputID uint) (bool, error)
+	InputRemoved(inputType string, inputID uint) error
+	OutputExists(outputType string, outputID uint) (bool, error)
+	OutputRemoved(outputType string, outputID uint) error
+
+	NewInput(channelID uint) (*webhookdb.WebhookInput, string, error) // Returns the URL to push events to.
+
+	NewOutput(channelID uint, url string, timeZone string) (*webhookdb.WebhookOutput, error)
+	GetOutput(outputID uint) (*webhookdb.WebhookOutput, error)
+
+	SendReminder(*daemon.Event, *daemon.Output) error
+	SendDailyReminder(*daemon.DailyReminder, *daemon.Output) error
+
+	ToLocalTime(time.Time, *daemon.Output) time.Time
+
+	Cleanup() error
+}
+
+// Payload is the JSON body posted to webhooks.
+type Payload struct {
+	Type    string         `json:"type"`
+	SentAt  time.Time      `json:"sent_at"`
+	Channel ChannelPayload `json:"channel"`
+	Event   *EventPayload  `json:"event,omitempty"`  // Set for reminders and pre-notifications.
+	Events  []EventPayload `json:"events,omitempty"` // Set for daily reminders.
+	// Set for daily reminders.
+	Overdue          []EventPayload `json:"overdue,omitempty"`
+	LookaheadSeconds int64          `json:"lookahead_seconds,omitempty"`
+	RecurringCount   int            `json:"recurring_count,omitempty"`
+}
+
+// ChannelPayload holds information about the channel the event belongs to.
+type ChannelPayload struct {
+	ID uint `json:"id"`
+}
+
+// EventPayload holds information about a single event occurrence.
+type EventPayload struct {
+	ID              uint      `json:"id"`
+	Message         string    `json:"message"`
+	OccurrenceTime  time.Time `json:"occurrence_time"`
+	Importance      string    `json:"importance"`
+	Recurring       bool      `json:"recurring"`
+	StartsInSeconds *int64    `json:"starts_in_seconds,omitempty"`
+	EscalationLevel *uint     `json:"escalation_level,omitempty"`
+	Mentions        []string  `json:"mentions,omitempty"` // Users to notify about the escalation.
+}
@@ -0,0 +1,356 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	webhookdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/daemon"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/httpclient"
+)
+
+const requestTimeout = time.Second * 10
+
+// Config holds the configuration for the service.
+type Config struct {
+	WebhookDB webhookdb.Service
+	Database  database.Service
+
+	BaseURL *url.URL
+
+	// AllowPrivateNetworks allows webhooks to loopback, private and link-local addresses.
+	AllowPrivateNetworks bool
+}
+
+type service struct {
+	config *Config
+	logger *slog.Logger
+	client *http.Client
+
+	stop chan bool
+}
+
+// New assembles a new webhook connector service.
+func New(config *Config, logger *slog.Logger) Service {
+	return &service{
+		config: config,
+		logger: logger,
+		client: httpClient(config.AllowPrivateNetworks),
+		stop:   make(chan bool, 1),
+	}
+}
+
+// httpClient returns the client to call webhooks with. Redirects are not followed and proxies are not used. Unless
+// private networks are allowed it refuses to connect to non-public addresses.
+func httpClient(allowPrivateNetworks bool) *http.Client {
+	transport := httpclient.PublicTransport()
+	if allowPrivateNetworks {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = nil
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   requestTimeout,
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func (service *service) Start() error {
+	// Webhooks are only called by the daemon, nothing to do in the background.
+	<-service.stop
+
+	return nil
+}
+
+func (service *service) Stop() error {
+	service.stop <- true
+
+	service.logger.Info("stopping")
+
+	return nil
+}
+
+func (service *service) InputExists(inputType string, inputID uint) (bool, error) {
+	if inputType != InputType {
+		return false, nil
+	}
+
+	_, err := service.config.WebhookDB.GetWebhookInputByID(inputID)
+	if errors.Is(err, webhookdb.ErrNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func (service *service) InputRemoved(inputType string, inputID uint) error {
+	if inputType != InputType {
+		return nil
+	}
+
+	err := service.config.WebhookDB.DeleteWebhookInput(inputID)
+	if errors.Is(err, webhookdb.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+func (service *service) OutputExists(outputType string, outputID uint) (bool, error) {
+	if outputType != OutputType {
+		return false, nil
+	}
+
+	_, err := service.config.WebhookDB.GetWebhookOutputByID(outputID)
+	if errors.Is(err, webhookdb.ErrNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func (service *service) OutputRemoved(outputType string, outputID uint) error {
+	if outputType != OutputType {
+		return nil
+	}
+
+	err := service.config.WebhookDB.DeleteWebhookOutput(outputID)
+	if errors.Is(err, webhookdb.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+func (service *service) NewInput(channelID uint) (*webhookdb.WebhookInput, string, error) {
+	webhookInput, err := service.config.WebhookDB.NewWebhookInput(&webhookdb.WebhookInput{})
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = service.config.Database.AddInputToChannel(channelID, &database.Input{
+		ChannelID: channelID,
+		InputType: InputType,
+		InputID:   webhookInput.ID,
+		Enabled:   true,
+	})
+	if err != nil {
+		// Do not leave an input behind no channel uses.
+		deleteErr := service.config.WebhookDB.DeleteWebhookInput(webhookInput.ID)
+		if deleteErr != nil {
+			service.logger.Error("failed to delete webhook input", "error", deleteErr, "webhook.input_id", webhookInput.ID)
+		}
+
+		return nil, "", err
+	}
+
+	inputURL := service.config.BaseURL.JoinPath(fmt.Sprintf("/webhook/%d/events", webhookInput.ID))
+	inputURL.RawQuery = url.Values{"token": []string{webhookInput.Token}}.Encode()
+
+	return webhookInput, inputURL.String(), nil
+}
+
+func (service *service) NewOutput(channelID uint, outputURL string, timeZone string) (*webhookdb.WebhookOutput, error) {
+	parsedURL, err := url.Parse(outputURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return nil, ErrInvalidURL
+	}
+
+	webhookOutput, err := service.config.WebhookDB.NewWebhookOutput(&webhookdb.WebhookOutput{
+		ChannelID: channelID,
+		URL:       parsedURL.String(),
+		TimeZone:  timeZone,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = service.config.Database.AddOutputToChannel(channelID, &database.Output{
+		ChannelID:  channelID,
+		OutputType: OutputType,
+		OutputID:   webhookOutput.ID,
+		Enabled:    true,
+	})
+	if err != nil {
+		// Do not leave an output behind no channel uses.
+		deleteErr := service.config.WebhookDB.DeleteWebhookOutput(webhookOutput.ID)
+		if deleteErr != nil {
+			service.logger.Error("failed to delete webhook output", "error", deleteErr, "webhook.output_id", webhookOutput.ID)
+		}
+
+		return nil, err
+	}
+
+	return webhookOutput, nil
+}
+
+func (service *service) GetOutput(outputID uint) (*webhookdb.WebhookOutput, error) {
+	output, err := service.config.WebhookDB.GetWebhookOutputByID(outputID)
+	if err != nil {
+		if errors.Is(err, webhookdb.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (service *service) SendReminder(event *daemon.Event, output *daemon.Output) error {
+	webhookOutput, err := service.GetOutput(output.OutputID)
+	if err != nil {
+		return err
+	}
+
+	payloadType := PayloadTypeReminder
+
+	switch {
+	case event.StartsIn != nil:
+		payloadType = PayloadTypePreNotification
+	case event.Escalation != nil:
+		payloadType = PayloadTypeEscalation
+	}
+
+	return service.post(webhookOutput, &Payload{
+		Type:    payloadType,
+		SentAt:  time.Now().UTC(),
+		Channel: ChannelPayload{ID: webhookOutput.ChannelID},
+		Event:   eventToPayload(event),
+	})
+}
+
+func (service *service) SendDailyReminder(reminder *daemon.DailyReminder, output *daemon.Output) error {
+	webhookOutput, err := service.GetOutput(output.OutputID)
+	if err != nil {
+		return err
+	}
+
+	return service.post(webhookOutput, &Payload{
+		Type:             PayloadTypeDailyReminder,
+		SentAt:           time.Now().UTC(),
+		Channel:          ChannelPayload{ID: webhookOutput.ChannelID},
+		Events:           eventsToPayload(reminder.Events),
+		Overdue:          eventsToPayload(reminder.Overdue),
+		LookaheadSeconds: int64(reminder.Lookahead.Seconds()),
+		RecurringCount:   reminder.RecurringCount(),
+	})
+}
+
+func (service *service) ToLocalTime(date time.Time, output *daemon.Output) time.Time {
+	webhookOutput, err := service.GetOutput(output.OutputID)
+	if err != nil {
+		service.logger.Error("failed to get webhook output", "error", err, "output.id", output.OutputID)
+		return date
+	}
+
+	if webhookOutput.TimeZone == "" {
+		return date
+	}
+
+	loc, err := time.LoadLocation(webhookOutput.TimeZone)
+	if err != nil {
+		return date
+	}
+
+	return date.In(loc)
+}
+
+func (service *service) Cleanup() error {
+	// Not supported.
+	return nil
+}
+
+func (service *service) post(output *webhookdb.WebhookOutput, payload *Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, output.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, payload.Type)
+	req.Header.Set(HeaderSignature, Sign(body, output.Secret))
+
+	resp, err := service.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%w: %d", ErrBadStatusCode, resp.StatusCode)
+	}
+
+	service.logger.Debug("called webhook", "webhook.id", output.ID, "payload.type", payload.Type)
+
+	return nil
+}
+
+// Sign returns the signature of the body as send in the signature header.
+// Receivers can verify payloads by comparing it with the HMAC-SHA256 of the
+// raw request body using the webhook secret as key.
+func Sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func eventToPayload(event *daemon.Event) *EventPayload {
+	payload := &EventPayload{
+		ID:             event.ID,
+		Message:        event.Message,
+		OccurrenceTime: event.EventTime.UTC(),
+		Importance:     "default",
+		Recurring:      event.IsRecurring(),
+	}
+
+	switch event.Importance {
+	case daemon.ImportanceImportant:
+		payload.Importance = "important"
+	case daemon.ImportanceUrgent:
+		payload.Importance = "urgent"
+	}
+
+	if event.StartsIn != nil {
+		payload.StartsInSeconds = new(int64(event.StartsIn.Seconds()))
+	}
+
+	if event.Escalation != nil {
+		payload.EscalationLevel = &event.Escalation.Level
+		payload.Mentions = event.Escalation.Mentions
+	}
+
+	return payload
+}
+
+func eventsToPayload(events []daemon.Event) []EventPayload {
+	payloads := make([]EventPayload, 0, len(events))
+	for i := range events {
+		payloads = append(payloads, *eventToPayload(&events[i]))
+	}
+
+	return payloads
+}
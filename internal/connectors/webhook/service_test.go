@@ -0,0 +1,599 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook"
+	webhookdb "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/connectors/webhook/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/daemon"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func testService(t *testing.T) (webhook.Service, *webhookdb.MockService, *database.MockService) {
+	t.Helper()
+
+	baseURL, _ := url.Parse("https://example.com")
+	db := database.NewMockService(t)
+	webhookDB := webhookdb.NewMockService(t)
+
+	return webhook.New(&webhook.Config{
+			Database:             db,
+			WebhookDB:            webhookDB,
+			BaseURL:              baseURL,
+			AllowPrivateNetworks: true,
+		}, slog.New(slog.NewTextHandler(os.Stdout, nil))),
+		webhookDB,
+		db
+}
+
+type receivedRequest struct {
+	header  http.Header
+	body    []byte
+	payload webhook.Payload
+}
+
+func testServer(t *testing.T, statusCode int) (*httptest.Server, chan receivedRequest) {
+	t.Helper()
+
+	requests := make(chan receivedRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		req := receivedRequest{
+			header: r.Header,
+			body:   body,
+		}
+		assert.NoError(t, json.Unmarshal(body, &req.payload))
+
+		requests <- req
+
+		w.WriteHeader(statusCode)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, requests
+}
+
+func testWebhookOutput(url string) *webhookdb.WebhookOutput {
+	return &webhookdb.WebhookOutput{
+		Model: gorm.Model{
+			ID: 2,
+		},
+		ChannelID: 3,
+		URL:       url,
+		Secret:    "secret",
+		TimeZone:  "Europe/Berlin",
+	}
+}
+
+func TestService_InputExists(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+
+	webhookDB.EXPECT().GetWebhookInputByID(uint(1)).Return(&webhookdb.WebhookInput{}, nil)
+
+	exists, err := service.InputExists("webhook", 1)
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestService_InputExistsWithNotFound(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+
+	webhookDB.EXPECT().GetWebhookInputByID(uint(1)).Return(nil, webhookdb.ErrNotFound)
+
+	exists, err := service.InputExists("webhook", 1)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestService_InputExistsWithWrongType(t *testing.T) {
+	service, _, _ := testService(t)
+
+	exists, err := service.InputExists("notwebhook", 1)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestService_InputExistsWithError(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+
+	webhookDB.EXPECT().GetWebhookInputByID(uint(1)).Return(nil, errors.New("test"))
+
+	_, err := service.InputExists("webhook", 1)
+	require.Error(t, err)
+}
+
+func TestService_InputRemoved(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+
+	webhookDB.EXPECT().DeleteWebhookInput(uint(1)).Return(nil)
+
+	err := service.InputRemoved("webhook", 1)
+	require.NoError(t, err)
+}
+
+func TestService_InputRemovedWithNotFound(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+
+	webhookDB.EXPECT().DeleteWebhookInput(uint(1)).Return(webhookdb.ErrNotFound)
+
+	err := service.InputRemoved("webhook", 1)
+	require.NoError(t, err)
+}
+
+func TestService_InputRemovedWithWrongType(t *testing.T) {
+	service, _, _ := testService(t)
+
+	err := service.InputRemoved("notwebhook", 1)
+	require.NoError(t, err)
+}
+
+func TestService_InputRemovedWithError(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+
+	webhookDB.EXPECT().DeleteWebhookInput(uint(1)).Return(errors.New("test"))
+
+	err := service.InputRemoved("webhook", 1)
+	require.Error(t, err)
+}
+
+func TestService_NewInput(t *testing.T) {
+	service, webhookDB, db := testService(t)
+
+	webhookDB.EXPECT().NewWebhookInput(&webhookdb.WebhookInput{}).Return(&webhookdb.WebhookInput{
+		Model: gorm.Model{
+			ID: 4,
+		},
+		Token: "abc",
+	}, nil)
+	db.EXPECT().AddInputToChannel(uint(3), &database.Input{
+		ChannelID: 3,
+		InputType: "webhook",
+		InputID:   4,
+		Enabled:   true,
+	}).Return(nil)
+
+	input, inputURL, err := service.NewInput(3)
+	require.NoError(t, err)
+	assert.Equal(t, uint(4), input.ID)
+	assert.Equal(t, "https://example.com/webhook/4/events?token=abc", inputURL)
+}
+
+func TestService_NewInputWithError(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+
+	webhookDB.EXPECT().NewWebhookInput(&webhookdb.WebhookInput{}).Return(nil, errors.New("test"))
+
+	_, _, err := service.NewInput(3)
+	require.Error(t, err)
+}
+
+func TestService_NewInputWithChannelError(t *testing.T) {
+	service, webhookDB, db := testService(t)
+
+	webhookDB.EXPECT().NewWebhookInput(&webhookdb.WebhookInput{}).Return(&webhookdb.WebhookInput{
+		Model: gorm.Model{
+			ID: 4,
+		},
+	}, nil)
+	db.EXPECT().AddInputToChannel(uint(3), &database.Input{
+		ChannelID: 3,
+		InputType: "webhook",
+		InputID:   4,
+		Enabled:   true,
+	}).Return(errors.New("test"))
+	webhookDB.EXPECT().DeleteWebhookInput(uint(4)).Return(nil)
+
+	_, _, err := service.NewInput(3)
+	require.Error(t, err)
+}
+
+func TestService_OutputExists(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+
+	webhookDB.EXPECT().GetWebhookOutputByID(uint(1)).Return(&webhookdb.WebhookOutput{}, nil)
+
+	exists, err := service.OutputExists("webhook", 1)
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestService_OutputExistsWithNotFound(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+
+	webhookDB.EXPECT().GetWebhookOutputByID(uint(1)).Return(nil, webhookdb.ErrNotFound)
+
+	exists, err := service.OutputExists("webhook", 1)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestService_OutputExistsWithWrongType(t *testing.T) {
+	service, _, _ := testService(t)
+
+	exists, err := service.OutputExists("notwebhook", 1)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestService_OutputExistsWithError(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+
+	webhookDB.EXPECT().GetWebhookOutputByID(uint(1)).Return(nil, errors.New("test"))
+
+	_, err := service.OutputExists("webhook", 1)
+	require.Error(t, err)
+}
+
+func TestService_OutputRemoved(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+
+	webhookDB.EXPECT().DeleteWebhookOutput(uint(1)).Return(nil)
+
+	err := service.OutputRemoved("webhook", 1)
+	require.NoError(t, err)
+}
+
+func TestService_OutputRemovedWithNotFound(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+
+	webhookDB.EXPECT().DeleteWebhookOutput(uint(1)).Return(webhookdb.ErrNotFound)
+
+	err := service.OutputRemoved("webhook", 1)
+	require.NoError(t, err)
+}
+
+func TestService_OutputRemovedWithWrongType(t *testing.T) {
+	service, _, _ := testService(t)
+
+	err := service.OutputRemoved("notwebhook", 1)
+	require.NoError(t, err)
+}
+
+func TestService_OutputRemovedWithError(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+
+	webhookDB.EXPECT().DeleteWebhookOutput(uint(1)).Return(errors.New("test"))
+
+	err := service.OutputRemoved("webhook", 1)
+	require.Error(t, err)
+}
+
+func TestService_NewOutput(t *testing.T) {
+	service, webhookDB, db := testService(t)
+
+	webhookDB.EXPECT().NewWebhookOutput(&webhookdb.WebhookOutput{
+		ChannelID: 3,
+		URL:       "https://example.com/hook",
+		TimeZone:  "Europe/Berlin",
+	}).Return(testWebhookOutput("https://example.com/hook"), nil)
+	db.EXPECT().AddOutputToChannel(uint(3), &database.Output{
+		ChannelID:  3,
+		OutputType: "webhook",
+		OutputID:   2,
+		Enabled:    true,
+	}).Return(nil)
+
+	output, err := service.NewOutput(3, "https://example.com/hook", "Europe/Berlin")
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), output.ID)
+	assert.Equal(t, "secret", output.Secret)
+}
+
+func TestService_NewOutputWithInvalidURL(t *testing.T) {
+	service, _, _ := testService(t)
+
+	for _, url := range []string{"", "example.com", "ftp://example.com", "https://"} {
+		_, err := service.NewOutput(3, url, "UTC")
+		require.ErrorIs(t, err, webhook.ErrInvalidURL)
+	}
+}
+
+func TestService_NewOutputWithChannelError(t *testing.T) {
+	service, webhookDB, db := testService(t)
+
+	webhookDB.EXPECT().NewWebhookOutput(&webhookdb.WebhookOutput{
+		ChannelID: 3,
+		URL:       "https://example.com/hook",
+		TimeZone:  "UTC",
+	}).Return(testWebhookOutput("https://example.com/hook"), nil)
+	db.EXPECT().AddOutputToChannel(uint(3), &database.Output{
+		ChannelID:  3,
+		OutputType: "webhook",
+		OutputID:   2,
+		Enabled:    true,
+	}).Return(errors.New("test"))
+	webhookDB.EXPECT().DeleteWebhookOutput(uint(2)).Return(nil)
+
+	_, err := service.NewOutput(3, "https://example.com/hook", "UTC")
+	require.Error(t, err)
+}
+
+func TestService_GetOutputWithNotFound(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+
+	webhookDB.EXPECT().GetWebhookOutputByID(uint(2)).Return(nil, webhookdb.ErrNotFound)
+
+	_, err := service.GetOutput(2)
+	require.ErrorIs(t, err, webhook.ErrNotFound)
+}
+
+func TestService_SendReminder(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+	server, requests := testServer(t, http.StatusNoContent)
+
+	webhookDB.EXPECT().GetWebhookOutputByID(uint(2)).Return(testWebhookOutput(server.URL), nil)
+
+	err := service.SendReminder(&daemon.Event{
+		ID:             5,
+		EventTime:      time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC),
+		Message:        "test event",
+		RepeatInterval: new(time.Hour),
+		Importance:     daemon.ImportanceImportant,
+	}, &daemon.Output{
+		ID:         1,
+		OutputType: "webhook",
+		OutputID:   2,
+	})
+	require.NoError(t, err)
+
+	req := <-requests
+	assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+	assert.Equal(t, "reminder", req.header.Get(webhook.HeaderEvent))
+	assert.Equal(t, webhook.Sign(req.body, "secret"), req.header.Get(webhook.HeaderSignature))
+
+	assert.Equal(t, "reminder", req.payload.Type)
+	assert.Equal(t, uint(3), req.payload.Channel.ID)
+	assert.Empty(t, req.payload.Events)
+	require.NotNil(t, req.payload.Event)
+	assert.Equal(t, webhook.EventPayload{
+		ID:             5,
+		Message:        "test event",
+		OccurrenceTime: time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC),
+		Importance:     "important",
+		Recurring:      true,
+	}, *req.payload.Event)
+}
+
+func TestService_SendReminderWithPreNotification(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+	server, requests := testServer(t, http.StatusOK)
+
+	webhookDB.EXPECT().GetWebhookOutputByID(uint(2)).Return(testWebhookOutput(server.URL), nil)
+
+	err := service.SendReminder(&daemon.Event{
+		ID:        5,
+		EventTime: time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC),
+		Message:   "test event",
+		StartsIn:  new(time.Minute * 15),
+	}, &daemon.Output{OutputID: 2})
+	require.NoError(t, err)
+
+	req := <-requests
+	assert.Equal(t, "pre_notification", req.header.Get(webhook.HeaderEvent))
+	assert.Equal(t, "pre_notification", req.payload.Type)
+	require.NotNil(t, req.payload.Event)
+	assert.Equal(t, "default", req.payload.Event.Importance)
+	assert.False(t, req.payload.Event.Recurring)
+	assert.Equal(t, new(int64(900)), req.payload.Event.StartsInSeconds)
+}
+
+func TestService_SendReminderWithEscalation(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+	server, requests := testServer(t, http.StatusOK)
+
+	webhookDB.EXPECT().GetWebhookOutputByID(uint(2)).Return(testWebhookOutput(server.URL), nil)
+
+	err := service.SendReminder(&daemon.Event{
+		ID:         5,
+		EventTime:  time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC),
+		Message:    "test event",
+		Importance: daemon.ImportanceImportant,
+		Escalation: &daemon.Escalation{
+			Level:    2,
+			Resends:  4,
+			Mentions: []string{"@alice:example.com"},
+		},
+	}, &daemon.Output{OutputID: 2})
+	require.NoError(t, err)
+
+	req := <-requests
+	assert.Equal(t, "escalation", req.header.Get(webhook.HeaderEvent))
+	assert.Equal(t, "escalation", req.payload.Type)
+	require.NotNil(t, req.payload.Event)
+	assert.Equal(t, new(uint(2)), req.payload.Event.EscalationLevel)
+	assert.Equal(t, []string{"@alice:example.com"}, req.payload.Event.Mentions)
+}
+
+func TestService_SendReminderWithBadStatusCode(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+	server, requests := testServer(t, http.StatusInternalServerError)
+
+	webhookDB.EXPECT().GetWebhookOutputByID(uint(2)).Return(testWebhookOutput(server.URL), nil)
+
+	err := service.SendReminder(&daemon.Event{ID: 5}, &daemon.Output{OutputID: 2})
+	require.ErrorIs(t, err, webhook.ErrBadStatusCode)
+
+	<-requests
+}
+
+func TestService_SendReminderWithPrivateAddress(t *testing.T) {
+	webhookDB := webhookdb.NewMockService(t)
+	service := webhook.New(&webhook.Config{
+		Database:  database.NewMockService(t),
+		WebhookDB: webhookDB,
+	}, slog.New(slog.NewTextHandler(os.Stdout, nil)))
+
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	webhookDB.EXPECT().GetWebhookOutputByID(uint(2)).Return(testWebhookOutput(server.URL), nil)
+
+	err := service.SendReminder(&daemon.Event{ID: 5}, &daemon.Output{OutputID: 2})
+	require.ErrorIs(t, err, httpclient.ErrNonPublicAddress)
+
+	assert.False(t, called)
+}
+
+func TestService_SendReminderDoesNotFollowRedirects(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+	target, requests := testServer(t, http.StatusOK)
+
+	server := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer server.Close()
+
+	webhookDB.EXPECT().GetWebhookOutputByID(uint(2)).Return(testWebhookOutput(server.URL), nil)
+
+	err := service.SendReminder(&daemon.Event{ID: 5}, &daemon.Output{OutputID: 2})
+	require.ErrorIs(t, err, webhook.ErrBadStatusCode)
+
+	assert.Empty(t, requests)
+}
+
+func TestService_SendReminderWithUnknownOutput(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+
+	webhookDB.EXPECT().GetWebhookOutputByID(uint(2)).Return(nil, webhookdb.ErrNotFound)
+
+	err := service.SendReminder(&daemon.Event{ID: 5}, &daemon.Output{OutputID: 2})
+	require.ErrorIs(t, err, webhook.ErrNotFound)
+}
+
+func TestService_SendDailyReminder(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+	server, requests := testServer(t, http.StatusOK)
+
+	webhookDB.EXPECT().GetWebhookOutputByID(uint(2)).Return(testWebhookOutput(server.URL), nil)
+
+	err := service.SendDailyReminder(&daemon.DailyReminder{
+		Events: []daemon.Event{
+			{
+				ID:        5,
+				EventTime: time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC),
+				Message:   "event 1",
+			},
+			{
+				ID:         6,
+				EventTime:  time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+				Message:    "event 2",
+				Importance: daemon.ImportanceImportant,
+			},
+		},
+	}, &daemon.Output{OutputID: 2})
+	require.NoError(t, err)
+
+	req := <-requests
+	assert.Equal(t, "daily_reminder", req.header.Get(webhook.HeaderEvent))
+	assert.Equal(t, webhook.Sign(req.body, "secret"), req.header.Get(webhook.HeaderSignature))
+	assert.Equal(t, "daily_reminder", req.payload.Type)
+	assert.Nil(t, req.payload.Event)
+	assert.Equal(t, []webhook.EventPayload{
+		{
+			ID:             5,
+			Message:        "event 1",
+			OccurrenceTime: time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC),
+			Importance:     "default",
+		},
+		{
+			ID:             6,
+			Message:        "event 2",
+			OccurrenceTime: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			Importance:     "important",
+		},
+	}, req.payload.Events)
+	assert.Empty(t, req.payload.Overdue)
+}
+
+func TestService_SendDailyReminderWithLookaheadAndOverdue(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+	server, requests := testServer(t, http.StatusOK)
+
+	webhookDB.EXPECT().GetWebhookOutputByID(uint(2)).Return(testWebhookOutput(server.URL), nil)
+
+	err := service.SendDailyReminder(&daemon.DailyReminder{
+		Overdue: []daemon.Event{
+			{
+				ID:         5,
+				EventTime:  time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC),
+				Message:    "event 1",
+				RepeatRule: "FREQ=DAILY",
+			},
+		},
+		Lookahead: 7 * 24 * time.Hour,
+	}, &daemon.Output{OutputID: 2})
+	require.NoError(t, err)
+
+	req := <-requests
+	assert.Equal(t, "daily_reminder", req.payload.Type)
+	assert.Empty(t, req.payload.Events)
+	assert.Equal(t, int64(604800), req.payload.LookaheadSeconds)
+	assert.Equal(t, 1, req.payload.RecurringCount)
+	assert.Equal(t, []webhook.EventPayload{
+		{
+			ID:             5,
+			Message:        "event 1",
+			OccurrenceTime: time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC),
+			Importance:     "default",
+			Recurring:      true,
+		},
+	}, req.payload.Overdue)
+}
+
+func TestService_ToLocalTime(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+
+	webhookDB.EXPECT().GetWebhookOutputByID(uint(2)).Return(testWebhookOutput("https://example.com"), nil)
+
+	date := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	localTime := service.ToLocalTime(date, &daemon.Output{OutputID: 2})
+
+	assert.Equal(t, 12, localTime.Hour())
+	assert.True(t, date.Equal(localTime))
+}
+
+func TestService_ToLocalTimeWithoutTimeZone(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+
+	output := testWebhookOutput("https://example.com")
+	output.TimeZone = ""
+	webhookDB.EXPECT().GetWebhookOutputByID(uint(2)).Return(output, nil)
+
+	// Without an own time zone the date is kept as given, e.g. on the channels clock.
+	date := time.Date(2024, 5, 1, 10, 30, 0, 0, time.FixedZone("test", 3600))
+	assert.Equal(t, date, service.ToLocalTime(date, &daemon.Output{OutputID: 2}))
+}
+
+func TestService_ToLocalTimeWithError(t *testing.T) {
+	service, webhookDB, _ := testService(t)
+
+	webhookDB.EXPECT().GetWebhookOutputByID(uint(2)).Return(nil, errors.New("test"))
+
+	date := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	assert.Equal(t, date, service.ToLocalTime(date, &daemon.Output{OutputID: 2}))
+}
+
+func TestSign(t *testing.T) {
+	assert.Equal(t,
+		"sha256=6c437a9331f977304eca39b8158df7cb0cf2da61f9a18af07753de0d3bea9933",
+		webhook.Sign([]byte(`{"type":"reminder"}`), "secret"),
+	)
+}
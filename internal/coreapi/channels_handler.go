@@ -1,9 +1,12 @@
 package coreapi
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/api/apictx"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/api/response"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 	"github.com/gin-gonic/gin"
@@ -14,21 +17,142 @@ type Channel struct {
 	CreatedAt     string // RFC 3339 formated time
 	Description   string
 	DailyReminder *string // HH:MM of daily reminder or null if disabled
+	TimeZone      string  // IANA time zone of the daily reminder, defaults to UTC
+}
+
+// ChannelDetails holds a channel with its in- and outputs.
+type ChannelDetails struct {
+	Channel
+
+	DefaultReminderTime *string     // HH:MM of the default reminder time or null if not set
+	Digest              Digest      // Days and content of the daily reminder
+	Escalation          *Escalation // Escalation of unacknowledged important events or null if disabled
+	Inputs              []Input
+	Outputs             []Output
+}
+
+// Escalation defines how unacknowledged important events of a channel are escalated.
+type Escalation struct {
+	After     uint     `binding:"required,min=1"` // Escalate with every n-th resend of an unacknowledged event
+	OutputID  *uint    // Output to escalate to or null
+	ChannelID *uint    // Channel to escalate to or null, defaults to the own channel if no output is set
+	Mentions  []string // Identifiers of the users to mention
+}
+
+// Digest defines on which days the daily reminder is sent and what it contains.
+type Digest struct {
+	Weekdays      []string `binding:"dive,oneof=monday tuesday wednesday thursday friday saturday sunday"` // Lowercase english weekday names, empty for every day
+	LookaheadDays uint     `binding:"max=90"`                                                              // Days of upcoming events included, 0 defaults to 1
+	Overdue       bool     // Include due events that are not acknowledged yet
+	SkipEmpty     bool     // Do not send the daily reminder if it lists no events
+}
+
+// ChannelRequest holds the data to create a channel.
+type ChannelRequest struct {
+	Description         string
+	DailyReminder       *string // HH:MM or null to disable
+	DefaultReminderTime *string // HH:MM or null
+	TimeZone            string  // IANA time zone like "Europe/Berlin", empty for UTC
+}
+
+// UpdateChannelRequest holds the data to update a channel, only provided fields are changed.
+type UpdateChannelRequest struct {
+	Description         *string
+	DailyReminder       *string // HH:MM, an empty string disables the daily reminder
+	DefaultReminderTime *string // HH:MM, an empty string unsets the default reminder time
+	TimeZone            *string // IANA time zone like "Europe/Berlin", an empty string resets to UTC
+	Digest              *Digest
+	Escalation          *Escalation
+	DisableEscalation   bool
 }
 
 func channelToResponse(channelIn *database.Channel) Channel {
-	channelOut := Channel{
-		ID:          channelIn.ID,
-		CreatedAt:   channelIn.CreatedAt.Format(time.RFC3339),
-		Description: channelIn.Description,
+	return Channel{
+		ID:            channelIn.ID,
+		CreatedAt:     channelIn.CreatedAt.Format(time.RFC3339),
+		Description:   channelIn.Description,
+		DailyReminder: minutesToString(channelIn.DailyReminder),
+		TimeZone:      channelIn.TimeZone,
 	}
+}
 
-	if channelIn.DailyReminder != nil {
-		dailyReminder := fmt.Sprintf("%02d:%02d", int(*channelIn.DailyReminder/60), *channelIn.DailyReminder%60)
-		channelOut.DailyReminder = &dailyReminder
+func channelToDetailsResponse(channelIn *database.Channel) ChannelDetails {
+	return ChannelDetails{
+		Channel:             channelToResponse(channelIn),
+		DefaultReminderTime: minutesToString(channelIn.DefaultReminderTime),
+		Digest:              digestToResponse(&channelIn.Digest),
+		Escalation:          escalationToResponse(channelIn),
+		Inputs:              inputsToResponse(channelIn.Inputs),
+		Outputs:             outputsToResponse(channelIn.Outputs),
 	}
+}
 
-	return channelOut
+func digestToResponse(digestIn *database.DigestConfig) Digest {
+	weekdays := []string{}
+	if digestIn.Weekdays != database.WeekdaysAll {
+		for _, weekday := range digestIn.WeekdayList() {
+			weekdays = append(weekdays, strings.ToLower(weekday.String()))
+		}
+	}
+
+	return Digest{
+		Weekdays:      weekdays,
+		LookaheadDays: uint(digestIn.LookaheadOrDefault() / (24 * time.Hour)),
+		Overdue:       digestIn.Overdue,
+		SkipEmpty:     digestIn.SkipEmpty,
+	}
+}
+
+// applyDigest sets the digest of the channel, a nil digest leaves it unchanged.
+func applyDigest(channel *database.Channel, digest *Digest) {
+	if digest == nil {
+		return
+	}
+
+	weekdays := []time.Weekday{}
+	for _, name := range digest.Weekdays {
+		for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+			if strings.ToLower(weekday.String()) == name {
+				weekdays = append(weekdays, weekday)
+			}
+		}
+	}
+
+	channel.Digest = database.DigestConfig{
+		Weekdays:  database.WeekdayMask(weekdays...),
+		Lookahead: time.Duration(digest.LookaheadDays) * 24 * time.Hour,
+		Overdue:   digest.Overdue,
+		SkipEmpty: digest.SkipEmpty,
+	}
+}
+
+func escalationToResponse(channelIn *database.Channel) *Escalation {
+	if channelIn.EscalateAfter == nil {
+		return nil
+	}
+
+	return &Escalation{
+		After:     *channelIn.EscalateAfter,
+		OutputID:  channelIn.EscalationOutputID,
+		ChannelID: channelIn.EscalationChannelID,
+		Mentions:  channelIn.EscalationUsers(),
+	}
+}
+
+// applyEscalation sets the escalation policy of the channel, a nil escalation leaves it unchanged.
+func applyEscalation(channel *database.Channel, escalation *Escalation, disable bool) {
+	switch {
+	case disable:
+		channel.EscalateAfter = nil
+		channel.EscalationOutputID = nil
+		channel.EscalationChannelID = nil
+		channel.EscalationMentions = ""
+	case escalation != nil:
+		channel.EscalateAfter = &escalation.After
+		channel.EscalationOutputID = escalation.OutputID
+		channel.EscalationChannelID = escalation.ChannelID
+		channel.EscalationMentions = strings.Join(escalation.Mentions, ",")
+	}
 }
 
 func channelsToResponse(channelsIn []database.Channel) []Channel {
@@ -41,6 +165,58 @@ func channelsToResponse(channelsIn []database.Channel) []Channel {
 	return channelsOut
 }
 
+// minutesToString formats minutes from midnight as HH:MM.
+func minutesToString(minutes *uint) *string {
+	if minutes == nil {
+		return nil
+	}
+
+	return new(fmt.Sprintf("%02d:%02d", int(*minutes/60), *minutes%60))
+}
+
+// setMinutes sets the target to the minutes from midnight of the HH:MM value, an empty value unsets the target.
+func setMinutes(target **uint, value *string) error {
+	switch {
+	case value == nil:
+		return nil
+	case *value == "":
+		*target = nil
+		return nil
+	}
+
+	t, err := time.Parse("15:04", *value)
+	if err != nil {
+		return err
+	}
+
+	*target = new(uint(t.Hour()*60 + t.Minute()))
+
+	return nil
+}
+
+func applyChannelTimes(channel *database.Channel, dailyReminder, defaultReminderTime, timeZone *string) error {
+	err := setMinutes(&channel.DailyReminder, dailyReminder)
+	if err != nil {
+		return err
+	}
+
+	err = setMinutes(&channel.DefaultReminderTime, defaultReminderTime)
+	if err != nil {
+		return err
+	}
+
+	if timeZone != nil {
+		_, err = time.LoadLocation(*timeZone)
+		if err != nil {
+			return err
+		}
+
+		channel.TimeZone = *timeZone
+	}
+
+	return nil
+}
+
 // listChannelsHandler godoc
 // @Summary List all channels
 // @Description List all channels
@@ -62,3 +238,177 @@ func (api *coreAPI) listChannelsHandler(ctx *gin.Context) {
 
 	response.WithData(ctx, channelsToResponse(channels))
 }
+
+// getChannelHandler godoc
+// @Summary Get a channel
+// @Description Get a channel with its in- and outputs.
+// @Tags Channels
+// @Security APIKeyAuthentication
+// @Produce json
+// @Param id path int true "Channel ID"
+// @Success 200 {object} response.DataResponse{data=ChannelDetails}
+// @Failure 400 {object} response.MessageErrorResponse
+// @Failure 401 {object} response.MessageErrorResponse
+// @Failure 404 {object} response.MessageErrorResponse
+// @Failure 500 ""
+// @Router /core/channels/{id} [get]
+func (api *coreAPI) getChannelHandler(ctx *gin.Context) {
+	channel, ok := api.channelFromRequest(ctx)
+	if !ok {
+		return
+	}
+
+	response.WithData(ctx, channelToDetailsResponse(channel))
+}
+
+// createChannelHandler godoc
+// @Summary Create a channel
+// @Description Create a channel without any in- or outputs.
+// @Tags Channels
+// @Security APIKeyAuthentication
+// @Accept json
+// @Produce json
+// @Param payload body ChannelRequest true "Channel"
+// @Success 200 {object} response.DataResponse{data=ChannelDetails}
+// @Failure 400 {object} response.MessageErrorResponse
+// @Failure 401 {object} response.MessageErrorResponse
+// @Failure 500 ""
+// @Router /core/channels [post]
+func (api *coreAPI) createChannelHandler(ctx *gin.Context) {
+	var request ChannelRequest
+
+	channel := &database.Channel{}
+
+	err := ctx.ShouldBindJSON(&request)
+	if err == nil {
+		channel.Description = request.Description
+		err = applyChannelTimes(channel, request.DailyReminder, request.DefaultReminderTime, &request.TimeZone)
+	}
+
+	if err != nil {
+		response.AbortWithBadRequestError(ctx, "Invalid channel, times need to be formated as HH:MM and time zones as IANA names")
+		return
+	}
+
+	channel, err = api.config.Database.NewChannel(channel)
+	if err != nil {
+		api.logger.Error("failed to create channel", "error", err)
+		response.AbortWithInternalServerError(ctx)
+
+		return
+	}
+
+	response.WithData(ctx, channelToDetailsResponse(channel))
+}
+
+// updateChannelHandler godoc
+// @Summary Update a channel
+// @Description Update a channel, only provided fields are changed. Escalations of unacknowledged important events
+// @Description are sent with every n-th resend to the given output, the outputs of the given channel or the own channel.
+// @Description The digest replaces the days and content of the daily reminder, e.g. a weekly summary on monday.
+// @Tags Channels
+// @Security APIKeyAuthentication
+// @Accept json
+// @Produce json
+// @Param id path int true "Channel ID"
+// @Param payload body UpdateChannelRequest true "Changes"
+// @Success 200 {object} response.DataResponse{data=ChannelDetails}
+// @Failure 400 {object} response.MessageErrorResponse
+// @Failure 401 {object} response.MessageErrorResponse
+// @Failure 404 {object} response.MessageErrorResponse
+// @Failure 500 ""
+// @Router /core/channels/{id} [patch]
+func (api *coreAPI) updateChannelHandler(ctx *gin.Context) {
+	channel, ok := api.channelFromRequest(ctx)
+	if !ok {
+		return
+	}
+
+	var request UpdateChannelRequest
+
+	err := ctx.ShouldBindJSON(&request)
+	if err == nil {
+		err = applyChannelTimes(channel, request.DailyReminder, request.DefaultReminderTime, request.TimeZone)
+	}
+
+	if err != nil {
+		response.AbortWithBadRequestError(ctx, "Invalid changes, times need to be formated as HH:MM, time zones as IANA names, escalations need After to be at least 1 and digests lowercase weekdays and at most 90 days")
+		return
+	}
+
+	if request.Description != nil {
+		channel.Description = *request.Description
+	}
+
+	applyDigest(channel, request.Digest)
+	applyEscalation(channel, request.Escalation, request.DisableEscalation)
+
+	updatedChannel, err := api.config.Database.UpdateChannel(channel)
+	if err != nil {
+		api.logger.Error("failed to update channel", "error", err, "channel.id", channel.ID)
+		response.AbortWithInternalServerError(ctx)
+
+		return
+	}
+
+	response.WithData(ctx, channelToDetailsResponse(updatedChannel))
+}
+
+// deleteChannelHandler godoc
+// @Summary Delete a channel
+// @Description Delete a channel with all its events, in- and outputs.
+// @Tags Channels
+// @Security APIKeyAuthentication
+// @Produce json
+// @Param id path int true "Channel ID"
+// @Success 200 {object} response.MessageSuccessResponse
+// @Failure 400 {object} response.MessageErrorResponse
+// @Failure 401 {object} response.MessageErrorResponse
+// @Failure 404 {object} response.MessageErrorResponse
+// @Failure 500 ""
+// @Router /core/channels/{id} [delete]
+func (api *coreAPI) deleteChannelHandler(ctx *gin.Context) {
+	channelID, ok := apictx.GetUintFromContext(ctx, "id")
+	if !ok || channelID < 1 {
+		response.AbortWithNotFoundError(ctx)
+		return
+	}
+
+	err := api.config.Database.DeleteChannel(channelID)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			response.AbortWithNotFoundError(ctx)
+			return
+		}
+
+		api.logger.Error("failed to delete channel", "error", err, "channel.id", channelID)
+		response.AbortWithInternalServerError(ctx)
+
+		return
+	}
+
+	response.WithMessage(ctx, "Deleted channel")
+}
+
+func (api *coreAPI) channelFromRequest(ctx *gin.Context) (*database.Channel, bool) {
+	channelID, ok := apictx.GetUintFromContext(ctx, "id")
+	if !ok || channelID < 1 {
+		response.AbortWithNotFoundError(ctx)
+		return nil, false
+	}
+
+	channel, err := api.config.Database.GetChannelByID(channelID)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			response.AbortWithNotFoundError(ctx)
+			return nil, false
+		}
+
+		api.logger.Error("failed to get channel", "error", err, "channel.id", channelID)
+		response.AbortWithInternalServerError(ctx)
+
+		return nil, false
+	}
+
+	return channel, true
+}
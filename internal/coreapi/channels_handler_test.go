@@ -5,9 +5,11 @@ import (
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -36,7 +38,7 @@ func TestCoreAPI_ListChannelsHandler(t *testing.T) {
 
 	body, err := io.ReadAll(resp.Body)
 	require.NoError(t, err)
-	assert.JSONEq(t, `{"status":"success","data":[{"ID":1,"CreatedAt":"2006-01-02T15:04:05+07:00","Description":"chan desc","DailyReminder":"02:10"}]}`, string(body))
+	assert.JSONEq(t, `{"status":"success","data":[{"ID":1,"CreatedAt":"2006-01-02T15:04:05+07:00","Description":"chan desc","DailyReminder":"02:10","TimeZone":""}]}`, string(body))
 }
 
 func TestCoreAPI_ListChannelsHandlerWithError(t *testing.T) {
@@ -85,3 +87,330 @@ func TestCoreAPI_ListChannelsHandlerWithoutAuth(t *testing.T) {
 	require.NoError(t, err)
 	assert.JSONEq(t, `{"message":"Unauthenticated","status":"error"}`, string(body))
 }
+
+func TestCoreAPI_GetChannelHandler(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	channel := testDatabaseChannel()
+	channel.DefaultReminderTime = new(uint(600))
+	channel.Inputs = []database.Input{{InputType: "matrix", InputID: 3, Enabled: true}}
+	channel.Inputs[0].ID = 7
+	channel.Outputs = []database.Output{{OutputType: "ical", OutputID: 4}}
+	channel.Outputs[0].ID = 8
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(&channel, nil)
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodGet, server.URL+"/core/channels/1", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"status":"success","data":{"ID":1,"CreatedAt":"2006-01-02T15:04:05+07:00","Description":"chan desc",`+
+		`"DailyReminder":"02:10","TimeZone":"","DefaultReminderTime":"10:00","Digest":{"Weekdays":[],"LookaheadDays":1,"Overdue":false,"SkipEmpty":false},"Escalation":null,`+
+		`"Inputs":[{"ID":7,"InputType":"matrix","InputID":3,"Enabled":true}],`+
+		`"Outputs":[{"ID":8,"OutputType":"ical","OutputID":4,"Enabled":false}]}}`, body)
+}
+
+func TestCoreAPI_GetChannelHandlerWithUnknownChannel(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(nil, database.ErrNotFound)
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodGet, server.URL+"/core/channels/1", "")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.JSONEq(t, `{"message":"Not Found","status":"error"}`, body)
+}
+
+func TestCoreAPI_GetChannelHandlerWithError(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(nil, errors.New("test"))
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodGet, server.URL+"/core/channels/1", "")
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
+
+func TestCoreAPI_CreateChannelHandler(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().NewChannel(&database.Channel{
+		Description:         "new channel",
+		DailyReminder:       new(uint(510)),
+		DefaultReminderTime: new(uint(1439)),
+		TimeZone:            "Europe/Berlin",
+	}).RunAndReturn(func(channel *database.Channel) (*database.Channel, error) {
+		channel.ID = 2
+		return channel, nil
+	})
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels",
+		`{"Description":"new channel","DailyReminder":"08:30","DefaultReminderTime":"23:59","TimeZone":"Europe/Berlin"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"status":"success","data":{"ID":2,"CreatedAt":"0001-01-01T00:00:00Z","Description":"new channel",`+
+		`"DailyReminder":"08:30","TimeZone":"Europe/Berlin","DefaultReminderTime":"23:59","Digest":{"Weekdays":[],"LookaheadDays":1,"Overdue":false,"SkipEmpty":false},"Escalation":null,"Inputs":[],"Outputs":[]}}`, body)
+}
+
+func TestCoreAPI_CreateChannelHandlerWithInvalidTime(t *testing.T) {
+	// Setup
+	server, _ := testCoreAPI(t)
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels", `{"DailyReminder":"8am"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestCoreAPI_CreateChannelHandlerWithInvalidTimeZone(t *testing.T) {
+	// Setup
+	server, _ := testCoreAPI(t)
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels", `{"TimeZone":"Europe/Atlantis"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestCoreAPI_CreateChannelHandlerWithError(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().NewChannel(mock.Anything).Return(nil, errors.New("test"))
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels", `{"Description":"new channel"}`)
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
+
+func TestCoreAPI_UpdateChannelHandler(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	channel := testDatabaseChannel()
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(&channel, nil)
+	db.EXPECT().UpdateChannel(mock.Anything).RunAndReturn(func(channel *database.Channel) (*database.Channel, error) {
+		assert.Equal(t, "chan desc", channel.Description)
+		assert.Nil(t, channel.DailyReminder)
+		assert.Equal(t, new(uint(615)), channel.DefaultReminderTime)
+
+		return channel, nil
+	})
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodPatch, server.URL+"/core/channels/1",
+		`{"DailyReminder":"","DefaultReminderTime":"10:15"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"DailyReminder":null`)
+	assert.Contains(t, body, `"DefaultReminderTime":"10:15"`)
+}
+
+func TestCoreAPI_UpdateChannelHandlerWithEscalation(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	channel := testDatabaseChannel()
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(&channel, nil)
+	db.EXPECT().UpdateChannel(mock.Anything).RunAndReturn(func(channel *database.Channel) (*database.Channel, error) {
+		assert.Equal(t, new(uint(3)), channel.EscalateAfter)
+		assert.Equal(t, new(uint(8)), channel.EscalationOutputID)
+		assert.Nil(t, channel.EscalationChannelID)
+		assert.Equal(t, "@alice:example.com,@bob:example.com", channel.EscalationMentions)
+
+		return channel, nil
+	})
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodPatch, server.URL+"/core/channels/1",
+		`{"Escalation":{"After":3,"OutputID":8,"Mentions":["@alice:example.com","@bob:example.com"]}}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"Escalation":{"After":3,"OutputID":8,"ChannelID":null,"Mentions":["@alice:example.com","@bob:example.com"]}`)
+}
+
+func TestCoreAPI_UpdateChannelHandlerWithDisableEscalation(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	channel := testDatabaseChannel()
+	channel.EscalateAfter = new(uint(3))
+	channel.EscalationChannelID = new(uint(2))
+	channel.EscalationMentions = "@alice:example.com"
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(&channel, nil)
+	db.EXPECT().UpdateChannel(mock.Anything).RunAndReturn(func(channel *database.Channel) (*database.Channel, error) {
+		assert.Nil(t, channel.EscalateAfter)
+		assert.Nil(t, channel.EscalationChannelID)
+		assert.Empty(t, channel.EscalationMentions)
+
+		return channel, nil
+	})
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodPatch, server.URL+"/core/channels/1", `{"DisableEscalation":true}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"Escalation":null`)
+}
+
+func TestCoreAPI_UpdateChannelHandlerWithInvalidEscalation(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	channel := testDatabaseChannel()
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(&channel, nil)
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPatch, server.URL+"/core/channels/1", `{"Escalation":{"After":0}}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestCoreAPI_UpdateChannelHandlerWithDigest(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	channel := testDatabaseChannel()
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(&channel, nil)
+	db.EXPECT().UpdateChannel(mock.Anything).RunAndReturn(func(channel *database.Channel) (*database.Channel, error) {
+		assert.Equal(t, database.DigestConfig{
+			Weekdays:  database.WeekdayMask(time.Monday, time.Friday),
+			Lookahead: 7 * 24 * time.Hour,
+			Overdue:   true,
+			SkipEmpty: true,
+		}, channel.Digest)
+
+		return channel, nil
+	})
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodPatch, server.URL+"/core/channels/1",
+		`{"Digest":{"Weekdays":["friday","monday"],"LookaheadDays":7,"Overdue":true,"SkipEmpty":true}}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"Digest":{"Weekdays":["monday","friday"],"LookaheadDays":7,"Overdue":true,"SkipEmpty":true}`)
+}
+
+func TestCoreAPI_UpdateChannelHandlerWithInvalidDigest(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	channel := testDatabaseChannel()
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(&channel, nil)
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPatch, server.URL+"/core/channels/1", `{"Digest":{"Weekdays":["Montag"]}}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestCoreAPI_UpdateChannelHandlerWithInvalidTime(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	channel := testDatabaseChannel()
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(&channel, nil)
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPatch, server.URL+"/core/channels/1", `{"DailyReminder":"25:00"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestCoreAPI_UpdateChannelHandlerWithTimeZone(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	channel := testDatabaseChannel()
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(&channel, nil)
+	db.EXPECT().UpdateChannel(mock.Anything).RunAndReturn(func(channel *database.Channel) (*database.Channel, error) {
+		assert.Equal(t, "Asia/Tokyo", channel.TimeZone)
+		return channel, nil
+	})
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodPatch, server.URL+"/core/channels/1", `{"TimeZone":"Asia/Tokyo"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"TimeZone":"Asia/Tokyo"`)
+}
+
+func TestCoreAPI_UpdateChannelHandlerWithInvalidTimeZone(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	channel := testDatabaseChannel()
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(&channel, nil)
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPatch, server.URL+"/core/channels/1", `{"TimeZone":"Europe/Atlantis"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestCoreAPI_UpdateChannelHandlerWithError(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	channel := testDatabaseChannel()
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(&channel, nil)
+	db.EXPECT().UpdateChannel(mock.Anything).Return(nil, errors.New("test"))
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPatch, server.URL+"/core/channels/1", `{"Description":"new"}`)
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
+
+func TestCoreAPI_DeleteChannelHandler(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().DeleteChannel(uint(1)).Return(nil)
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodDelete, server.URL+"/core/channels/1", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"message":"Deleted channel","status":"success"}`, body)
+}
+
+func TestCoreAPI_DeleteChannelHandlerWithUnknownChannel(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().DeleteChannel(uint(1)).Return(database.ErrNotFound)
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodDelete, server.URL+"/core/channels/1", "")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestCoreAPI_DeleteChannelHandlerWithError(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().DeleteChannel(uint(1)).Return(errors.New("test"))
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodDelete, server.URL+"/core/channels/1", "")
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
@@ -3,6 +3,7 @@ package coreapi
 import (
 	"log/slog"
 
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/api/apictx"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 	"github.com/gin-gonic/gin"
 )
@@ -31,7 +32,27 @@ func New(config *Config, logger *slog.Logger) CoreAPI {
 func (api *coreAPI) RegisterRoutes(r *gin.Engine) error {
 	router := r.Group("/core")
 
-	router.GET("/channels", api.config.DefaultAuthProvider, api.listChannelsHandler)
+	channels := router.Group("/channels")
+	channels.Use(api.config.DefaultAuthProvider)
+	channels.GET("", api.listChannelsHandler)
+	channels.POST("", api.createChannelHandler)
+
+	channel := channels.Group("/:id")
+	channel.Use(apictx.RequireIDInURI())
+	channel.GET("", api.getChannelHandler)
+	channel.PATCH("", api.updateChannelHandler)
+	channel.DELETE("", api.deleteChannelHandler)
+	channel.POST("/inputs", api.addInputHandler)
+	channel.DELETE("/inputs/:inputID", apictx.RequireUintInURI("inputID"), api.removeInputHandler)
+	channel.POST("/outputs", api.addOutputHandler)
+	channel.DELETE("/outputs/:outputID", apictx.RequireUintInURI("outputID"), api.removeOutputHandler)
+
+	events := channel.Group("/events")
+	events.GET("", api.listEventsHandler)
+	events.POST("", api.createEventHandler)
+	events.GET("/:eventID", apictx.RequireUintInURI("eventID"), api.getEventHandler)
+	events.PATCH("/:eventID", apictx.RequireUintInURI("eventID"), api.updateEventHandler)
+	events.DELETE("/:eventID", apictx.RequireUintInURI("eventID"), api.deleteEventHandler)
 
 	return nil
 }
@@ -0,0 +1,439 @@
+package coreapi
+
+import (
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/api/apictx"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/api/response"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	importanceDefault   = "default"
+	importanceImportant = "important"
+	importanceUrgent    = "urgent"
+
+	defaultEventDuration = time.Minute * 5
+)
+
+type Event struct {
+	ID                    uint
+	CreatedAt             string // RFC 3339 formated time
+	Time                  string // RFC 3339 formated time
+	DurationMinutes       uint
+	Message               string
+	Active                bool
+	Importance            string  // "default", "important" or "urgent"
+	RepeatRule            *string // RRULE including the DTSTART or null if not repeated by a rule
+	RepeatIntervalMinutes *uint   // Interval between repetitions or null if not repeated by an interval
+	RepeatUntil           *string // RFC 3339 formated time or null
+	InputID               *uint   // ID of the input the event was created by or null
+	ExternalReference     string
+	Escalations           []EventEscalation
+}
+
+// EventEscalation records an escalation of an unacknowledged event.
+type EventEscalation struct {
+	CreatedAt string // RFC 3339 formated time
+	Level     uint
+	Resends   uint // Number of unacknowledged resends before the escalation
+	Outputs   uint // Number of outputs notified
+}
+
+// EventRequest holds the data to create an event.
+type EventRequest struct {
+	Time                  time.Time `binding:"required"`
+	DurationMinutes       uint      // Defaults to 5 minutes
+	Message               string    `binding:"required"`
+	Active                *bool     // Defaults to true
+	Importance            string    `binding:"omitempty,oneof=default important urgent"`
+	RepeatRule            string    // RRULE like "FREQ=WEEKLY;BYDAY=MO,FR", evaluated in the channels time zone starting at the events time
+	RepeatIntervalMinutes uint
+	RepeatUntil           *time.Time
+}
+
+// UpdateEventRequest holds the data to update an event, only provided fields are changed.
+type UpdateEventRequest struct {
+	Time                  *time.Time
+	DurationMinutes       *uint
+	Message               *string `binding:"omitempty,min=1"`
+	Active                *bool
+	Importance            *string `binding:"omitempty,oneof=default important urgent"`
+	RepeatRule            *string // RRULE like "FREQ=WEEKLY;BYDAY=MO,FR", an empty string removes the rule
+	RepeatIntervalMinutes *uint   // 0 removes the interval
+	RepeatUntil           *time.Time
+}
+
+type listEventsQuery struct {
+	IDs             []uint     `form:"ids"`
+	InputID         *uint      `form:"input_id"`
+	Reference       *string    `form:"reference"`
+	IncludeInactive bool       `form:"include_inactive"`
+	Before          *time.Time `form:"before"`
+	After           *time.Time `form:"after"`
+}
+
+func eventToResponse(eventIn *database.Event) Event {
+	eventOut := Event{
+		ID:                eventIn.ID,
+		CreatedAt:         eventIn.CreatedAt.Format(time.RFC3339),
+		Time:              eventIn.Time.Format(time.RFC3339),
+		DurationMinutes:   uint(eventIn.Duration / time.Minute),
+		Message:           eventIn.Message,
+		Active:            eventIn.Active,
+		Importance:        importanceDefault,
+		InputID:           eventIn.InputID,
+		ExternalReference: eventIn.ExternalReference,
+		Escalations:       make([]EventEscalation, len(eventIn.Escalations)),
+	}
+
+	for i, escalation := range eventIn.Escalations {
+		eventOut.Escalations[i] = EventEscalation{
+			CreatedAt: escalation.CreatedAt.Format(time.RFC3339),
+			Level:     escalation.Level,
+			Resends:   escalation.Resends,
+			Outputs:   escalation.Outputs,
+		}
+	}
+
+	switch eventIn.Importance {
+	case database.ImportanceImportant:
+		eventOut.Importance = importanceImportant
+	case database.ImportanceUrgent:
+		eventOut.Importance = importanceUrgent
+	}
+
+	if eventIn.RepeatRule != "" {
+		eventOut.RepeatRule = &eventIn.RepeatRule
+	}
+
+	if eventIn.RepeatInterval != nil {
+		eventOut.RepeatIntervalMinutes = new(uint(*eventIn.RepeatInterval / time.Minute))
+	}
+
+	if eventIn.RepeatUntil != nil {
+		eventOut.RepeatUntil = new(eventIn.RepeatUntil.Format(time.RFC3339))
+	}
+
+	return eventOut
+}
+
+func eventsToResponse(eventsIn []database.Event) []Event {
+	eventsOut := make([]Event, len(eventsIn))
+
+	for i := range eventsIn {
+		eventsOut[i] = eventToResponse(&eventsIn[i])
+	}
+
+	return eventsOut
+}
+
+func importanceFromRequest(importance string) database.Importance {
+	switch importance {
+	case importanceImportant:
+		return database.ImportanceImportant
+	case importanceUrgent:
+		return database.ImportanceUrgent
+	}
+
+	return database.ImportanceDefault
+}
+
+// listEventsHandler godoc
+// @Summary List events of a channel
+// @Description List the events of a channel, by default only active ones.
+// @Tags Events
+// @Security APIKeyAuthentication
+// @Produce json
+// @Param id path int true "Channel ID"
+// @Param ids query []int false "Only events with these IDs"
+// @Param input_id query int false "Only events created by this input"
+// @Param reference query string false "Only events with this external reference"
+// @Param include_inactive query bool false "Include inactive events"
+// @Param before query string false "Only events before this RFC 3339 formated time"
+// @Param after query string false "Only events after this RFC 3339 formated time"
+// @Success 200 {object} response.DataResponse{data=[]Event}
+// @Failure 400 {object} response.MessageErrorResponse
+// @Failure 401 {object} response.MessageErrorResponse
+// @Failure 404 {object} response.MessageErrorResponse
+// @Failure 500 ""
+// @Router /core/channels/{id}/events [get]
+func (api *coreAPI) listEventsHandler(ctx *gin.Context) {
+	channel, ok := api.channelFromRequest(ctx)
+	if !ok {
+		return
+	}
+
+	var query listEventsQuery
+
+	err := ctx.ShouldBindQuery(&query)
+	if err != nil {
+		response.AbortWithBadRequestError(ctx, "Invalid query parameters")
+		return
+	}
+
+	events, err := api.config.Database.ListEvents(&database.ListEventsOpts{
+		IDs:               query.IDs,
+		InputID:           query.InputID,
+		ChannelID:         &channel.ID,
+		ExternalReference: query.Reference,
+		IncludeInactive:   query.IncludeInactive,
+		EventsBefore:      query.Before,
+		EventsAfter:       query.After,
+	})
+	if err != nil {
+		api.logger.Error("failed to list events", "error", err, "channel.id", channel.ID)
+		response.AbortWithInternalServerError(ctx)
+
+		return
+	}
+
+	response.WithData(ctx, eventsToResponse(events))
+}
+
+// getEventHandler godoc
+// @Summary Get an event
+// @Description Get an event of a channel.
+// @Tags Events
+// @Security APIKeyAuthentication
+// @Produce json
+// @Param id path int true "Channel ID"
+// @Param eventID path int true "Event ID"
+// @Success 200 {object} response.DataResponse{data=Event}
+// @Failure 400 {object} response.MessageErrorResponse
+// @Failure 401 {object} response.MessageErrorResponse
+// @Failure 404 {object} response.MessageErrorResponse
+// @Failure 500 ""
+// @Router /core/channels/{id}/events/{eventID} [get]
+func (api *coreAPI) getEventHandler(ctx *gin.Context) {
+	event, ok := api.eventFromRequest(ctx)
+	if !ok {
+		return
+	}
+
+	response.WithData(ctx, eventToResponse(event))
+}
+
+// createEventHandler godoc
+// @Summary Create an event
+// @Description Create an event in a channel.
+// @Tags Events
+// @Security APIKeyAuthentication
+// @Accept json
+// @Produce json
+// @Param id path int true "Channel ID"
+// @Param payload body EventRequest true "Event"
+// @Success 200 {object} response.DataResponse{data=Event}
+// @Failure 400 {object} response.MessageErrorResponse
+// @Failure 401 {object} response.MessageErrorResponse
+// @Failure 404 {object} response.MessageErrorResponse
+// @Failure 500 ""
+// @Router /core/channels/{id}/events [post]
+func (api *coreAPI) createEventHandler(ctx *gin.Context) {
+	channel, ok := api.channelFromRequest(ctx)
+	if !ok {
+		return
+	}
+
+	var request EventRequest
+
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.AbortWithBadRequestError(ctx, "Invalid event, message and time are required")
+		return
+	}
+
+	event := &database.Event{
+		ChannelID:   channel.ID,
+		Time:        request.Time,
+		Duration:    defaultEventDuration,
+		Message:     request.Message,
+		Active:      true,
+		Importance:  importanceFromRequest(request.Importance),
+		RepeatUntil: request.RepeatUntil,
+	}
+
+	if request.DurationMinutes > 0 {
+		event.Duration = time.Minute * time.Duration(request.DurationMinutes)
+	}
+
+	if request.Active != nil {
+		event.Active = *request.Active
+	}
+
+	if request.RepeatIntervalMinutes > 0 {
+		event.RepeatInterval = new(time.Minute * time.Duration(request.RepeatIntervalMinutes))
+	}
+
+	if request.RepeatRule != "" {
+		event.RepeatRule, err = database.NewRepeatRule(request.RepeatRule, channel.LocalTime(event.Time))
+		if err != nil {
+			response.AbortWithBadRequestError(ctx, "Invalid repeat rule")
+			return
+		}
+	}
+
+	event, err = api.config.Database.NewEvent(event)
+	if err != nil {
+		api.logger.Error("failed to create event", "error", err, "channel.id", channel.ID)
+		response.AbortWithInternalServerError(ctx)
+
+		return
+	}
+
+	response.WithData(ctx, eventToResponse(event))
+}
+
+// updateEventHandler godoc
+// @Summary Update an event
+// @Description Update an event of a channel, only provided fields are changed. A repeat rule is moved along if only the time changes.
+// @Tags Events
+// @Security APIKeyAuthentication
+// @Accept json
+// @Produce json
+// @Param id path int true "Channel ID"
+// @Param eventID path int true "Event ID"
+// @Param payload body UpdateEventRequest true "Changes"
+// @Success 200 {object} response.DataResponse{data=Event}
+// @Failure 400 {object} response.MessageErrorResponse
+// @Failure 401 {object} response.MessageErrorResponse
+// @Failure 404 {object} response.MessageErrorResponse
+// @Failure 500 ""
+// @Router /core/channels/{id}/events/{eventID} [patch]
+func (api *coreAPI) updateEventHandler(ctx *gin.Context) {
+	event, ok := api.eventFromRequest(ctx)
+	if !ok {
+		return
+	}
+
+	var request UpdateEventRequest
+
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.AbortWithBadRequestError(ctx, "Invalid changes")
+		return
+	}
+
+	err = applyEventChanges(event, &request)
+	if err != nil {
+		response.AbortWithBadRequestError(ctx, "Invalid repeat rule")
+		return
+	}
+
+	updatedEvent, err := api.config.Database.UpdateEvent(event)
+	if err != nil {
+		api.logger.Error("failed to update event", "error", err, "event.id", event.ID)
+		response.AbortWithInternalServerError(ctx)
+
+		return
+	}
+
+	response.WithData(ctx, eventToResponse(updatedEvent))
+}
+
+func applyEventChanges(event *database.Event, request *UpdateEventRequest) error {
+	if request.Time != nil {
+		err := event.Reschedule(*request.Time)
+		if err != nil {
+			return err
+		}
+	}
+
+	if request.DurationMinutes != nil {
+		event.Duration = time.Minute * time.Duration(*request.DurationMinutes)
+	}
+
+	if request.Message != nil {
+		event.Message = *request.Message
+	}
+
+	if request.Active != nil {
+		event.Active = *request.Active
+	}
+
+	if request.Importance != nil {
+		event.Importance = importanceFromRequest(*request.Importance)
+	}
+
+	if request.RepeatIntervalMinutes != nil {
+		event.RepeatInterval = nil
+		if *request.RepeatIntervalMinutes > 0 {
+			event.RepeatInterval = new(time.Minute * time.Duration(*request.RepeatIntervalMinutes))
+		}
+	}
+
+	if request.RepeatUntil != nil {
+		event.RepeatUntil = request.RepeatUntil
+	}
+
+	if request.RepeatRule != nil {
+		return event.SetRepeatRule(*request.RepeatRule, event.Channel.LocalTime(event.Time).Location())
+	}
+
+	return nil
+}
+
+// deleteEventHandler godoc
+// @Summary Delete an event
+// @Description Delete an event of a channel.
+// @Tags Events
+// @Security APIKeyAuthentication
+// @Produce json
+// @Param id path int true "Channel ID"
+// @Param eventID path int true "Event ID"
+// @Success 200 {object} response.MessageSuccessResponse
+// @Failure 400 {object} response.MessageErrorResponse
+// @Failure 401 {object} response.MessageErrorResponse
+// @Failure 404 {object} response.MessageErrorResponse
+// @Failure 500 ""
+// @Router /core/channels/{id}/events/{eventID} [delete]
+func (api *coreAPI) deleteEventHandler(ctx *gin.Context) {
+	event, ok := api.eventFromRequest(ctx)
+	if !ok {
+		return
+	}
+
+	err := api.config.Database.DeleteEvent(event)
+	if err != nil {
+		api.logger.Error("failed to delete event", "error", err, "event.id", event.ID)
+		response.AbortWithInternalServerError(ctx)
+
+		return
+	}
+
+	response.WithMessage(ctx, "Deleted event")
+}
+
+func (api *coreAPI) eventFromRequest(ctx *gin.Context) (*database.Event, bool) {
+	channelID, ok := apictx.GetUintFromContext(ctx, "id")
+	if !ok || channelID < 1 {
+		response.AbortWithNotFoundError(ctx)
+		return nil, false
+	}
+
+	eventID, ok := apictx.GetUintFromContext(ctx, "eventID")
+	if !ok || eventID < 1 {
+		response.AbortWithNotFoundError(ctx)
+		return nil, false
+	}
+
+	events, err := api.config.Database.ListEvents(&database.ListEventsOpts{
+		IDs:             []uint{eventID},
+		ChannelID:       &channelID,
+		IncludeInactive: true,
+	})
+	if err != nil {
+		api.logger.Error("failed to get event", "error", err, "channel.id", channelID, "event.id", eventID)
+		response.AbortWithInternalServerError(ctx)
+
+		return nil, false
+	}
+
+	if len(events) == 0 {
+		response.AbortWithNotFoundError(ctx)
+		return nil, false
+	}
+
+	return &events[0], true
+}
@@ -0,0 +1,539 @@
+package coreapi_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func doCoreAPIRequest(t *testing.T, method, url, body string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), method, url, strings.NewReader(body))
+	require.NoError(t, err)
+
+	req.Header.Add("Authorization", "123")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(respBody)
+}
+
+func testDatabaseEvent() database.Event {
+	created, _ := time.Parse(time.RFC3339, "2006-01-02T15:04:05+07:00")
+	eventTime, _ := time.Parse(time.RFC3339, "2106-01-02T15:04:05Z")
+	inputID := uint(3)
+
+	e := database.Event{
+		Time:              eventTime,
+		Duration:          time.Minute * 10,
+		Message:           "test event",
+		Active:            true,
+		ChannelID:         1,
+		InputID:           &inputID,
+		ExternalReference: "ref",
+		Importance:        database.ImportanceImportant,
+	}
+
+	e.ID = 5
+	e.CreatedAt = created
+
+	return e
+}
+
+const testEventResponse = `{"ID":5,"CreatedAt":"2006-01-02T15:04:05+07:00","Time":"2106-01-02T15:04:05Z","DurationMinutes":10,` +
+	`"Message":"test event","Active":true,"Importance":"important","RepeatRule":null,"RepeatIntervalMinutes":null,` +
+	`"RepeatUntil":null,"InputID":3,"ExternalReference":"ref","Escalations":[]}`
+
+func TestCoreAPI_ListEventsHandler(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().ListEvents(&database.ListEventsOpts{
+		ChannelID: new(uint(1)),
+	}).Return([]database.Event{testDatabaseEvent()}, nil)
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodGet, server.URL+"/core/channels/1/events", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"status":"success","data":[`+testEventResponse+`]}`, body)
+}
+
+func TestCoreAPI_ListEventsHandlerWithFilters(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	before := time.Date(2106, 1, 2, 15, 4, 5, 0, time.UTC)
+	after := time.Date(2105, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().ListEvents(mock.MatchedBy(func(opts *database.ListEventsOpts) bool {
+		return assert.Equal(t, []uint{5, 6}, opts.IDs) &&
+			assert.Equal(t, new(uint(3)), opts.InputID) &&
+			assert.Equal(t, new(uint(1)), opts.ChannelID) &&
+			assert.Equal(t, new("ref"), opts.ExternalReference) &&
+			assert.True(t, opts.IncludeInactive) &&
+			assert.True(t, before.Equal(*opts.EventsBefore)) &&
+			assert.True(t, after.Equal(*opts.EventsAfter))
+	})).Return([]database.Event{}, nil)
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodGet, server.URL+"/core/channels/1/events"+
+		"?ids=5&ids=6&input_id=3&reference=ref&include_inactive=true&before=2106-01-02T15:04:05Z&after=2105-01-02T15:04:05Z", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"status":"success","data":[]}`, body)
+}
+
+func TestCoreAPI_ListEventsHandlerWithInvalidFilter(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodGet, server.URL+"/core/channels/1/events?before=yesterday", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.JSONEq(t, `{"message":"Invalid query parameters","status":"error"}`, body)
+}
+
+func TestCoreAPI_ListEventsHandlerWithUnknownChannel(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(nil, database.ErrNotFound)
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodGet, server.URL+"/core/channels/1/events", "")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.JSONEq(t, `{"message":"Not Found","status":"error"}`, body)
+}
+
+func TestCoreAPI_ListEventsHandlerWithError(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().ListEvents(mock.Anything).Return(nil, errors.New("test"))
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodGet, server.URL+"/core/channels/1/events", "")
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.JSONEq(t, `{"message":"Internal Server Error","status":"error"}`, body)
+}
+
+func TestCoreAPI_ListEventsHandlerWithoutAuth(t *testing.T) {
+	// Setup
+	server, _ := testCoreAPI(t)
+
+	// Assemble request
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL+"/core/channels/1/events", nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	// Assert response
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestCoreAPI_GetEventHandler(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().ListEvents(&database.ListEventsOpts{
+		IDs:             []uint{5},
+		ChannelID:       new(uint(1)),
+		IncludeInactive: true,
+	}).Return([]database.Event{testDatabaseEvent()}, nil)
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodGet, server.URL+"/core/channels/1/events/5", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"status":"success","data":`+testEventResponse+`}`, body)
+}
+
+func TestCoreAPI_GetEventHandlerWithUnknownEvent(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().ListEvents(mock.Anything).Return([]database.Event{}, nil)
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodGet, server.URL+"/core/channels/1/events/5", "")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.JSONEq(t, `{"message":"Not Found","status":"error"}`, body)
+}
+
+func TestCoreAPI_GetEventHandlerWithInvalidID(t *testing.T) {
+	// Setup
+	server, _ := testCoreAPI(t)
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodGet, server.URL+"/core/channels/1/events/abc", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestCoreAPI_GetEventHandlerWithError(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().ListEvents(mock.Anything).Return(nil, errors.New("test"))
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodGet, server.URL+"/core/channels/1/events/5", "")
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.JSONEq(t, `{"message":"Internal Server Error","status":"error"}`, body)
+}
+
+func TestCoreAPI_CreateEventHandler(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().NewEvent(mock.Anything).RunAndReturn(func(event *database.Event) (*database.Event, error) {
+		assert.Equal(t, uint(1), event.ChannelID)
+		assert.True(t, time.Date(2106, 1, 2, 15, 4, 5, 0, time.UTC).Equal(event.Time))
+		assert.Equal(t, time.Minute*5, event.Duration)
+		assert.Equal(t, "test event", event.Message)
+		assert.True(t, event.Active)
+		assert.Equal(t, database.ImportanceDefault, event.Importance)
+		assert.Equal(t, "DTSTART:21060102T150405Z\nRRULE:FREQ=WEEKLY;BYDAY=MO", event.RepeatRule)
+		assert.Nil(t, event.RepeatInterval)
+		assert.Nil(t, event.InputID)
+
+		event.ID = 5
+
+		return event, nil
+	})
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/events",
+		`{"Time":"2106-01-02T22:04:05+07:00","Message":"test event","RepeatRule":"FREQ=WEEKLY;BYDAY=MO"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"ID":5`)
+	assert.Contains(t, body, `"RepeatRule":"DTSTART:21060102T150405Z\nRRULE:FREQ=WEEKLY;BYDAY=MO"`)
+}
+
+func TestCoreAPI_CreateEventHandlerWithChannelTimeZone(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	channel := testDatabaseChannel()
+	channel.TimeZone = "Europe/Berlin"
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(&channel, nil)
+	db.EXPECT().NewEvent(mock.Anything).RunAndReturn(func(event *database.Event) (*database.Event, error) {
+		assert.Equal(t, "DTSTART;TZID=Europe/Berlin:21060102T160405\nRRULE:FREQ=WEEKLY;BYDAY=MO", event.RepeatRule)
+		return event, nil
+	})
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/events",
+		`{"Time":"2106-01-02T22:04:05+07:00","Message":"test event","RepeatRule":"FREQ=WEEKLY;BYDAY=MO"}`)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestCoreAPI_CreateEventHandlerWithAllFields(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().NewEvent(mock.Anything).RunAndReturn(func(event *database.Event) (*database.Event, error) {
+		assert.Equal(t, time.Minute*30, event.Duration)
+		assert.False(t, event.Active)
+		assert.Equal(t, database.ImportanceImportant, event.Importance)
+		assert.Empty(t, event.RepeatRule)
+		assert.Equal(t, new(time.Hour*24), event.RepeatInterval)
+		assert.True(t, time.Date(2107, 1, 2, 15, 4, 5, 0, time.UTC).Equal(*event.RepeatUntil))
+
+		return event, nil
+	})
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/events",
+		`{"Time":"2106-01-02T15:04:05Z","Message":"test event","DurationMinutes":30,"Active":false,`+
+			`"Importance":"important","RepeatIntervalMinutes":1440,"RepeatUntil":"2107-01-02T15:04:05Z"}`)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestCoreAPI_CreateEventHandlerWithInvalidBody(t *testing.T) {
+	for _, body := range []string{
+		`{"Message":"test event"}`,
+		`{"Time":"2106-01-02T15:04:05Z"}`,
+		`{"Time":"2106-01-02T15:04:05Z","Message":"test event","Importance":"critical"}`,
+		`{"Time":"2106-01-02T15:04:05Z","Message":"test event","RepeatRule":"FREQ=SOMETIMES"}`,
+		`not json`,
+	} {
+		t.Run(body, func(t *testing.T) {
+			// Setup
+			server, db := testCoreAPI(t)
+
+			// Mock expectations
+			db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+
+			// Assert response
+			status, _ := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/events", body)
+			assert.Equal(t, http.StatusBadRequest, status)
+		})
+	}
+}
+
+func TestCoreAPI_CreateEventHandlerWithUnknownChannel(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(nil, database.ErrNotFound)
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/events",
+		`{"Time":"2106-01-02T15:04:05Z","Message":"test event"}`)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestCoreAPI_CreateEventHandlerWithError(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().NewEvent(mock.Anything).Return(nil, errors.New("test"))
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/events",
+		`{"Time":"2106-01-02T15:04:05Z","Message":"test event"}`)
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
+
+func TestCoreAPI_UpdateEventHandler(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().ListEvents(mock.Anything).Return([]database.Event{testDatabaseEvent()}, nil)
+	db.EXPECT().UpdateEvent(mock.Anything).RunAndReturn(func(event *database.Event) (*database.Event, error) {
+		assert.Equal(t, uint(5), event.ID)
+		assert.Equal(t, "new message", event.Message)
+		assert.False(t, event.Active)
+		assert.Equal(t, database.ImportanceDefault, event.Importance)
+		// Unchanged fields.
+		assert.Equal(t, time.Minute*10, event.Duration)
+		assert.True(t, time.Date(2106, 1, 2, 15, 4, 5, 0, time.UTC).Equal(event.Time))
+
+		return event, nil
+	})
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodPatch, server.URL+"/core/channels/1/events/5",
+		`{"Message":"new message","Active":false,"Importance":"default"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"Message":"new message"`)
+}
+
+func TestCoreAPI_UpdateEventHandlerMovesRepeatRule(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	event := testDatabaseEvent()
+	event.RepeatRule = "DTSTART:21060102T150405Z\nRRULE:FREQ=WEEKLY;BYDAY=MO"
+
+	// Mock expectations
+	db.EXPECT().ListEvents(mock.Anything).Return([]database.Event{event}, nil)
+	db.EXPECT().UpdateEvent(mock.Anything).RunAndReturn(func(event *database.Event) (*database.Event, error) {
+		assert.Equal(t, "DTSTART:21060105T100000Z\nRRULE:FREQ=WEEKLY;BYDAY=MO", event.RepeatRule)
+		return event, nil
+	})
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPatch, server.URL+"/core/channels/1/events/5",
+		`{"Time":"2106-01-05T10:00:00Z"}`)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestCoreAPI_UpdateEventHandlerMovesRepeatRuleInItsLocation(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	event := testDatabaseEvent()
+	event.RepeatRule = "DTSTART;TZID=Europe/Berlin:21060102T160405\nRRULE:FREQ=WEEKLY;COUNT=5;BYDAY=MO"
+
+	// Mock expectations
+	db.EXPECT().ListEvents(mock.Anything).Return([]database.Event{event}, nil)
+	db.EXPECT().UpdateEvent(mock.Anything).RunAndReturn(func(event *database.Event) (*database.Event, error) {
+		assert.Equal(t, "DTSTART;TZID=Europe/Berlin:21060105T110000\nRRULE:FREQ=WEEKLY;COUNT=5;BYDAY=MO", event.RepeatRule)
+		return event, nil
+	})
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPatch, server.URL+"/core/channels/1/events/5",
+		`{"Time":"2106-01-05T10:00:00Z"}`)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestCoreAPI_UpdateEventHandlerKeepsRepeatRule(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	event := testDatabaseEvent()
+	event.RepeatRule = "DTSTART;TZID=Europe/Berlin:21060101T090000\nRRULE:FREQ=WEEKLY;COUNT=5;BYDAY=MO"
+
+	// Mock expectations
+	db.EXPECT().ListEvents(mock.Anything).Return([]database.Event{event}, nil)
+	db.EXPECT().UpdateEvent(mock.Anything).RunAndReturn(func(event *database.Event) (*database.Event, error) {
+		assert.Equal(t, "new message", event.Message)
+		assert.Equal(t, "DTSTART;TZID=Europe/Berlin:21060101T090000\nRRULE:FREQ=WEEKLY;COUNT=5;BYDAY=MO", event.RepeatRule)
+
+		return event, nil
+	})
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPatch, server.URL+"/core/channels/1/events/5",
+		`{"Message":"new message"}`)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestCoreAPI_UpdateEventHandlerAddsRepeatRuleInChannelTimeZone(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	event := testDatabaseEvent()
+	event.Channel.TimeZone = "Europe/Berlin"
+
+	// Mock expectations
+	db.EXPECT().ListEvents(mock.Anything).Return([]database.Event{event}, nil)
+	db.EXPECT().UpdateEvent(mock.Anything).RunAndReturn(func(event *database.Event) (*database.Event, error) {
+		assert.Equal(t, "DTSTART;TZID=Europe/Berlin:21060102T160405\nRRULE:FREQ=DAILY", event.RepeatRule)
+		return event, nil
+	})
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPatch, server.URL+"/core/channels/1/events/5",
+		`{"RepeatRule":"FREQ=DAILY"}`)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestCoreAPI_UpdateEventHandlerRemovesRepetition(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	event := testDatabaseEvent()
+	event.RepeatRule = "DTSTART:21060102T150405Z\nRRULE:FREQ=WEEKLY;BYDAY=MO"
+	event.RepeatInterval = new(time.Hour)
+
+	// Mock expectations
+	db.EXPECT().ListEvents(mock.Anything).Return([]database.Event{event}, nil)
+	db.EXPECT().UpdateEvent(mock.Anything).RunAndReturn(func(event *database.Event) (*database.Event, error) {
+		assert.Empty(t, event.RepeatRule)
+		assert.Nil(t, event.RepeatInterval)
+
+		return event, nil
+	})
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPatch, server.URL+"/core/channels/1/events/5",
+		`{"RepeatRule":"","RepeatIntervalMinutes":0}`)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestCoreAPI_UpdateEventHandlerWithInvalidBody(t *testing.T) {
+	for _, body := range []string{
+		`{"Message":""}`,
+		`{"Importance":"critical"}`,
+		`{"RepeatRule":"FREQ=SOMETIMES"}`,
+		`not json`,
+	} {
+		t.Run(body, func(t *testing.T) {
+			// Setup
+			server, db := testCoreAPI(t)
+
+			// Mock expectations
+			db.EXPECT().ListEvents(mock.Anything).Return([]database.Event{testDatabaseEvent()}, nil)
+
+			// Assert response
+			status, _ := doCoreAPIRequest(t, http.MethodPatch, server.URL+"/core/channels/1/events/5", body)
+			assert.Equal(t, http.StatusBadRequest, status)
+		})
+	}
+}
+
+func TestCoreAPI_UpdateEventHandlerWithError(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().ListEvents(mock.Anything).Return([]database.Event{testDatabaseEvent()}, nil)
+	db.EXPECT().UpdateEvent(mock.Anything).Return(nil, errors.New("test"))
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPatch, server.URL+"/core/channels/1/events/5", `{"Active":false}`)
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
+
+func TestCoreAPI_DeleteEventHandler(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().ListEvents(mock.Anything).Return([]database.Event{testDatabaseEvent()}, nil)
+	db.EXPECT().DeleteEvent(mock.MatchedBy(func(event *database.Event) bool {
+		return event.ID == 5
+	})).Return(nil)
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodDelete, server.URL+"/core/channels/1/events/5", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"message":"Deleted event","status":"success"}`, body)
+}
+
+func TestCoreAPI_DeleteEventHandlerWithUnknownEvent(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().ListEvents(mock.Anything).Return([]database.Event{}, nil)
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodDelete, server.URL+"/core/channels/1/events/5", "")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestCoreAPI_DeleteEventHandlerWithError(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().ListEvents(mock.Anything).Return([]database.Event{testDatabaseEvent()}, nil)
+	db.EXPECT().DeleteEvent(mock.Anything).Return(errors.New("test"))
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodDelete, server.URL+"/core/channels/1/events/5", "")
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
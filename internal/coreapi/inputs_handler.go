@@ -0,0 +1,172 @@
+package coreapi
+
+import (
+	"errors"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/api/apictx"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/api/response"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/gin-gonic/gin"
+)
+
+type Input struct {
+	ID        uint
+	InputType string // Type of the connector, e.g. "matrix" or "ical"
+	InputID   uint   // ID of the input within the connector
+	Enabled   bool
+}
+
+// InputRequest holds the data to attach an input to a channel.
+type InputRequest struct {
+	InputType string `binding:"required"`
+	InputID   uint   `binding:"required"`
+	Enabled   *bool  // Defaults to true
+}
+
+func inputToResponse(inputIn *database.Input) Input {
+	return Input{
+		ID:        inputIn.ID,
+		InputType: inputIn.InputType,
+		InputID:   inputIn.InputID,
+		Enabled:   inputIn.Enabled,
+	}
+}
+
+func inputsToResponse(inputsIn []database.Input) []Input {
+	inputsOut := make([]Input, len(inputsIn))
+
+	for i := range inputsIn {
+		inputsOut[i] = inputToResponse(&inputsIn[i])
+	}
+
+	return inputsOut
+}
+
+// addInputHandler godoc
+// @Summary Attach an input to a channel
+// @Description Attach an input of a connector to a channel. An input already attached to another channel has to be
+// @Description detached there first.
+// @Tags Channels
+// @Security APIKeyAuthentication
+// @Accept json
+// @Produce json
+// @Param id path int true "Channel ID"
+// @Param payload body InputRequest true "Input"
+// @Success 200 {object} response.DataResponse{data=Input}
+// @Failure 400 {object} response.MessageErrorResponse
+// @Failure 401 {object} response.MessageErrorResponse
+// @Failure 404 {object} response.MessageErrorResponse
+// @Failure 500 ""
+// @Router /core/channels/{id}/inputs [post]
+func (api *coreAPI) addInputHandler(ctx *gin.Context) {
+	channel, ok := api.channelFromRequest(ctx)
+	if !ok {
+		return
+	}
+
+	var request InputRequest
+
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.AbortWithBadRequestError(ctx, "Invalid input, type and ID are required")
+		return
+	}
+
+	exists, err := api.config.Database.InputExists(request.InputType, request.InputID)
+	switch {
+	case errors.Is(err, database.ErrUnknownInput):
+		response.AbortWithBadRequestError(ctx, "Unknown input type")
+		return
+	case err != nil:
+		api.logger.Error("failed to check input", "error", err)
+		response.AbortWithInternalServerError(ctx)
+
+		return
+	case !exists:
+		response.AbortWithNotFoundError(ctx)
+		return
+	}
+
+	input, err := api.config.Database.GetInputByType(request.InputID, request.InputType)
+	if errors.Is(err, database.ErrNotFound) {
+		input = &database.Input{
+			InputType: request.InputType,
+			InputID:   request.InputID,
+			Enabled:   true,
+		}
+	} else if err != nil {
+		api.logger.Error("failed to get input", "error", err)
+		response.AbortWithInternalServerError(ctx)
+
+		return
+	} else if input.ChannelID != channel.ID {
+		response.AbortWithBadRequestError(ctx, "Input is attached to another channel, detach it there first")
+		return
+	}
+
+	if request.Enabled != nil {
+		input.Enabled = *request.Enabled
+	}
+
+	err = api.config.Database.AddInputToChannel(channel.ID, input)
+	if err != nil {
+		api.logger.Error("failed to add input to channel", "error", err, "channel.id", channel.ID)
+		response.AbortWithInternalServerError(ctx)
+
+		return
+	}
+
+	response.WithData(ctx, inputToResponse(input))
+}
+
+// removeInputHandler godoc
+// @Summary Detach an input from a channel
+// @Description Detach an input from a channel, the connector of the input is informed and cleans up.
+// @Tags Channels
+// @Security APIKeyAuthentication
+// @Produce json
+// @Param id path int true "Channel ID"
+// @Param inputID path int true "Input ID"
+// @Success 200 {object} response.MessageSuccessResponse
+// @Failure 400 {object} response.MessageErrorResponse
+// @Failure 401 {object} response.MessageErrorResponse
+// @Failure 404 {object} response.MessageErrorResponse
+// @Failure 500 ""
+// @Router /core/channels/{id}/inputs/{inputID} [delete]
+func (api *coreAPI) removeInputHandler(ctx *gin.Context) {
+	channel, ok := api.channelFromRequest(ctx)
+	if !ok {
+		return
+	}
+
+	inputID, ok := apictx.GetUintFromContext(ctx, "inputID")
+	if !ok || !channelHasInput(channel, inputID) {
+		response.AbortWithNotFoundError(ctx)
+		return
+	}
+
+	err := api.config.Database.RemoveInputFromChannel(channel.ID, inputID)
+	if err != nil {
+		if errors.Is(err, database.ErrUnknownInput) {
+			response.AbortWithBadRequestError(ctx, "Unknown input type, can not remove the input")
+			return
+		}
+
+		api.logger.Error("failed to remove input from channel", "error", err, "channel.id", channel.ID, "input.id", inputID)
+		response.AbortWithInternalServerError(ctx)
+
+		return
+	}
+
+	response.WithMessage(ctx, "Removed input")
+}
+
+func channelHasInput(channel *database.Channel, inputID uint) bool {
+	for i := range channel.Inputs {
+		if channel.Inputs[i].ID == inputID {
+			return true
+		}
+	}
+
+	return false
+}
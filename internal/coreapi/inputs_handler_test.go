@@ -0,0 +1,207 @@
+package coreapi_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testDatabaseChannelWithInput() *database.Channel {
+	channel := testDatabaseChannel()
+	channel.Inputs = []database.Input{{ChannelID: 1, InputType: "matrix", InputID: 3, Enabled: true}}
+	channel.Inputs[0].ID = 7
+
+	return &channel
+}
+
+func TestCoreAPI_AddInputHandler(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().InputExists("ical", uint(3)).Return(true, nil)
+	db.EXPECT().GetInputByType(uint(3), "ical").Return(nil, database.ErrNotFound)
+	db.EXPECT().AddInputToChannel(uint(1), &database.Input{
+		InputType: "ical",
+		InputID:   3,
+		Enabled:   true,
+	}).RunAndReturn(func(_ uint, input *database.Input) error {
+		input.ID = 9
+		return nil
+	})
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/inputs",
+		`{"InputType":"ical","InputID":3}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"status":"success","data":{"ID":9,"InputType":"ical","InputID":3,"Enabled":true}}`, body)
+}
+
+func TestCoreAPI_AddInputHandlerUpdatesExistingInput(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	input := &database.Input{ChannelID: 1, InputType: "matrix", InputID: 3, Enabled: true}
+	input.ID = 7
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().InputExists("matrix", uint(3)).Return(true, nil)
+	db.EXPECT().GetInputByType(uint(3), "matrix").Return(input, nil)
+	db.EXPECT().AddInputToChannel(uint(1), mock.MatchedBy(func(input *database.Input) bool {
+		return input.ID == 7 && !input.Enabled
+	})).Return(nil)
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/inputs",
+		`{"InputType":"matrix","InputID":3,"Enabled":false}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"status":"success","data":{"ID":7,"InputType":"matrix","InputID":3,"Enabled":false}}`, body)
+}
+
+func TestCoreAPI_AddInputHandlerWithInputOfOtherChannel(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	input := &database.Input{ChannelID: 2, InputType: "matrix", InputID: 3, Enabled: true}
+	input.ID = 7
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().InputExists("matrix", uint(3)).Return(true, nil)
+	db.EXPECT().GetInputByType(uint(3), "matrix").Return(input, nil)
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/inputs",
+		`{"InputType":"matrix","InputID":3}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.JSONEq(t, `{"message":"Input is attached to another channel, detach it there first","status":"error"}`, body)
+}
+
+func TestCoreAPI_AddInputHandlerWithUnknownInputType(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().InputExists("unknown", uint(3)).Return(false, database.ErrUnknownInput)
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/inputs",
+		`{"InputType":"unknown","InputID":3}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.JSONEq(t, `{"message":"Unknown input type","status":"error"}`, body)
+}
+
+func TestCoreAPI_AddInputHandlerWithUnknownInput(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().InputExists("ical", uint(99)).Return(false, nil)
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/inputs",
+		`{"InputType":"ical","InputID":99}`)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestCoreAPI_AddInputHandlerWithExistsError(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().InputExists("ical", uint(3)).Return(false, errors.New("test"))
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/inputs",
+		`{"InputType":"ical","InputID":3}`)
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
+
+func TestCoreAPI_AddInputHandlerWithInvalidBody(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/inputs", `{"InputType":"matrix"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestCoreAPI_AddInputHandlerWithError(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().InputExists("matrix", uint(3)).Return(true, nil)
+	db.EXPECT().GetInputByType(uint(3), "matrix").Return(nil, database.ErrNotFound)
+	db.EXPECT().AddInputToChannel(uint(1), mock.Anything).Return(errors.New("test"))
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/inputs",
+		`{"InputType":"matrix","InputID":3}`)
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
+
+func TestCoreAPI_RemoveInputHandler(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(testDatabaseChannelWithInput(), nil)
+	db.EXPECT().RemoveInputFromChannel(uint(1), uint(7)).Return(nil)
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodDelete, server.URL+"/core/channels/1/inputs/7", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"message":"Removed input","status":"success"}`, body)
+}
+
+func TestCoreAPI_RemoveInputHandlerWithInputOfOtherChannel(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(testDatabaseChannelWithInput(), nil)
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodDelete, server.URL+"/core/channels/1/inputs/8", "")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestCoreAPI_RemoveInputHandlerWithUnknownInputType(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(testDatabaseChannelWithInput(), nil)
+	db.EXPECT().RemoveInputFromChannel(uint(1), uint(7)).Return(database.ErrUnknownInput)
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodDelete, server.URL+"/core/channels/1/inputs/7", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestCoreAPI_RemoveInputHandlerWithError(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(testDatabaseChannelWithInput(), nil)
+	db.EXPECT().RemoveInputFromChannel(uint(1), uint(7)).Return(errors.New("test"))
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodDelete, server.URL+"/core/channels/1/inputs/7", "")
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
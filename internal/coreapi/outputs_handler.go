@@ -0,0 +1,172 @@
+package coreapi
+
+import (
+	"errors"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/api/apictx"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/api/response"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/gin-gonic/gin"
+)
+
+type Output struct {
+	ID         uint
+	OutputType string // Type of the connector, e.g. "matrix" or "ical"
+	OutputID   uint   // ID of the output within the connector
+	Enabled    bool
+}
+
+// OutputRequest holds the data to attach an output to a channel.
+type OutputRequest struct {
+	OutputType string `binding:"required"`
+	OutputID   uint   `binding:"required"`
+	Enabled    *bool  // Defaults to true
+}
+
+func outputToResponse(outputIn *database.Output) Output {
+	return Output{
+		ID:         outputIn.ID,
+		OutputType: outputIn.OutputType,
+		OutputID:   outputIn.OutputID,
+		Enabled:    outputIn.Enabled,
+	}
+}
+
+func outputsToResponse(outputsIn []database.Output) []Output {
+	outputsOut := make([]Output, len(outputsIn))
+
+	for i := range outputsIn {
+		outputsOut[i] = outputToResponse(&outputsIn[i])
+	}
+
+	return outputsOut
+}
+
+// addOutputHandler godoc
+// @Summary Attach an output to a channel
+// @Description Attach an output of a connector to a channel. An output already attached to another channel has to be
+// @Description detached there first.
+// @Tags Channels
+// @Security APIKeyAuthentication
+// @Accept json
+// @Produce json
+// @Param id path int true "Channel ID"
+// @Param payload body OutputRequest true "Output"
+// @Success 200 {object} response.DataResponse{data=Output}
+// @Failure 400 {object} response.MessageErrorResponse
+// @Failure 401 {object} response.MessageErrorResponse
+// @Failure 404 {object} response.MessageErrorResponse
+// @Failure 500 ""
+// @Router /core/channels/{id}/outputs [post]
+func (api *coreAPI) addOutputHandler(ctx *gin.Context) {
+	channel, ok := api.channelFromRequest(ctx)
+	if !ok {
+		return
+	}
+
+	var request OutputRequest
+
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.AbortWithBadRequestError(ctx, "Invalid output, type and ID are required")
+		return
+	}
+
+	exists, err := api.config.Database.OutputExists(request.OutputType, request.OutputID)
+	switch {
+	case errors.Is(err, database.ErrUnknownOutput):
+		response.AbortWithBadRequestError(ctx, "Unknown output type")
+		return
+	case err != nil:
+		api.logger.Error("failed to check output", "error", err)
+		response.AbortWithInternalServerError(ctx)
+
+		return
+	case !exists:
+		response.AbortWithNotFoundError(ctx)
+		return
+	}
+
+	output, err := api.config.Database.GetOutputByType(request.OutputID, request.OutputType)
+	if errors.Is(err, database.ErrNotFound) {
+		output = &database.Output{
+			OutputType: request.OutputType,
+			OutputID:   request.OutputID,
+			Enabled:    true,
+		}
+	} else if err != nil {
+		api.logger.Error("failed to get output", "error", err)
+		response.AbortWithInternalServerError(ctx)
+
+		return
+	} else if output.ChannelID != channel.ID {
+		response.AbortWithBadRequestError(ctx, "Output is attached to another channel, detach it there first")
+		return
+	}
+
+	if request.Enabled != nil {
+		output.Enabled = *request.Enabled
+	}
+
+	err = api.config.Database.AddOutputToChannel(channel.ID, output)
+	if err != nil {
+		api.logger.Error("failed to add output to channel", "error", err, "channel.id", channel.ID)
+		response.AbortWithInternalServerError(ctx)
+
+		return
+	}
+
+	response.WithData(ctx, outputToResponse(output))
+}
+
+// removeOutputHandler godoc
+// @Summary Detach an output from a channel
+// @Description Detach an output from a channel, the connector of the output is informed and cleans up.
+// @Tags Channels
+// @Security APIKeyAuthentication
+// @Produce json
+// @Param id path int true "Channel ID"
+// @Param outputID path int true "Output ID"
+// @Success 200 {object} response.MessageSuccessResponse
+// @Failure 400 {object} response.MessageErrorResponse
+// @Failure 401 {object} response.MessageErrorResponse
+// @Failure 404 {object} response.MessageErrorResponse
+// @Failure 500 ""
+// @Router /core/channels/{id}/outputs/{outputID} [delete]
+func (api *coreAPI) removeOutputHandler(ctx *gin.Context) {
+	channel, ok := api.channelFromRequest(ctx)
+	if !ok {
+		return
+	}
+
+	outputID, ok := apictx.GetUintFromContext(ctx, "outputID")
+	if !ok || !channelHasOutput(channel, outputID) {
+		response.AbortWithNotFoundError(ctx)
+		return
+	}
+
+	err := api.config.Database.RemoveOutputFromChannel(channel.ID, outputID)
+	if err != nil {
+		if errors.Is(err, database.ErrUnknownOutput) {
+			response.AbortWithBadRequestError(ctx, "Unknown output type, can not remove the output")
+			return
+		}
+
+		api.logger.Error("failed to remove output from channel", "error", err, "channel.id", channel.ID, "output.id", outputID)
+		response.AbortWithInternalServerError(ctx)
+
+		return
+	}
+
+	response.WithMessage(ctx, "Removed output")
+}
+
+func channelHasOutput(channel *database.Channel, outputID uint) bool {
+	for i := range channel.Outputs {
+		if channel.Outputs[i].ID == outputID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,207 @@
+package coreapi_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testDatabaseChannelWithOutput() *database.Channel {
+	channel := testDatabaseChannel()
+	channel.Outputs = []database.Output{{ChannelID: 1, OutputType: "matrix", OutputID: 3, Enabled: true}}
+	channel.Outputs[0].ID = 7
+
+	return &channel
+}
+
+func TestCoreAPI_AddOutputHandler(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().OutputExists("ical", uint(3)).Return(true, nil)
+	db.EXPECT().GetOutputByType(uint(3), "ical").Return(nil, database.ErrNotFound)
+	db.EXPECT().AddOutputToChannel(uint(1), &database.Output{
+		OutputType: "ical",
+		OutputID:   3,
+		Enabled:    true,
+	}).RunAndReturn(func(_ uint, output *database.Output) error {
+		output.ID = 9
+		return nil
+	})
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/outputs",
+		`{"OutputType":"ical","OutputID":3}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"status":"success","data":{"ID":9,"OutputType":"ical","OutputID":3,"Enabled":true}}`, body)
+}
+
+func TestCoreAPI_AddOutputHandlerUpdatesExistingOutput(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	output := &database.Output{ChannelID: 1, OutputType: "matrix", OutputID: 3, Enabled: true}
+	output.ID = 7
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().OutputExists("matrix", uint(3)).Return(true, nil)
+	db.EXPECT().GetOutputByType(uint(3), "matrix").Return(output, nil)
+	db.EXPECT().AddOutputToChannel(uint(1), mock.MatchedBy(func(output *database.Output) bool {
+		return output.ID == 7 && !output.Enabled
+	})).Return(nil)
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/outputs",
+		`{"OutputType":"matrix","OutputID":3,"Enabled":false}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"status":"success","data":{"ID":7,"OutputType":"matrix","OutputID":3,"Enabled":false}}`, body)
+}
+
+func TestCoreAPI_AddOutputHandlerWithOutputOfOtherChannel(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	output := &database.Output{ChannelID: 2, OutputType: "matrix", OutputID: 3, Enabled: true}
+	output.ID = 7
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().OutputExists("matrix", uint(3)).Return(true, nil)
+	db.EXPECT().GetOutputByType(uint(3), "matrix").Return(output, nil)
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/outputs",
+		`{"OutputType":"matrix","OutputID":3}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.JSONEq(t, `{"message":"Output is attached to another channel, detach it there first","status":"error"}`, body)
+}
+
+func TestCoreAPI_AddOutputHandlerWithUnknownOutputType(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().OutputExists("unknown", uint(3)).Return(false, database.ErrUnknownOutput)
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/outputs",
+		`{"OutputType":"unknown","OutputID":3}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.JSONEq(t, `{"message":"Unknown output type","status":"error"}`, body)
+}
+
+func TestCoreAPI_AddOutputHandlerWithUnknownOutput(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().OutputExists("ical", uint(99)).Return(false, nil)
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/outputs",
+		`{"OutputType":"ical","OutputID":99}`)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestCoreAPI_AddOutputHandlerWithExistsError(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().OutputExists("ical", uint(3)).Return(false, errors.New("test"))
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/outputs",
+		`{"OutputType":"ical","OutputID":3}`)
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
+
+func TestCoreAPI_AddOutputHandlerWithInvalidBody(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/outputs", `{"OutputType":"matrix"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestCoreAPI_AddOutputHandlerWithError(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(new(testDatabaseChannel()), nil)
+	db.EXPECT().OutputExists("matrix", uint(3)).Return(true, nil)
+	db.EXPECT().GetOutputByType(uint(3), "matrix").Return(nil, database.ErrNotFound)
+	db.EXPECT().AddOutputToChannel(uint(1), mock.Anything).Return(errors.New("test"))
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodPost, server.URL+"/core/channels/1/outputs",
+		`{"OutputType":"matrix","OutputID":3}`)
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
+
+func TestCoreAPI_RemoveOutputHandler(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(testDatabaseChannelWithOutput(), nil)
+	db.EXPECT().RemoveOutputFromChannel(uint(1), uint(7)).Return(nil)
+
+	// Assert response
+	status, body := doCoreAPIRequest(t, http.MethodDelete, server.URL+"/core/channels/1/outputs/7", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"message":"Removed output","status":"success"}`, body)
+}
+
+func TestCoreAPI_RemoveOutputHandlerWithOutputOfOtherChannel(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(testDatabaseChannelWithOutput(), nil)
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodDelete, server.URL+"/core/channels/1/outputs/8", "")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestCoreAPI_RemoveOutputHandlerWithUnknownOutputType(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(testDatabaseChannelWithOutput(), nil)
+	db.EXPECT().RemoveOutputFromChannel(uint(1), uint(7)).Return(database.ErrUnknownOutput)
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodDelete, server.URL+"/core/channels/1/outputs/7", "")
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestCoreAPI_RemoveOutputHandlerWithError(t *testing.T) {
+	// Setup
+	server, db := testCoreAPI(t)
+
+	// Mock expectations
+	db.EXPECT().GetChannelByID(uint(1)).Return(testDatabaseChannelWithOutput(), nil)
+	db.EXPECT().RemoveOutputFromChannel(uint(1), uint(7)).Return(errors.New("test"))
+
+	// Assert response
+	status, _ := doCoreAPIRequest(t, http.MethodDelete, server.URL+"/core/channels/1/outputs/7", "")
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
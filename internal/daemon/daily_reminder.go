@@ -1,15 +1,25 @@
 package daemon
 
-import "github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+import (
+	"time"
 
-func dailyReminderFromDatabase(events []database.Event) *DailyReminder {
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+func dailyReminderFromDatabase(events, overdue []database.Event, lookahead time.Duration) *DailyReminder {
 	dailyReminder := &DailyReminder{
-		Events: make([]Event, len(events)),
+		Events:    make([]Event, len(events)),
+		Overdue:   make([]Event, len(overdue)),
+		Lookahead: lookahead,
 	}
 
 	for i := range events {
 		dailyReminder.Events[i] = *eventFromDatabase(&events[i])
 	}
 
+	for i := range overdue {
+		dailyReminder.Overdue[i] = *eventFromDatabase(&overdue[i])
+	}
+
 	return dailyReminder
 }
@@ -18,15 +18,14 @@ func (service *service) sendOutDailyReminders() error {
 	service.metricLastDailyReminderRun.WithLabelValues().
 		Set(float64(time.Now().Unix()))
 
-	eventsAfter := time.Now()
-	eventsBefore := eventsAfter.Add(time.Hour*24 + time.Second)
+	now := time.Now()
 
 	for _, channel := range channels {
-		events, err := service.database.ListEvents(&database.ListEventsOpts{
-			ChannelID:    &channel.ID,
-			EventsAfter:  &eventsAfter,
-			EventsBefore: &eventsBefore,
-		})
+		if channel.DailyReminder == nil {
+			continue
+		}
+
+		dailyReminder, err := service.dailyReminderForChannel(&channel, now)
 		if err != nil {
 			service.logger.Error("failed to list events", "error", err)
 			continue
@@ -39,7 +38,9 @@ func (service *service) sendOutDailyReminders() error {
 				continue
 			}
 
-			if !isDailyReminderTimeReached(channel, &output, outputService) {
+			localNow := localTime(now, &channel, &output, outputService)
+
+			if !isDailyReminderTimeReached(&channel, localNow) {
 				service.logger.
 					Debug("no daily reminder send out",
 						"channel.id", channel.ID,
@@ -50,7 +51,18 @@ func (service *service) sendOutDailyReminders() error {
 				continue
 			}
 
-			if isDailyReminderSentToday(&output, outputService) {
+			if !isDigestDay(&channel, localNow) {
+				service.logger.
+					Debug("no daily reminder send out",
+						"channel.id", channel.ID,
+						"output.id", output.ID,
+						"output.output_type", output.OutputType,
+						"reason", "not a digest day")
+
+				continue
+			}
+
+			if isDailyReminderSentToday(&output, localNow) {
 				service.logger.
 					Debug("no daily reminder send out",
 						"channel.id", channel.ID,
@@ -61,55 +73,107 @@ func (service *service) sendOutDailyReminders() error {
 				continue
 			}
 
+			if channel.Digest.SkipEmpty && dailyReminder.IsEmpty() {
+				service.logger.
+					Debug("no daily reminder send out",
+						"channel.id", channel.ID,
+						"output.id", output.ID,
+						"output.output_type", output.OutputType,
+						"reason", "no events")
+
+				// Mark as done anyway, events added later today should not trigger a belated daily reminder.
+				service.markDailyReminderSent(&output)
+
+				continue
+			}
+
 			service.logger.With(
-				"events", len(events),
+				"events", len(dailyReminder.Events),
+				"overdue_events", len(dailyReminder.Overdue),
 				"output.type", output.OutputType,
 				"output.id", output.OutputID,
 				"daily_reminder_time", channel.DailyReminder,
 			).Debug("sending out daily reminder")
 
-			err := outputService.SendDailyReminder(dailyReminderFromDatabase(events), outputFromDatabase(&output))
+			err := outputService.SendDailyReminder(dailyReminder, outputFromDatabase(&output))
 			if err != nil {
 				service.logger.Error("failed to send out daily reminder", "error", err)
 				continue
 			}
 
-			now := time.Now().UTC()
-			output.LastDailyReminder = &now
-
-			_, err = service.database.UpdateOutput(&output)
-			if err != nil {
-				service.logger.Error("failed to update output", "error", err)
-			}
+			service.markDailyReminderSent(&output)
 		}
 	}
 
 	return nil
 }
 
-func isDailyReminderTimeReached(
-	channel database.Channel,
-	output *database.Output,
-	outputService OutputService,
-) bool {
+func (service *service) markDailyReminderSent(output *database.Output) {
+	now := time.Now().UTC()
+	output.LastDailyReminder = &now
+
+	_, err := service.database.UpdateOutput(output)
+	if err != nil {
+		service.logger.Error("failed to update output", "error", err)
+	}
+}
+
+// dailyReminderForChannel collects the events of the digest of the channel. Events that are due or were sent without
+// being acknowledged are listed as overdue if the channel asks for it.
+func (service *service) dailyReminderForChannel(channel *database.Channel, now time.Time) (*DailyReminder, error) {
+	lookahead := channel.Digest.LookaheadOrDefault()
+	eventsBefore := now.Add(lookahead + time.Second)
+
+	opts := &database.ListEventsOpts{
+		ChannelID:    &channel.ID,
+		EventsBefore: &eventsBefore,
+	}
+	if !channel.Digest.Overdue {
+		opts.EventsAfter = &now
+	}
+
+	events, err := service.database.ListEvents(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	upcoming := []database.Event{}
+	overdue := []database.Event{}
+
+	for i := range events {
+		if channel.Digest.Overdue && (events[i].Time.Before(now) || events[i].Resends > 0) {
+			overdue = append(overdue, events[i])
+		} else {
+			upcoming = append(upcoming, events[i])
+		}
+	}
+
+	return dailyReminderFromDatabase(upcoming, overdue, lookahead), nil
+}
+
+// localTime returns the time on the clock of the output, outputs without an own time zone use the one of the channel.
+func localTime(t time.Time, channel *database.Channel, output *database.Output, outputService OutputService) time.Time {
+	return outputService.ToLocalTime(channel.LocalTime(t), outputFromDatabase(output))
+}
+
+func isDailyReminderTimeReached(channel *database.Channel, localNow time.Time) bool {
 	if channel.DailyReminder == nil {
 		return false
 	}
 
-	now := outputService.ToLocalTime(time.Now(), outputFromDatabase(output))
+	return (localNow.Hour()*60 + localNow.Minute()) >= int(*channel.DailyReminder)
+}
 
-	return (now.Hour()*60 + now.Minute()) >= int(*channel.DailyReminder)
+func isDigestDay(channel *database.Channel, localNow time.Time) bool {
+	return channel.Digest.SendsOn(localNow.Weekday())
 }
 
-func isDailyReminderSentToday(
-	output *database.Output,
-	outputService OutputService,
-) bool {
+func isDailyReminderSentToday(output *database.Output, localNow time.Time) bool {
 	if output.LastDailyReminder == nil {
 		return false
 	}
 
-	now := outputService.ToLocalTime(time.Now(), outputFromDatabase(output))
+	last := output.LastDailyReminder.In(localNow.Location())
 
-	return now.Day() == output.LastDailyReminder.Day() && now.Month() == output.LastDailyReminder.Month() && now.Year() == output.LastDailyReminder.Year()
+	return localNow.Day() == last.Day() && localNow.Month() == last.Month() && localNow.Year() == last.Year()
 }
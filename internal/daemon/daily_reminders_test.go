@@ -6,6 +6,7 @@ import (
 
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/daemon"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
 	mock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -19,6 +20,15 @@ func testDatabaseChannel() database.Channel {
 	}
 }
 
+// expectChannelClock lets the output service keep the time handed in, like outputs without an own time zone.
+func expectChannelClock(outputService *daemon.MockOutputService) {
+	outputService.EXPECT().ToLocalTime(mock.Anything, mock.Anything).RunAndReturn(
+		func(t time.Time, _ *daemon.Output) time.Time {
+			return t
+		},
+	)
+}
+
 func TestService_SendOutDailyReminders(t *testing.T) {
 	service, db, outputService := testDaemon(t, false, true, false)
 
@@ -26,10 +36,12 @@ func TestService_SendOutDailyReminders(t *testing.T) {
 	output := testOutput()
 
 	db.EXPECT().GetChannels().Return([]database.Channel{channel}, nil)
+	expectChannelClock(outputService)
 	db.EXPECT().ListEvents(mock.Anything).Return([]database.Event{*testDatabaseEvent()}, nil)
-	outputService.EXPECT().ToLocalTime(mock.Anything, output).Return(time.Now())
 	outputService.EXPECT().SendDailyReminder(&daemon.DailyReminder{
-		Events: []daemon.Event{*testEvent()},
+		Events:    []daemon.Event{*testEvent()},
+		Overdue:   []daemon.Event{},
+		Lookahead: 24 * time.Hour,
 	}, output).Return(nil)
 	db.EXPECT().UpdateOutput(mock.Anything).Return(nil, nil)
 
@@ -40,3 +52,163 @@ func TestService_SendOutDailyReminders(t *testing.T) {
 	err := service.Stop()
 	require.NoError(t, err)
 }
+
+func TestService_SendOutDailyRemindersWithOverdue(t *testing.T) {
+	service, db, outputService := testDaemon(t, false, true, false)
+
+	channel := testDatabaseChannel()
+	channel.Digest = database.DigestConfig{
+		Lookahead: 7 * 24 * time.Hour,
+		Overdue:   true,
+	}
+	output := testOutput()
+
+	pastEvent := testDatabaseEvent()
+	pastEvent.Time = time.Now().Add(-time.Hour)
+	resentEvent := testDatabaseEvent(testDatabaseEventWithImportanceImportant())
+	resentEvent.Resends = 2
+
+	db.EXPECT().GetChannels().Return([]database.Channel{channel}, nil)
+	expectChannelClock(outputService)
+	db.EXPECT().ListEvents(mock.MatchedBy(func(opts *database.ListEventsOpts) bool {
+		return opts.EventsAfter == nil && opts.EventsBefore.After(time.Now().Add(6*24*time.Hour))
+	})).Return([]database.Event{*testDatabaseEvent(), *pastEvent, *resentEvent}, nil)
+	outputService.EXPECT().SendDailyReminder(mock.MatchedBy(func(dailyReminder *daemon.DailyReminder) bool {
+		return len(dailyReminder.Events) == 1 &&
+			len(dailyReminder.Overdue) == 2 &&
+			dailyReminder.Overdue[1].Importance == daemon.ImportanceImportant &&
+			dailyReminder.Lookahead == 7*24*time.Hour
+	}), output).Return(nil)
+	db.EXPECT().UpdateOutput(mock.Anything).Return(nil, nil)
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5)
+
+	err := service.Stop()
+	require.NoError(t, err)
+}
+
+func TestService_SendOutDailyRemindersNotOnDigestDay(t *testing.T) {
+	service, db, outputService := testDaemon(t, false, true, false)
+
+	// The channel is at least one day behind Kiritimati (UTC+14).
+	kiritimati, err := time.LoadLocation("Pacific/Kiritimati")
+	require.NoError(t, err)
+
+	channel := testDatabaseChannel()
+	channel.TimeZone = "Etc/GMT+12"
+	channel.Digest.Weekdays = database.WeekdayMask(time.Now().In(kiritimati).Weekday())
+
+	db.EXPECT().GetChannels().Return([]database.Channel{channel}, nil)
+	expectChannelClock(outputService)
+	db.EXPECT().ListEvents(mock.Anything).Return([]database.Event{*testDatabaseEvent()}, nil)
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5)
+
+	err = service.Stop()
+	require.NoError(t, err)
+}
+
+func TestService_SendOutDailyRemindersOnDigestDayOfChannel(t *testing.T) {
+	service, db, outputService := testDaemon(t, false, true, false)
+
+	kiritimati, err := time.LoadLocation("Pacific/Kiritimati")
+	require.NoError(t, err)
+
+	channel := testDatabaseChannel()
+	channel.TimeZone = "Pacific/Kiritimati"
+	channel.Digest.Weekdays = database.WeekdayMask(time.Now().In(kiritimati).Weekday())
+
+	db.EXPECT().GetChannels().Return([]database.Channel{channel}, nil)
+	expectChannelClock(outputService)
+	db.EXPECT().ListEvents(mock.Anything).Return([]database.Event{*testDatabaseEvent()}, nil)
+	outputService.EXPECT().SendDailyReminder(mock.Anything, testOutput()).Return(nil)
+	db.EXPECT().UpdateOutput(mock.Anything).Return(nil, nil)
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5)
+
+	err = service.Stop()
+	require.NoError(t, err)
+}
+
+func TestService_SendOutDailyRemindersOnDigestDayOfOutput(t *testing.T) {
+	service, db, outputService := testDaemon(t, false, true, false)
+
+	// The channel is at least one day behind Kiritimati (UTC+14).
+	kiritimati, err := time.LoadLocation("Pacific/Kiritimati")
+	require.NoError(t, err)
+
+	channel := testDatabaseChannel()
+	channel.TimeZone = "Etc/GMT+12"
+	channel.Digest.Weekdays = database.WeekdayMask(time.Now().In(kiritimati).Weekday())
+
+	outputInKiritimati := testDatabaseOutput()
+	outputInKiritimati.ID = 13
+	outputInKiritimati.OutputID = 2
+	channel.Outputs = append(channel.Outputs, *outputInKiritimati)
+
+	db.EXPECT().GetChannels().Return([]database.Channel{channel}, nil)
+	db.EXPECT().ListEvents(mock.Anything).Return([]database.Event{*testDatabaseEvent()}, nil)
+	outputService.EXPECT().ToLocalTime(mock.Anything, testOutput()).RunAndReturn(
+		func(t time.Time, _ *daemon.Output) time.Time {
+			return t
+		},
+	)
+	outputService.EXPECT().ToLocalTime(mock.Anything, &daemon.Output{ID: 13, OutputType: "test", OutputID: 2}).RunAndReturn(
+		func(t time.Time, _ *daemon.Output) time.Time {
+			return t.In(kiritimati)
+		},
+	)
+	outputService.EXPECT().SendDailyReminder(mock.Anything, &daemon.Output{ID: 13, OutputType: "test", OutputID: 2}).Return(nil)
+	db.EXPECT().UpdateOutput(mock.MatchedBy(func(output *database.Output) bool {
+		return output.ID == 13
+	})).Return(nil, nil)
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5)
+
+	err = service.Stop()
+	require.NoError(t, err)
+}
+
+func TestService_SendOutDailyRemindersSkipEmpty(t *testing.T) {
+	service, db, outputService := testDaemon(t, false, true, false)
+
+	channel := testDatabaseChannel()
+	channel.Digest.SkipEmpty = true
+
+	db.EXPECT().GetChannels().Return([]database.Channel{channel}, nil)
+	expectChannelClock(outputService)
+	db.EXPECT().ListEvents(mock.Anything).Return([]database.Event{}, nil)
+	db.EXPECT().UpdateOutput(mock.MatchedBy(func(output *database.Output) bool {
+		return output.LastDailyReminder != nil
+	})).Return(nil, nil)
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5)
+
+	err := service.Stop()
+	require.NoError(t, err)
+}
+
+func TestDailyReminder_IsEmpty(t *testing.T) {
+	assert.True(t, (&daemon.DailyReminder{}).IsEmpty())
+	assert.False(t, (&daemon.DailyReminder{Events: []daemon.Event{*testEvent()}}).IsEmpty())
+	assert.False(t, (&daemon.DailyReminder{Overdue: []daemon.Event{*testEvent()}}).IsEmpty())
+}
+
+func TestDailyReminder_RecurringCount(t *testing.T) {
+	dailyReminder := &daemon.DailyReminder{
+		Events:  []daemon.Event{*testEvent(), {Message: "once"}},
+		Overdue: []daemon.Event{{Message: "rule", RepeatRule: "FREQ=DAILY"}},
+	}
+
+	assert.Equal(t, 2, dailyReminder.RecurringCount())
+}
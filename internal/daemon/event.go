@@ -10,7 +10,10 @@ func eventFromDatabase(event *database.Event) *Event {
 		EventTime:      event.Time,
 		Message:        event.Message,
 		RepeatInterval: event.RepeatInterval,
+		RepeatRule:     event.RepeatRule,
 		RepeatUntil:    event.RepeatUntil,
 		Importance:     Importance(event.Importance),
+		CreatedBy:      event.CreatedBy,
+		AssignedTo:     event.AssignedTo,
 	}
 }
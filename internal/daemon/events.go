@@ -6,6 +6,13 @@ import (
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 )
 
+// Delivery states reported in the metrics.
+const (
+	deliveryStatusDelivered = "delivered"
+	deliveryStatusFailed    = "failed"
+	deliveryStatusGaveUp    = "gave_up"
+)
+
 func (service *service) sendOutEvents() error {
 	events, err := service.database.GetEventsPending()
 	if err != nil {
@@ -18,34 +25,8 @@ func (service *service) sendOutEvents() error {
 		Add(float64(len(events)))
 
 	for _, event := range events {
-		eventSuccess := true
-
-		for j := range event.Channel.Outputs {
-			outputService, ok := service.config.OutputServices[event.Channel.Outputs[j].OutputType]
-
-			if !ok {
-				service.logger.Error("unknown output type", "output.type", event.Channel.Outputs[j].OutputType)
-				continue
-			}
-
-			err = outputService.SendReminder(eventFromDatabase(&event), outputFromDatabase(&event.Channel.Outputs[j]))
-			if err != nil {
-				eventSuccess = false
-
-				service.logger.Error("failed to send reminder to output",
-					"error", err,
-					"output.id", event.Channel.Outputs[j].OutputID,
-					"output.type", event.Channel.Outputs[j].OutputType)
-
-				continue
-			}
-		}
-
-		if !eventSuccess {
-			// Try again later.
-			//
-			// This approach is only viable as there is only 1 output (matrix channel) possible currently. The iCal
-			// output is no-op. Adding more outputs requires another solution here!
+		if !service.deliverEvent(&event) {
+			// Outputs that failed are retried later, the others already got the reminder.
 			continue
 		}
 
@@ -56,10 +37,17 @@ func (service *service) sendOutEvents() error {
 				event.Active = false
 			} else {
 				// Important events are rescheduled according to the configured interval until they are acknowledged.
+				if !service.escalate(&event) {
+					// Escalation outputs that failed are retried later, the event is rescheduled afterwards.
+					continue
+				}
+
+				event.Resends++
 				event.Time = event.Time.Add(service.config.ResendUnacknowledgedInterval)
 			}
 		} else {
 			event.Time = nextTime
+			event.Resends = 0
 		}
 
 		_, err = service.database.UpdateEvent(&event)
@@ -71,3 +59,207 @@ func (service *service) sendOutEvents() error {
 
 	return nil
 }
+
+// deliverEvent sends the event to all outputs it was not delivered to yet. Returns true once every output either
+// received the event or delivery was given up.
+func (service *service) deliverEvent(event *database.Event) bool {
+	done, _ := service.deliver(event, eventFromDatabase(event), event.Channel.Outputs, 0, 0)
+
+	return done
+}
+
+// deliver sends the daemon event to the given outputs. Deliveries are tracked per output for the occurrence of the
+// event, the lead time and the escalation level, both zero for the event itself. Returns true once every output
+// either received the event or delivery was given up and the number of outputs that received the event.
+func (service *service) deliver(
+	event *database.Event,
+	daemonEvent *Event,
+	outputs []database.Output,
+	leadTime time.Duration,
+	escalationLevel uint,
+) (bool, uint) {
+	if isDeferred(event, time.Now()) {
+		service.logger.Debug("deferring reminder", "reason", "quiet hours", "event.id", event.ID, "lead_time", leadTime)
+		return false, 0
+	}
+
+	deliveries, err := service.database.ListEventDeliveries(event.ID, event.Time, leadTime, escalationLevel)
+	if err != nil {
+		service.logger.Error("failed to list event deliveries", "error", err, "event.id", event.ID)
+		return false, 0
+	}
+
+	deliveriesByOutput := make(map[uint]*database.EventDelivery)
+	for i := range deliveries {
+		deliveriesByOutput[deliveries[i].OutputID] = &deliveries[i]
+	}
+
+	done := true
+	delivered := uint(0)
+
+	for j := range outputs {
+		output := &outputs[j]
+
+		outputService, ok := service.config.OutputServices[output.OutputType]
+		if !ok {
+			service.logger.Error("unknown output type", "output.type", output.OutputType)
+			continue
+		}
+
+		delivery, ok := deliveriesByOutput[output.ID]
+		if !ok {
+			delivery = &database.EventDelivery{
+				EventID:         event.ID,
+				OutputID:        output.ID,
+				EventTime:       event.Time,
+				LeadTime:        leadTime,
+				EscalationLevel: escalationLevel,
+			}
+		}
+
+		if delivery.Delivered {
+			delivered++
+			continue
+		}
+
+		if delivery.GaveUp {
+			continue
+		}
+
+		if delivery.NextAttempt != nil && time.Now().Before(*delivery.NextAttempt) {
+			done = false
+			continue
+		}
+
+		err = outputService.SendReminder(daemonEvent, outputFromDatabase(output))
+		if !service.trackDelivery(delivery, err) {
+			done = false
+		}
+
+		if delivery.Delivered {
+			delivered++
+		}
+	}
+
+	return done, delivered
+}
+
+// isDeferred returns true if the event is not urgent and the channel is in its quiet hours. Deferred events are sent
+// once the quiet hours end, deferred pre-notifications lapse once the event starts.
+func isDeferred(event *database.Event, now time.Time) bool {
+	return event.Importance < database.ImportanceUrgent && event.Channel.IsQuiet(now)
+}
+
+// escalate notifies the escalation outputs of the channel if the event was sent again often enough without being
+// acknowledged. Deliveries are tracked per escalation level, returns true once every escalation output either
+// received the escalation or delivery was given up.
+func (service *service) escalate(event *database.Event) bool {
+	level := event.Channel.EscalationLevel(event.Resends)
+	if level == 0 {
+		return true
+	}
+
+	outputs, err := service.escalationOutputs(&event.Channel)
+	if err != nil {
+		service.logger.Error("failed to get escalation outputs", "error", err, "event.id", event.ID)
+		return false
+	}
+
+	escalatedEvent := eventFromDatabase(event)
+	escalatedEvent.Escalation = &Escalation{
+		Level:    level,
+		Resends:  event.Resends,
+		Mentions: event.Channel.EscalationUsers(),
+	}
+
+	done, notified := service.deliver(event, escalatedEvent, outputs, 0, level)
+	if !done {
+		return false
+	}
+
+	_, err = service.database.NewEventEscalation(&database.EventEscalation{
+		EventID:  event.ID,
+		Level:    level,
+		Resends:  event.Resends,
+		Outputs:  notified,
+		Mentions: event.Channel.EscalationMentions,
+	})
+	if err != nil {
+		service.logger.Error("failed to save event escalation", "error", err, "event.id", event.ID)
+	}
+
+	return true
+}
+
+// escalationOutputs returns the outputs escalations of the channel are sent to.
+func (service *service) escalationOutputs(channel *database.Channel) ([]database.Output, error) {
+	switch {
+	case channel.EscalationOutputID != nil:
+		output, err := service.database.GetOutputByID(*channel.EscalationOutputID)
+		if err != nil {
+			return nil, err
+		}
+
+		return []database.Output{*output}, nil
+	case channel.EscalationChannelID != nil:
+		escalationChannel, err := service.database.GetChannelByID(*channel.EscalationChannelID)
+		if err != nil {
+			return nil, err
+		}
+
+		return escalationChannel.Outputs, nil
+	}
+
+	return channel.Outputs, nil
+}
+
+// trackDelivery stores the result of a delivery attempt and returns true if no further attempts are required.
+func (service *service) trackDelivery(delivery *database.EventDelivery, sendErr error) bool {
+	delivery.Attempts++
+
+	status := deliveryStatusDelivered
+
+	switch {
+	case sendErr == nil:
+		delivery.Delivered = true
+	case delivery.Attempts >= service.config.MaxDeliveryAttempts:
+		status = deliveryStatusGaveUp
+		delivery.GaveUp = true
+		delivery.LastError = sendErr.Error()
+
+		service.logger.Error("giving up sending reminder to output",
+			"error", sendErr,
+			"event.id", delivery.EventID,
+			"output.id", delivery.OutputID,
+			"attempts", delivery.Attempts)
+	default:
+		status = deliveryStatusFailed
+		delivery.LastError = sendErr.Error()
+		delivery.NextAttempt = new(time.Now().Add(service.deliveryBackoff(delivery.Attempts)))
+
+		service.logger.Error("failed to send reminder to output",
+			"error", sendErr,
+			"event.id", delivery.EventID,
+			"output.id", delivery.OutputID,
+			"attempts", delivery.Attempts)
+	}
+
+	service.metricEventDeliveries.WithLabelValues(status).Inc()
+
+	_, err := service.database.SaveEventDelivery(delivery)
+	if err != nil {
+		service.logger.Error("failed to save event delivery", "error", err, "event.id", delivery.EventID)
+	}
+
+	return status != deliveryStatusFailed
+}
+
+// deliveryBackoff doubles the time between attempts, starting at the configured backoff.
+func (service *service) deliveryBackoff(attempts uint) time.Duration {
+	backoff := service.config.DeliveryRetryBackoff
+	for i := uint(1); i < attempts && backoff < maxDeliveryRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, maxDeliveryRetryBackoff)
+}
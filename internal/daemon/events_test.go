@@ -8,6 +8,7 @@ import (
 
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/daemon"
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -16,6 +17,10 @@ func testDaemon(t *testing.T, events, daily, cleanup bool) (daemon.Service, *dat
 	db := database.NewMockService(t)
 	outputService := daemon.NewMockOutputService(t)
 
+	if events {
+		db.EXPECT().GetPreNotificationsPending().Return([]database.PendingPreNotification{}, nil).Maybe()
+	}
+
 	return daemon.New(&daemon.Config{
 		OutputServices: map[string]daemon.OutputService{
 			"test": outputService,
@@ -142,7 +147,9 @@ func TestService_SendOutEvents(t *testing.T) {
 
 	event := testDatabaseEvent()
 	db.EXPECT().GetEventsPending().Return([]database.Event{*event}, nil)
+	db.EXPECT().ListEventDeliveries(uint(0), event.Time, time.Duration(0), uint(0)).Return([]database.EventDelivery{}, nil)
 	outputService.EXPECT().SendReminder(testEvent(), testOutput()).Return(nil)
+	db.EXPECT().SaveEventDelivery(mock.MatchedBy(deliveryMatcher(true, false, 1))).Return(nil, nil)
 
 	event.Active = false
 	db.EXPECT().UpdateEvent(event).Return(nil, nil)
@@ -160,12 +167,249 @@ func TestService_SendOutEventsWithImportantEvent(t *testing.T) {
 
 	event := testDatabaseEvent(testDatabaseEventWithImportanceImportant())
 	db.EXPECT().GetEventsPending().Return([]database.Event{*event}, nil)
+	db.EXPECT().ListEventDeliveries(uint(0), event.Time, time.Duration(0), uint(0)).Return([]database.EventDelivery{}, nil)
+	outputService.EXPECT().SendReminder(
+		testEvent(testEventWithImportanceImportant()),
+		testOutput(),
+	).Return(nil)
+	db.EXPECT().SaveEventDelivery(mock.MatchedBy(deliveryMatcher(true, false, 1))).Return(nil, nil)
+
+	event.Time = testEvent().EventTime.Add(time.Hour)
+	event.Resends = 1
+	db.EXPECT().UpdateEvent(event).Return(nil, nil)
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5) // give time to execute
+
+	err := service.Stop()
+	require.NoError(t, err)
+}
+
+func TestService_SendOutEventsWithEscalation(t *testing.T) {
+	service, db, outputService := testDaemon(t, true, false, false)
+
+	event := testDatabaseEvent(testDatabaseEventWithImportanceImportant(), func(e *database.Event) {
+		e.Resends = 2
+		e.Channel.EscalateAfter = new(uint(2))
+		e.Channel.EscalationMentions = "@alice:example.com"
+	})
+	db.EXPECT().GetEventsPending().Return([]database.Event{*event}, nil)
+	db.EXPECT().ListEventDeliveries(uint(0), event.Time, time.Duration(0), uint(0)).Return([]database.EventDelivery{}, nil)
+	outputService.EXPECT().SendReminder(
+		testEvent(testEventWithImportanceImportant()),
+		testOutput(),
+	).Return(nil)
+	db.EXPECT().SaveEventDelivery(mock.MatchedBy(deliveryMatcher(true, false, 1))).Return(nil, nil)
+
+	db.EXPECT().ListEventDeliveries(uint(0), event.Time, time.Duration(0), uint(1)).Return([]database.EventDelivery{}, nil)
+	outputService.EXPECT().SendReminder(
+		testEvent(testEventWithImportanceImportant(), func(e *daemon.Event) {
+			e.Escalation = &daemon.Escalation{
+				Level:    1,
+				Resends:  2,
+				Mentions: []string{"@alice:example.com"},
+			}
+		}),
+		testOutput(),
+	).Return(nil)
+	db.EXPECT().SaveEventDelivery(mock.MatchedBy(escalationDeliveryMatcher(12, 1, true, 1))).Return(nil, nil)
+	db.EXPECT().NewEventEscalation(&database.EventEscalation{
+		Level:    1,
+		Resends:  2,
+		Outputs:  1,
+		Mentions: "@alice:example.com",
+	}).Return(nil, nil)
+
+	event.Time = testEvent().EventTime.Add(time.Hour)
+	event.Resends = 3
+	db.EXPECT().UpdateEvent(event).Return(nil, nil)
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5) // give time to execute
+
+	err := service.Stop()
+	require.NoError(t, err)
+}
+
+func TestService_SendOutEventsWithEscalationToOutput(t *testing.T) {
+	service, db, outputService := testDaemon(t, true, false, false)
+
+	event := testDatabaseEvent(testDatabaseEventWithImportanceImportant(), func(e *database.Event) {
+		e.Resends = 4
+		e.Channel.EscalateAfter = new(uint(2))
+		e.Channel.EscalationOutputID = new(uint(13))
+	})
+	db.EXPECT().GetEventsPending().Return([]database.Event{*event}, nil)
+	db.EXPECT().ListEventDeliveries(uint(0), event.Time, time.Duration(0), uint(0)).Return([]database.EventDelivery{}, nil)
+	outputService.EXPECT().SendReminder(
+		testEvent(testEventWithImportanceImportant()),
+		testOutput(),
+	).Return(nil)
+	db.EXPECT().SaveEventDelivery(mock.MatchedBy(deliveryMatcher(true, false, 1))).Return(nil, nil)
+
+	escalationOutput := testDatabaseOutput()
+	escalationOutput.ID = 13
+	escalationOutput.OutputID = 2
+	db.EXPECT().GetOutputByID(uint(13)).Return(escalationOutput, nil)
+	db.EXPECT().ListEventDeliveries(uint(0), event.Time, time.Duration(0), uint(2)).Return([]database.EventDelivery{}, nil)
+	outputService.EXPECT().SendReminder(
+		testEvent(testEventWithImportanceImportant(), func(e *daemon.Event) {
+			e.Escalation = &daemon.Escalation{
+				Level:    2,
+				Resends:  4,
+				Mentions: []string{},
+			}
+		}),
+		&daemon.Output{
+			ID:         13,
+			OutputType: "test",
+			OutputID:   2,
+		},
+	).Return(errors.New("test"))
+	db.EXPECT().SaveEventDelivery(mock.MatchedBy(func(delivery *database.EventDelivery) bool {
+		return escalationDeliveryMatcher(13, 2, false, 1)(delivery) && delivery.NextAttempt != nil
+	})).Return(nil, nil)
+
+	// The event is not rescheduled until the escalation is delivered.
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5) // give time to execute
+
+	err := service.Stop()
+	require.NoError(t, err)
+}
+
+func TestService_SendOutEventsWithEscalationRetry(t *testing.T) {
+	service, db, outputService := testDaemon(t, true, false, false)
+
+	event := testDatabaseEvent(testDatabaseEventWithImportanceImportant(), func(e *database.Event) {
+		e.Resends = 4
+		e.Channel.EscalateAfter = new(uint(2))
+		e.Channel.EscalationOutputID = new(uint(13))
+	})
+	db.EXPECT().GetEventsPending().Return([]database.Event{*event}, nil)
+	db.EXPECT().ListEventDeliveries(uint(0), event.Time, time.Duration(0), uint(0)).Return([]database.EventDelivery{
+		{
+			OutputID:  12,
+			EventTime: event.Time,
+			Delivered: true,
+			Attempts:  1,
+		},
+	}, nil)
+
+	escalationOutput := testDatabaseOutput()
+	escalationOutput.ID = 13
+	escalationOutput.OutputID = 2
+	db.EXPECT().GetOutputByID(uint(13)).Return(escalationOutput, nil)
+	db.EXPECT().ListEventDeliveries(uint(0), event.Time, time.Duration(0), uint(2)).Return([]database.EventDelivery{
+		{
+			OutputID:        13,
+			EventTime:       event.Time,
+			EscalationLevel: 2,
+			Attempts:        1,
+			NextAttempt:     new(time.Now().Add(-time.Minute)),
+		},
+	}, nil)
+
+	// Only the escalation is sent again.
+	outputService.EXPECT().SendReminder(
+		testEvent(testEventWithImportanceImportant(), func(e *daemon.Event) {
+			e.Escalation = &daemon.Escalation{
+				Level:    2,
+				Resends:  4,
+				Mentions: []string{},
+			}
+		}),
+		&daemon.Output{
+			ID:         13,
+			OutputType: "test",
+			OutputID:   2,
+		},
+	).Return(nil)
+	db.EXPECT().SaveEventDelivery(mock.MatchedBy(escalationDeliveryMatcher(13, 2, true, 2))).Return(nil, nil)
+	db.EXPECT().NewEventEscalation(&database.EventEscalation{
+		Level:   2,
+		Resends: 4,
+		Outputs: 1,
+	}).Return(nil, nil)
+
+	event.Time = testEvent().EventTime.Add(time.Hour)
+	event.Resends = 5
+	db.EXPECT().UpdateEvent(event).Return(nil, nil)
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5) // give time to execute
+
+	err := service.Stop()
+	require.NoError(t, err)
+}
+
+func TestService_SendOutEventsWithEscalationToChannel(t *testing.T) {
+	service, db, outputService := testDaemon(t, true, false, false)
+
+	event := testDatabaseEvent(testDatabaseEventWithImportanceImportant(), func(e *database.Event) {
+		e.Resends = 1
+		e.Channel.EscalateAfter = new(uint(1))
+		e.Channel.EscalationChannelID = new(uint(5))
+	})
+	db.EXPECT().GetEventsPending().Return([]database.Event{*event}, nil)
+	db.EXPECT().ListEventDeliveries(uint(0), event.Time, time.Duration(0), uint(0)).Return([]database.EventDelivery{}, nil)
 	outputService.EXPECT().SendReminder(
 		testEvent(testEventWithImportanceImportant()),
 		testOutput(),
 	).Return(nil)
+	db.EXPECT().SaveEventDelivery(mock.MatchedBy(deliveryMatcher(true, false, 1))).Return(nil, nil)
+
+	// The event is not rescheduled, the escalation is retried with the next run.
+	db.EXPECT().GetChannelByID(uint(5)).Return(nil, errors.New("test"))
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5) // give time to execute
+
+	err := service.Stop()
+	require.NoError(t, err)
+}
+
+func TestService_SendOutEventsWithQuietHours(t *testing.T) {
+	service, db, _ := testDaemon(t, true, false, false)
+
+	event := testDatabaseEvent()
+	event.Channel.DoNotDisturbUntil = new(time.Now().Add(time.Hour))
+	db.EXPECT().GetEventsPending().Return([]database.Event{*event}, nil)
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5) // give time to execute
+
+	err := service.Stop()
+	require.NoError(t, err)
+}
+
+func TestService_SendOutEventsWithUrgentEventInQuietHours(t *testing.T) {
+	service, db, outputService := testDaemon(t, true, false, false)
+
+	event := testDatabaseEvent(func(e *database.Event) {
+		e.Importance = database.ImportanceUrgent
+		e.Channel.QuietHoursStart = new(uint(0))
+		e.Channel.QuietHoursEnd = new(uint(24 * 60))
+	})
+	db.EXPECT().GetEventsPending().Return([]database.Event{*event}, nil)
+	db.EXPECT().ListEventDeliveries(uint(0), event.Time, time.Duration(0), uint(0)).Return([]database.EventDelivery{}, nil)
+	outputService.EXPECT().SendReminder(
+		testEvent(func(e *daemon.Event) {
+			e.Importance = daemon.ImportanceUrgent
+		}),
+		testOutput(),
+	).Return(nil)
+	db.EXPECT().SaveEventDelivery(mock.MatchedBy(deliveryMatcher(true, false, 1))).Return(nil, nil)
 
 	event.Time = testEvent().EventTime.Add(time.Hour)
+	event.Resends = 1
 	db.EXPECT().UpdateEvent(event).Return(nil, nil)
 
 	go service.Start() //nolint:errcheck
@@ -181,10 +425,12 @@ func TestService_SendOutEventsWithRecurringEvent(t *testing.T) {
 
 	event := testDatabaseEvent(testDatabaseEventWithRecurring(time.Hour, time2123().Add(time.Hour*12)))
 	db.EXPECT().GetEventsPending().Return([]database.Event{*event}, nil)
+	db.EXPECT().ListEventDeliveries(uint(0), event.Time, time.Duration(0), uint(0)).Return([]database.EventDelivery{}, nil)
 	outputService.EXPECT().SendReminder(
 		testEvent(testEventWithRecurring(time.Hour, time2123().Add(time.Hour*12))),
 		testOutput(),
 	).Return(nil)
+	db.EXPECT().SaveEventDelivery(mock.MatchedBy(deliveryMatcher(true, false, 1))).Return(nil, nil)
 
 	event.Time = testEvent().EventTime.Add(time.Hour)
 	db.EXPECT().UpdateEvent(event).Return(nil, nil)
@@ -214,7 +460,140 @@ func TestService_SendOutEventsWithOutputError(t *testing.T) {
 	service, db, outputService := testDaemon(t, true, false, false)
 
 	db.EXPECT().GetEventsPending().Return([]database.Event{*testDatabaseEvent()}, nil)
+	db.EXPECT().ListEventDeliveries(uint(0), time2123(), time.Duration(0), uint(0)).Return([]database.EventDelivery{}, nil)
+	outputService.EXPECT().SendReminder(testEvent(), testOutput()).Return(errors.New("test"))
+	db.EXPECT().SaveEventDelivery(mock.MatchedBy(func(delivery *database.EventDelivery) bool {
+		return !delivery.Delivered && !delivery.GaveUp && delivery.Attempts == 1 &&
+			delivery.LastError == "test" &&
+			delivery.NextAttempt != nil && delivery.NextAttempt.After(time.Now())
+	})).Return(nil, nil)
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5) // give time to execute
+
+	err := service.Stop()
+	require.NoError(t, err)
+}
+
+func deliveryMatcher(delivered, gaveUp bool, attempts uint) func(*database.EventDelivery) bool {
+	return func(delivery *database.EventDelivery) bool {
+		return delivery.OutputID == 12 &&
+			delivery.EscalationLevel == 0 &&
+			delivery.EventTime.Equal(time2123()) &&
+			delivery.Delivered == delivered &&
+			delivery.GaveUp == gaveUp &&
+			delivery.Attempts == attempts
+	}
+}
+
+func escalationDeliveryMatcher(outputID uint, level uint, delivered bool, attempts uint) func(*database.EventDelivery) bool {
+	return func(delivery *database.EventDelivery) bool {
+		return delivery.OutputID == outputID &&
+			delivery.EscalationLevel == level &&
+			delivery.EventTime.Equal(time2123()) &&
+			delivery.Delivered == delivered &&
+			delivery.Attempts == attempts
+	}
+}
+
+func TestService_SendOutEventsWithPartialDelivery(t *testing.T) {
+	service, db, outputService := testDaemon(t, true, false, false)
+
+	secondOutput := testDatabaseOutput()
+	secondOutput.ID = 13
+	secondOutput.OutputID = 2
+
+	event := testDatabaseEvent(func(e *database.Event) {
+		e.Channel.Outputs = append(e.Channel.Outputs, *secondOutput)
+	})
+	db.EXPECT().GetEventsPending().Return([]database.Event{*event}, nil)
+	db.EXPECT().ListEventDeliveries(uint(0), event.Time, time.Duration(0), uint(0)).Return([]database.EventDelivery{
+		{
+			OutputID:  12,
+			EventTime: event.Time,
+			Delivered: true,
+			Attempts:  1,
+		},
+	}, nil)
+
+	// Only the output without successful delivery is retried.
+	outputService.EXPECT().SendReminder(testEvent(), &daemon.Output{
+		ID:         13,
+		OutputType: "test",
+		OutputID:   2,
+	}).Return(nil)
+	db.EXPECT().SaveEventDelivery(mock.MatchedBy(func(delivery *database.EventDelivery) bool {
+		return delivery.OutputID == 13 && delivery.Delivered && delivery.Attempts == 1
+	})).Return(nil, nil)
+
+	event.Active = false
+	db.EXPECT().UpdateEvent(event).Return(nil, nil)
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5) // give time to execute
+
+	err := service.Stop()
+	require.NoError(t, err)
+}
+
+func TestService_SendOutEventsWithPendingBackoff(t *testing.T) {
+	service, db, _ := testDaemon(t, true, false, false)
+
+	event := testDatabaseEvent()
+	db.EXPECT().GetEventsPending().Return([]database.Event{*event}, nil)
+	db.EXPECT().ListEventDeliveries(uint(0), event.Time, time.Duration(0), uint(0)).Return([]database.EventDelivery{
+		{
+			OutputID:    12,
+			EventTime:   event.Time,
+			Attempts:    1,
+			NextAttempt: new(time.Now().Add(time.Hour)),
+		},
+	}, nil)
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5) // give time to execute
+
+	err := service.Stop()
+	require.NoError(t, err)
+}
+
+func TestService_SendOutEventsWithGiveUp(t *testing.T) {
+	service, db, outputService := testDaemon(t, true, false, false)
+
+	event := testDatabaseEvent()
+	db.EXPECT().GetEventsPending().Return([]database.Event{*event}, nil)
+	db.EXPECT().ListEventDeliveries(uint(0), event.Time, time.Duration(0), uint(0)).Return([]database.EventDelivery{
+		{
+			OutputID:    12,
+			EventTime:   event.Time,
+			Attempts:    9,
+			NextAttempt: new(time.Now().Add(-time.Minute)),
+		},
+	}, nil)
 	outputService.EXPECT().SendReminder(testEvent(), testOutput()).Return(errors.New("test"))
+	db.EXPECT().SaveEventDelivery(mock.MatchedBy(deliveryMatcher(false, true, 10))).Return(nil, nil)
+
+	// The event is handled as sent once all outputs gave up.
+	event.Active = false
+	db.EXPECT().UpdateEvent(event).Return(nil, nil)
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5) // give time to execute
+
+	err := service.Stop()
+	require.NoError(t, err)
+}
+
+func TestService_SendOutEventsWithDeliveriesError(t *testing.T) {
+	service, db, _ := testDaemon(t, true, false, false)
+
+	event := testDatabaseEvent()
+	db.EXPECT().GetEventsPending().Return([]database.Event{*event}, nil)
+	db.EXPECT().ListEventDeliveries(uint(0), event.Time, time.Duration(0), uint(0)).Return(nil, errors.New("test"))
 
 	go service.Start() //nolint:errcheck
 
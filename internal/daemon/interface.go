@@ -17,6 +17,7 @@ type Importance int
 const (
 	ImportanceDefault   Importance = 0
 	ImportanceImportant Importance = 1
+	ImportanceUrgent    Importance = 2
 )
 
 // Event holds information about a reminder.
@@ -25,13 +26,52 @@ type Event struct {
 	EventTime      time.Time
 	Message        string
 	RepeatInterval *time.Duration
+	RepeatRule     string
 	RepeatUntil    *time.Time
 	Importance     Importance
+	StartsIn       *time.Duration // Only set for notifications sent ahead of the event.
+	CreatedBy      string
+	AssignedTo     string
+	Escalation     *Escalation // Only set for escalations of unacknowledged events.
+}
+
+// Escalation holds information about the escalation of an unacknowledged event.
+type Escalation struct {
+	Level    uint
+	Resends  uint     // Number of times the event was sent again without being acknowledged.
+	Mentions []string // Identifiers of the users to mention.
+}
+
+// IsRecurring returns true if the event repeats.
+func (event *Event) IsRecurring() bool {
+	return event.RepeatRule != "" || event.RepeatInterval != nil
 }
 
 // DailyReminder holds information about a daily reminder.
 type DailyReminder struct {
-	Events []Event
+	Events    []Event
+	Overdue   []Event       // Events that are due but not acknowledged yet, only filled if the channel asks for it.
+	Lookahead time.Duration // Range of upcoming events covered by Events.
+}
+
+// IsEmpty returns true if the daily reminder lists no events.
+func (dailyReminder *DailyReminder) IsEmpty() bool {
+	return len(dailyReminder.Events) == 0 && len(dailyReminder.Overdue) == 0
+}
+
+// RecurringCount returns the number of recurring events in the daily reminder.
+func (dailyReminder *DailyReminder) RecurringCount() int {
+	count := 0
+
+	for _, events := range [][]Event{dailyReminder.Events, dailyReminder.Overdue} {
+		for i := range events {
+			if events[i].IsRecurring() {
+				count++
+			}
+		}
+	}
+
+	return count
 }
 
 // Output holds information about the output.
@@ -0,0 +1,70 @@
+package daemon
+
+import (
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+)
+
+func (service *service) sendOutPreNotifications() error {
+	preNotifications, err := service.database.GetPreNotificationsPending()
+	if err != nil {
+		return err
+	}
+
+	service.metricPreNotificationsProcessed.WithLabelValues().
+		Add(float64(len(preNotifications)))
+
+	// Multiple lead times of the same event can be due at once, e.g. if the event was created shortly before it
+	// starts. Only one notification is sent for those.
+	eventIDs := []uint{}
+	preNotificationsByEvent := make(map[uint][]database.PendingPreNotification)
+
+	for _, preNotification := range preNotifications {
+		if _, ok := preNotificationsByEvent[preNotification.Event.ID]; !ok {
+			eventIDs = append(eventIDs, preNotification.Event.ID)
+		}
+
+		preNotificationsByEvent[preNotification.Event.ID] = append(preNotificationsByEvent[preNotification.Event.ID], preNotification)
+	}
+
+	for _, eventID := range eventIDs {
+		eventPreNotifications := preNotificationsByEvent[eventID]
+		event := &eventPreNotifications[0].Event
+
+		if !service.deliverPreNotification(event, shortestLeadTime(eventPreNotifications)) {
+			// Outputs that failed are retried later, the others already got the pre-notification.
+			continue
+		}
+
+		for _, preNotification := range eventPreNotifications {
+			err = service.database.MarkPreNotificationSent(event.ID, event.Time, preNotification.LeadTime)
+			if err != nil {
+				service.logger.Error("failed marking pre-notification as sent", "error", err, "event.id", event.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// deliverPreNotification sends the pre-notification to all outputs it was not delivered to yet. Deliveries are
+// tracked by the lead time the notification is sent for.
+func (service *service) deliverPreNotification(event *database.Event, leadTime time.Duration) bool {
+	daemonEvent := eventFromDatabase(event)
+	daemonEvent.StartsIn = new(time.Until(event.Time).Round(time.Minute))
+
+	done, _ := service.deliver(event, daemonEvent, event.Channel.Outputs, leadTime, 0)
+
+	return done
+}
+
+// shortestLeadTime returns the lead time of the most recent of the due pre-notifications.
+func shortestLeadTime(preNotifications []database.PendingPreNotification) time.Duration {
+	leadTime := preNotifications[0].LeadTime
+	for _, preNotification := range preNotifications[1:] {
+		leadTime = min(leadTime, preNotification.LeadTime)
+	}
+
+	return leadTime
+}
@@ -0,0 +1,161 @@
+package daemon_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/daemon"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testPreNotificationDaemon(t *testing.T) (daemon.Service, *database.MockService, *daemon.MockOutputService) {
+	t.Helper()
+	db := database.NewMockService(t)
+	outputService := daemon.NewMockOutputService(t)
+
+	db.EXPECT().GetEventsPending().Return([]database.Event{}, nil).Maybe()
+
+	return daemon.New(&daemon.Config{
+		OutputServices: map[string]daemon.OutputService{
+			"test": outputService,
+		},
+		EventsInterval:               time.Millisecond,
+		DailyReminderInterval:        time.Hour,
+		CleanupInterval:              time.Hour,
+		ResendUnacknowledgedInterval: time.Hour,
+	}, db, slog.Default()), db, outputService
+}
+
+func startsInMatcher(expected time.Duration) func(*daemon.Event) bool {
+	return func(event *daemon.Event) bool {
+		return event.StartsIn != nil && *event.StartsIn == expected && event.Message == "test"
+	}
+}
+
+func TestService_SendOutPreNotifications(t *testing.T) {
+	service, db, outputService := testPreNotificationDaemon(t)
+
+	event := testDatabaseEvent()
+	event.ID = 5
+	event.Time = time.Now().Add(15 * time.Minute)
+
+	db.EXPECT().GetPreNotificationsPending().Return([]database.PendingPreNotification{
+		{Event: *event, LeadTime: 15 * time.Minute},
+		{Event: *event, LeadTime: time.Hour},
+	}, nil).Once()
+	db.EXPECT().GetPreNotificationsPending().Return([]database.PendingPreNotification{}, nil).Maybe()
+
+	db.EXPECT().ListEventDeliveries(uint(5), event.Time, 15*time.Minute, uint(0)).Return([]database.EventDelivery{}, nil).Once()
+	outputService.EXPECT().SendReminder(mock.MatchedBy(startsInMatcher(15*time.Minute)), testOutput()).Return(nil).Once()
+	db.EXPECT().SaveEventDelivery(mock.MatchedBy(func(delivery *database.EventDelivery) bool {
+		return delivery.EventID == 5 && delivery.OutputID == 12 && delivery.LeadTime == 15*time.Minute && delivery.Delivered
+	})).Return(nil, nil).Once()
+
+	db.EXPECT().MarkPreNotificationSent(uint(5), event.Time, 15*time.Minute).Return(nil).Once()
+	db.EXPECT().MarkPreNotificationSent(uint(5), event.Time, time.Hour).Return(nil).Once()
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5) // give time to execute
+
+	err := service.Stop()
+	require.NoError(t, err)
+}
+
+func TestService_SendOutPreNotificationsWithOutputError(t *testing.T) {
+	service, db, outputService := testPreNotificationDaemon(t)
+
+	event := testDatabaseEvent()
+	event.Time = time.Now().Add(time.Hour)
+
+	db.EXPECT().GetPreNotificationsPending().Return([]database.PendingPreNotification{
+		{Event: *event, LeadTime: time.Hour},
+	}, nil).Once()
+	db.EXPECT().GetPreNotificationsPending().Return([]database.PendingPreNotification{}, nil).Maybe()
+
+	db.EXPECT().ListEventDeliveries(uint(0), event.Time, time.Hour, uint(0)).Return([]database.EventDelivery{}, nil).Once()
+	outputService.EXPECT().SendReminder(mock.MatchedBy(startsInMatcher(time.Hour)), testOutput()).Return(errors.New("test")).Once()
+	db.EXPECT().SaveEventDelivery(mock.MatchedBy(func(delivery *database.EventDelivery) bool {
+		return delivery.LeadTime == time.Hour && !delivery.Delivered && delivery.NextAttempt != nil
+	})).Return(nil, nil).Once()
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5) // give time to execute
+
+	err := service.Stop()
+	require.NoError(t, err)
+}
+
+func TestService_SendOutPreNotificationsWithPartialDelivery(t *testing.T) {
+	service, db, outputService := testPreNotificationDaemon(t)
+
+	otherOutput := testDatabaseOutput()
+	otherOutput.ID = 13
+
+	event := testDatabaseEvent()
+	event.Time = time.Now().Add(time.Hour)
+	event.Channel.Outputs = append(event.Channel.Outputs, *otherOutput)
+
+	db.EXPECT().GetPreNotificationsPending().Return([]database.PendingPreNotification{
+		{Event: *event, LeadTime: time.Hour},
+	}, nil).Once()
+	db.EXPECT().GetPreNotificationsPending().Return([]database.PendingPreNotification{}, nil).Maybe()
+
+	// Only the output that did not get the pre-notification yet is retried.
+	db.EXPECT().ListEventDeliveries(uint(0), event.Time, time.Hour, uint(0)).Return([]database.EventDelivery{
+		{OutputID: 12, EventTime: event.Time, LeadTime: time.Hour, Delivered: true, Attempts: 1},
+		{OutputID: 13, EventTime: event.Time, LeadTime: time.Hour, Attempts: 1},
+	}, nil).Once()
+	outputService.EXPECT().SendReminder(mock.MatchedBy(startsInMatcher(time.Hour)), mock.MatchedBy(func(output *daemon.Output) bool {
+		return output.ID == 13
+	})).Return(nil).Once()
+	db.EXPECT().SaveEventDelivery(mock.MatchedBy(func(delivery *database.EventDelivery) bool {
+		return delivery.OutputID == 13 && delivery.Delivered && delivery.Attempts == 2
+	})).Return(nil, nil).Once()
+	db.EXPECT().MarkPreNotificationSent(uint(0), event.Time, time.Hour).Return(nil).Once()
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5) // give time to execute
+
+	err := service.Stop()
+	require.NoError(t, err)
+}
+
+func TestService_SendOutPreNotificationsWithDatabaseError(t *testing.T) {
+	service, db, _ := testPreNotificationDaemon(t)
+
+	db.EXPECT().GetPreNotificationsPending().Return(nil, errors.New("test"))
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5) // give time to execute
+
+	err := service.Stop()
+	require.NoError(t, err)
+}
+
+func TestService_SendOutPreNotificationsWithQuietHours(t *testing.T) {
+	service, db, _ := testPreNotificationDaemon(t)
+
+	event := testDatabaseEvent()
+	event.Time = time.Now().Add(time.Hour)
+	event.Channel.QuietHoursStart = new(uint(0))
+	event.Channel.QuietHoursEnd = new(uint(24 * 60))
+
+	db.EXPECT().GetPreNotificationsPending().Return([]database.PendingPreNotification{
+		{Event: *event, LeadTime: time.Hour},
+	}, nil)
+
+	go service.Start() //nolint:errcheck
+
+	time.Sleep(time.Millisecond * 5) // give time to execute
+
+	err := service.Stop()
+	require.NoError(t, err)
+}
@@ -29,6 +29,18 @@ var (
 		Help:      "Amount of events processed by the event daemon.",
 	}, []string{})
 
+	metricPreNotificationsProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "remindme",
+		Name:      "daemon_event_pre_notifications_processed_total",
+		Help:      "Amount of pre-notifications processed by the event daemon.",
+	}, []string{})
+
+	metricEventDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "remindme",
+		Name:      "daemon_event_deliveries_total",
+		Help:      "Amount of attempts to deliver events to outputs by status (delivered, failed, gave_up).",
+	}, []string{"status"})
+
 	metricLastCleanupRun = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "remindme",
 		Name:      "daemon_cleanup_last_run_timestamp_seconds",
@@ -53,6 +65,9 @@ type service struct {
 	metricLastDailyReminderRun *prometheus.GaugeVec
 	metricLastEventRun         *prometheus.GaugeVec
 	metricEventsProcessed      *prometheus.CounterVec
+	metricEventDeliveries      *prometheus.CounterVec
+
+	metricPreNotificationsProcessed *prometheus.CounterVec
 
 	metricLastCleanupRun *prometheus.GaugeVec
 	metricItemsCleaned   *prometheus.CounterVec
@@ -66,16 +81,33 @@ type OutputService interface {
 	Cleanup() error
 }
 
+// Defaults for delivering events to outputs.
+const (
+	defaultMaxDeliveryAttempts  = 10
+	defaultDeliveryRetryBackoff = time.Minute
+	maxDeliveryRetryBackoff     = 6 * time.Hour
+)
+
 type Config struct {
 	OutputServices               map[string]OutputService // Maps OutputTypes to the services
 	EventsInterval               time.Duration            // Interval in which to send out event reminders
 	DailyReminderInterval        time.Duration            // Interval in which to send out daily reminder
 	CleanupInterval              time.Duration            // Interval in which to run cleanup
 	ResendUnacknowledgedInterval time.Duration            // Duration to resend unacknowledged events again.
+	MaxDeliveryAttempts          uint                     // Attempts to deliver an event to an output before giving up.
+	DeliveryRetryBackoff         time.Duration            // Wait time after the first failed delivery, doubled with every attempt.
 }
 
 // New assembles a new service.
 func New(config *Config, database database.Service, logger *slog.Logger) Service {
+	if config.MaxDeliveryAttempts == 0 {
+		config.MaxDeliveryAttempts = defaultMaxDeliveryAttempts
+	}
+
+	if config.DeliveryRetryBackoff == 0 {
+		config.DeliveryRetryBackoff = defaultDeliveryRetryBackoff
+	}
+
 	return &service{
 		config:   config,
 		database: database,
@@ -87,6 +119,9 @@ func New(config *Config, database database.Service, logger *slog.Logger) Service
 		metricLastDailyReminderRun: metricLastDailyReminderRun,
 		metricLastEventRun:         metricLastEventRun,
 		metricEventsProcessed:      metricEventsProcessed,
+		metricEventDeliveries:      metricEventDeliveries,
+
+		metricPreNotificationsProcessed: metricPreNotificationsProcessed,
 
 		metricLastCleanupRun: metricLastCleanupRun,
 		metricItemsCleaned:   metricItemsCleaned,
@@ -117,6 +152,11 @@ func (service *service) startEventDaemon() {
 			if err != nil {
 				service.logger.Error("failed to send out events", "error", err)
 			}
+
+			err = service.sendOutPreNotifications()
+			if err != nil {
+				service.logger.Error("failed to send out pre-notifications", "error", err)
+			}
 		case <-service.done:
 			service.logger.Debug("event daemon stopped")
 			service.daemonWG.Done()
@@ -2,10 +2,67 @@ package database
 
 import (
 	"errors"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+// LocalTime returns the time in the timezone of the channel.
+func (channel *Channel) LocalTime(t time.Time) time.Time {
+	loc, err := time.LoadLocation(channel.TimeZone)
+	if err != nil {
+		return t.UTC()
+	}
+
+	return t.In(loc)
+}
+
+// IsQuiet returns true if reminders to the channel should be deferred at the given time.
+func (channel *Channel) IsQuiet(now time.Time) bool {
+	if channel.DoNotDisturbUntil != nil && now.Before(*channel.DoNotDisturbUntil) {
+		return true
+	}
+
+	if channel.QuietHoursStart == nil || channel.QuietHoursEnd == nil {
+		return false
+	}
+
+	localTime := channel.LocalTime(now)
+	minutes := uint(localTime.Hour()*60 + localTime.Minute())
+	start, end := *channel.QuietHoursStart, *channel.QuietHoursEnd
+
+	if start <= end {
+		return minutes >= start && minutes < end
+	}
+
+	// Quiet hours span midnight.
+	return minutes >= start || minutes < end
+}
+
+// EscalationLevel returns the level of the escalation due after the given number of resends of an unacknowledged
+// event. Returns 0 if no escalation is due.
+func (channel *Channel) EscalationLevel(resends uint) uint {
+	if channel.EscalateAfter == nil || *channel.EscalateAfter == 0 || resends == 0 || resends%*channel.EscalateAfter != 0 {
+		return 0
+	}
+
+	return resends / *channel.EscalateAfter
+}
+
+// EscalationUsers returns the users to mention on escalation.
+func (channel *Channel) EscalationUsers() []string {
+	users := []string{}
+
+	for user := range strings.SplitSeq(channel.EscalationMentions, ",") {
+		if user = strings.TrimSpace(user); user != "" {
+			users = append(users, user)
+		}
+	}
+
+	return users
+}
+
 func (service *service) NewChannel(channel *Channel) (*Channel, error) {
 	err := service.db.Create(&channel).Error
 	return channel, err
@@ -40,27 +97,35 @@ func (service *service) AddOutputToChannel(channelID uint, output *Output) error
 	return service.db.Save(output).Error
 }
 
-func (service *service) RemoveInputFromChannel(channelID, inputID uint) error {
-	tx := service.newSession()
-
-	input, err := tx.GetInputByID(inputID)
-	if err != nil {
-		return tx.rollbackWithError(err)
+// InputExists asks the connector of the input type whether it knows the input.
+func (service *service) InputExists(inputType string, inputID uint) (bool, error) {
+	inputService, ok := service.config.InputServices[inputType]
+	if !ok {
+		return false, ErrUnknownInput
 	}
 
-	// Inform the input service that we will delete the input
-	inputService, ok := service.config.InputServices[input.InputType]
+	return inputService.InputExists(inputType, inputID)
+}
+
+// OutputExists asks the connector of the output type whether it knows the output.
+func (service *service) OutputExists(outputType string, outputID uint) (bool, error) {
+	outputService, ok := service.config.OutputServices[outputType]
 	if !ok {
-		return tx.rollbackWithError(ErrUnknownInput)
+		return false, ErrUnknownOutput
 	}
 
-	err = inputService.InputRemoved(input.InputType, input.InputID)
+	return outputService.OutputExists(outputType, outputID)
+}
+
+func (service *service) RemoveInputFromChannel(channelID, inputID uint) error {
+	tx := service.newSession()
+
+	input, err := tx.GetInputByID(inputID)
 	if err != nil {
 		return tx.rollbackWithError(err)
 	}
 
-	// Delete the input permanently
-	err = tx.deleteInput(channelID, inputID)
+	err = tx.removeInput(channelID, input)
 	if err != nil {
 		return tx.rollbackWithError(err)
 	}
@@ -73,6 +138,22 @@ func (service *service) RemoveInputFromChannel(channelID, inputID uint) error {
 	return nil
 }
 
+func (service *service) removeInput(channelID uint, input *Input) error {
+	// Inform the input service that we will delete the input
+	inputService, ok := service.config.InputServices[input.InputType]
+	if !ok {
+		return ErrUnknownInput
+	}
+
+	err := inputService.InputRemoved(input.InputType, input.InputID)
+	if err != nil {
+		return err
+	}
+
+	// Delete the input permanently
+	return service.deleteInput(channelID, input.ID)
+}
+
 func (service *service) deleteInput(channelID, inputID uint) error {
 	return service.db.Unscoped().Where("id = ? AND channel_id = ?", inputID, channelID).Delete(&Input{}).Error
 }
@@ -85,29 +166,33 @@ func (service *service) RemoveOutputFromChannel(channelID, outputID uint) error
 		return tx.rollbackWithError(err)
 	}
 
-	// Inform the output service that we will delete the output
-	outputService, ok := service.config.OutputServices[output.OutputType]
-	if !ok {
-		return tx.rollbackWithError(ErrUnknownOutput)
-	}
-
-	err = outputService.OutputRemoved(output.OutputType, output.OutputID)
+	err = tx.removeOutput(channelID, output)
 	if err != nil {
 		return tx.rollbackWithError(err)
 	}
 
-	// Delete the output permanently
-	err = tx.deleteOutput(channelID, outputID)
+	err = tx.commit()
 	if err != nil {
 		return tx.rollbackWithError(err)
 	}
 
-	err = tx.commit()
+	return nil
+}
+
+func (service *service) removeOutput(channelID uint, output *Output) error {
+	// Inform the output service that we will delete the output
+	outputService, ok := service.config.OutputServices[output.OutputType]
+	if !ok {
+		return ErrUnknownOutput
+	}
+
+	err := outputService.OutputRemoved(output.OutputType, output.OutputID)
 	if err != nil {
-		return tx.rollbackWithError(err)
+		return err
 	}
 
-	return nil
+	// Delete the output permanently
+	return service.deleteOutput(channelID, output.ID)
 }
 
 func (service *service) deleteOutput(channelID, outputID uint) error {
@@ -120,15 +205,59 @@ func (service *service) UpdateChannel(channel *Channel) (*Channel, error) {
 	return channel, err
 }
 
+// DeleteChannel deletes the channel with all its events. In- and output services are informed about
+// the removal of the channel's in- and outputs.
 func (service *service) DeleteChannel(channelID uint) error {
-	result := service.db.Unscoped().Delete(&Channel{}, "id = ?", channelID)
+	tx := service.newSession()
+
+	channel, err := tx.GetChannelByID(channelID)
+	if err != nil {
+		return tx.rollbackWithError(err)
+	}
+
+	for _, input := range channel.Inputs {
+		err = tx.removeInput(channelID, &input)
+		if err != nil {
+			return tx.rollbackWithError(err)
+		}
+	}
+
+	for _, output := range channel.Outputs {
+		err = tx.removeOutput(channelID, &output)
+		if err != nil {
+			return tx.rollbackWithError(err)
+		}
+	}
+
+	err = tx.deleteChannelEvents(channelID)
+	if err != nil {
+		return tx.rollbackWithError(err)
+	}
+
+	result := tx.db.Unscoped().Delete(&Channel{}, "id = ?", channelID)
 	if result.Error != nil {
-		return result.Error
+		return tx.rollbackWithError(result.Error)
 	}
 
 	if result.RowsAffected != 1 {
-		return ErrNotFound
+		return tx.rollbackWithError(ErrNotFound)
+	}
+
+	err = tx.commit()
+	if err != nil {
+		return tx.rollbackWithError(err)
 	}
 
 	return nil
 }
+
+func (service *service) deleteChannelEvents(channelID uint) error {
+	err := service.db.Unscoped().
+		Where("channel_id = ? OR event_id IN (?)", channelID, service.db.Unscoped().Model(&Event{}).Select("id").Where("channel_id = ?", channelID)).
+		Delete(&PreNotification{}).Error
+	if err != nil {
+		return err
+	}
+
+	return service.db.Unscoped().Where("channel_id = ?", channelID).Delete(&Event{}).Error
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 	"github.com/stretchr/testify/assert"
@@ -51,6 +52,79 @@ func testOutput() *database.Output {
 	}
 }
 
+func TestChannel_IsQuiet(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2123, 1, 2, hour, minute, 0, 0, time.UTC)
+	}
+
+	assert.False(t, (&database.Channel{}).IsQuiet(at(3, 0)))
+
+	overnight := &database.Channel{
+		QuietHoursStart: new(uint(22 * 60)),
+		QuietHoursEnd:   new(uint(7 * 60)),
+	}
+	assert.True(t, overnight.IsQuiet(at(23, 0)))
+	assert.True(t, overnight.IsQuiet(at(3, 0)))
+	assert.False(t, overnight.IsQuiet(at(7, 0)))
+	assert.False(t, overnight.IsQuiet(at(12, 0)))
+
+	lunch := &database.Channel{
+		QuietHoursStart: new(uint(12 * 60)),
+		QuietHoursEnd:   new(uint(13 * 60)),
+	}
+	assert.True(t, lunch.IsQuiet(at(12, 30)))
+	assert.False(t, lunch.IsQuiet(at(13, 0)))
+	assert.False(t, lunch.IsQuiet(at(3, 0)))
+
+	dnd := &database.Channel{
+		DoNotDisturbUntil: new(at(9, 0)),
+	}
+	assert.True(t, dnd.IsQuiet(at(8, 59)))
+	assert.False(t, dnd.IsQuiet(at(9, 0)))
+
+	berlin := &database.Channel{
+		TimeZone:        "Europe/Berlin",
+		QuietHoursStart: new(uint(22 * 60)),
+		QuietHoursEnd:   new(uint(7 * 60)),
+	}
+	assert.True(t, berlin.IsQuiet(at(5, 30)))
+	assert.False(t, berlin.IsQuiet(at(6, 0)))
+}
+
+func TestChannel_LocalTime(t *testing.T) {
+	now := time.Date(2123, 1, 2, 23, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.UTC, (&database.Channel{}).LocalTime(now).Location())
+	assert.Equal(t, time.UTC, (&database.Channel{TimeZone: "unknown"}).LocalTime(now).Location())
+
+	local := (&database.Channel{TimeZone: "Europe/Berlin"}).LocalTime(now)
+	assert.Equal(t, 0, local.Hour())
+	assert.Equal(t, time.January, local.Month())
+	assert.Equal(t, 3, local.Day())
+}
+
+func TestChannel_EscalationLevel(t *testing.T) {
+	assert.Zero(t, (&database.Channel{}).EscalationLevel(3))
+
+	channel := &database.Channel{
+		EscalateAfter: new(uint(3)),
+	}
+	assert.Zero(t, channel.EscalationLevel(0))
+	assert.Zero(t, channel.EscalationLevel(2))
+	assert.Equal(t, uint(1), channel.EscalationLevel(3))
+	assert.Zero(t, channel.EscalationLevel(4))
+	assert.Equal(t, uint(2), channel.EscalationLevel(6))
+}
+
+func TestChannel_EscalationUsers(t *testing.T) {
+	assert.Empty(t, (&database.Channel{}).EscalationUsers())
+
+	channel := &database.Channel{
+		EscalationMentions: "@alice:example.com, @bob:example.com,,",
+	}
+	assert.Equal(t, []string{"@alice:example.com", "@bob:example.com"}, channel.EscalationUsers())
+}
+
 func TestService_NewChannel(t *testing.T) {
 	channelBefore := testChannel()
 
@@ -122,13 +196,50 @@ func TestService_AddOutputToChannel(t *testing.T) {
 	assert.Equal(t, outputBefore.Enabled, channelAfter.Outputs[0].Enabled)
 }
 
+func TestService_InputExists(t *testing.T) {
+	inputService := database.NewMockInputService(t)
+	inputService.EXPECT().InputExists("test", uint(3)).Return(true, nil)
+
+	service := getService(&database.Config{
+		Driver:        config.Driver,
+		Connection:    config.Connection,
+		InputServices: map[string]database.InputService{"test": inputService},
+	})
+
+	exists, err := service.InputExists("test", 3)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	_, err = service.InputExists("unknown", 3)
+	require.ErrorIs(t, err, database.ErrUnknownInput)
+}
+
+func TestService_OutputExists(t *testing.T) {
+	outputService := database.NewMockOutputService(t)
+	outputService.EXPECT().OutputExists("test", uint(3)).Return(false, nil)
+
+	service := getService(&database.Config{
+		Driver:         config.Driver,
+		Connection:     config.Connection,
+		OutputServices: map[string]database.OutputService{"test": outputService},
+	})
+
+	exists, err := service.OutputExists("test", 3)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = service.OutputExists("unknown", 3)
+	require.ErrorIs(t, err, database.ErrUnknownOutput)
+}
+
 func TestService_RemoveInputFromChannel(t *testing.T) { //nolint:dupl // wrong hint
 	input := testInput()
 	inputService := database.NewMockInputService(t)
 	inputService.EXPECT().InputRemoved(input.InputType, input.InputID).Return(nil)
 
 	service := getService(&database.Config{
-		Connection:    getConnection(),
+		Driver:        config.Driver,
+		Connection:    config.Connection,
 		InputServices: map[string]database.InputService{"test": inputService},
 	})
 
@@ -159,7 +270,8 @@ func TestService_RemoveInputFromChannelWithInputServiceError(t *testing.T) {
 	inputService.EXPECT().InputRemoved(input.InputType, input.InputID).Return(expectedErr)
 
 	service := getService(&database.Config{
-		Connection:    getConnection(),
+		Driver:        config.Driver,
+		Connection:    config.Connection,
 		InputServices: map[string]database.InputService{"test": inputService},
 	})
 
@@ -204,7 +316,8 @@ func TestService_RemoveOutputFromChannel(t *testing.T) { //nolint:dupl // wrong
 	outputService.EXPECT().OutputRemoved(output.OutputType, output.OutputID).Return(nil)
 
 	service := getService(&database.Config{
-		Connection:     getConnection(),
+		Driver:         config.Driver,
+		Connection:     config.Connection,
 		OutputServices: map[string]database.OutputService{"test": outputService},
 	})
 
@@ -235,7 +348,8 @@ func TestService_RemoveOutputFromChannelWithOutputServiceError(t *testing.T) {
 	outputService.EXPECT().OutputRemoved(output.OutputType, output.OutputID).Return(expectedErr)
 
 	service := getService(&database.Config{
-		Connection:     getConnection(),
+		Driver:         config.Driver,
+		Connection:     config.Connection,
 		OutputServices: map[string]database.OutputService{"test": outputService},
 	})
 
@@ -324,3 +438,96 @@ func TestService_DeleteChannelWithChannelNotFound(t *testing.T) {
 	err := service.DeleteChannel(999999999)
 	assert.ErrorIs(t, err, database.ErrNotFound)
 }
+
+func TestService_DeleteChannelWithInputsOutputsAndEvents(t *testing.T) {
+	input := testInput()
+	output := testOutput()
+
+	inputService := database.NewMockInputService(t)
+	inputService.EXPECT().InputRemoved(input.InputType, input.InputID).Return(nil)
+
+	outputService := database.NewMockOutputService(t)
+	outputService.EXPECT().OutputRemoved(output.OutputType, output.OutputID).Return(nil)
+
+	service := getService(&database.Config{
+		Driver:         config.Driver,
+		Connection:     config.Connection,
+		InputServices:  map[string]database.InputService{"test": inputService},
+		OutputServices: map[string]database.OutputService{"test": outputService},
+	})
+
+	channel, err := service.NewChannel(testChannel())
+	require.NoError(t, err)
+
+	err = service.AddInputToChannel(channel.ID, input)
+	require.NoError(t, err)
+
+	err = service.AddOutputToChannel(channel.ID, output)
+	require.NoError(t, err)
+
+	event := testEvent()
+	event.ChannelID = channel.ID
+	event.Channel = *channel
+
+	event, err = service.NewEvent(event)
+	require.NoError(t, err)
+
+	_, err = service.NewPreNotification(&database.PreNotification{
+		EventID:  &event.ID,
+		LeadTime: time.Hour,
+	})
+	require.NoError(t, err)
+
+	err = service.DeleteChannel(channel.ID)
+	require.NoError(t, err)
+
+	_, err = service.GetChannelByID(channel.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	_, err = service.GetInputByID(input.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	_, err = service.GetOutputByID(output.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	events, err := service.ListEvents(&database.ListEventsOpts{
+		IDs:             []uint{event.ID},
+		IncludeInactive: true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	preNotifications, err := service.ListPreNotifications(&database.ListPreNotificationsOpts{
+		EventID: &event.ID,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, preNotifications)
+}
+
+func TestService_DeleteChannelWithInputServiceError(t *testing.T) {
+	input := testInput()
+	expectedErr := errors.New("test")
+
+	inputService := database.NewMockInputService(t)
+	inputService.EXPECT().InputRemoved(input.InputType, input.InputID).Return(expectedErr)
+
+	service := getService(&database.Config{
+		Driver:        config.Driver,
+		Connection:    config.Connection,
+		InputServices: map[string]database.InputService{"test": inputService},
+	})
+
+	channel, err := service.NewChannel(testChannel())
+	require.NoError(t, err)
+
+	err = service.AddInputToChannel(channel.ID, input)
+	require.NoError(t, err)
+
+	err = service.DeleteChannel(channel.ID)
+	require.ErrorIs(t, err, expectedErr)
+
+	channelAfter, err := service.GetChannelByID(channel.ID)
+	require.NoError(t, err)
+
+	assert.Len(t, channelAfter.Inputs, 1)
+}
@@ -8,6 +8,31 @@ type CleanupOpts struct {
 
 func (service *service) Cleanup(opts *CleanupOpts) (int64, error) {
 	result := service.db.Unscoped().Where("time < ? AND (repeat_until IS NULL OR repeat_until < ?)", time.Now().Add(-opts.OlderThan), time.Now().Add(-opts.OlderThan)).Delete(&Event{})
+	if result.Error != nil {
+		return result.RowsAffected, result.Error
+	}
 
-	return result.RowsAffected, result.Error
+	// Pre-notifications of the deleted events.
+	resultEventPreNotifications := service.db.Unscoped().
+		Where("event_id IS NOT NULL AND event_id NOT IN (?)", service.db.Unscoped().Model(&Event{}).Select("id")).
+		Delete(&PreNotification{})
+	result.RowsAffected += resultEventPreNotifications.RowsAffected
+
+	if resultEventPreNotifications.Error != nil {
+		return result.RowsAffected, resultEventPreNotifications.Error
+	}
+
+	resultPreNotifications := service.db.Unscoped().Where("event_time < ?", time.Now().Add(-opts.OlderThan)).Delete(&SentPreNotification{})
+	if resultPreNotifications.Error != nil {
+		return result.RowsAffected + resultPreNotifications.RowsAffected, resultPreNotifications.Error
+	}
+
+	resultDeliveries := service.db.Unscoped().Where("event_time < ?", time.Now().Add(-opts.OlderThan)).Delete(&EventDelivery{})
+	if resultDeliveries.Error != nil {
+		return result.RowsAffected + resultPreNotifications.RowsAffected + resultDeliveries.RowsAffected, resultDeliveries.Error
+	}
+
+	resultEscalations := service.db.Unscoped().Where("created_at < ?", time.Now().Add(-opts.OlderThan)).Delete(&EventEscalation{})
+
+	return result.RowsAffected + resultPreNotifications.RowsAffected + resultDeliveries.RowsAffected + resultEscalations.RowsAffected, resultEscalations.Error
 }
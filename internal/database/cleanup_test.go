@@ -31,6 +31,12 @@ func TestService_Cleanup(t *testing.T) {
 	})
 	require.NoError(t, err)
 
+	_, err = service.NewPreNotification(&database.PreNotification{
+		EventID:  &oldEvent.ID,
+		LeadTime: time.Hour,
+	})
+	require.NoError(t, err)
+
 	deleted, err := service.Cleanup(&database.CleanupOpts{
 		OlderThan: 24 * time.Hour,
 	})
@@ -44,4 +50,10 @@ func TestService_Cleanup(t *testing.T) {
 
 	require.Len(t, events, 1)
 	assert.Equal(t, newEvent.Message, events[0].Message)
+
+	preNotifications, err := service.ListPreNotifications(&database.ListPreNotificationsOpts{
+		EventID: &oldEvent.ID,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, preNotifications)
 }
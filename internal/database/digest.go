@@ -0,0 +1,50 @@
+package database
+
+import "time"
+
+// DefaultDigestLookahead is the range of upcoming events in a digest if none is configured.
+const DefaultDigestLookahead = 24 * time.Hour
+
+// Weekday masks commonly used for digests.
+const (
+	WeekdaysAll      uint = 0
+	WeekdaysWorkdays uint = 1<<time.Monday | 1<<time.Tuesday | 1<<time.Wednesday | 1<<time.Thursday | 1<<time.Friday
+)
+
+// WeekdayMask returns the bitmask of the given weekdays.
+func WeekdayMask(weekdays ...time.Weekday) uint {
+	mask := uint(0)
+	for _, weekday := range weekdays {
+		mask |= 1 << weekday
+	}
+
+	return mask
+}
+
+// SendsOn returns true if the digest is sent on the given weekday.
+func (digest *DigestConfig) SendsOn(weekday time.Weekday) bool {
+	return digest.Weekdays == WeekdaysAll || digest.Weekdays&(1<<weekday) != 0
+}
+
+// WeekdayList returns the weekdays the digest is sent on starting with monday.
+func (digest *DigestConfig) WeekdayList() []time.Weekday {
+	weekdays := []time.Weekday{}
+
+	for i := range 7 {
+		weekday := time.Weekday((i + 1) % 7)
+		if digest.SendsOn(weekday) {
+			weekdays = append(weekdays, weekday)
+		}
+	}
+
+	return weekdays
+}
+
+// LookaheadOrDefault returns the range of upcoming events included in the digest.
+func (digest *DigestConfig) LookaheadOrDefault() time.Duration {
+	if digest.Lookahead <= 0 {
+		return DefaultDigestLookahead
+	}
+
+	return digest.Lookahead
+}
@@ -0,0 +1,33 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigestConfig_SendsOn(t *testing.T) {
+	everyDay := &database.DigestConfig{}
+	assert.True(t, everyDay.SendsOn(time.Sunday))
+	assert.True(t, everyDay.SendsOn(time.Wednesday))
+
+	workdays := &database.DigestConfig{Weekdays: database.WeekdaysWorkdays}
+	assert.True(t, workdays.SendsOn(time.Monday))
+	assert.True(t, workdays.SendsOn(time.Friday))
+	assert.False(t, workdays.SendsOn(time.Saturday))
+	assert.False(t, workdays.SendsOn(time.Sunday))
+}
+
+func TestDigestConfig_WeekdayList(t *testing.T) {
+	assert.Len(t, (&database.DigestConfig{}).WeekdayList(), 7)
+
+	digest := &database.DigestConfig{Weekdays: database.WeekdayMask(time.Sunday, time.Monday)}
+	assert.Equal(t, []time.Weekday{time.Monday, time.Sunday}, digest.WeekdayList())
+}
+
+func TestDigestConfig_LookaheadOrDefault(t *testing.T) {
+	assert.Equal(t, 24*time.Hour, (&database.DigestConfig{}).LookaheadOrDefault())
+	assert.Equal(t, 7*24*time.Hour, (&database.DigestConfig{Lookahead: 7 * 24 * time.Hour}).LookaheadOrDefault())
+}
@@ -1,12 +1,103 @@
 package database
 
 import (
+	"fmt"
 	"time"
+
+	"github.com/teambition/rrule-go"
 )
 
+// NewRepeatRule anchors the RRULE (e.g. "FREQ=MONTHLY;BYMONTHDAY=1") at the given start time.
+// The location of the start time is used to evaluate the rule, hence it is safe across DST switches.
+func NewRepeatRule(rule string, start time.Time) (string, error) {
+	if start.Location().String() == "" {
+		// Unnamed fixed zones (e.g. from an offset in a JSON time) can not be written as TZID.
+		start = start.UTC()
+	}
+
+	opts, err := rrule.StrToROptionInLocation(rule, start.Location())
+	if err != nil {
+		return "", fmt.Errorf("invalid rule: %w", err)
+	}
+
+	opts.Dtstart = start.Truncate(time.Second)
+
+	_, err = rrule.NewRRule(*opts)
+	if err != nil {
+		return "", fmt.Errorf("invalid rule: %w", err)
+	}
+
+	return opts.String(), nil
+}
+
+// Reschedule moves the event to the given time on purpose. A repeat rule is re-anchored at the new time in the
+// location of its current start, all other rule parts (e.g. COUNT or UNTIL) are kept.
+func (event *Event) Reschedule(t time.Time) error {
+	if event.RepeatRule != "" {
+		opts, err := rrule.StrToROption(event.RepeatRule)
+		if err != nil {
+			return fmt.Errorf("invalid rule: %w", err)
+		}
+
+		rule, err := NewRepeatRule(opts.RRuleString(), t.In(opts.Dtstart.Location()))
+		if err != nil {
+			return err
+		}
+
+		event.RepeatRule = rule
+	}
+
+	event.Time = t
+
+	return nil
+}
+
+// SetRepeatRule replaces the repeat rule of the event, an empty rule removes it. The new rule is anchored at the
+// events time in the location of the current rule or the given location if the event has no rule yet.
+func (event *Event) SetRepeatRule(rule string, loc *time.Location) error {
+	if rule == "" {
+		event.RepeatRule = ""
+		return nil
+	}
+
+	if event.RepeatRule != "" {
+		opts, err := rrule.StrToROption(event.RepeatRule)
+		if err == nil {
+			loc = opts.Dtstart.Location()
+		}
+	}
+
+	newRule, err := NewRepeatRule(rule, event.Time.In(loc))
+	if err != nil {
+		return err
+	}
+
+	event.RepeatRule = newRule
+
+	return nil
+}
+
+// IsRecurring returns whether the event is repeated.
+func (event *Event) IsRecurring() bool {
+	return event.RepeatRule != "" || event.RepeatInterval != nil
+}
+
+// RepeatRuleSet returns the parsed repeat rule of the event.
+func (event *Event) RepeatRuleSet() (*rrule.Set, error) {
+	if event.RepeatRule == "" {
+		return nil, ErrNoRepeatRule
+	}
+
+	return rrule.StrToRRuleSet(event.RepeatRule)
+}
+
 // NextEventTime returns the next time the event will happen.
 // If the RepeatUntil date is reached or the event is not recurring the zero time is returned.
 func (event *Event) NextEventTime() time.Time {
+	if event.RepeatRule != "" {
+		return event.nextEventTimeFromRule()
+	}
+
 	if event.RepeatInterval == nil || event.RepeatUntil == nil || time.Until(*event.RepeatUntil) < 0 {
 		return time.Time{}
 	}
@@ -23,21 +114,48 @@ func (event *Event) NextEventTime() time.Time {
 	return nextTime
 }
 
+func (event *Event) nextEventTimeFromRule() time.Time {
+	if event.RepeatUntil != nil && time.Until(*event.RepeatUntil) < 0 {
+		return time.Time{}
+	}
+
+	set, err := event.RepeatRuleSet()
+	if err != nil {
+		return time.Time{}
+	}
+
+	refTime := event.Time
+	if now := time.Now(); now.After(refTime) {
+		refTime = now
+	}
+
+	nextTime := set.After(refTime, false)
+	if event.RepeatUntil != nil && nextTime.After(*event.RepeatUntil) {
+		return time.Time{}
+	}
+
+	return nextTime
+}
+
 func (service *service) NewEvent(event *Event) (*Event, error) {
 	err := service.db.Create(event).Error
 
 	return event, err
 }
 
+// NewEvents creates the events with a single statement, none of them is created if one fails.
 func (service *service) NewEvents(events []Event) error {
 	return service.db.Create(events).Error
 }
 
 // ListEventsOpts holds options for listing events.
 type ListEventsOpts struct {
-	IDs       []uint
-	InputID   *uint
-	ChannelID *uint
+	IDs               []uint
+	InputID           *uint
+	ChannelID         *uint
+	ExternalReference *string
+	// User limits the events to those assigned to the user or created by the user without an assignee.
+	User *string
 
 	IncludeInactive bool
 
@@ -60,6 +178,14 @@ func (service *service) ListEvents(opts *ListEventsOpts) ([]Event, error) {
 		query = query.Where("events.id IN ?", opts.IDs)
 	}
 
+	if opts.ExternalReference != nil {
+		query = query.Where("events.external_reference = ?", *opts.ExternalReference)
+	}
+
+	if opts.User != nil {
+		query = query.Where("events.assigned_to = ? OR (events.assigned_to = '' AND events.created_by = ?)", *opts.User, *opts.User)
+	}
+
 	if !opts.IncludeInactive {
 		query = query.Where("events.active = ?", true)
 	}
@@ -76,7 +202,7 @@ func (service *service) ListEvents(opts *ListEventsOpts) ([]Event, error) {
 
 	var events []Event
 
-	err := query.Preload("Channel").Preload("Input").Find(&events).Error
+	err := query.Preload("Channel").Preload("Input").Preload("Escalations").Find(&events).Error
 
 	return events, err
 }
@@ -105,6 +231,19 @@ func (service *service) UpdateEvent(event *Event) (*Event, error) {
 	return event, err
 }
 
+// DeleteEvent deletes the event together with its pre-notifications.
 func (service *service) DeleteEvent(event *Event) error {
-	return service.db.Delete(event).Error
+	tx := service.newSession()
+
+	err := tx.db.Unscoped().Where("event_id = ?", event.ID).Delete(&PreNotification{}).Error
+	if err != nil {
+		return tx.rollbackWithError(err)
+	}
+
+	err = tx.db.Delete(event).Error
+	if err != nil {
+		return tx.rollbackWithError(err)
+	}
+
+	return tx.commit()
 }
@@ -0,0 +1,22 @@
+package database
+
+import "time"
+
+// ListEventDeliveries lists the deliveries of an occurrence of an event, a lead time selects the deliveries of
+// the pre-notification and an escalation level the ones of the escalation.
+func (service *service) ListEventDeliveries(eventID uint, eventTime time.Time, leadTime time.Duration, escalationLevel uint) ([]EventDelivery, error) {
+	var deliveries []EventDelivery
+
+	err := service.db.Find(&deliveries,
+		"event_deliveries.event_id = ? AND event_deliveries.event_time = ? AND event_deliveries.lead_time = ? AND event_deliveries.escalation_level = ?",
+		eventID, eventTime, leadTime, escalationLevel,
+	).Error
+
+	return deliveries, err
+}
+
+func (service *service) SaveEventDelivery(delivery *EventDelivery) (*EventDelivery, error) {
+	err := service.db.Save(delivery).Error
+
+	return delivery, err
+}
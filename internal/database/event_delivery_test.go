@@ -0,0 +1,87 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_SaveEventDelivery(t *testing.T) {
+	event, err := service.NewEvent(testEvent())
+	require.NoError(t, err)
+
+	delivery, err := service.SaveEventDelivery(&database.EventDelivery{
+		EventID:     event.ID,
+		OutputID:    12,
+		EventTime:   event.Time,
+		Attempts:    1,
+		NextAttempt: new(time2125()),
+		LastError:   "test",
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, delivery.ID)
+
+	delivery.Delivered = true
+	delivery.Attempts = 2
+
+	_, err = service.SaveEventDelivery(delivery)
+	require.NoError(t, err)
+
+	deliveries, err := service.ListEventDeliveries(event.ID, event.Time, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.True(t, deliveries[0].Delivered)
+	assert.Equal(t, uint(2), deliveries[0].Attempts)
+	assert.Equal(t, uint(12), deliveries[0].OutputID)
+
+	deliveries, err = service.ListEventDeliveries(event.ID, event.Time.Add(time.Hour), 0, 0)
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+}
+
+func TestService_SaveEventDeliveryForPreNotification(t *testing.T) {
+	event, err := service.NewEvent(testEvent())
+	require.NoError(t, err)
+
+	for _, leadTime := range []time.Duration{0, time.Hour} {
+		_, err = service.SaveEventDelivery(&database.EventDelivery{
+			EventID:   event.ID,
+			OutputID:  12,
+			EventTime: event.Time,
+			LeadTime:  leadTime,
+			Delivered: true,
+			Attempts:  1,
+		})
+		require.NoError(t, err)
+	}
+
+	deliveries, err := service.ListEventDeliveries(event.ID, event.Time, time.Hour, 0)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, time.Hour, deliveries[0].LeadTime)
+}
+
+func TestService_SaveEventDeliveryForEscalation(t *testing.T) {
+	event, err := service.NewEvent(testEvent())
+	require.NoError(t, err)
+
+	for _, level := range []uint{0, 1, 2} {
+		_, err = service.SaveEventDelivery(&database.EventDelivery{
+			EventID:         event.ID,
+			OutputID:        12,
+			EventTime:       event.Time,
+			EscalationLevel: level,
+			Delivered:       true,
+			Attempts:        1,
+		})
+		require.NoError(t, err)
+	}
+
+	deliveries, err := service.ListEventDeliveries(event.ID, event.Time, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, uint(2), deliveries[0].EscalationLevel)
+}
@@ -0,0 +1,7 @@
+package database
+
+func (service *service) NewEventEscalation(escalation *EventEscalation) (*EventEscalation, error) {
+	err := service.db.Create(escalation).Error
+
+	return escalation, err
+}
@@ -0,0 +1,34 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_NewEventEscalation(t *testing.T) {
+	event, err := service.NewEvent(testEvent())
+	require.NoError(t, err)
+
+	escalation, err := service.NewEventEscalation(&database.EventEscalation{
+		EventID:  event.ID,
+		Level:    1,
+		Resends:  3,
+		Outputs:  2,
+		Mentions: "@alice:example.com",
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, escalation.ID)
+
+	events, err := service.ListEvents(&database.ListEventsOpts{
+		IDs: []uint{event.ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Len(t, events[0].Escalations, 1)
+	assert.Equal(t, uint(1), events[0].Escalations[0].Level)
+	assert.Equal(t, uint(3), events[0].Escalations[0].Resends)
+	assert.Equal(t, "@alice:example.com", events[0].Escalations[0].Mentions)
+}
@@ -4,6 +4,7 @@ import (
 	"math/rand"
 	"testing"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
 	"github.com/stretchr/testify/assert"
@@ -108,6 +109,146 @@ func TestEvent_NextEventTime(t *testing.T) {
 	}
 }
 
+func TestEvent_NextEventTimeWithRepeatRule(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	start := time.Date(2123, 3, 1, 10, 0, 0, 0, berlin)
+	rule, err := database.NewRepeatRule("FREQ=MONTHLY;BYMONTHDAY=1", start)
+	require.NoError(t, err)
+	assert.Equal(t, "DTSTART;TZID=Europe/Berlin:21230301T100000\nRRULE:FREQ=MONTHLY;BYMONTHDAY=1", rule)
+
+	type testCase struct {
+		Name         string
+		Event        database.Event
+		ExpectedTime time.Time
+	}
+
+	testCases := []testCase{
+		{
+			Name: "Success across DST switch",
+			Event: database.Event{
+				Time:       start,
+				RepeatRule: rule,
+			},
+			ExpectedTime: time.Date(2123, 4, 1, 10, 0, 0, 0, berlin),
+		},
+		{
+			Name: "Success with repeat until",
+			Event: database.Event{
+				Time:        start,
+				RepeatRule:  rule,
+				RepeatUntil: timeP2124(),
+			},
+			ExpectedTime: time.Date(2123, 4, 1, 10, 0, 0, 0, berlin),
+		},
+		{
+			Name: "Repeat until reached",
+			Event: database.Event{
+				Time:        start,
+				RepeatRule:  rule,
+				RepeatUntil: timeP2122(),
+			},
+		},
+		{
+			Name: "Next occurrence after repeat until",
+			Event: database.Event{
+				Time:        start,
+				RepeatRule:  rule,
+				RepeatUntil: new(start.Add(time.Hour * 24)),
+			},
+		},
+		{
+			Name: "Invalid rule",
+			Event: database.Event{
+				Time:       start,
+				RepeatRule: "RRULE:FREQ=SOMETIMES",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			actualTime := testCase.Event.NextEventTime()
+			assert.True(t, testCase.ExpectedTime.Equal(actualTime), "expected %s, got %s", testCase.ExpectedTime, actualTime)
+		})
+	}
+}
+
+func TestNewRepeatRuleWithInvalidRule(t *testing.T) {
+	_, err := database.NewRepeatRule("FREQ=SOMETIMES", time2123())
+	require.Error(t, err)
+}
+
+func TestEvent_Reschedule(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	event := database.Event{
+		Time:       time.Date(2123, 3, 1, 10, 0, 0, 0, berlin),
+		RepeatRule: "DTSTART;TZID=Europe/Berlin:21230301T100000\nRRULE:FREQ=WEEKLY;COUNT=5",
+	}
+
+	err = event.Reschedule(time.Date(2123, 3, 2, 8, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	assert.True(t, time.Date(2123, 3, 2, 8, 30, 0, 0, time.UTC).Equal(event.Time))
+	assert.Equal(t, "DTSTART;TZID=Europe/Berlin:21230302T093000\nRRULE:FREQ=WEEKLY;COUNT=5", event.RepeatRule)
+}
+
+func TestEvent_RescheduleWithoutRule(t *testing.T) {
+	event := database.Event{Time: time2123()}
+
+	err := event.Reschedule(time2123().Add(time.Hour))
+	require.NoError(t, err)
+
+	assert.Equal(t, time2123().Add(time.Hour), event.Time)
+	assert.Empty(t, event.RepeatRule)
+}
+
+func TestEvent_RescheduleWithInvalidRule(t *testing.T) {
+	event := database.Event{Time: time2123(), RepeatRule: "RRULE:FREQ=SOMETIMES"}
+
+	err := event.Reschedule(time2123().Add(time.Hour))
+	require.Error(t, err)
+
+	assert.Equal(t, time2123(), event.Time)
+}
+
+func TestEvent_SetRepeatRule(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	event := database.Event{Time: time.Date(2123, 3, 1, 9, 0, 0, 0, time.UTC)}
+
+	require.NoError(t, event.SetRepeatRule("FREQ=DAILY", berlin))
+	assert.Equal(t, "DTSTART;TZID=Europe/Berlin:21230301T100000\nRRULE:FREQ=DAILY", event.RepeatRule)
+
+	// The location of the existing rule is kept.
+	require.NoError(t, event.SetRepeatRule("FREQ=WEEKLY;COUNT=3", tokyo))
+	assert.Equal(t, "DTSTART;TZID=Europe/Berlin:21230301T100000\nRRULE:FREQ=WEEKLY;COUNT=3", event.RepeatRule)
+
+	require.Error(t, event.SetRepeatRule("FREQ=SOMETIMES", tokyo))
+
+	require.NoError(t, event.SetRepeatRule("", tokyo))
+	assert.Empty(t, event.RepeatRule)
+}
+
+func TestNewRepeatRuleWithUnnamedZone(t *testing.T) {
+	rule, err := database.NewRepeatRule("FREQ=DAILY", time.Date(2123, 3, 1, 10, 0, 0, 0, time.FixedZone("", 3600)))
+	require.NoError(t, err)
+
+	assert.Equal(t, "DTSTART:21230301T090000Z\nRRULE:FREQ=DAILY", rule)
+}
+
+func TestEvent_IsRecurring(t *testing.T) {
+	assert.False(t, (&database.Event{}).IsRecurring())
+	assert.True(t, (&database.Event{RepeatInterval: interval24Hours()}).IsRecurring())
+	assert.True(t, (&database.Event{RepeatRule: "RRULE:FREQ=DAILY"}).IsRecurring())
+}
+
 func testEvent() *database.Event {
 	var channel *database.Channel
 
@@ -159,6 +300,19 @@ func TestService_NewEvents(t *testing.T) {
 	require.NotZero(t, eventsBefore[1].ID)
 }
 
+func TestService_NewEventsWithInvalidEvent(t *testing.T) {
+	eventsBefore := []database.Event{
+		*testEvent(),
+		{},
+	}
+	err := service.NewEvents(eventsBefore)
+	require.Error(t, err)
+
+	events, err := service.GetEventsByChannel(eventsBefore[0].ChannelID)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
 func TestService_NewEventWithoutChannel(t *testing.T) {
 	_, err := service.NewEvent(&database.Event{})
 	require.Error(t, err)
@@ -201,6 +355,7 @@ func TestService_ListEvents(t *testing.T) {
 	require.NoError(t, err)
 
 	eventBefore.InputID = &input.ID
+	eventBefore.ExternalReference = "list-events-ref"
 	eventBefore, err = service.UpdateEvent(eventBefore)
 	require.NoError(t, err)
 
@@ -228,6 +383,23 @@ func TestService_ListEvents(t *testing.T) {
 		assert.True(t, evtFound, "missing event not in response")
 	})
 
+	t.Run("list by input and reference", func(t *testing.T) {
+		events, err := service.ListEvents(&database.ListEventsOpts{
+			InputID:           &input.ID,
+			ExternalReference: new("list-events-ref"),
+		})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, eventBefore.ID, events[0].ID)
+
+		events, err = service.ListEvents(&database.ListEventsOpts{
+			InputID:           &input.ID,
+			ExternalReference: new("unknown"),
+		})
+		require.NoError(t, err)
+		require.Empty(t, events)
+	})
+
 	t.Run("list by channel and time", func(t *testing.T) {
 		events, err := service.ListEvents(&database.ListEventsOpts{
 			ChannelID:    &eventBefore.ChannelID,
@@ -251,6 +423,41 @@ func TestService_ListEvents(t *testing.T) {
 	})
 }
 
+func TestService_ListEventsByUser(t *testing.T) {
+	assigned := testEvent()
+	assigned.CreatedBy = "@creator:example.com"
+	assigned.AssignedTo = "@owner:example.com"
+	assigned, err := service.NewEvent(assigned)
+	require.NoError(t, err)
+
+	created := testEvent()
+	created.ChannelID = assigned.ChannelID
+	created.CreatedBy = "@owner:example.com"
+	created, err = service.NewEvent(created)
+	require.NoError(t, err)
+
+	other := testEvent()
+	other.ChannelID = assigned.ChannelID
+	other.CreatedBy = "@owner:example.com"
+	other.AssignedTo = "@creator:example.com"
+	_, err = service.NewEvent(other)
+	require.NoError(t, err)
+
+	ownerless := testEvent()
+	ownerless.ChannelID = assigned.ChannelID
+	_, err = service.NewEvent(ownerless)
+	require.NoError(t, err)
+
+	events, err := service.ListEvents(&database.ListEventsOpts{
+		ChannelID: &assigned.ChannelID,
+		User:      new("@owner:example.com"),
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.ElementsMatch(t, []uint{assigned.ID, created.ID}, []uint{events[0].ID, events[1].ID})
+}
+
 func TestService_ListEventsWithInactiveEvent(t *testing.T) {
 	eventBefore, err := service.NewEvent(testEvent())
 	eventBefore.Active = false
@@ -381,6 +588,12 @@ func TestService_DeleteEvent(t *testing.T) {
 	event, err := service.NewEvent(testEvent())
 	require.NoError(t, err)
 
+	_, err = service.NewPreNotification(&database.PreNotification{
+		EventID:  &event.ID,
+		LeadTime: time.Hour,
+	})
+	require.NoError(t, err)
+
 	err = service.DeleteEvent(event)
 	require.NoError(t, err)
 
@@ -389,4 +602,10 @@ func TestService_DeleteEvent(t *testing.T) {
 	})
 	require.NoError(t, err)
 	assert.Empty(t, events)
+
+	preNotifications, err := service.ListPreNotifications(&database.ListPreNotificationsOpts{
+		EventID: &event.ID,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, preNotifications)
 }
@@ -35,6 +35,72 @@ func (_m *MockInputService) EXPECT() *MockInputService_Expecter {
 	return &MockInputService_Expecter{mock: &_m.Mock}
 }
 
+// InputExists provides a mock function for the type MockInputService
+func (_mock *MockInputService) InputExists(inputType string, inputID uint) (bool, error) {
+	ret := _mock.Called(inputType, inputID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InputExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, uint) (bool, error)); ok {
+		return returnFunc(inputType, inputID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, uint) bool); ok {
+		r0 = returnFunc(inputType, inputID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, uint) error); ok {
+		r1 = returnFunc(inputType, inputID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockInputService_InputExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InputExists'
+type MockInputService_InputExists_Call struct {
+	*mock.Call
+}
+
+// InputExists is a helper method to define mock.On call
+//   - inputType string
+//   - inputID uint
+func (_e *MockInputService_Expecter) InputExists(inputType interface{}, inputID interface{}) *MockInputService_InputExists_Call {
+	return &MockInputService_InputExists_Call{Call: _e.mock.On("InputExists", inputType, inputID)}
+}
+
+func (_c *MockInputService_InputExists_Call) Run(run func(inputType string, inputID uint)) *MockInputService_InputExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 uint
+		if args[1] != nil {
+			arg1 = args[1].(uint)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInputService_InputExists_Call) Return(b bool, err error) *MockInputService_InputExists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockInputService_InputExists_Call) RunAndReturn(run func(inputType string, inputID uint) (bool, error)) *MockInputService_InputExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // InputRemoved provides a mock function for the type MockInputService
 func (_mock *MockInputService) InputRemoved(inputType string, inputID uint) error {
 	ret := _mock.Called(inputType, inputID)
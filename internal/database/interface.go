@@ -14,6 +14,7 @@ var (
 	ErrRolledBack    = errors.New("rolled back")
 	ErrUnknownInput  = errors.New("unknown input type")
 	ErrUnknownOutput = errors.New("unknown output type")
+	ErrNoRepeatRule  = errors.New("event has no repeat rule")
 )
 
 // Service defines a database service interface.
@@ -27,6 +28,9 @@ type Service interface {
 	AddInputToChannel(uint, *Input) error
 	AddOutputToChannel(uint, *Output) error
 
+	InputExists(inputType string, inputID uint) (bool, error)
+	OutputExists(outputType string, outputID uint) (bool, error)
+
 	RemoveInputFromChannel(channelID, inputID uint) error
 	RemoveOutputFromChannel(channelID, outputID uint) error
 
@@ -57,6 +61,21 @@ type Service interface {
 	DeleteEvent(event *Event) error
 	Cleanup(opts *CleanupOpts) (int64, error)
 
+	// PreNotification
+	NewPreNotification(*PreNotification) (*PreNotification, error)
+	ListPreNotifications(opts *ListPreNotificationsOpts) ([]PreNotification, error)
+	DeletePreNotification(*PreNotification) error
+
+	GetPreNotificationsPending() ([]PendingPreNotification, error)
+	MarkPreNotificationSent(eventID uint, eventTime time.Time, leadTime time.Duration) error
+
+	// EventEscalation
+	NewEventEscalation(*EventEscalation) (*EventEscalation, error)
+
+	// EventDelivery
+	ListEventDeliveries(eventID uint, eventTime time.Time, leadTime time.Duration, escalationLevel uint) ([]EventDelivery, error)
+	SaveEventDelivery(*EventDelivery) (*EventDelivery, error)
+
 	// Misc
 	GormDB() *gorm.DB
 }
@@ -66,13 +85,36 @@ type Channel struct {
 	gorm.Model
 
 	Description         string
-	DailyReminder       *uint // minutes from midnight when to send the daily reminder. Null to deactivate.
-	DefaultReminderTime *uint // minutes from midnight.
+	DailyReminder       *uint        // minutes from midnight when to send the daily reminder. Null to deactivate.
+	Digest              DigestConfig `gorm:"embedded;embeddedPrefix:digest_"`
+	DefaultReminderTime *uint        // minutes from midnight.
+	// Timezone the times of day of the channel are evaluated in, e.g. the quiet hours and the daily reminder.
+	// Empty for UTC.
+	TimeZone string `gorm:"size:255"`
+	// Quiet hours in minutes from midnight, reminders are deferred until they end. The window can span midnight.
+	// Null to deactivate.
+	QuietHoursStart   *uint
+	QuietHoursEnd     *uint
+	DoNotDisturbUntil *time.Time // Reminders are deferred until this time.
+	// Unacknowledged important events are escalated with every n-th resend. Null to deactivate.
+	// Escalations go to the output or else the outputs of the channel, both default to the own channel.
+	EscalateAfter       *uint
+	EscalationOutputID  *uint
+	EscalationChannelID *uint
+	EscalationMentions  string `gorm:"size:1024"` // Comma separated user identifiers to mention on escalation.
 
 	Inputs  []Input
 	Outputs []Output
 }
 
+// DigestConfig defines on which days the daily reminder of a channel is sent and what it contains.
+type DigestConfig struct {
+	Weekdays  uint          // Bitmask of the weekdays (1 << time.Weekday) to send the digest on, 0 for every day.
+	Lookahead time.Duration // Range of upcoming events included, 0 for the next 24 hours.
+	Overdue   bool          // Include events of the past that are still active, e.g. unacknowledged important events.
+	SkipEmpty bool          // Do not send the digest if it contains no events.
+}
+
 // Input takes in data.
 type Input struct {
 	gorm.Model
@@ -102,6 +144,7 @@ type Importance int
 const (
 	ImportanceDefault   Importance = 0
 	ImportanceImportant Importance = 1
+	ImportanceUrgent    Importance = 2 // Important and sent during quiet hours.
 )
 
 // Event holds information about an event
@@ -113,6 +156,7 @@ type Event struct {
 	Message           string
 	Active            bool `gorm:"index"`
 	RepeatInterval    *time.Duration
+	RepeatRule        string // RRULE with DTSTART, takes precedence over the RepeatInterval if set.
 	RepeatUntil       *time.Time
 	ChannelID         uint
 	Channel           Channel
@@ -120,4 +164,64 @@ type Event struct {
 	Input             *Input
 	ExternalReference string
 	Importance        Importance
+	// Identifiers of the user who created the event and the user it is for, e.g. matrix user IDs.
+	CreatedBy  string `gorm:"size:255"`
+	AssignedTo string `gorm:"size:255;index"`
+	// Number of times the unacknowledged event was sent again.
+	Resends     uint
+	Escalations []EventEscalation
+}
+
+// EventEscalation records an escalation of an unacknowledged event.
+type EventEscalation struct {
+	gorm.Model
+
+	EventID  uint `gorm:"index"`
+	Level    uint
+	Resends  uint
+	Outputs  uint   // Number of outputs notified.
+	Mentions string `gorm:"size:1024"`
+}
+
+// PreNotification defines how long before an event starts a notification is sent.
+// It either applies to all events of a channel or to a single event.
+type PreNotification struct {
+	gorm.Model
+
+	ChannelID *uint `gorm:"index"`
+	EventID   *uint `gorm:"index"`
+	LeadTime  time.Duration
+}
+
+// SentPreNotification keeps track of pre-notifications sent for an occurrence of an event.
+type SentPreNotification struct {
+	gorm.Model
+
+	EventID   uint `gorm:"index"`
+	EventTime time.Time
+	LeadTime  time.Duration
+}
+
+// EventDelivery tracks the delivery of an occurrence of an event to an output.
+type EventDelivery struct {
+	gorm.Model
+
+	EventID   uint      `gorm:"index:idx_event_delivery,unique"`
+	OutputID  uint      `gorm:"index:idx_event_delivery,unique"`
+	EventTime time.Time `gorm:"index:idx_event_delivery,unique"`
+	// LeadTime of the pre-notification delivered, zero for the event itself.
+	LeadTime time.Duration `gorm:"index:idx_event_delivery,unique"`
+	// EscalationLevel of the escalation delivered, zero for the event itself.
+	EscalationLevel uint `gorm:"index:idx_event_delivery,unique"`
+	Delivered       bool
+	GaveUp          bool
+	Attempts        uint
+	NextAttempt     *time.Time
+	LastError       string
+}
+
+// PendingPreNotification is a pre-notification that is due to be sent.
+type PendingPreNotification struct {
+	Event    Event
+	LeadTime time.Duration
 }
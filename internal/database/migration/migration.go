@@ -0,0 +1,183 @@
+package migration
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration is a single versioned schema change of a module.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      func(db *gorm.DB) error
+}
+
+// SchemaMigration records an applied migration.
+type SchemaMigration struct {
+	Module    string `gorm:"primaryKey;size:64"`
+	Version   uint   `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"size:255"`
+	AppliedAt time.Time
+}
+
+// TableName overwrites the gorm table name.
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator applies the migrations of a single module in order of their version.
+type Migrator struct {
+	db         *gorm.DB
+	module     string
+	migrations []Migration
+}
+
+// New assembles a migrator for the given module.
+func New(db *gorm.DB, module string, migrations []Migration) *Migrator {
+	migrations = slices.Clone(migrations)
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return &Migrator{
+		db:         db,
+		module:     module,
+		migrations: migrations,
+	}
+}
+
+// AutoMigrate returns an up function creating the tables and missing columns of the given models.
+func AutoMigrate(models ...any) func(db *gorm.DB) error {
+	return func(db *gorm.DB) error {
+		for _, model := range models {
+			err := db.AutoMigrate(model)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// Pending returns the migrations not applied yet.
+func (migrator *Migrator) Pending() ([]Migration, error) {
+	if !migrator.db.Migrator().HasTable(&SchemaMigration{}) {
+		return migrator.migrations, nil
+	}
+
+	applied := []uint{}
+
+	err := migrator.db.Model(&SchemaMigration{}).
+		Where("module = ?", migrator.module).
+		Pluck("version", &applied).Error
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []Migration{}
+
+	for _, migration := range migrator.migrations {
+		if !slices.Contains(applied, migration.Version) {
+			pending = append(pending, migration)
+		}
+	}
+
+	return pending, nil
+}
+
+// Up applies all pending migrations and returns the applied ones.
+func (migrator *Migrator) Up() ([]Migration, error) {
+	err := migrator.db.AutoMigrate(&SchemaMigration{})
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := migrator.Pending()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, migration := range pending {
+		err := migrator.db.Transaction(func(tx *gorm.DB) error {
+			return migrator.apply(tx, migration)
+		})
+		if err != nil {
+			return pending[:i], fmt.Errorf("migration %s %d (%s) failed: %w", migrator.module, migration.Version, migration.Name, err)
+		}
+	}
+
+	return pending, nil
+}
+
+// DryRun returns the SQL statements the pending migrations would execute without applying them.
+// Read queries are still run against the database.
+func (migrator *Migrator) DryRun() ([]string, error) {
+	recorder := &recorder{
+		ConnPool:  migrator.db.Statement.ConnPool,
+		dialector: migrator.db.Dialector,
+	}
+
+	tx := migrator.db.Session(&gorm.Session{
+		NewDB:                  true,
+		SkipDefaultTransaction: true,
+	})
+	tx.Statement.ConnPool = recorder
+
+	err := tx.AutoMigrate(&SchemaMigration{})
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := migrator.Pending()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, migration := range pending {
+		err := migrator.apply(tx, migration)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s %d (%s) failed: %w", migrator.module, migration.Version, migration.Name, err)
+		}
+	}
+
+	return recorder.statements, nil
+}
+
+func (migrator *Migrator) apply(tx *gorm.DB, migration Migration) error {
+	err := migration.Up(tx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&SchemaMigration{
+		Module:    migrator.module,
+		Version:   migration.Version,
+		Name:      migration.Name,
+		AppliedAt: time.Now().UTC(),
+	}).Error
+}
+
+// recorder passes queries to the database but only records statements changing it. As nothing is
+// created, AutoMigrate repeats statements for referenced tables, those are recorded once.
+type recorder struct {
+	gorm.ConnPool
+	dialector  gorm.Dialector
+	statements []string
+}
+
+func (recorder *recorder) ExecContext(_ context.Context, query string, args ...any) (sql.Result, error) {
+	statement := recorder.dialector.Explain(query, args...)
+	if !slices.Contains(recorder.statements, statement) {
+		recorder.statements = append(recorder.statements, statement)
+	}
+
+	return driver.RowsAffected(0), nil
+}
@@ -0,0 +1,133 @@
+package migration_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database/migration"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var gormDB *gorm.DB
+
+func TestMain(m *testing.M) {
+	config, cleanup := tests.Config()
+	defer cleanup()
+
+	gormDB = tests.GormDB(config)
+
+	m.Run()
+}
+
+type migrationTestModel struct {
+	ID   uint
+	Name string `gorm:"size:255"`
+}
+
+func testMigrations(t *testing.T) (string, []migration.Migration) {
+	t.Helper()
+
+	module := "test_" + t.Name()
+
+	t.Cleanup(func() {
+		require.NoError(t, gormDB.Migrator().DropTable(&migrationTestModel{}))
+		require.NoError(t, gormDB.Where("module = ?", module).Delete(&migration.SchemaMigration{}).Error)
+	})
+
+	return module, []migration.Migration{
+		{
+			Version: 2,
+			Name:    "add name",
+			Up: func(db *gorm.DB) error {
+				return db.Exec("UPDATE migration_test_models SET name = 'test'").Error
+			},
+		},
+		{
+			Version: 1,
+			Name:    "create table",
+			Up:      migration.AutoMigrate(&migrationTestModel{}),
+		},
+	}
+}
+
+func TestMigrator_Up(t *testing.T) {
+	module, migrations := testMigrations(t)
+	migrator := migration.New(gormDB, module, migrations)
+
+	applied, err := migrator.Up()
+	require.NoError(t, err)
+	require.Len(t, applied, 2)
+	assert.Equal(t, uint(1), applied[0].Version)
+	assert.Equal(t, uint(2), applied[1].Version)
+
+	assert.True(t, gormDB.Migrator().HasTable(&migrationTestModel{}))
+
+	var versions []uint
+
+	require.NoError(t, gormDB.Model(&migration.SchemaMigration{}).Where("module = ?", module).Order("version").Pluck("version", &versions).Error)
+	assert.Equal(t, []uint{1, 2}, versions)
+
+	applied, err = migrator.Up()
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+}
+
+func TestMigrator_UpWithError(t *testing.T) {
+	module, migrations := testMigrations(t)
+	migrations[0].Up = func(*gorm.DB) error {
+		return errors.New("test")
+	}
+	migrator := migration.New(gormDB, module, migrations)
+
+	applied, err := migrator.Up()
+	require.Error(t, err)
+	require.Len(t, applied, 1)
+	assert.Equal(t, uint(1), applied[0].Version)
+
+	pending, err := migrator.Pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, uint(2), pending[0].Version)
+}
+
+func TestMigrator_Pending(t *testing.T) {
+	module, migrations := testMigrations(t)
+	migrator := migration.New(gormDB, module, migrations)
+
+	pending, err := migrator.Pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, uint(1), pending[0].Version)
+
+	_, err = migration.New(gormDB, module, migrations[1:]).Up()
+	require.NoError(t, err)
+
+	pending, err = migrator.Pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, uint(2), pending[0].Version)
+}
+
+func TestMigrator_DryRun(t *testing.T) {
+	module, migrations := testMigrations(t)
+	migrator := migration.New(gormDB, module, migrations)
+
+	statements, err := migrator.DryRun()
+	require.NoError(t, err)
+	require.NotEmpty(t, statements)
+	assert.True(t, slices.ContainsFunc(statements, func(statement string) bool {
+		return strings.HasPrefix(statement, "CREATE TABLE `migration_test_models`")
+	}))
+	assert.Contains(t, statements, "UPDATE migration_test_models SET name = 'test'")
+
+	assert.False(t, gormDB.Migrator().HasTable(&migrationTestModel{}))
+
+	pending, err := migrator.Pending()
+	require.NoError(t, err)
+	assert.Len(t, pending, 2)
+}
@@ -0,0 +1,318 @@
+package database
+
+import (
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database/migration"
+	"gorm.io/gorm"
+)
+
+// Migrations returns the versioned schema migrations of the core module.
+//
+// Migrations work on snapshots of the models as of their version, changes to
+// the models require a new migration with a new snapshot.
+func Migrations() []migration.Migration {
+	return []migration.Migration{
+		{
+			Version: 1,
+			Name:    "initial schema",
+			Up: migration.AutoMigrate(
+				&channelV1{},
+				&inputV1{},
+				&outputV1{},
+				&eventV1{},
+				&preNotificationV1{},
+				&sentPreNotificationV1{},
+				&eventDeliveryV1{},
+			),
+		},
+		{
+			Version: 2,
+			Name:    "event owners",
+			Up:      migration.AutoMigrate(&eventV2{}),
+		},
+		{
+			Version: 3,
+			Name:    "quiet hours",
+			Up:      migration.AutoMigrate(&channelV3{}),
+		},
+		{
+			Version: 4,
+			Name:    "escalations",
+			Up:      migration.AutoMigrate(&channelV4{}, &eventV4{}, &eventEscalationV4{}),
+		},
+		{
+			Version: 5,
+			Name:    "digest schedule",
+			Up:      migration.AutoMigrate(&channelV5{}),
+		},
+		{
+			Version: 6,
+			Name:    "digest overdue section and skip empty",
+			Up:      migration.AutoMigrate(&channelV6{}),
+		},
+		{
+			Version: 7,
+			Name:    "pre-notification deliveries",
+			Up:      addEventDeliveryLeadTime,
+		},
+		{
+			Version: 8,
+			Name:    "channel time zone",
+			Up:      migration.AutoMigrate(&channelV8{}),
+		},
+		{
+			Version: 9,
+			Name:    "escalation deliveries",
+			Up:      addEventDeliveryEscalationLevel,
+		},
+	}
+}
+
+// Version 1
+
+type channelV1 struct {
+	gorm.Model
+
+	Description         string
+	DailyReminder       *uint
+	DefaultReminderTime *uint
+
+	Inputs  []inputV1  `gorm:"foreignKey:ChannelID"`
+	Outputs []outputV1 `gorm:"foreignKey:ChannelID"`
+}
+
+func (channelV1) TableName() string { return "channels" }
+
+type inputV1 struct {
+	gorm.Model
+
+	ChannelID uint
+	Channel   channelV1
+	InputType string `gorm:"index:idx_input,unique"`
+	InputID   uint   `gorm:"index:idx_input,unique"`
+	Enabled   bool
+}
+
+func (inputV1) TableName() string { return "inputs" }
+
+type outputV1 struct {
+	gorm.Model
+
+	ChannelID         uint
+	Channel           channelV1
+	OutputType        string `gorm:"index:idx_output,unique"`
+	OutputID          uint   `gorm:"index:idx_output,unique"`
+	Enabled           bool
+	LastDailyReminder *time.Time
+}
+
+func (outputV1) TableName() string { return "outputs" }
+
+type eventV1 struct {
+	gorm.Model
+
+	Time              time.Time `gorm:"index"`
+	Duration          time.Duration
+	Message           string
+	Active            bool `gorm:"index"`
+	RepeatInterval    *time.Duration
+	RepeatRule        string
+	RepeatUntil       *time.Time
+	ChannelID         uint
+	Channel           channelV1
+	InputID           *uint
+	Input             *inputV1
+	ExternalReference string
+	Importance        int
+}
+
+func (eventV1) TableName() string { return "events" }
+
+type preNotificationV1 struct {
+	gorm.Model
+
+	ChannelID *uint `gorm:"index"`
+	EventID   *uint `gorm:"index"`
+	LeadTime  time.Duration
+}
+
+func (preNotificationV1) TableName() string { return "pre_notifications" }
+
+type sentPreNotificationV1 struct {
+	gorm.Model
+
+	EventID   uint `gorm:"index"`
+	EventTime time.Time
+	LeadTime  time.Duration
+}
+
+func (sentPreNotificationV1) TableName() string { return "sent_pre_notifications" }
+
+type eventDeliveryV1 struct {
+	gorm.Model
+
+	EventID     uint      `gorm:"index:idx_event_delivery,unique"`
+	OutputID    uint      `gorm:"index:idx_event_delivery,unique"`
+	EventTime   time.Time `gorm:"index:idx_event_delivery,unique"`
+	Delivered   bool
+	GaveUp      bool
+	Attempts    uint
+	NextAttempt *time.Time
+	LastError   string
+}
+
+func (eventDeliveryV1) TableName() string { return "event_deliveries" }
+
+// Version 2
+
+type eventV2 struct {
+	CreatedBy  string `gorm:"size:255"`
+	AssignedTo string `gorm:"size:255;index"`
+}
+
+func (eventV2) TableName() string { return "events" }
+
+// Version 3
+
+type channelV3 struct {
+	QuietHoursStart   *uint
+	QuietHoursEnd     *uint
+	DoNotDisturbUntil *time.Time
+}
+
+func (channelV3) TableName() string { return "channels" }
+
+// Version 4
+
+type channelV4 struct {
+	EscalateAfter       *uint
+	EscalationOutputID  *uint
+	EscalationChannelID *uint
+	EscalationMentions  string `gorm:"size:1024"`
+}
+
+func (channelV4) TableName() string { return "channels" }
+
+type eventV4 struct {
+	ID          uint `gorm:"primarykey"`
+	Resends     uint
+	Escalations []eventEscalationV4 `gorm:"foreignKey:EventID"`
+}
+
+func (eventV4) TableName() string { return "events" }
+
+type eventEscalationV4 struct {
+	gorm.Model
+
+	EventID  uint `gorm:"index"`
+	Level    uint
+	Resends  uint
+	Outputs  uint
+	Mentions string `gorm:"size:1024"`
+}
+
+func (eventEscalationV4) TableName() string { return "event_escalations" }
+
+// Version 5
+
+type channelV5 struct {
+	DigestWeekdays  uint
+	DigestLookahead time.Duration
+}
+
+func (channelV5) TableName() string { return "channels" }
+
+// Version 6
+
+type channelV6 struct {
+	DigestOverdue   bool
+	DigestSkipEmpty bool
+}
+
+func (channelV6) TableName() string { return "channels" }
+
+// Version 7
+
+type eventDeliveryV7 struct {
+	gorm.Model
+
+	EventID     uint          `gorm:"index:idx_event_delivery,unique"`
+	OutputID    uint          `gorm:"index:idx_event_delivery,unique"`
+	EventTime   time.Time     `gorm:"index:idx_event_delivery,unique"`
+	LeadTime    time.Duration `gorm:"index:idx_event_delivery,unique"`
+	Delivered   bool
+	GaveUp      bool
+	Attempts    uint
+	NextAttempt *time.Time
+	LastError   string
+}
+
+func (eventDeliveryV7) TableName() string { return "event_deliveries" }
+
+// addEventDeliveryLeadTime tracks deliveries of pre-notifications next to the ones of the event itself.
+func addEventDeliveryLeadTime(db *gorm.DB) error {
+	err := db.Migrator().AddColumn(&eventDeliveryV7{}, "LeadTime")
+	if err != nil {
+		return err
+	}
+
+	err = db.Model(&eventDeliveryV7{}).Where("lead_time IS NULL").Update("lead_time", 0).Error
+	if err != nil {
+		return err
+	}
+
+	err = db.Migrator().DropIndex(&eventDeliveryV1{}, "idx_event_delivery")
+	if err != nil {
+		return err
+	}
+
+	return db.Migrator().CreateIndex(&eventDeliveryV7{}, "idx_event_delivery")
+}
+
+// Version 8
+
+type channelV8 struct {
+	TimeZone string `gorm:"size:255"`
+}
+
+func (channelV8) TableName() string { return "channels" }
+
+// Version 9
+
+type eventDeliveryV9 struct {
+	gorm.Model
+
+	EventID         uint          `gorm:"index:idx_event_delivery,unique"`
+	OutputID        uint          `gorm:"index:idx_event_delivery,unique"`
+	EventTime       time.Time     `gorm:"index:idx_event_delivery,unique"`
+	LeadTime        time.Duration `gorm:"index:idx_event_delivery,unique"`
+	EscalationLevel uint          `gorm:"index:idx_event_delivery,unique"`
+	Delivered       bool
+	GaveUp          bool
+	Attempts        uint
+	NextAttempt     *time.Time
+	LastError       string
+}
+
+func (eventDeliveryV9) TableName() string { return "event_deliveries" }
+
+// addEventDeliveryEscalationLevel tracks deliveries of escalations next to the ones of the event itself.
+func addEventDeliveryEscalationLevel(db *gorm.DB) error {
+	err := db.Migrator().AddColumn(&eventDeliveryV9{}, "EscalationLevel")
+	if err != nil {
+		return err
+	}
+
+	err = db.Model(&eventDeliveryV9{}).Where("escalation_level IS NULL").Update("escalation_level", 0).Error
+	if err != nil {
+		return err
+	}
+
+	err = db.Migrator().DropIndex(&eventDeliveryV7{}, "idx_event_delivery")
+	if err != nil {
+		return err
+	}
+
+	return db.Migrator().CreateIndex(&eventDeliveryV9{}, "idx_event_delivery")
+}
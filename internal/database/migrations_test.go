@@ -0,0 +1,21 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database/tests"
+)
+
+func TestMigrations_MatchModels(t *testing.T) {
+	tests.AssertSchema(t, gormDB,
+		&database.Channel{},
+		&database.Input{},
+		&database.Output{},
+		&database.Event{},
+		&database.EventEscalation{},
+		&database.PreNotification{},
+		&database.SentPreNotification{},
+		&database.EventDelivery{},
+	)
+}
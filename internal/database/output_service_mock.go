@@ -35,6 +35,72 @@ func (_m *MockOutputService) EXPECT() *MockOutputService_Expecter {
 	return &MockOutputService_Expecter{mock: &_m.Mock}
 }
 
+// OutputExists provides a mock function for the type MockOutputService
+func (_mock *MockOutputService) OutputExists(outputType string, outputID uint) (bool, error) {
+	ret := _mock.Called(outputType, outputID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OutputExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, uint) (bool, error)); ok {
+		return returnFunc(outputType, outputID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, uint) bool); ok {
+		r0 = returnFunc(outputType, outputID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, uint) error); ok {
+		r1 = returnFunc(outputType, outputID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOutputService_OutputExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OutputExists'
+type MockOutputService_OutputExists_Call struct {
+	*mock.Call
+}
+
+// OutputExists is a helper method to define mock.On call
+//   - outputType string
+//   - outputID uint
+func (_e *MockOutputService_Expecter) OutputExists(outputType interface{}, outputID interface{}) *MockOutputService_OutputExists_Call {
+	return &MockOutputService_OutputExists_Call{Call: _e.mock.On("OutputExists", outputType, outputID)}
+}
+
+func (_c *MockOutputService_OutputExists_Call) Run(run func(outputType string, outputID uint)) *MockOutputService_OutputExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 uint
+		if args[1] != nil {
+			arg1 = args[1].(uint)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutputService_OutputExists_Call) Return(b bool, err error) *MockOutputService_OutputExists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockOutputService_OutputExists_Call) RunAndReturn(run func(outputType string, outputID uint) (bool, error)) *MockOutputService_OutputExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // OutputRemoved provides a mock function for the type MockOutputService
 func (_mock *MockOutputService) OutputRemoved(outputType string, outputID uint) error {
 	ret := _mock.Called(outputType, outputID)
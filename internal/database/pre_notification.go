@@ -0,0 +1,144 @@
+package database
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+func (service *service) NewPreNotification(preNotification *PreNotification) (*PreNotification, error) {
+	err := service.db.Create(preNotification).Error
+
+	return preNotification, err
+}
+
+// ListPreNotificationsOpts holds options for listing pre-notifications.
+type ListPreNotificationsOpts struct {
+	ChannelID *uint
+	EventID   *uint
+}
+
+func (service *service) ListPreNotifications(opts *ListPreNotificationsOpts) ([]PreNotification, error) {
+	query := service.db
+
+	if opts.ChannelID != nil {
+		query = query.Where("pre_notifications.channel_id = ?", *opts.ChannelID)
+	}
+
+	if opts.EventID != nil {
+		query = query.Where("pre_notifications.event_id = ?", *opts.EventID)
+	}
+
+	var preNotifications []PreNotification
+
+	err := query.Order("lead_time DESC").Find(&preNotifications).Error
+
+	return preNotifications, err
+}
+
+func (service *service) DeletePreNotification(preNotification *PreNotification) error {
+	return service.db.Unscoped().Delete(preNotification).Error
+}
+
+// GetPreNotificationsPending returns all pre-notifications whose lead time is reached for events that did not start
+// yet. Pre-notifications already sent for the current occurrence of the event are omitted.
+func (service *service) GetPreNotificationsPending() ([]PendingPreNotification, error) {
+	var preNotifications []PreNotification
+
+	err := service.db.Find(&preNotifications).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(preNotifications) == 0 {
+		return []PendingPreNotification{}, nil
+	}
+
+	maxLeadTime := time.Duration(0)
+	leadTimesByChannel := make(map[uint][]time.Duration)
+	leadTimesByEvent := make(map[uint][]time.Duration)
+
+	for _, preNotification := range preNotifications {
+		maxLeadTime = max(maxLeadTime, preNotification.LeadTime)
+
+		if preNotification.ChannelID != nil {
+			leadTimesByChannel[*preNotification.ChannelID] = append(leadTimesByChannel[*preNotification.ChannelID], preNotification.LeadTime)
+		}
+
+		if preNotification.EventID != nil {
+			leadTimesByEvent[*preNotification.EventID] = append(leadTimesByEvent[*preNotification.EventID], preNotification.LeadTime)
+		}
+	}
+
+	now := time.Now()
+
+	var events []Event
+
+	err = service.db.Preload("Channel").Preload("Input").Preload("Channel.Outputs").
+		Find(&events, "events.active = ? AND events.time > ? AND events.time <= ?", true, now, now.Add(maxLeadTime)).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sent, err := service.sentPreNotifications(events)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []PendingPreNotification{}
+
+	for _, event := range events {
+		leadTimes := slices.Concat(leadTimesByChannel[event.ChannelID], leadTimesByEvent[event.ID])
+		for _, leadTime := range leadTimes {
+			if event.Time.Add(-leadTime).After(now) || sent[sentPreNotificationKey(event.ID, event.Time, leadTime)] {
+				continue
+			}
+
+			sent[sentPreNotificationKey(event.ID, event.Time, leadTime)] = true
+
+			pending = append(pending, PendingPreNotification{
+				Event:    event,
+				LeadTime: leadTime,
+			})
+		}
+	}
+
+	return pending, nil
+}
+
+func (service *service) sentPreNotifications(events []Event) (map[string]bool, error) {
+	sent := make(map[string]bool)
+	if len(events) == 0 {
+		return sent, nil
+	}
+
+	eventIDs := make([]uint, 0, len(events))
+	for _, event := range events {
+		eventIDs = append(eventIDs, event.ID)
+	}
+
+	var sentPreNotifications []SentPreNotification
+
+	err := service.db.Find(&sentPreNotifications, "sent_pre_notifications.event_id IN ?", eventIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sentPreNotification := range sentPreNotifications {
+		sent[sentPreNotificationKey(sentPreNotification.EventID, sentPreNotification.EventTime, sentPreNotification.LeadTime)] = true
+	}
+
+	return sent, nil
+}
+
+func sentPreNotificationKey(eventID uint, eventTime time.Time, leadTime time.Duration) string {
+	return fmt.Sprintf("%d-%d-%d", eventID, eventTime.Unix(), leadTime)
+}
+
+func (service *service) MarkPreNotificationSent(eventID uint, eventTime time.Time, leadTime time.Duration) error {
+	return service.db.Create(&SentPreNotification{
+		EventID:   eventID,
+		EventTime: eventTime,
+		LeadTime:  leadTime,
+	}).Error
+}
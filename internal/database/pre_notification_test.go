@@ -0,0 +1,92 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_NewPreNotification(t *testing.T) {
+	event, err := service.NewEvent(testEvent())
+	require.NoError(t, err)
+
+	preNotification, err := service.NewPreNotification(&database.PreNotification{
+		EventID:  &event.ID,
+		LeadTime: 15 * time.Minute,
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, preNotification.ID)
+
+	preNotifications, err := service.ListPreNotifications(&database.ListPreNotificationsOpts{
+		EventID: &event.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, preNotifications, 1)
+	assert.Equal(t, 15*time.Minute, preNotifications[0].LeadTime)
+
+	err = service.DeletePreNotification(&preNotifications[0])
+	require.NoError(t, err)
+
+	preNotifications, err = service.ListPreNotifications(&database.ListPreNotificationsOpts{
+		EventID: &event.ID,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, preNotifications)
+}
+
+func TestService_GetPreNotificationsPending(t *testing.T) {
+	event := testEvent()
+	event.Time = time.Now().Add(10 * time.Minute).UTC()
+	event, err := service.NewEvent(event)
+	require.NoError(t, err)
+
+	_, err = service.NewPreNotification(&database.PreNotification{
+		ChannelID: &event.ChannelID,
+		LeadTime:  24 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	_, err = service.NewPreNotification(&database.PreNotification{
+		EventID:  &event.ID,
+		LeadTime: 15 * time.Minute,
+	})
+	require.NoError(t, err)
+
+	_, err = service.NewPreNotification(&database.PreNotification{
+		EventID:  &event.ID,
+		LeadTime: 5 * time.Minute,
+	})
+	require.NoError(t, err)
+
+	pending, err := service.GetPreNotificationsPending()
+	require.NoError(t, err)
+
+	leadTimes := []time.Duration{}
+
+	for _, preNotification := range pending {
+		if preNotification.Event.ID == event.ID {
+			leadTimes = append(leadTimes, preNotification.LeadTime)
+		}
+	}
+
+	assert.ElementsMatch(t, []time.Duration{24 * time.Hour, 15 * time.Minute}, leadTimes)
+
+	err = service.MarkPreNotificationSent(event.ID, event.Time, 15*time.Minute)
+	require.NoError(t, err)
+
+	pending, err = service.GetPreNotificationsPending()
+	require.NoError(t, err)
+
+	leadTimes = []time.Duration{}
+
+	for _, preNotification := range pending {
+		if preNotification.Event.ID == event.ID {
+			leadTimes = append(leadTimes, preNotification.LeadTime)
+		}
+	}
+
+	assert.Equal(t, []time.Duration{24 * time.Hour}, leadTimes)
+}
@@ -1,10 +1,15 @@
 package database
 
 import (
+	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/CubicrootXYZ/gormlogger"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database/migration"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
@@ -16,34 +21,40 @@ type service struct {
 
 // InputService defines an interface for any arbitrary input connector.
 type InputService interface {
+	InputExists(inputType string, inputID uint) (bool, error)
 	InputRemoved(inputType string, inputID uint) error
 }
 
 // OutputService defines an interface for any arbitrary output connector.
 type OutputService interface {
+	OutputExists(outputType string, outputID uint) (bool, error)
 	OutputRemoved(outputType string, outputID uint) error
 }
 
+// Supported database drivers.
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
 type Config struct {
-	LogStatements  bool
+	LogStatements bool
+	// Driver selects the database backend, defaults to MySQL.
+	Driver         string
 	Connection     string
 	InputServices  map[string]InputService
 	OutputServices map[string]OutputService
 }
 
+// MigrationModule is the module name the core schema version is tracked with.
+const MigrationModule = "core"
+
 // NewService assembles a new database service.
 func NewService(config *Config, logger *slog.Logger) (Service, error) {
 	logger.Debug("setting up database")
 
-	if config == nil {
-		return nil, ErrInvalidConfig
-	}
-
-	gormLogger := gormlogger.NewLogger(config.LogStatements)
-
-	db, err := gorm.Open(mysql.Open(config.Connection+"?parseTime=True"), &gorm.Config{
-		Logger: gormLogger,
-	})
+	db, err := Open(config)
 	if err != nil {
 		return nil, err
 	}
@@ -66,26 +77,77 @@ func NewService(config *Config, logger *slog.Logger) (Service, error) {
 	return service, nil
 }
 
+// Open connects to the configured database without migrating it.
+func Open(config *Config) (*gorm.DB, error) {
+	if config == nil {
+		return nil, ErrInvalidConfig
+	}
+
+	dialector, err := newDialector(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return gorm.Open(dialector, &gorm.Config{
+		Logger: gormlogger.NewLogger(config.LogStatements),
+	})
+}
+
+// newDialector selects the gorm dialector for the configured driver.
+func newDialector(config *Config) (gorm.Dialector, error) {
+	if config.Connection == "" {
+		return nil, fmt.Errorf("%w: missing connection", ErrInvalidConfig)
+	}
+
+	switch config.Driver {
+	case "", DriverMySQL:
+		return mysql.Open(mysqlDSN(config.Connection)), nil
+	case DriverPostgres:
+		return postgres.Open(config.Connection), nil
+	case DriverSQLite:
+		return sqlite.Open(sqliteDSN(config.Connection)), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrInvalidConfig, config.Driver)
+}
+
+// mysqlDSN makes the driver scan DATETIME columns into time.Time, other drivers do so by default.
+func mysqlDSN(connection string) string {
+	if strings.Contains(connection, "parseTime=") {
+		return connection
+	}
+
+	if strings.Contains(connection, "?") {
+		return connection + "&parseTime=True"
+	}
+
+	return connection + "?parseTime=True"
+}
+
+// sqliteDSN enables foreign keys, SQLite ignores them by default.
+func sqliteDSN(connection string) string {
+	if strings.Contains(connection, "_foreign_keys=") || strings.Contains(connection, "_fk=") {
+		return connection
+	}
+
+	if strings.Contains(connection, "?") {
+		return connection + "&_foreign_keys=on"
+	}
+
+	return connection + "?_foreign_keys=on"
+}
+
 func (service *service) GormDB() *gorm.DB {
 	return service.db
 }
 
 func (service *service) migrate() error {
-	models := []any{
-		Channel{},
-		Input{},
-		Output{},
-		Event{},
+	applied, err := migration.New(service.db, MigrationModule, Migrations()).Up()
+	for _, m := range applied {
+		service.logger.Info("applied migration", "version", m.Version, "name", m.Name)
 	}
 
-	for i := range models {
-		err := service.db.AutoMigrate(&models[i])
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return err
 }
 
 func (service *service) newSession() *service {
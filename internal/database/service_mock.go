@@ -5,6 +5,8 @@
 package database
 
 import (
+	"time"
+
 	mock "github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 )
@@ -312,6 +314,57 @@ func (_c *MockService_DeleteEvent_Call) RunAndReturn(run func(event *Event) erro
 	return _c
 }
 
+// DeletePreNotification provides a mock function for the type MockService
+func (_mock *MockService) DeletePreNotification(preNotification *PreNotification) error {
+	ret := _mock.Called(preNotification)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePreNotification")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*PreNotification) error); ok {
+		r0 = returnFunc(preNotification)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_DeletePreNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePreNotification'
+type MockService_DeletePreNotification_Call struct {
+	*mock.Call
+}
+
+// DeletePreNotification is a helper method to define mock.On call
+//   - preNotification *PreNotification
+func (_e *MockService_Expecter) DeletePreNotification(preNotification interface{}) *MockService_DeletePreNotification_Call {
+	return &MockService_DeletePreNotification_Call{Call: _e.mock.On("DeletePreNotification", preNotification)}
+}
+
+func (_c *MockService_DeletePreNotification_Call) Run(run func(preNotification *PreNotification)) *MockService_DeletePreNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *PreNotification
+		if args[0] != nil {
+			arg0 = args[0].(*PreNotification)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_DeletePreNotification_Call) Return(err error) *MockService_DeletePreNotification_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_DeletePreNotification_Call) RunAndReturn(run func(preNotification *PreNotification) error) *MockService_DeletePreNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetChannelByID provides a mock function for the type MockService
 func (_mock *MockService) GetChannelByID(v uint) (*Channel, error) {
 	ret := _mock.Called(v)
@@ -806,6 +859,61 @@ func (_c *MockService_GetOutputByType_Call) RunAndReturn(run func(outputID uint,
 	return _c
 }
 
+// GetPreNotificationsPending provides a mock function for the type MockService
+func (_mock *MockService) GetPreNotificationsPending() ([]PendingPreNotification, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPreNotificationsPending")
+	}
+
+	var r0 []PendingPreNotification
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]PendingPreNotification, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []PendingPreNotification); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]PendingPreNotification)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetPreNotificationsPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPreNotificationsPending'
+type MockService_GetPreNotificationsPending_Call struct {
+	*mock.Call
+}
+
+// GetPreNotificationsPending is a helper method to define mock.On call
+func (_e *MockService_Expecter) GetPreNotificationsPending() *MockService_GetPreNotificationsPending_Call {
+	return &MockService_GetPreNotificationsPending_Call{Call: _e.mock.On("GetPreNotificationsPending")}
+}
+
+func (_c *MockService_GetPreNotificationsPending_Call) Run(run func()) *MockService_GetPreNotificationsPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockService_GetPreNotificationsPending_Call) Return(pendingPreNotifications []PendingPreNotification, err error) *MockService_GetPreNotificationsPending_Call {
+	_c.Call.Return(pendingPreNotifications, err)
+	return _c
+}
+
+func (_c *MockService_GetPreNotificationsPending_Call) RunAndReturn(run func() ([]PendingPreNotification, error)) *MockService_GetPreNotificationsPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GormDB provides a mock function for the type MockService
 func (_mock *MockService) GormDB() *gorm.DB {
 	ret := _mock.Called()
@@ -852,6 +960,152 @@ func (_c *MockService_GormDB_Call) RunAndReturn(run func() *gorm.DB) *MockServic
 	return _c
 }
 
+// InputExists provides a mock function for the type MockService
+func (_mock *MockService) InputExists(inputType string, inputID uint) (bool, error) {
+	ret := _mock.Called(inputType, inputID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InputExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, uint) (bool, error)); ok {
+		return returnFunc(inputType, inputID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, uint) bool); ok {
+		r0 = returnFunc(inputType, inputID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, uint) error); ok {
+		r1 = returnFunc(inputType, inputID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_InputExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InputExists'
+type MockService_InputExists_Call struct {
+	*mock.Call
+}
+
+// InputExists is a helper method to define mock.On call
+//   - inputType string
+//   - inputID uint
+func (_e *MockService_Expecter) InputExists(inputType interface{}, inputID interface{}) *MockService_InputExists_Call {
+	return &MockService_InputExists_Call{Call: _e.mock.On("InputExists", inputType, inputID)}
+}
+
+func (_c *MockService_InputExists_Call) Run(run func(inputType string, inputID uint)) *MockService_InputExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 uint
+		if args[1] != nil {
+			arg1 = args[1].(uint)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_InputExists_Call) Return(b bool, err error) *MockService_InputExists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockService_InputExists_Call) RunAndReturn(run func(inputType string, inputID uint) (bool, error)) *MockService_InputExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEventDeliveries provides a mock function for the type MockService
+func (_mock *MockService) ListEventDeliveries(eventID uint, eventTime time.Time, leadTime time.Duration, escalationLevel uint) ([]EventDelivery, error) {
+	ret := _mock.Called(eventID, eventTime, leadTime, escalationLevel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEventDeliveries")
+	}
+
+	var r0 []EventDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uint, time.Time, time.Duration, uint) ([]EventDelivery, error)); ok {
+		return returnFunc(eventID, eventTime, leadTime, escalationLevel)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uint, time.Time, time.Duration, uint) []EventDelivery); ok {
+		r0 = returnFunc(eventID, eventTime, leadTime, escalationLevel)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]EventDelivery)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uint, time.Time, time.Duration, uint) error); ok {
+		r1 = returnFunc(eventID, eventTime, leadTime, escalationLevel)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_ListEventDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEventDeliveries'
+type MockService_ListEventDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListEventDeliveries is a helper method to define mock.On call
+//   - eventID uint
+//   - eventTime time.Time
+//   - leadTime time.Duration
+//   - escalationLevel uint
+func (_e *MockService_Expecter) ListEventDeliveries(eventID interface{}, eventTime interface{}, leadTime interface{}, escalationLevel interface{}) *MockService_ListEventDeliveries_Call {
+	return &MockService_ListEventDeliveries_Call{Call: _e.mock.On("ListEventDeliveries", eventID, eventTime, leadTime, escalationLevel)}
+}
+
+func (_c *MockService_ListEventDeliveries_Call) Run(run func(eventID uint, eventTime time.Time, leadTime time.Duration, escalationLevel uint)) *MockService_ListEventDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uint
+		if args[0] != nil {
+			arg0 = args[0].(uint)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		var arg3 uint
+		if args[3] != nil {
+			arg3 = args[3].(uint)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ListEventDeliveries_Call) Return(eventDeliverys []EventDelivery, err error) *MockService_ListEventDeliveries_Call {
+	_c.Call.Return(eventDeliverys, err)
+	return _c
+}
+
+func (_c *MockService_ListEventDeliveries_Call) RunAndReturn(run func(eventID uint, eventTime time.Time, leadTime time.Duration, escalationLevel uint) ([]EventDelivery, error)) *MockService_ListEventDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListEvents provides a mock function for the type MockService
 func (_mock *MockService) ListEvents(opts *ListEventsOpts) ([]Event, error) {
 	ret := _mock.Called(opts)
@@ -914,6 +1168,131 @@ func (_c *MockService_ListEvents_Call) RunAndReturn(run func(opts *ListEventsOpt
 	return _c
 }
 
+// ListPreNotifications provides a mock function for the type MockService
+func (_mock *MockService) ListPreNotifications(opts *ListPreNotificationsOpts) ([]PreNotification, error) {
+	ret := _mock.Called(opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPreNotifications")
+	}
+
+	var r0 []PreNotification
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*ListPreNotificationsOpts) ([]PreNotification, error)); ok {
+		return returnFunc(opts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*ListPreNotificationsOpts) []PreNotification); ok {
+		r0 = returnFunc(opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]PreNotification)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*ListPreNotificationsOpts) error); ok {
+		r1 = returnFunc(opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_ListPreNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPreNotifications'
+type MockService_ListPreNotifications_Call struct {
+	*mock.Call
+}
+
+// ListPreNotifications is a helper method to define mock.On call
+//   - opts *ListPreNotificationsOpts
+func (_e *MockService_Expecter) ListPreNotifications(opts interface{}) *MockService_ListPreNotifications_Call {
+	return &MockService_ListPreNotifications_Call{Call: _e.mock.On("ListPreNotifications", opts)}
+}
+
+func (_c *MockService_ListPreNotifications_Call) Run(run func(opts *ListPreNotificationsOpts)) *MockService_ListPreNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *ListPreNotificationsOpts
+		if args[0] != nil {
+			arg0 = args[0].(*ListPreNotificationsOpts)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ListPreNotifications_Call) Return(preNotifications []PreNotification, err error) *MockService_ListPreNotifications_Call {
+	_c.Call.Return(preNotifications, err)
+	return _c
+}
+
+func (_c *MockService_ListPreNotifications_Call) RunAndReturn(run func(opts *ListPreNotificationsOpts) ([]PreNotification, error)) *MockService_ListPreNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkPreNotificationSent provides a mock function for the type MockService
+func (_mock *MockService) MarkPreNotificationSent(eventID uint, eventTime time.Time, leadTime time.Duration) error {
+	ret := _mock.Called(eventID, eventTime, leadTime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkPreNotificationSent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(uint, time.Time, time.Duration) error); ok {
+		r0 = returnFunc(eventID, eventTime, leadTime)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_MarkPreNotificationSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkPreNotificationSent'
+type MockService_MarkPreNotificationSent_Call struct {
+	*mock.Call
+}
+
+// MarkPreNotificationSent is a helper method to define mock.On call
+//   - eventID uint
+//   - eventTime time.Time
+//   - leadTime time.Duration
+func (_e *MockService_Expecter) MarkPreNotificationSent(eventID interface{}, eventTime interface{}, leadTime interface{}) *MockService_MarkPreNotificationSent_Call {
+	return &MockService_MarkPreNotificationSent_Call{Call: _e.mock.On("MarkPreNotificationSent", eventID, eventTime, leadTime)}
+}
+
+func (_c *MockService_MarkPreNotificationSent_Call) Run(run func(eventID uint, eventTime time.Time, leadTime time.Duration)) *MockService_MarkPreNotificationSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uint
+		if args[0] != nil {
+			arg0 = args[0].(uint)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_MarkPreNotificationSent_Call) Return(err error) *MockService_MarkPreNotificationSent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_MarkPreNotificationSent_Call) RunAndReturn(run func(eventID uint, eventTime time.Time, leadTime time.Duration) error) *MockService_MarkPreNotificationSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewChannel provides a mock function for the type MockService
 func (_mock *MockService) NewChannel(channel *Channel) (*Channel, error) {
 	ret := _mock.Called(channel)
@@ -1038,6 +1417,68 @@ func (_c *MockService_NewEvent_Call) RunAndReturn(run func(event *Event) (*Event
 	return _c
 }
 
+// NewEventEscalation provides a mock function for the type MockService
+func (_mock *MockService) NewEventEscalation(eventEscalation *EventEscalation) (*EventEscalation, error) {
+	ret := _mock.Called(eventEscalation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewEventEscalation")
+	}
+
+	var r0 *EventEscalation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*EventEscalation) (*EventEscalation, error)); ok {
+		return returnFunc(eventEscalation)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*EventEscalation) *EventEscalation); ok {
+		r0 = returnFunc(eventEscalation)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*EventEscalation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*EventEscalation) error); ok {
+		r1 = returnFunc(eventEscalation)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_NewEventEscalation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewEventEscalation'
+type MockService_NewEventEscalation_Call struct {
+	*mock.Call
+}
+
+// NewEventEscalation is a helper method to define mock.On call
+//   - eventEscalation *EventEscalation
+func (_e *MockService_Expecter) NewEventEscalation(eventEscalation interface{}) *MockService_NewEventEscalation_Call {
+	return &MockService_NewEventEscalation_Call{Call: _e.mock.On("NewEventEscalation", eventEscalation)}
+}
+
+func (_c *MockService_NewEventEscalation_Call) Run(run func(eventEscalation *EventEscalation)) *MockService_NewEventEscalation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *EventEscalation
+		if args[0] != nil {
+			arg0 = args[0].(*EventEscalation)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_NewEventEscalation_Call) Return(eventEscalation1 *EventEscalation, err error) *MockService_NewEventEscalation_Call {
+	_c.Call.Return(eventEscalation1, err)
+	return _c
+}
+
+func (_c *MockService_NewEventEscalation_Call) RunAndReturn(run func(eventEscalation *EventEscalation) (*EventEscalation, error)) *MockService_NewEventEscalation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewEvents provides a mock function for the type MockService
 func (_mock *MockService) NewEvents(events []Event) error {
 	ret := _mock.Called(events)
@@ -1089,6 +1530,134 @@ func (_c *MockService_NewEvents_Call) RunAndReturn(run func(events []Event) erro
 	return _c
 }
 
+// NewPreNotification provides a mock function for the type MockService
+func (_mock *MockService) NewPreNotification(preNotification *PreNotification) (*PreNotification, error) {
+	ret := _mock.Called(preNotification)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewPreNotification")
+	}
+
+	var r0 *PreNotification
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*PreNotification) (*PreNotification, error)); ok {
+		return returnFunc(preNotification)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*PreNotification) *PreNotification); ok {
+		r0 = returnFunc(preNotification)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*PreNotification)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*PreNotification) error); ok {
+		r1 = returnFunc(preNotification)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_NewPreNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewPreNotification'
+type MockService_NewPreNotification_Call struct {
+	*mock.Call
+}
+
+// NewPreNotification is a helper method to define mock.On call
+//   - preNotification *PreNotification
+func (_e *MockService_Expecter) NewPreNotification(preNotification interface{}) *MockService_NewPreNotification_Call {
+	return &MockService_NewPreNotification_Call{Call: _e.mock.On("NewPreNotification", preNotification)}
+}
+
+func (_c *MockService_NewPreNotification_Call) Run(run func(preNotification *PreNotification)) *MockService_NewPreNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *PreNotification
+		if args[0] != nil {
+			arg0 = args[0].(*PreNotification)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_NewPreNotification_Call) Return(preNotification1 *PreNotification, err error) *MockService_NewPreNotification_Call {
+	_c.Call.Return(preNotification1, err)
+	return _c
+}
+
+func (_c *MockService_NewPreNotification_Call) RunAndReturn(run func(preNotification *PreNotification) (*PreNotification, error)) *MockService_NewPreNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OutputExists provides a mock function for the type MockService
+func (_mock *MockService) OutputExists(outputType string, outputID uint) (bool, error) {
+	ret := _mock.Called(outputType, outputID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OutputExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, uint) (bool, error)); ok {
+		return returnFunc(outputType, outputID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, uint) bool); ok {
+		r0 = returnFunc(outputType, outputID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, uint) error); ok {
+		r1 = returnFunc(outputType, outputID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_OutputExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OutputExists'
+type MockService_OutputExists_Call struct {
+	*mock.Call
+}
+
+// OutputExists is a helper method to define mock.On call
+//   - outputType string
+//   - outputID uint
+func (_e *MockService_Expecter) OutputExists(outputType interface{}, outputID interface{}) *MockService_OutputExists_Call {
+	return &MockService_OutputExists_Call{Call: _e.mock.On("OutputExists", outputType, outputID)}
+}
+
+func (_c *MockService_OutputExists_Call) Run(run func(outputType string, outputID uint)) *MockService_OutputExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 uint
+		if args[1] != nil {
+			arg1 = args[1].(uint)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_OutputExists_Call) Return(b bool, err error) *MockService_OutputExists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockService_OutputExists_Call) RunAndReturn(run func(outputType string, outputID uint) (bool, error)) *MockService_OutputExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveInputFromChannel provides a mock function for the type MockService
 func (_mock *MockService) RemoveInputFromChannel(channelID uint, inputID uint) error {
 	ret := _mock.Called(channelID, inputID)
@@ -1203,6 +1772,68 @@ func (_c *MockService_RemoveOutputFromChannel_Call) RunAndReturn(run func(channe
 	return _c
 }
 
+// SaveEventDelivery provides a mock function for the type MockService
+func (_mock *MockService) SaveEventDelivery(eventDelivery *EventDelivery) (*EventDelivery, error) {
+	ret := _mock.Called(eventDelivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveEventDelivery")
+	}
+
+	var r0 *EventDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*EventDelivery) (*EventDelivery, error)); ok {
+		return returnFunc(eventDelivery)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*EventDelivery) *EventDelivery); ok {
+		r0 = returnFunc(eventDelivery)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*EventDelivery)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*EventDelivery) error); ok {
+		r1 = returnFunc(eventDelivery)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_SaveEventDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveEventDelivery'
+type MockService_SaveEventDelivery_Call struct {
+	*mock.Call
+}
+
+// SaveEventDelivery is a helper method to define mock.On call
+//   - eventDelivery *EventDelivery
+func (_e *MockService_Expecter) SaveEventDelivery(eventDelivery interface{}) *MockService_SaveEventDelivery_Call {
+	return &MockService_SaveEventDelivery_Call{Call: _e.mock.On("SaveEventDelivery", eventDelivery)}
+}
+
+func (_c *MockService_SaveEventDelivery_Call) Run(run func(eventDelivery *EventDelivery)) *MockService_SaveEventDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *EventDelivery
+		if args[0] != nil {
+			arg0 = args[0].(*EventDelivery)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_SaveEventDelivery_Call) Return(eventDelivery1 *EventDelivery, err error) *MockService_SaveEventDelivery_Call {
+	_c.Call.Return(eventDelivery1, err)
+	return _c
+}
+
+func (_c *MockService_SaveEventDelivery_Call) RunAndReturn(run func(eventDelivery *EventDelivery) (*EventDelivery, error)) *MockService_SaveEventDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateChannel provides a mock function for the type MockService
 func (_mock *MockService) UpdateChannel(channel *Channel) (*Channel, error) {
 	ret := _mock.Called(channel)
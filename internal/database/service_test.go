@@ -3,26 +3,20 @@ package database_test
 import (
 	"log/slog"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database/tests"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
 
 var logger *slog.Logger
 var service database.Service
 var gormDB *gorm.DB
-
-func getConnection() string {
-	host := os.Getenv("TEST_DB_HOST")
-	if host == "" {
-		host = "localhost"
-	}
-
-	return "root:mypass@tcp(" + host + ":3306)/remindme"
-}
+var config *database.Config
 
 func getService(config *database.Config) database.Service {
 	service, err := database.NewService(
@@ -36,47 +30,60 @@ func getService(config *database.Config) database.Service {
 	return service
 }
 
-func getGormDB() *gorm.DB {
-	db, err := gorm.Open(mysql.Open(getConnection()+"?parseTime=True"), &gorm.Config{})
-	if err != nil {
-		panic(err)
-	}
-
-	return db
-}
-
 func getLogger() *slog.Logger {
 	return slog.New(slog.NewTextHandler(os.Stdout, nil))
 }
 
 func TestMain(m *testing.M) {
+	var cleanup func()
+
+	config, cleanup = tests.Config()
+	defer cleanup()
+
 	logger = getLogger()
-	service = getService(&database.Config{
-		Connection:    getConnection(),
-		LogStatements: true,
-	})
-	gormDB = getGormDB()
+	service = getService(config)
+	gormDB = tests.GormDB(config)
 
 	m.Run()
 }
 
 func TestNewService(t *testing.T) {
+	_, err := database.NewService(config, logger)
+	require.NoError(t, err)
+}
+
+func TestNewServiceWithNoConnection(t *testing.T) {
 	_, err := database.NewService(
+		&database.Config{},
+		logger,
+	)
+	require.Error(t, err)
+}
+
+func TestNewServiceWithSQLite(t *testing.T) {
+	service, err := database.NewService(
 		&database.Config{
-			Connection:    getConnection(),
-			LogStatements: true,
+			Driver:     database.DriverSQLite,
+			Connection: filepath.Join(t.TempDir(), "remindme.db"),
 		},
 		logger,
 	)
 	require.NoError(t, err)
+
+	channel, err := service.NewChannel(&database.Channel{Description: "test"})
+	require.NoError(t, err)
+	assert.NotZero(t, channel.ID)
 }
 
-func TestNewServiceWithNoConnection(t *testing.T) {
+func TestNewServiceWithUnknownDriver(t *testing.T) {
 	_, err := database.NewService(
-		&database.Config{},
+		&database.Config{
+			Driver:     "oracle",
+			Connection: config.Connection,
+		},
 		logger,
 	)
-	require.Error(t, err)
+	require.ErrorIs(t, err, database.ErrInvalidConfig)
 }
 
 func TestNewServiceWithNoConfig(t *testing.T) {
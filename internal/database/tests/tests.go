@@ -0,0 +1,103 @@
+// Package tests provides the database the tests run against.
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database"
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/database/migration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// Config returns the configuration of the test database and a function removing it again. Tests run against a
+// SQLite file by default, set TEST_DB_DRIVER to "mysql" or "postgres" to use the server at TEST_DB_HOST instead.
+func Config() (*database.Config, func()) {
+	host := os.Getenv("TEST_DB_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+
+	switch driver := os.Getenv("TEST_DB_DRIVER"); driver {
+	case database.DriverMySQL:
+		return &database.Config{
+			Driver:     driver,
+			Connection: "root:mypass@tcp(" + host + ":3306)/remindme",
+		}, func() {}
+	case database.DriverPostgres:
+		return &database.Config{
+			Driver:     driver,
+			Connection: "host=" + host + " port=5432 user=postgres password=mypass dbname=remindme sslmode=disable",
+		}, func() {}
+	}
+
+	dir, err := os.MkdirTemp("", "remindme-test-")
+	if err != nil {
+		panic(err)
+	}
+
+	return &database.Config{
+		Driver:     database.DriverSQLite,
+		Connection: filepath.Join(dir, "remindme.db") + "?_busy_timeout=5000",
+	}, func() {
+		_ = os.RemoveAll(dir)
+	}
+}
+
+// GormDB opens the test database.
+func GormDB(config *database.Config) *gorm.DB {
+	db, err := database.Open(config)
+	if err != nil {
+		panic(err)
+	}
+
+	return db
+}
+
+// GormDBWithCoreSchema opens the test database and applies the core migrations connectors build on.
+func GormDBWithCoreSchema(config *database.Config) *gorm.DB {
+	db := GormDB(config)
+
+	_, err := migration.New(db, database.MigrationModule, database.Migrations()).Up()
+	if err != nil {
+		panic(err)
+	}
+
+	return db
+}
+
+// AssertSchema asserts the columns, indexes and foreign keys of the given models exist in the database.
+func AssertSchema(t *testing.T, db *gorm.DB, models ...any) {
+	t.Helper()
+
+	migrator := db.Migrator()
+
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		require.NoError(t, stmt.Parse(model))
+
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" || field.IgnoreMigration {
+				continue
+			}
+
+			assert.True(t, migrator.HasColumn(model, field.DBName), "missing column %s.%s", stmt.Table, field.DBName)
+		}
+
+		for _, index := range stmt.Schema.ParseIndexes() {
+			assert.True(t, migrator.HasIndex(model, index.Name), "missing index %s.%s", stmt.Table, index.Name)
+		}
+
+		for _, rel := range stmt.Schema.Relationships.Relations {
+			constraint := rel.ParseConstraint()
+			if constraint == nil || constraint.Schema != stmt.Schema {
+				continue
+			}
+
+			assert.True(t, migrator.HasConstraint(model, constraint.Name), "missing constraint %s.%s", stmt.Table, constraint.Name)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Package httpclient provides HTTP transports for requests to user provided URLs.
+package httpclient
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+)
+
+// ErrNonPublicAddress is returned when connecting to a non-public address is refused.
+var ErrNonPublicAddress = errors.New("address is not public")
+
+// sharedAddressSpace is used for carrier-grade NAT (RFC 6598).
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// PublicTransport returns a transport that refuses to connect to non-public addresses. The check is done on the
+// resolved address, hence it also applies to redirects and DNS rebinding. Proxies are not used as they would bypass
+// that check.
+func PublicTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Control: denyNonPublicAddress,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return transport
+}
+
+// denyNonPublicAddress is called with the resolved address before connecting.
+func denyNonPublicAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+
+	ip = ip.Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip) {
+		return ErrNonPublicAddress
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package httpclient_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CubicrootXYZ/matrix-reminder-and-calendar-bot/internal/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicTransport(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	for _, url := range []string{
+		server.URL,
+		"http://[::1]:80",
+		"http://10.0.0.1:80",
+		"http://100.64.0.1:80",
+		"http://169.254.169.254:80",
+		"http://[::ffff:127.0.0.1]:80",
+	} {
+		t.Run(url, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			client := &http.Client{Transport: httpclient.PublicTransport()}
+
+			_, err = client.Do(req) //nolint:bodyclose // Request is refused.
+			require.ErrorIs(t, err, httpclient.ErrNonPublicAddress)
+		})
+	}
+
+	assert.False(t, called)
+}